@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/charleslr/jig/internal/state"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and prune the local plan cache",
+	Long: `Inspect and prune the local plan cache.
+
+Subcommands:
+  stats    Show what the cache is holding
+  clean    Remove orphaned and stale cache entries
+  export   Back up every cached plan to an archive
+  import   Restore plans from an archive`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show what the cache is holding",
+	Long: `Show counts of the cached plans and the size of the cache on disk.
+
+Archived plans are included in the counts. A plan counts as synced once it has
+been synced to the tracker at least once.
+
+Examples:
+  jig cache stats
+  jig cache stats --json`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStats,
+}
+
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove orphaned and stale cache entries",
+	Long: `Remove cache entries that are no longer usable.
+
+Removes plan entries whose JSON fails to parse and plan markdown files with no
+matching JSON entry. With --older-than, also removes plans last cached longer
+ago than the given duration (e.g. 720h for 30 days). Lock and temporary files
+left behind by a removed plan are removed with it.
+
+Use --dry-run to list what would be removed without removing anything.
+
+Examples:
+  jig cache clean --dry-run
+  jig cache clean --older-than 720h`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClean,
+}
+
+var cacheExportCmd = &cobra.Command{
+	Use:   "export <FILE>",
+	Short: "Back up every cached plan to an archive",
+	Long: `Write every cached plan, including archived ones, to a gzipped tar archive.
+
+The archive keeps each plan's sync and archive state, so it can be imported on
+another machine with 'jig cache import'.
+
+Examples:
+  jig cache export plans.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCacheExport,
+}
+
+var cacheImportCmd = &cobra.Command{
+	Use:   "import <FILE>",
+	Short: "Restore plans from an archive",
+	Long: `Restore plans from an archive written by 'jig cache export'.
+
+Each plan is validated before it is restored, and invalid plans are reported
+and skipped. Plans that are already cached are kept unless --overwrite is set.
+
+Examples:
+  jig cache import plans.tar.gz
+  jig cache import plans.tar.gz --overwrite`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCacheImport,
+}
+
+var (
+	cacheStatsJSON       bool
+	cacheImportOverwrite bool
+	cacheCleanDryRun     bool
+	cacheCleanOlderThan  time.Duration
+)
+
+func init() {
+	cacheStatsCmd.Flags().BoolVar(&cacheStatsJSON, "json", false, "output as JSON")
+	cacheCleanCmd.Flags().BoolVar(&cacheCleanDryRun, "dry-run", false, "show what would be removed without removing")
+	cacheCleanCmd.Flags().DurationVar(&cacheCleanOlderThan, "older-than", 0, "also remove plans last cached longer ago than this (e.g. 720h)")
+
+	cacheImportCmd.Flags().BoolVar(&cacheImportOverwrite, "overwrite", false, "replace plans that are already cached")
+
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+	cacheCmd.AddCommand(cacheExportCmd)
+	cacheCmd.AddCommand(cacheImportCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize state: %w", err)
+	}
+
+	stats, err := state.DefaultCache.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to compute cache stats: %w", err)
+	}
+
+	if cacheStatsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+
+	writeCacheStatsText(os.Stdout, stats)
+	return nil
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) error {
+	if cacheCleanOlderThan < 0 {
+		return fmt.Errorf("--older-than must not be negative")
+	}
+
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize state: %w", err)
+	}
+
+	removed, err := state.DefaultCache.Clean(state.CleanOptions{
+		OlderThan: cacheCleanOlderThan,
+		DryRun:    cacheCleanDryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clean cache: %w", err)
+	}
+
+	if len(removed) == 0 {
+		printSuccess("Cache is clean, nothing to remove")
+		return nil
+	}
+
+	for _, entry := range removed {
+		fmt.Printf("  %s (%s)\n", entry.File, entry.Reason)
+	}
+	if cacheCleanDryRun {
+		printInfo(fmt.Sprintf("Would remove %d file(s) (dry run)", len(removed)))
+		return nil
+	}
+	printSuccess(fmt.Sprintf("Removed %d file(s)", len(removed)))
+	return nil
+}
+
+func runCacheExport(cmd *cobra.Command, args []string) error {
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize state: %w", err)
+	}
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	count, err := state.DefaultCache.ExportAll(f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write archive: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(args[0])
+		return fmt.Errorf("failed to export cache: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Exported %d plan(s) to %s", count, args[0]))
+	return nil
+}
+
+func runCacheImport(cmd *cobra.Command, args []string) error {
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize state: %w", err)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	result, err := state.DefaultCache.ImportAll(f, cacheImportOverwrite)
+	if err != nil {
+		return fmt.Errorf("failed to import cache: %w", err)
+	}
+
+	writeCacheImportText(os.Stdout, result)
+	if len(result.Invalid) > 0 {
+		return fmt.Errorf("%d plan(s) in the archive were invalid", len(result.Invalid))
+	}
+	return nil
+}
+
+// writeCacheImportText writes the outcome of an import in a human-readable form
+func writeCacheImportText(w io.Writer, result *state.ImportResult) {
+	fmt.Fprintf(w, "Imported: %d\n", len(result.Imported))
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(w, "Skipped:  %d (already cached, use --overwrite to replace)\n", len(result.Skipped))
+		for _, id := range result.Skipped {
+			fmt.Fprintf(w, "  %s\n", id)
+		}
+	}
+	if len(result.Invalid) > 0 {
+		fmt.Fprintf(w, "Invalid:  %d\n", len(result.Invalid))
+		ids := make([]string, 0, len(result.Invalid))
+		for id := range result.Invalid {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Fprintf(w, "  %s: %s\n", id, result.Invalid[id])
+		}
+	}
+}
+
+// writeCacheStatsText writes cache stats in a human-readable form
+func writeCacheStatsText(w io.Writer, stats *state.CacheStats) {
+	fmt.Fprintf(w, "Plans:     %d\n", stats.TotalPlans)
+	fmt.Fprintf(w, "  Synced:   %d\n", stats.SyncedPlans)
+	fmt.Fprintf(w, "  Unsynced: %d\n", stats.UnsyncedPlans)
+	fmt.Fprintf(w, "  Linked:   %d\n", stats.LinkedPlans)
+	fmt.Fprintf(w, "  Unlinked: %d\n", stats.UnlinkedPlans)
+	fmt.Fprintf(w, "Size:      %s\n", formatBytes(stats.TotalBytes))
+	if stats.OldestCachedAt != nil {
+		fmt.Fprintf(w, "Oldest:    %s\n", stats.OldestCachedAt.Format(time.RFC3339))
+	}
+	if stats.NewestCachedAt != nil {
+		fmt.Fprintf(w, "Newest:    %s\n", stats.NewestCachedAt.Format(time.RFC3339))
+	}
+}
+
+// formatBytes formats a byte count using binary units (e.g. "1.5 KiB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
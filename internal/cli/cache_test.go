@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/state"
+)
+
+func TestWriteCacheStatsText(t *testing.T) {
+	var buf bytes.Buffer
+	writeCacheStatsText(&buf, &state.CacheStats{
+		TotalPlans:    3,
+		SyncedPlans:   1,
+		UnsyncedPlans: 2,
+		LinkedPlans:   2,
+		UnlinkedPlans: 1,
+		TotalBytes:    1536,
+	})
+
+	out := buf.String()
+	for _, want := range []string{"Plans:     3", "Synced:   1", "Unlinked: 1", "Size:      1.5 KiB"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Oldest") {
+		t.Errorf("expected no CachedAt range without plans, got:\n%s", out)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRunCacheExportImport(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	createTestPlanInCache(t, jigHome, "PLAN-1")
+	createTestPlanInCache(t, jigHome, "PLAN-2")
+	archive := filepath.Join(t.TempDir(), "plans.tar.gz")
+
+	output, err := captureStdout(t, func() error { return runCacheExport(cacheExportCmd, []string{archive}) })
+	if err != nil {
+		t.Fatalf("runCacheExport() error = %v", err)
+	}
+	if !strings.Contains(output, "Exported 2 plan(s)") {
+		t.Errorf("expected export count, got:\n%s", output)
+	}
+
+	// Importing into the same cache skips the plans already there
+	output, err = captureStdout(t, func() error { return runCacheImport(cacheImportCmd, []string{archive}) })
+	if err != nil {
+		t.Fatalf("runCacheImport() error = %v", err)
+	}
+	for _, want := range []string{"Imported: 0", "Skipped:  2", "PLAN-1", "PLAN-2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteCacheImportText(t *testing.T) {
+	var buf bytes.Buffer
+	writeCacheImportText(&buf, &state.ImportResult{
+		Imported: []string{"PLAN-1"},
+		Invalid:  map[string]string{"PLAN-2": "invalid plan JSON"},
+	})
+
+	out := buf.String()
+	for _, want := range []string{"Imported: 1", "Invalid:  1", "PLAN-2: invalid plan JSON"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Skipped") {
+		t.Errorf("expected no skipped section, got:\n%s", out)
+	}
+}
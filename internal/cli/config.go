@@ -1,13 +1,18 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/tracker"
 	"github.com/charleslr/jig/internal/ui"
 )
 
@@ -57,6 +62,17 @@ var configSetCmd = &cobra.Command{
 			printSuccess("Linear API key saved securely")
 			return nil
 		}
+		if key == "gitlab.token" {
+			store, err := config.NewStore()
+			if err != nil {
+				return err
+			}
+			if err := store.SetGitLabToken(value); err != nil {
+				return err
+			}
+			printSuccess("GitLab token saved securely")
+			return nil
+		}
 
 		if err := config.Set(key, value); err != nil {
 			return fmt.Errorf("failed to set config: %w", err)
@@ -86,11 +102,26 @@ Same as running 'jig config' without arguments.`,
 	RunE: runConfigEdit,
 }
 
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the current configuration",
+	Long: `Check that the current configuration is usable.
+
+Verifies that the tracker is recognized, that a Linear API key can be found
+and is accepted by Linear, that linear.team_id refers to an accessible team,
+and that git.worktree_dir is writable.
+
+Prints a checklist and exits non-zero if any critical check fails.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
 func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configValidateCmd)
 }
 
 func printSettings(prefix string, settings map[string]interface{}) {
@@ -243,3 +274,204 @@ var configPathCmd = &cobra.Command{
 func init() {
 	configCmd.AddCommand(configPathCmd)
 }
+
+// knownTrackers lists the values accepted for default.tracker
+var knownTrackers = []string{"linear", "gitlab", "none"}
+
+// configCheck is a single item of the 'jig config validate' checklist
+type configCheck struct {
+	Name     string
+	Passed   bool
+	Critical bool // a failed critical check makes the command fail
+	Detail   string
+}
+
+// configValidateDeps holds dependencies for config validation (for testability)
+type configValidateDeps struct {
+	newStore func() (*config.Store, error)
+	getTeams func(ctx context.Context, cfg *config.Config, apiKey string) ([]tracker.Team, error)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	deps := configValidateDeps{
+		newStore: config.NewStore,
+		getTeams: func(ctx context.Context, cfg *config.Config, apiKey string) ([]tracker.Team, error) {
+			client, err := newLinearClient(cfg, apiKey)
+			if err != nil {
+				return nil, err
+			}
+			return client.GetTeams(ctx)
+		},
+	}
+
+	var failed int
+	for _, check := range validateConfigWithDeps(ctx, cfg, deps) {
+		switch {
+		case check.Passed:
+			printSuccess(fmt.Sprintf("%s: %s", check.Name, check.Detail))
+		case check.Critical:
+			fmt.Printf("✗ %s: %s\n", check.Name, check.Detail)
+			failed++
+		default:
+			printWarning(fmt.Sprintf("%s: %s", check.Name, check.Detail))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d critical configuration check(s) failed", failed)
+	}
+	return nil
+}
+
+// validateConfigWithDeps runs every configuration check and returns the checklist.
+// Linear checks only run when Linear is the configured tracker, and each one
+// depends on the previous one passing.
+func validateConfigWithDeps(ctx context.Context, cfg *config.Config, deps configValidateDeps) []configCheck {
+	var checks []configCheck
+
+	trackerCheck := configCheck{Name: "Tracker", Critical: true}
+	for _, known := range knownTrackers {
+		if cfg.Default.Tracker == known {
+			trackerCheck.Passed = true
+		}
+	}
+	if trackerCheck.Passed {
+		trackerCheck.Detail = cfg.Default.Tracker
+	} else {
+		trackerCheck.Detail = fmt.Sprintf("unknown tracker %q (default.tracker must be one of: %s)", cfg.Default.Tracker, strings.Join(knownTrackers, ", "))
+	}
+	checks = append(checks, trackerCheck)
+
+	if cfg.Default.Tracker == "linear" {
+		checks = append(checks, checkLinearConfig(ctx, cfg, deps)...)
+	}
+
+	return append(checks, checkWorktreeDir(cfg))
+}
+
+// checkLinearConfig checks that a Linear API key is configured and works, and
+// that the configured team (if any) is accessible with it
+func checkLinearConfig(ctx context.Context, cfg *config.Config, deps configValidateDeps) []configCheck {
+	keyCheck := configCheck{Name: "Linear API key", Critical: true}
+	apiKey, source, err := resolveLinearAPIKey(cfg, deps.newStore)
+	switch {
+	case err != nil:
+		keyCheck.Detail = err.Error()
+	case apiKey == "":
+		keyCheck.Detail = "not configured (run 'jig config set linear.api_key <KEY>')"
+	default:
+		keyCheck.Passed = true
+		keyCheck.Detail = "found in " + source
+	}
+	if !keyCheck.Passed {
+		return []configCheck{keyCheck}
+	}
+
+	connCheck := configCheck{Name: "Linear connection", Critical: true}
+	teams, err := deps.getTeams(ctx, cfg, apiKey)
+	if err != nil {
+		connCheck.Detail = fmt.Sprintf("could not list teams: %v", friendlyTrackerError(err))
+		return []configCheck{keyCheck, connCheck}
+	}
+	connCheck.Passed = true
+	connCheck.Detail = fmt.Sprintf("API key accepted, %d team(s) accessible", len(teams))
+	checks := []configCheck{keyCheck, connCheck}
+
+	if cfg.Linear.TeamID == "" {
+		return append(checks, configCheck{
+			Name:   "Linear team",
+			Detail: "linear.team_id is not set; issues can't be created until a team is configured",
+		})
+	}
+
+	teamCheck := configCheck{Name: "Linear team", Critical: true}
+	for _, team := range teams {
+		if team.ID == cfg.Linear.TeamID || strings.EqualFold(team.Key, cfg.Linear.TeamID) {
+			teamCheck.Passed = true
+			teamCheck.Detail = fmt.Sprintf("%s (%s)", team.Name, team.Key)
+		}
+	}
+	if !teamCheck.Passed {
+		teamCheck.Detail = fmt.Sprintf("linear.team_id %q does not match any accessible team", cfg.Linear.TeamID)
+	}
+	return append(checks, teamCheck)
+}
+
+// resolveLinearAPIKey finds the Linear API key the same way the Linear client
+// does (credentials store first, then config) and reports where it was found
+func resolveLinearAPIKey(cfg *config.Config, newStore func() (*config.Store, error)) (key, source string, err error) {
+	store, err := newStore()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get config store: %w", err)
+	}
+	key, err = store.GetLinearAPIKey()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if key != "" {
+		return key, "credentials store", nil
+	}
+	if cfg.Linear.APIKey != "" {
+		return cfg.Linear.APIKey, "config file", nil
+	}
+	return "", "", nil
+}
+
+// checkWorktreeDir checks that git.worktree_dir (or the default location) is
+// writable. A directory that doesn't exist yet passes if it can be created.
+func checkWorktreeDir(cfg *config.Config) configCheck {
+	check := configCheck{Name: "Worktree directory", Critical: true}
+
+	dir := cfg.Git.WorktreeDir
+	if dir == "" {
+		jigDir, err := config.JigDir()
+		if err != nil {
+			check.Detail = err.Error()
+			return check
+		}
+		dir = filepath.Join(jigDir, "worktrees")
+	}
+	if strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			check.Detail = fmt.Sprintf("failed to expand %s: %v", dir, err)
+			return check
+		}
+		dir = filepath.Join(home, dir[2:])
+	}
+
+	// Probe the closest existing ancestor without creating anything
+	probe := dir
+	for {
+		info, err := os.Stat(probe)
+		if err == nil {
+			if !info.IsDir() {
+				check.Detail = fmt.Sprintf("%s is not a directory", probe)
+				return check
+			}
+			break
+		}
+		parent := filepath.Dir(probe)
+		if !os.IsNotExist(err) || parent == probe {
+			check.Detail = fmt.Sprintf("cannot access %s: %v", dir, err)
+			return check
+		}
+		probe = parent
+	}
+
+	f, err := os.CreateTemp(probe, ".jig-write-check-*")
+	if err != nil {
+		check.Detail = fmt.Sprintf("%s is not writable: %v", dir, err)
+		return check
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	check.Passed = true
+	check.Detail = dir
+	return check
+}
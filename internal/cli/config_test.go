@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+func TestValidateConfigWithDeps(t *testing.T) {
+	ctx := context.Background()
+	teams := []tracker.Team{{ID: "team-uuid-1", Key: "NUM", Name: "Numbers"}}
+
+	// newValidateDeps returns deps backed by a temporary credentials store
+	// holding apiKey, and a stubbed teams call that counts invocations
+	newValidateDeps := func(t *testing.T, apiKey string, teamsErr error) (configValidateDeps, *int) {
+		t.Helper()
+		storeDir := t.TempDir()
+		store, err := config.NewStoreWithPath(storeDir)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if apiKey != "" {
+			if err := store.SetLinearAPIKey(apiKey); err != nil {
+				t.Fatalf("failed to set API key: %v", err)
+			}
+		}
+
+		calls := 0
+		deps := configValidateDeps{
+			newStore: func() (*config.Store, error) {
+				return config.NewStoreWithPath(storeDir)
+			},
+			getTeams: func(ctx context.Context, cfg *config.Config, key string) ([]tracker.Team, error) {
+				calls++
+				if key != apiKey {
+					t.Errorf("expected API key %q, got %q", apiKey, key)
+				}
+				return teams, teamsErr
+			},
+		}
+		return deps, &calls
+	}
+
+	newCfg := func(t *testing.T, trackerName, teamID string) *config.Config {
+		return &config.Config{
+			Default: config.DefaultConfig{Tracker: trackerName},
+			Linear:  config.LinearConfig{TeamID: teamID},
+			Git:     config.GitConfig{WorktreeDir: t.TempDir()},
+		}
+	}
+
+	// checksByName indexes a checklist by check name
+	checksByName := func(checks []configCheck) map[string]configCheck {
+		byName := make(map[string]configCheck)
+		for _, c := range checks {
+			byName[c.Name] = c
+		}
+		return byName
+	}
+
+	t.Run("valid linear configuration passes every check", func(t *testing.T) {
+		deps, calls := newValidateDeps(t, "lin_api_test", nil)
+
+		checks := validateConfigWithDeps(ctx, newCfg(t, "linear", "NUM"), deps)
+
+		if len(checks) != 5 {
+			t.Errorf("expected 5 checks, got %d: %+v", len(checks), checks)
+		}
+		for _, c := range checks {
+			if !c.Passed {
+				t.Errorf("expected %s to pass, got: %s", c.Name, c.Detail)
+			}
+		}
+		if *calls != 1 {
+			t.Errorf("expected teams to be fetched once, got %d", *calls)
+		}
+	})
+
+	t.Run("unknown tracker fails and skips Linear checks", func(t *testing.T) {
+		deps, calls := newValidateDeps(t, "lin_api_test", nil)
+
+		checks := checksByName(validateConfigWithDeps(ctx, newCfg(t, "jira", ""), deps))
+
+		trackerCheck := checks["Tracker"]
+		if trackerCheck.Passed || !trackerCheck.Critical {
+			t.Errorf("expected critical tracker failure, got %+v", trackerCheck)
+		}
+		if !strings.Contains(trackerCheck.Detail, `"jira"`) {
+			t.Errorf("expected detail to name the tracker, got %q", trackerCheck.Detail)
+		}
+		if _, ok := checks["Linear API key"]; ok {
+			t.Error("expected Linear checks to be skipped")
+		}
+		if *calls != 0 {
+			t.Errorf("expected no teams call, got %d", *calls)
+		}
+	})
+
+	t.Run("tracker none skips Linear checks", func(t *testing.T) {
+		deps, _ := newValidateDeps(t, "", nil)
+
+		checks := validateConfigWithDeps(ctx, newCfg(t, "none", ""), deps)
+
+		if len(checks) != 2 {
+			t.Errorf("expected tracker and worktree checks only, got %+v", checks)
+		}
+	})
+
+	t.Run("missing API key fails without pinging Linear", func(t *testing.T) {
+		deps, calls := newValidateDeps(t, "", nil)
+
+		checks := checksByName(validateConfigWithDeps(ctx, newCfg(t, "linear", ""), deps))
+
+		if key := checks["Linear API key"]; key.Passed || !key.Critical {
+			t.Errorf("expected critical API key failure, got %+v", key)
+		}
+		if *calls != 0 {
+			t.Errorf("expected no teams call, got %d", *calls)
+		}
+	})
+
+	t.Run("API key from config file is used", func(t *testing.T) {
+		deps, _ := newValidateDeps(t, "", nil)
+		deps.getTeams = func(ctx context.Context, cfg *config.Config, key string) ([]tracker.Team, error) {
+			return teams, nil
+		}
+		cfg := newCfg(t, "linear", "")
+		cfg.Linear.APIKey = "lin_api_config"
+
+		checks := checksByName(validateConfigWithDeps(ctx, cfg, deps))
+
+		if key := checks["Linear API key"]; !key.Passed || key.Detail != "found in config file" {
+			t.Errorf("expected API key from config file, got %+v", key)
+		}
+	})
+
+	t.Run("rejected API key fails the connection check", func(t *testing.T) {
+		deps, _ := newValidateDeps(t, "lin_api_bad", errors.New("authentication failed"))
+
+		checks := checksByName(validateConfigWithDeps(ctx, newCfg(t, "linear", "NUM"), deps))
+
+		conn := checks["Linear connection"]
+		if conn.Passed || !strings.Contains(conn.Detail, "authentication failed") {
+			t.Errorf("expected connection failure, got %+v", conn)
+		}
+		if _, ok := checks["Linear team"]; ok {
+			t.Error("expected team check to be skipped")
+		}
+	})
+
+	t.Run("unknown team fails", func(t *testing.T) {
+		deps, _ := newValidateDeps(t, "lin_api_test", nil)
+
+		checks := checksByName(validateConfigWithDeps(ctx, newCfg(t, "linear", "OTHER"), deps))
+
+		if team := checks["Linear team"]; team.Passed || !team.Critical {
+			t.Errorf("expected critical team failure, got %+v", team)
+		}
+	})
+
+	t.Run("unset team is a warning", func(t *testing.T) {
+		deps, _ := newValidateDeps(t, "lin_api_test", nil)
+
+		checks := checksByName(validateConfigWithDeps(ctx, newCfg(t, "linear", ""), deps))
+
+		if team := checks["Linear team"]; team.Passed || team.Critical {
+			t.Errorf("expected non-critical team warning, got %+v", team)
+		}
+	})
+}
+
+func TestCheckWorktreeDir(t *testing.T) {
+	t.Run("missing directory passes without being created", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "nested", "worktrees")
+
+		check := checkWorktreeDir(&config.Config{Git: config.GitConfig{WorktreeDir: dir}})
+
+		if !check.Passed {
+			t.Errorf("expected check to pass, got: %s", check.Detail)
+		}
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("expected %s not to be created", dir)
+		}
+	})
+
+	t.Run("file in place of directory fails", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "worktrees")
+		os.WriteFile(path, []byte("not a dir"), 0644)
+
+		check := checkWorktreeDir(&config.Config{Git: config.GitConfig{WorktreeDir: path}})
+
+		if check.Passed || !strings.Contains(check.Detail, "not a directory") {
+			t.Errorf("expected not-a-directory failure, got %+v", check)
+		}
+	})
+
+	t.Run("read-only directory fails", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("permissions are not enforced for root")
+		}
+		dir := t.TempDir()
+		os.Chmod(dir, 0555)
+		defer os.Chmod(dir, 0755)
+
+		check := checkWorktreeDir(&config.Config{Git: config.GitConfig{WorktreeDir: dir}})
+
+		if check.Passed || !strings.Contains(check.Detail, "not writable") {
+			t.Errorf("expected not-writable failure, got %+v", check)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+const diffContextLines = 3
+
+// diffOp is a single line of a line diff: ' ' (unchanged), '-' (removed) or '+' (added)
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns a unified diff turning from into to, or an empty
+// string if they are identical
+func unifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitDiffLines(from), splitDiffLines(to))
+
+	// Line positions in from/to before each op, for hunk headers
+	fromPos := make([]int, len(ops)+1)
+	toPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if op.kind != '+' {
+			fromPos[i+1]++
+		}
+		if op.kind != '-' {
+			toPos[i+1]++
+		}
+	}
+
+	// Group changes into hunks, merging changes whose context overlaps
+	type hunk struct{ start, end int }
+	var hunks []hunk
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		start := max(i-diffContextLines, 0)
+		end := min(i+diffContextLines+1, len(ops))
+		if n := len(hunks); n > 0 && start <= hunks[n-1].end {
+			hunks[n-1].end = end
+			continue
+		}
+		hunks = append(hunks, hunk{start, end})
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", fromName, toName))
+	for _, h := range hunks {
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			hunkRange(fromPos[h.start], fromPos[h.end]-fromPos[h.start]),
+			hunkRange(toPos[h.start], toPos[h.end]-toPos[h.start])))
+		for _, op := range ops[h.start:h.end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// hunkRange formats the "start,count" part of a hunk header. Lines are
+// numbered from 1; an empty range refers to the line before it.
+func hunkRange(pos, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", pos)
+	}
+	return fmt.Sprintf("%d,%d", pos+1, count)
+}
+
+// diffLines computes a line diff using the longest common subsequence.
+// Plans are small, so the quadratic table is not a concern.
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// splitDiffLines splits text into lines, ignoring trailing newlines
+func splitDiffLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
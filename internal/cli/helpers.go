@@ -2,9 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
 
 	"github.com/charleslr/jig/internal/plan"
 	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker/linear"
 	"github.com/charleslr/jig/internal/ui"
 )
 
@@ -45,9 +49,71 @@ func lookupPlanByID(id string) (*plan.Plan, string, error) {
 		return result.ByIssueID, result.ByIssueID.ID, nil
 	}
 
+	if result.IsAmbiguous() {
+		return selectAmbiguousPlan(id, result.IssueMatches)
+	}
+
 	p := result.Plan()
 	if p == nil {
 		return nil, "", nil
 	}
 	return p, p.ID, nil
 }
+
+// selectAmbiguousPlan lets the user pick one of several plans linked to the same issue.
+// In non-interactive mode, returns an error listing the candidate plan IDs.
+func selectAmbiguousPlan(issueID string, plans []*plan.Plan) (*plan.Plan, string, error) {
+	if !ui.IsInteractive() {
+		ids := make([]string, len(plans))
+		for i, p := range plans {
+			ids[i] = p.ID
+		}
+		return nil, "", fmt.Errorf("issue %s is linked to multiple plans (%s); specify a plan ID", issueID, strings.Join(ids, ", "))
+	}
+
+	printWarning(fmt.Sprintf("'%s' is linked to %d plans", issueID, len(plans)))
+	options := make([]ui.SelectOption, len(plans))
+	for i, p := range plans {
+		options[i] = ui.SelectOption{
+			Label:       fmt.Sprintf("Plan: %s", p.Title),
+			Value:       p.ID,
+			Description: fmt.Sprintf("Plan ID: %s, Status: %s", p.ID, p.Status),
+		}
+	}
+	selected, err := ui.RunSelect("Which plan do you want to use?", options)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to select plan: %w", err)
+	}
+	for _, p := range plans {
+		if p.ID == selected {
+			return p, p.ID, nil
+		}
+	}
+	return nil, "", nil // User cancelled
+}
+
+// linearAuthHint tells the user how to fix a rejected Linear API key
+const linearAuthHint = "Linear rejected the API key; check it or set a new one with 'jig config set linear.api_key <KEY>'"
+
+// friendlyTrackerError adds a hint on how to fix the problem to tracker errors
+// the user can act on, and returns other errors unchanged
+func friendlyTrackerError(err error) error {
+	if linear.IsAuthError(err) {
+		return fmt.Errorf("%s: %w", linearAuthHint, err)
+	}
+	return err
+}
+
+// openInBrowser opens url with the platform's default browser
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Run()
+}
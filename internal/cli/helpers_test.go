@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker/linear"
+)
+
+// setupLookupCache creates an isolated cache seeded with the given plans
+func setupLookupCache(t *testing.T, plans ...*plan.Plan) {
+	t.Helper()
+	jigHome := createTestJigHome(t)
+	t.Cleanup(func() { os.RemoveAll(jigHome) })
+	t.Setenv("JIG_HOME", jigHome)
+
+	if err := state.Init(); err != nil {
+		t.Fatalf("failed to initialize cache: %v", err)
+	}
+	for _, p := range plans {
+		if err := state.DefaultCache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", p.ID, err)
+		}
+	}
+}
+
+func TestLookupPlanByID(t *testing.T) {
+	t.Run("matches exact plan ID", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-1", "First", "tester")
+		p.IssueID = "NUM-1"
+		setupLookupCache(t, p)
+
+		found, planID, err := lookupPlanByID("PLAN-1")
+		if err != nil {
+			t.Fatalf("lookupPlanByID() error = %v", err)
+		}
+		if found == nil || planID != "PLAN-1" {
+			t.Errorf("expected PLAN-1, got %v (%q)", found, planID)
+		}
+	})
+
+	t.Run("matches linked issue ID", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-2", "Second", "tester")
+		p.IssueID = "NUM-41"
+		setupLookupCache(t, p)
+
+		found, planID, err := lookupPlanByID("NUM-41")
+		if err != nil {
+			t.Fatalf("lookupPlanByID() error = %v", err)
+		}
+		if found == nil || planID != "PLAN-2" {
+			t.Errorf("expected PLAN-2, got %v (%q)", found, planID)
+		}
+	})
+
+	t.Run("errors on ambiguous issue match in non-interactive mode", func(t *testing.T) {
+		a := plan.NewPlan("PLAN-A", "A", "tester")
+		a.IssueID = "NUM-7"
+		b := plan.NewPlan("PLAN-B", "B", "tester")
+		b.IssueID = "NUM-7"
+		setupLookupCache(t, a, b)
+
+		_, _, err := lookupPlanByID("NUM-7")
+		if err == nil {
+			t.Fatal("expected error for ambiguous issue match")
+		}
+		if !strings.Contains(err.Error(), "PLAN-A") || !strings.Contains(err.Error(), "PLAN-B") {
+			t.Errorf("expected error to list candidate plans, got: %v", err)
+		}
+	})
+
+	t.Run("returns nil when nothing matches", func(t *testing.T) {
+		setupLookupCache(t)
+
+		found, _, err := lookupPlanByID("NUM-404")
+		if err != nil {
+			t.Fatalf("lookupPlanByID() error = %v", err)
+		}
+		if found != nil {
+			t.Errorf("expected no plan, got %v", found)
+		}
+	})
+}
+
+func TestFriendlyTrackerError(t *testing.T) {
+	authErr := fmt.Errorf("failed to create issue: %w", &linear.APIError{Code: "AUTHENTICATION_ERROR", Message: "Authentication required"})
+	err := friendlyTrackerError(authErr)
+	if !strings.Contains(err.Error(), "jig config set linear.api_key") {
+		t.Errorf("expected a hint on fixing the API key, got %q", err)
+	}
+	if !strings.Contains(err.Error(), "AUTHENTICATION_ERROR") || !errors.Is(err, authErr) {
+		t.Errorf("expected the original error to be kept, got %q", err)
+	}
+
+	otherErr := &linear.APIError{Code: "INVALID_INPUT", Message: "teamId must be a UUID"}
+	if got := friendlyTrackerError(otherErr); got != error(otherErr) {
+		t.Errorf("expected non-auth errors unchanged, got %q", got)
+	}
+	if friendlyTrackerError(nil) != nil {
+		t.Error("expected nil to stay nil")
+	}
+}
@@ -2,13 +2,21 @@ package cli
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/logging"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
 )
 
 // HookInput represents the JSON input from Claude Code hooks
@@ -61,10 +69,35 @@ var hookMarkPlanSavedCmd = &cobra.Command{
 	},
 }
 
+var hookPostImplementCmd = &cobra.Command{
+	Use:   "post-implement",
+	Short: "Hook for the end of an implementation session",
+	Long: `This hook is invoked by Claude Code when an implementation session ends.
+It moves the issue linked to the session's worktree to in_review (or --status)
+and, with --comment, posts a summary comment on the issue.
+
+The hook does nothing when the worktree isn't linked to an issue, or when the
+issue is already in the target status or has been completed or canceled, so it
+is safe to run more than once.
+
+To enable it, add a SessionEnd hook to .claude/settings.json that runs:
+  jig hook post-implement`,
+	RunE: runHookPostImplement,
+}
+
+var (
+	hookPostImplementStatus  string
+	hookPostImplementComment bool
+)
+
 func init() {
 	hookCmd.AddCommand(hookExitPlanModeCmd)
 	hookCmd.AddCommand(hookMarkSkipSaveCmd)
 	hookCmd.AddCommand(hookMarkPlanSavedCmd)
+	hookCmd.AddCommand(hookPostImplementCmd)
+
+	hookPostImplementCmd.Flags().StringVar(&hookPostImplementStatus, "status", string(tracker.StatusInReview), "status to move the issue to")
+	hookPostImplementCmd.Flags().BoolVar(&hookPostImplementComment, "comment", false, "post a summary comment on the issue")
 
 	// Add session flag to marker commands for session-scoped markers
 	hookMarkSkipSaveCmd.Flags().String("session", "", "Session ID for session-scoped markers")
@@ -103,24 +136,27 @@ func runHookExitPlanMode(cmd *cobra.Command, args []string) error {
 	// Get session ID for session-scoped markers
 	sessionID := hookInput.SessionID
 
+	// Markers left behind by a session whose hook never ran are ignored
+	maxAge := config.Get().Plan.GetMarkerMaxAge()
+
 	// Check for "plan saved" marker (session-scoped if we have session ID)
 	markerPath := getSessionMarkerPath(sessionID, "plan-saved")
-	if _, err := os.Stat(markerPath); err == nil {
-		// Plan already saved, allow exit and clean up marker
-		os.Remove(markerPath)
+	if consumeMarker(markerPath, maxAge) {
+		// Plan already saved, allow exit
 		outputHookResponse("allow", "Plan has been saved. You may now exit plan mode.")
 		return nil
 	}
 
 	// Check for "skip save" marker (user chose not to save)
 	skipMarkerPath := getSessionMarkerPath(sessionID, "skip-save")
-	if _, err := os.Stat(skipMarkerPath); err == nil {
-		// User chose to skip, allow exit and clean up marker
-		os.Remove(skipMarkerPath)
+	if consumeMarker(skipMarkerPath, maxAge) {
+		// User chose to skip, allow exit
 		outputHookResponse("allow", "")
 		return nil
 	}
 
+	logging.Debug("no session marker", "session", sessionID)
+
 	// Find plan file by extracting slug from session transcript
 	planFile := findClaudePlan(hookInput.TranscriptPath)
 
@@ -135,6 +171,153 @@ func runHookExitPlanMode(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// hookPostImplementDeps holds the dependencies of 'jig hook post-implement'
+type hookPostImplementDeps struct {
+	getWorktree   func(path string) (*state.WorktreeInfo, error)
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	getTracker    func() (tracker.Tracker, error)
+}
+
+// hookPostImplementResult describes what 'jig hook post-implement' did
+type hookPostImplementResult struct {
+	// Issue is the linked issue, or nil if the session has none
+	Issue *tracker.Issue
+	// Transitioned is true if the issue was moved to the target status
+	Transitioned bool
+	// Commented is true if a summary comment was posted
+	Commented bool
+}
+
+func runHookPostImplement(cmd *cobra.Command, args []string) error {
+	status, err := parseIssueStatus(hookPostImplementStatus)
+	if err != nil {
+		return err
+	}
+
+	hookInput, err := readHookInput()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		hookInput = &HookInput{}
+	}
+
+	dir := hookInput.Cwd
+	if dir == "" {
+		if dir, err = os.Getwd(); err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+	}
+
+	cfg := config.Get()
+	deps := hookPostImplementDeps{
+		getWorktree: func(path string) (*state.WorktreeInfo, error) {
+			if err := state.InitWorktreeState(); err != nil {
+				return nil, err
+			}
+			return state.DefaultWorktreeState.GetByPath(path)
+		},
+		getCachedPlan: func(id string) (*state.CachedPlan, error) {
+			if err := state.Init(); err != nil {
+				return nil, err
+			}
+			return state.DefaultCache.GetCachedPlan(id)
+		},
+		getTracker: func() (tracker.Tracker, error) { return getTracker(cfg) },
+	}
+
+	result, err := runHookPostImplementWithDeps(context.Background(), dir, status, hookPostImplementComment, deps)
+	if err != nil {
+		// Never fail the session over a tracker problem
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", friendlyTrackerError(err))
+		return nil
+	}
+	if result.Transitioned {
+		fmt.Fprintf(os.Stderr, "Moved %s to %s\n", result.Issue.Identifier, status)
+	}
+	return nil
+}
+
+// runHookPostImplementWithDeps moves the issue linked to the worktree at dir
+// to status, then posts a summary comment if comment is set. It does
+// nothing if no issue is linked, and leaves issues that are already in status,
+// completed or canceled alone.
+func runHookPostImplementWithDeps(ctx context.Context, dir string, status tracker.Status, comment bool, deps hookPostImplementDeps) (*hookPostImplementResult, error) {
+	result := &hookPostImplementResult{}
+
+	info, err := deps.getWorktree(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up worktree: %w", err)
+	}
+	if info == nil {
+		logging.Debug("not a jig worktree", "path", dir)
+		return result, nil
+	}
+
+	issueID := info.IssueID
+	var planTitle string
+	if info.PlanID != "" {
+		cached, err := deps.getCachedPlan(info.PlanID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get plan: %w", err)
+		}
+		if cached != nil && cached.Plan != nil {
+			planTitle = cached.Plan.Title
+			if issueID == "" {
+				issueID = cached.Plan.IssueID
+			}
+		}
+	}
+	if issueID == "" {
+		logging.Debug("worktree has no linked issue", "path", dir)
+		return result, nil
+	}
+
+	t, err := deps.getTracker()
+	if err != nil {
+		return nil, err
+	}
+	issue, err := t.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	result.Issue = issue
+
+	switch issue.Status {
+	case status, tracker.StatusDone, tracker.StatusCanceled:
+		logging.Debug("issue needs no transition", "issue", issue.Identifier, "status", issue.Status)
+		return result, nil
+	}
+
+	if err := t.TransitionIssue(ctx, issue.ID, status); err != nil {
+		return nil, fmt.Errorf("failed to move %s to %s: %w", issue.Identifier, status, err)
+	}
+	result.Transitioned = true
+
+	// Comment only alongside a transition, so running the hook again doesn't
+	// post the summary twice
+	if comment {
+		if _, err := t.AddComment(ctx, issue.ID, buildPostImplementComment(info.Branch, planTitle)); err != nil {
+			return nil, fmt.Errorf("failed to comment on %s: %w", issue.Identifier, err)
+		}
+		result.Commented = true
+	}
+	return result, nil
+}
+
+// buildPostImplementComment builds the summary comment posted when an
+// implementation session ends
+func buildPostImplementComment(branch, planTitle string) string {
+	var b strings.Builder
+	b.WriteString("Implementation session finished")
+	if branch != "" {
+		fmt.Fprintf(&b, " on branch `%s`", branch)
+	}
+	b.WriteString(".")
+	if planTitle != "" {
+		fmt.Fprintf(&b, "\n\nPlan: %s", planTitle)
+	}
+	return b.String()
+}
+
 // findPlanFile looks for a plan file in the current directory
 func findPlanFile() string {
 	candidates := []string{"plan.md", "PLAN.md", "plan.markdown"}
@@ -237,13 +420,55 @@ func getSessionMarkerPath(sessionID, name string) string {
 // createMarker creates a marker file (legacy, non-session-scoped)
 func createMarker(name string) error {
 	path := getMarkerPath(name)
-	return os.WriteFile(path, []byte{}, 0644)
+	logging.Debug("creating marker", "marker", path)
+	return writeMarker(path)
 }
 
 // createSessionMarker creates a session-scoped marker file
 func createSessionMarker(sessionID, name string) error {
 	path := getSessionMarkerPath(sessionID, name)
-	return os.WriteFile(path, []byte{}, 0644)
+	logging.Debug("creating session marker", "session", sessionID, "marker", path)
+	return writeMarker(path)
+}
+
+// writeMarker writes a marker file holding the time it was created
+func writeMarker(path string) error {
+	return os.WriteFile(path, []byte(time.Now().UTC().Format(time.RFC3339)), 0644)
+}
+
+// markerCreatedAt returns when the marker at path was created, and false if
+// there is no marker. Markers written by older versions are empty, so their
+// modification time is used instead.
+func markerCreatedAt(path string) (time.Time, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if created, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data))); err == nil {
+			return created, true
+		}
+	}
+	return info.ModTime(), true
+}
+
+// consumeMarker reports whether the marker at path exists and is no older
+// than maxAge, removing it either way. A stale marker was left by a session
+// that never reached the hook, so it's treated as absent.
+func consumeMarker(path string, maxAge time.Duration) bool {
+	created, ok := markerCreatedAt(path)
+	if !ok {
+		return false
+	}
+	os.Remove(path)
+
+	if age := time.Since(created); age > maxAge {
+		logging.Debug("ignoring stale marker", "marker", path, "age", age)
+		return false
+	}
+	logging.Debug("session marker found", "marker", path)
+	return true
 }
 
 // HookSpecificOutput represents the inner payload for Claude Code hook responses
@@ -1,12 +1,19 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
 )
 
 func TestReadHookInput(t *testing.T) {
@@ -80,6 +87,9 @@ func TestReadHookInput(t *testing.T) {
 }
 
 func TestGetSessionMarkerPath(t *testing.T) {
+	// The legacy path creates .jig in the working directory
+	t.Chdir(t.TempDir())
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		t.Fatalf("failed to get home directory: %v", err)
@@ -358,6 +368,55 @@ func TestCreateSessionMarker(t *testing.T) {
 	}
 }
 
+func TestConsumeMarker(t *testing.T) {
+	dir := t.TempDir()
+	markerPath := filepath.Join(dir, "plan-saved.marker")
+
+	t.Run("fresh marker allows", func(t *testing.T) {
+		if err := writeMarker(markerPath); err != nil {
+			t.Fatalf("writeMarker() error = %v", err)
+		}
+		if !consumeMarker(markerPath, time.Hour) {
+			t.Error("expected a fresh marker to be found")
+		}
+		if _, err := os.Stat(markerPath); !os.IsNotExist(err) {
+			t.Error("expected the marker to be removed")
+		}
+	})
+
+	t.Run("stale marker is ignored", func(t *testing.T) {
+		stale := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+		os.WriteFile(markerPath, []byte(stale), 0644)
+
+		if consumeMarker(markerPath, time.Hour) {
+			t.Error("expected a stale marker to be ignored")
+		}
+		if _, err := os.Stat(markerPath); !os.IsNotExist(err) {
+			t.Error("expected the stale marker to be removed")
+		}
+	})
+
+	t.Run("empty marker falls back to its modification time", func(t *testing.T) {
+		os.WriteFile(markerPath, []byte{}, 0644)
+		if !consumeMarker(markerPath, time.Hour) {
+			t.Error("expected a freshly written legacy marker to be found")
+		}
+
+		os.WriteFile(markerPath, []byte{}, 0644)
+		old := time.Now().Add(-2 * time.Hour)
+		os.Chtimes(markerPath, old, old)
+		if consumeMarker(markerPath, time.Hour) {
+			t.Error("expected an old legacy marker to be ignored")
+		}
+	})
+
+	t.Run("missing marker", func(t *testing.T) {
+		if consumeMarker(filepath.Join(dir, "missing.marker"), time.Hour) {
+			t.Error("expected no marker")
+		}
+	})
+}
+
 func TestBuildExitPlanModePrompt(t *testing.T) {
 	t.Run("with session ID", func(t *testing.T) {
 		prompt := buildExitPlanModePrompt("/path/to/plan.md", "test-session-123")
@@ -973,4 +1032,191 @@ func TestRunHookExitPlanMode(t *testing.T) {
 			t.Error("session-scoped plan-saved marker should be removed after allowing exit")
 		}
 	})
+
+	t.Run("ignores a stale plan-saved marker", func(t *testing.T) {
+		jigDir := filepath.Join(tmpDir, ".jig")
+		os.MkdirAll(jigDir, 0755)
+		markerPath := filepath.Join(jigDir, "plan-saved.marker")
+		stale := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+		os.WriteFile(markerPath, []byte(stale), 0644)
+		defer os.Remove(markerPath)
+
+		oldStdin := os.Stdin
+		stdinR, stdinW, _ := os.Pipe()
+		stdinW.Close()
+		os.Stdin = stdinR
+		defer func() { os.Stdin = oldStdin }()
+
+		output, err := captureStdout(t, func() error { return runHookExitPlanMode(nil, nil) })
+		if err != nil {
+			t.Fatalf("runHookExitPlanMode returned error: %v", err)
+		}
+
+		// Without a plan file the exit is allowed, but not as a saved plan
+		if strings.Contains(output, "Plan has been saved") {
+			t.Errorf("expected the stale marker to be ignored, got: %s", output)
+		}
+		if _, err := os.Stat(markerPath); !os.IsNotExist(err) {
+			t.Error("stale plan-saved marker should be removed")
+		}
+	})
+}
+
+func TestRunHookPostImplementWithDeps(t *testing.T) {
+	ctx := context.Background()
+	const dir = "/worktrees/MOCK-1"
+
+	// newDeps returns deps serving info as the worktree at dir and a cached
+	// plan linked to planIssueID
+	newDeps := func(info *state.WorktreeInfo, planIssueID string, tr tracker.Tracker) hookPostImplementDeps {
+		return hookPostImplementDeps{
+			getWorktree: func(path string) (*state.WorktreeInfo, error) {
+				if path != dir {
+					return nil, nil
+				}
+				return info, nil
+			},
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				return &state.CachedPlan{Plan: &plan.Plan{ID: id, Title: "Test Plan", IssueID: planIssueID}}, nil
+			},
+			getTracker: func() (tracker.Tracker, error) { return tr, nil },
+		}
+	}
+
+	newIssue := func(t *testing.T, client *mock.Client, status tracker.Status) *tracker.Issue {
+		t.Helper()
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		if err := client.TransitionIssue(ctx, issue.ID, status); err != nil {
+			t.Fatalf("failed to set issue status: %v", err)
+		}
+		return issue
+	}
+
+	t.Run("moves the linked issue to in review", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, tracker.StatusInProgress)
+		deps := newDeps(&state.WorktreeInfo{IssueID: issue.Identifier, Path: dir, Branch: "mock-1-test"}, "", client)
+
+		result, err := runHookPostImplementWithDeps(ctx, dir, tracker.StatusInReview, false, deps)
+		if err != nil {
+			t.Fatalf("runHookPostImplementWithDeps() error = %v", err)
+		}
+		if !result.Transitioned || result.Commented {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if issue.Status != tracker.StatusInReview {
+			t.Errorf("expected status %s, got %s", tracker.StatusInReview, issue.Status)
+		}
+		if comments, _ := client.GetComments(ctx, issue.ID); len(comments) != 0 {
+			t.Errorf("expected no comments, got %d", len(comments))
+		}
+	})
+
+	t.Run("posts a summary comment", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, tracker.StatusInProgress)
+		deps := newDeps(&state.WorktreeInfo{IssueID: issue.Identifier, Path: dir, Branch: "mock-1-test", PlanID: "PLAN-1"}, "", client)
+
+		result, err := runHookPostImplementWithDeps(ctx, dir, tracker.StatusInReview, true, deps)
+		if err != nil {
+			t.Fatalf("runHookPostImplementWithDeps() error = %v", err)
+		}
+		if !result.Transitioned || !result.Commented {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		comments, _ := client.GetComments(ctx, issue.ID)
+		if len(comments) != 1 {
+			t.Fatalf("expected 1 comment, got %d", len(comments))
+		}
+		for _, want := range []string{"`mock-1-test`", "Plan: Test Plan"} {
+			if !strings.Contains(comments[0].Body, want) {
+				t.Errorf("comment %q should contain %q", comments[0].Body, want)
+			}
+		}
+	})
+
+	t.Run("uses a configured status", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, tracker.StatusInProgress)
+		deps := newDeps(&state.WorktreeInfo{IssueID: issue.Identifier, Path: dir}, "", client)
+
+		if _, err := runHookPostImplementWithDeps(ctx, dir, tracker.StatusTodo, false, deps); err != nil {
+			t.Fatalf("runHookPostImplementWithDeps() error = %v", err)
+		}
+		if issue.Status != tracker.StatusTodo {
+			t.Errorf("expected status %s, got %s", tracker.StatusTodo, issue.Status)
+		}
+	})
+
+	t.Run("falls back to the plan's issue", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, tracker.StatusInProgress)
+		deps := newDeps(&state.WorktreeInfo{Path: dir, PlanID: "PLAN-1"}, issue.Identifier, client)
+
+		result, err := runHookPostImplementWithDeps(ctx, dir, tracker.StatusInReview, false, deps)
+		if err != nil {
+			t.Fatalf("runHookPostImplementWithDeps() error = %v", err)
+		}
+		if !result.Transitioned || issue.Status != tracker.StatusInReview {
+			t.Errorf("expected plan's issue to be moved, got %+v (status %s)", result, issue.Status)
+		}
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, tracker.StatusInProgress)
+		deps := newDeps(&state.WorktreeInfo{IssueID: issue.Identifier, Path: dir}, "", client)
+
+		for i := 0; i < 2; i++ {
+			result, err := runHookPostImplementWithDeps(ctx, dir, tracker.StatusInReview, true, deps)
+			if err != nil {
+				t.Fatalf("run %d: runHookPostImplementWithDeps() error = %v", i+1, err)
+			}
+			if result.Transitioned != (i == 0) {
+				t.Errorf("run %d: Transitioned = %v", i+1, result.Transitioned)
+			}
+		}
+		if comments, _ := client.GetComments(ctx, issue.ID); len(comments) != 1 {
+			t.Errorf("expected 1 comment, got %d", len(comments))
+		}
+	})
+
+	t.Run("leaves completed issues alone", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, tracker.StatusDone)
+		deps := newDeps(&state.WorktreeInfo{IssueID: issue.Identifier, Path: dir}, "", client)
+
+		result, err := runHookPostImplementWithDeps(ctx, dir, tracker.StatusInReview, false, deps)
+		if err != nil {
+			t.Fatalf("runHookPostImplementWithDeps() error = %v", err)
+		}
+		if result.Transitioned || issue.Status != tracker.StatusDone {
+			t.Errorf("expected done issue to be left alone, got %+v (status %s)", result, issue.Status)
+		}
+	})
+
+	t.Run("no-op without a linked issue", func(t *testing.T) {
+		trackerCalled := false
+		deps := newDeps(&state.WorktreeInfo{Path: dir}, "", nil)
+		deps.getTracker = func() (tracker.Tracker, error) {
+			trackerCalled = true
+			return mock.NewClient(), nil
+		}
+
+		for _, path := range []string{dir, "/not/a/worktree"} {
+			result, err := runHookPostImplementWithDeps(ctx, path, tracker.StatusInReview, true, deps)
+			if err != nil {
+				t.Fatalf("runHookPostImplementWithDeps(%q) error = %v", path, err)
+			}
+			if result.Issue != nil || result.Transitioned {
+				t.Errorf("expected no-op for %q, got %+v", path, result)
+			}
+		}
+		if trackerCalled {
+			t.Error("tracker should not be used without a linked issue")
+		}
+	})
 }
@@ -12,7 +12,6 @@ import (
 	"github.com/charleslr/jig/internal/runner"
 	"github.com/charleslr/jig/internal/state"
 	"github.com/charleslr/jig/internal/tracker"
-	"github.com/charleslr/jig/internal/tracker/linear"
 	"github.com/charleslr/jig/internal/ui"
 )
 
@@ -30,6 +29,10 @@ This command:
 If no ISSUE is provided and the terminal is interactive, shows a table
 of cached plans to select from.
 
+Use --phase to work through a phased plan one phase at a time: the session
+only gets that phase (plus the problem statement and proposed solution for
+context), and the phase is marked in-progress.
+
 After your implementation session, use 'gh pr create' to open a PR.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runImplement,
@@ -39,12 +42,14 @@ var (
 	implRunner       string
 	implNoLaunch     bool
 	implNoAutoAccept bool
+	implPhase        string
 )
 
 func init() {
 	implementCmd.Flags().StringVarP(&implRunner, "runner", "r", "", "coding tool to use (default from config)")
 	implementCmd.Flags().BoolVar(&implNoLaunch, "no-launch", false, "set up worktree but don't launch tool")
 	implementCmd.Flags().BoolVar(&implNoAutoAccept, "no-auto-accept", false, "disable automatic acceptance of file edits")
+	implementCmd.Flags().StringVar(&implPhase, "phase", "", "implement only the phase with this ID")
 }
 
 func runImplement(cmd *cobra.Command, args []string) error {
@@ -135,16 +140,26 @@ func runImplement(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Transition plan to in-progress if it's draft or approved
-	if p != nil && (p.Status == plan.StatusDraft || p.Status == plan.StatusApproved) {
-		// Create tracker syncer if configured
-		var syncer state.TrackerSyncer
-		if cfg.Default.Tracker == "linear" {
-			syncer = getLinearSyncer(cfg)
+	// Scope the session to a single phase, failing before any setup if the
+	// phase doesn't exist
+	runnerPlan := p
+	if implPhase != "" {
+		phaseDeps := planPhaseDeps{
+			getCachedPlan: state.DefaultCache.GetCachedPlan,
+			savePlan:      state.DefaultCache.SavePlan,
+		}
+		updated, scoped, err := implementPhaseWithDeps(p, implPhase, phaseDeps)
+		if err != nil {
+			return err
 		}
+		p, runnerPlan = updated, scoped
+		printInfo(fmt.Sprintf("Implementing phase %s of %s", implPhase, p.ID))
+	}
 
+	// Transition plan to in-progress if it's draft or approved
+	if p != nil && (p.Status == plan.StatusDraft || p.Status == plan.StatusApproved) {
 		// Use PlanStatusManager for atomic cache + tracker update
-		mgr := state.NewPlanStatusManager(state.DefaultCache, syncer)
+		mgr := state.NewPlanStatusManager(state.DefaultCache, getTrackerSyncer(cfg))
 		result, err := mgr.StartProgress(ctx, p)
 		if err != nil {
 			printWarning(fmt.Sprintf("Could not transition plan to in-progress: %v", err))
@@ -228,7 +243,7 @@ func runImplement(cmd *cobra.Command, args []string) error {
 	// Prepare the runner context (writes plan to .jig/plan.md)
 	// This doesn't require the runner binary to be available
 	prepOpts := &runner.PrepareOpts{
-		Plan:        p,
+		Plan:        runnerPlan,
 		WorktreeDir: worktreePath,
 		PromptType:  runner.PromptTypeImplement,
 	}
@@ -277,24 +292,36 @@ func runImplement(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// getLinearSyncer creates a Linear client that implements TrackerSyncer.
-// Returns nil if Linear is not properly configured.
-func getLinearSyncer(cfg *config.Config) state.TrackerSyncer {
-	store, err := config.NewStore()
+// implementPhaseWithDeps marks a phase of a plan in-progress in the cache.
+// It returns the updated plan along with a copy scoped to the phase, which is
+// what the runner gets.
+func implementPhaseWithDeps(p *plan.Plan, phaseID string, deps planPhaseDeps) (updated, scoped *plan.Plan, err error) {
+	if _, err := p.ScopeToPhase(phaseID); err != nil {
+		return nil, nil, fmt.Errorf("%w in plan %s", err, p.ID)
+	}
+
+	updated, err = setPlanPhaseStatusWithDeps(p.ID, phaseID, string(plan.PhaseInProgress), deps)
 	if err != nil {
-		return nil
+		return nil, nil, err
 	}
 
-	apiKey, err := store.GetLinearAPIKey()
+	scoped, err = updated.ScopeToPhase(phaseID)
 	if err != nil {
-		return nil
+		return nil, nil, err
 	}
-	if apiKey == "" {
-		apiKey = cfg.Linear.APIKey
+	return updated, scoped, nil
+}
+
+// getTrackerSyncer returns the configured tracker as a TrackerSyncer.
+// Returns nil if the tracker doesn't sync plans or isn't properly configured.
+func getTrackerSyncer(cfg *config.Config) state.TrackerSyncer {
+	if !trackerSyncsPlans(cfg) {
+		return nil
 	}
-	if apiKey == "" {
+	t, err := getTracker(cfg)
+	if err != nil {
 		return nil
 	}
-
-	return linear.NewClient(apiKey, cfg.Linear.TeamID, cfg.Linear.DefaultProject)
+	syncer, _ := t.(state.TrackerSyncer)
+	return syncer
 }
@@ -224,3 +224,52 @@ func runGit(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %v failed: %v\n%s", args, err, out)
 	}
 }
+
+func TestImplementPhaseWithDeps(t *testing.T) {
+	t.Run("marks the phase in-progress and scopes the plan", func(t *testing.T) {
+		deps, saved := newPhaseDeps(t)
+		cached, _ := deps.getCachedPlan("PLAN-1")
+
+		updated, scoped, err := implementPhaseWithDeps(cached.Plan, "phase-2", deps)
+		if err != nil {
+			t.Fatalf("implementPhaseWithDeps() error = %v", err)
+		}
+
+		if *saved == nil || (*saved).Phases[1].Status != plan.PhaseInProgress {
+			t.Fatalf("expected phase-2 to be saved as in-progress, got %+v", *saved)
+		}
+		if len(updated.Phases) != 2 {
+			t.Errorf("expected the saved plan to keep every phase, got %+v", updated.Phases)
+		}
+		if len(scoped.Phases) != 1 || scoped.Phases[0].ID != "phase-2" || scoped.Phases[0].Status != plan.PhaseInProgress {
+			t.Errorf("expected the scoped plan to only have phase-2 in-progress, got %+v", scoped.Phases)
+		}
+
+		// The runner writes the scoped plan to .jig/plan.md
+		data, err := plan.Serialize(scoped)
+		if err != nil {
+			t.Fatalf("Serialize() error = %v", err)
+		}
+		for _, want := range []string{"Problem.", "Solution.", "## Phase: Write docs"} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("expected scoped plan to contain %q, got:\n%s", want, data)
+			}
+		}
+		if strings.Contains(string(data), "Add schema") {
+			t.Errorf("expected other phases to be left out, got:\n%s", data)
+		}
+	})
+
+	t.Run("rejects an unknown phase", func(t *testing.T) {
+		deps, saved := newPhaseDeps(t)
+		cached, _ := deps.getCachedPlan("PLAN-1")
+
+		_, _, err := implementPhaseWithDeps(cached.Plan, "phase-9", deps)
+		if err == nil || !strings.Contains(err.Error(), "phase not found: phase-9 in plan PLAN-1") {
+			t.Errorf("expected phase not found error, got %v", err)
+		}
+		if *saved != nil {
+			t.Error("plan should not be saved")
+		}
+	})
+}
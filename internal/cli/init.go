@@ -1,12 +1,14 @@
 package cli
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/skills"
 	"github.com/charleslr/jig/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -21,18 +23,57 @@ In interactive mode, this runs a setup wizard that configures:
 - Git settings (branch patterns, worktree directory)
 - Claude Code hooks and skills
 
-In non-interactive mode (or with --hooks-only), only sets up Claude Code hooks.`,
+Once jig is configured, running init again only updates hooks and skills. Use
+--reconfigure to step through the wizard starting from your current settings
+(the stored API key is reused if Linear still accepts it), or --force to
+start over from scratch.
+
+In non-interactive mode (or with --hooks-only), only sets up Claude Code hooks.
+
+For scripted setup (containers, CI), --non-interactive configures jig from
+flags alone. The Linear API key is read from the JIG_LINEAR_API_KEY
+environment variable.
+
+Each request to Linear during setup times out after 10s; use --timeout to
+wait longer on slow connections.
+
+Installing hooks and skills reports each file as installed, updated or
+unchanged. Skill files you have edited are kept unless --force is given.
+--status only reports whether the hooks and skill files are present and match
+this version of jig, without changing anything.
+
+Examples:
+  jig init --status
+  JIG_LINEAR_API_KEY=lin_api_... jig init --non-interactive --tracker linear --linear-team ENG
+  jig init --non-interactive --tracker none --worktree-dir /work/trees`,
 	RunE: runInit,
 }
 
 var (
-	initForce     bool
-	initHooksOnly bool
+	initForce       bool
+	initHooksOnly   bool
+	initReconfigure bool
+	initStatus      bool
+
+	initNonInteractive bool
+	initOptions        NonInteractiveOptions
 )
 
 func init() {
 	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "overwrite existing hook configuration")
 	initCmd.Flags().BoolVar(&initHooksOnly, "hooks-only", false, "only install Claude Code hooks, skip full setup")
+	initCmd.Flags().BoolVar(&initReconfigure, "reconfigure", false, "edit existing settings, starting from the current values")
+	initCmd.Flags().BoolVar(&initStatus, "status", false, "report which hooks and skill files are installed and current")
+	initCmd.Flags().DurationVar(&onboardingTimeout, "timeout", onboardingTimeout, "timeout for each request to Linear during setup")
+
+	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "configure from flags without prompting")
+	initCmd.Flags().StringVar(&initOptions.Tracker, "tracker", "", "issue tracker: linear or none (with --non-interactive)")
+	initCmd.Flags().StringVar(&initOptions.LinearTeam, "linear-team", "", "Linear team ID or key (with --non-interactive)")
+	initCmd.Flags().StringVar(&initOptions.LinearProject, "linear-project", "", "default Linear project ID or name (with --non-interactive)")
+	initCmd.Flags().StringVar(&initOptions.BranchPattern, "branch-pattern", "", "branch naming pattern (with --non-interactive)")
+	initCmd.Flags().StringVar(&initOptions.WorktreeDir, "worktree-dir", "", "worktree directory (with --non-interactive)")
+	initCmd.Flags().BoolVar(&initOptions.InstallSkills, "install-skills", false, "install Claude Code hooks and skills (with --non-interactive)")
+	initCmd.Flags().StringVar(&initOptions.SkillsLocation, "skills-location", "", "where to install skills: global or project (with --non-interactive)")
 }
 
 // ClaudeSettings represents the .claude/settings.json structure
@@ -62,6 +103,25 @@ type HookConfig struct {
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initStatus {
+		return runInitStatus(getSkillsLocation())
+	}
+
+	if initNonInteractive {
+		return runNonInteractiveInit(cmd.Context())
+	}
+
+	if initReconfigure {
+		if !ui.IsInteractive() {
+			return fmt.Errorf("--reconfigure requires an interactive terminal")
+		}
+		defaults, err := loadOnboardingDefaults()
+		if err != nil {
+			return fmt.Errorf("failed to load current configuration: %w", err)
+		}
+		return runInteractiveInit(defaults)
+	}
+
 	// Check if already configured
 	isConfigured := isJigConfigured()
 
@@ -72,7 +132,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	// If interactive and not hooks-only, run full onboarding
 	if ui.IsInteractive() && !initHooksOnly {
-		return runInteractiveInit()
+		return runInteractiveInit(nil)
 	}
 
 	// Otherwise, just set up hooks (non-interactive mode)
@@ -116,14 +176,15 @@ func runQuickUpdate() error {
 	fmt.Println()
 	fmt.Println("Your jig setup is up to date!")
 	fmt.Println()
-	fmt.Println("Use 'jig init --force' to reconfigure from scratch.")
+	fmt.Println("Use 'jig init --reconfigure' to change settings, or 'jig init --force' to start from scratch.")
 
 	return nil
 }
 
-// runInteractiveInit runs the full interactive onboarding wizard
-func runInteractiveInit() error {
-	result, err := RunOnboarding()
+// runInteractiveInit runs the full interactive onboarding wizard, starting
+// from defaults if non-nil
+func runInteractiveInit(defaults *OnboardingResult) error {
+	result, err := RunOnboarding(defaults)
 	if err != nil {
 		return err
 	}
@@ -150,6 +211,23 @@ func runInteractiveInit() error {
 	return nil
 }
 
+// runNonInteractiveInit configures jig from flags without any prompts
+func runNonInteractiveInit(ctx context.Context) error {
+	result, err := RunNonInteractiveOnboarding(ctx, initOptions)
+	if err != nil {
+		return err
+	}
+	printSuccess("Configuration saved")
+
+	if result.InstallSkills {
+		if err := InstallClaudeSkills(result.SkillsLocation); err != nil {
+			return fmt.Errorf("failed to install Claude skills: %w", err)
+		}
+		printSuccess("Claude Code hooks installed")
+	}
+	return nil
+}
+
 // runHooksOnlyInit sets up only the Claude Code hooks
 func runHooksOnlyInit() error {
 	// Ensure .claude directory exists
@@ -158,16 +236,10 @@ func runHooksOnlyInit() error {
 		return fmt.Errorf("failed to create .claude directory: %w", err)
 	}
 
-	settingsPath := filepath.Join(claudeDir, "settings.json")
-
 	// Read existing settings if present
-	var rawSettings map[string]interface{}
-	if data, err := os.ReadFile(settingsPath); err == nil {
-		if err := json.Unmarshal(data, &rawSettings); err != nil {
-			return fmt.Errorf("failed to parse existing settings.json: %w", err)
-		}
-	} else {
-		rawSettings = make(map[string]interface{})
+	rawSettings, err := readClaudeSettings(claudeSettingsPath)
+	if err != nil {
+		return err
 	}
 
 	// Check if jig hook already exists
@@ -212,6 +284,159 @@ func runHooksOnlyInit() error {
 	return nil
 }
 
+// installStatus is whether a file jig installs for Claude Code is present
+// and matches this version of jig
+type installStatus string
+
+const (
+	installStatusMissing installStatus = "missing"
+	installStatusStale   installStatus = "stale"
+	installStatusCurrent installStatus = "current"
+)
+
+// installAction is what installing a file for Claude Code did
+type installAction string
+
+const (
+	installActionInstalled installAction = "installed"
+	installActionUpdated   installAction = "updated"
+	installActionUnchanged installAction = "unchanged"
+	installActionKept      installAction = "kept" // Differs from jig's version, not overwritten without --force
+)
+
+// installedFile is a file jig installs for Claude Code and what happened to it
+type installedFile struct {
+	Path   string
+	Action installAction
+}
+
+// installFileStatus is a file jig installs for Claude Code and its status
+type installFileStatus struct {
+	Path   string
+	Status installStatus
+}
+
+// printInstallSummary prints what installing each file did
+func printInstallSummary(files []installedFile) {
+	for _, f := range files {
+		switch f.Action {
+		case installActionInstalled:
+			printSuccess(fmt.Sprintf("Installed %s", f.Path))
+		case installActionUpdated:
+			printSuccess(fmt.Sprintf("Updated %s", f.Path))
+		case installActionKept:
+			printWarning(fmt.Sprintf("Kept %s (differs from jig's version; use --force to update)", f.Path))
+		default:
+			printInfo(fmt.Sprintf("Unchanged %s", f.Path))
+		}
+	}
+}
+
+// runInitStatus reports whether the hooks and skill files are installed and
+// current, without changing anything
+func runInitStatus(location string) error {
+	statuses, err := claudeInstallStatus(location)
+	if err != nil {
+		return err
+	}
+
+	current := 0
+	for _, s := range statuses {
+		switch s.Status {
+		case installStatusCurrent:
+			current++
+			printSuccess(fmt.Sprintf("%s is current", s.Path))
+		case installStatusStale:
+			printWarning(fmt.Sprintf("%s is out of date", s.Path))
+		default:
+			printWarning(fmt.Sprintf("%s is missing", s.Path))
+		}
+	}
+
+	fmt.Println()
+	if current == len(statuses) {
+		fmt.Println("Your jig setup is up to date!")
+	} else {
+		fmt.Println("Run 'jig init' to install missing files, or 'jig init --force' to also update edited skill files.")
+	}
+	return nil
+}
+
+// claudeInstallStatus returns the status of the Claude Code hooks and of the
+// skill files installed at location
+func claudeInstallStatus(location string) ([]installFileStatus, error) {
+	settings, err := readClaudeSettings(claudeSettingsPath)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []installFileStatus{{Path: claudeSettingsPath, Status: claudeHooksStatus(settings)}}
+
+	commandsDir, err := skillsCommandsDir(location)
+	if err != nil {
+		return nil, err
+	}
+	for _, skillFile := range skillFileNames {
+		content, err := skills.EmbeddedSkills.ReadFile(skillFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read embedded skill %s: %w", skillFile, err)
+		}
+		path := filepath.Join(commandsDir, skillFile)
+		status, err := skillFileStatus(path, content)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, installFileStatus{Path: path, Status: status})
+	}
+	return statuses, nil
+}
+
+// claudeHooksStatus returns whether Claude Code settings have the jig hook,
+// and whether they also have every jig permission
+func claudeHooksStatus(settings map[string]interface{}) installStatus {
+	if !hasJigHook(settings) {
+		return installStatusMissing
+	}
+	if !hasJigPermissions(settings) {
+		return installStatusStale
+	}
+	return installStatusCurrent
+}
+
+// hasJigPermissions checks if every jig permission is allowed
+func hasJigPermissions(settings map[string]interface{}) bool {
+	permissions, _ := settings["permissions"].(map[string]interface{})
+	allowList, _ := permissions["allow"].([]interface{})
+
+	allowed := make(map[string]bool)
+	for _, item := range allowList {
+		if s, ok := item.(string); ok {
+			allowed[s] = true
+		}
+	}
+	for _, perm := range jigPermissions {
+		if !allowed[perm] {
+			return false
+		}
+	}
+	return true
+}
+
+// skillFileStatus compares an installed skill file with the embedded content
+// by hash
+func skillFileStatus(path string, embedded []byte) (installStatus, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return installStatusMissing, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if sha256.Sum256(data) != sha256.Sum256(embedded) {
+		return installStatusStale, nil
+	}
+	return installStatusCurrent, nil
+}
+
 // hasJigHook checks if jig hooks are already configured
 func hasJigHook(settings map[string]interface{}) bool {
 	hooks, ok := settings["hooks"].(map[string]interface{})
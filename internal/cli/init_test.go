@@ -3,6 +3,7 @@ package cli
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charleslr/jig/internal/config"
@@ -283,3 +284,107 @@ func TestHasJigHook(t *testing.T) {
 		}
 	})
 }
+
+func TestClaudeInstallStatus(t *testing.T) {
+	statusByPath := func(t *testing.T) map[string]installStatus {
+		t.Helper()
+		statuses, err := claudeInstallStatus("project")
+		if err != nil {
+			t.Fatalf("claudeInstallStatus() error = %v", err)
+		}
+		byPath := make(map[string]installStatus)
+		for _, s := range statuses {
+			byPath[s.Path] = s.Status
+		}
+		return byPath
+	}
+	planPath := filepath.Join(".claude", "commands", "jig", "plan.md")
+	implementPath := filepath.Join(".claude", "commands", "jig", "implement.md")
+
+	t.Run("reports missing files", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		for path, status := range statusByPath(t) {
+			if status != installStatusMissing {
+				t.Errorf("%s status = %s, want missing", path, status)
+			}
+		}
+	})
+
+	t.Run("reports current files after an install", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		if _, err := captureStdout(t, func() error { return InstallClaudeSkills("project") }); err != nil {
+			t.Fatalf("InstallClaudeSkills() error = %v", err)
+		}
+
+		got := statusByPath(t)
+		if len(got) != 3 {
+			t.Fatalf("expected 3 files, got %v", got)
+		}
+		for path, status := range got {
+			if status != installStatusCurrent {
+				t.Errorf("%s status = %s, want current", path, status)
+			}
+		}
+	})
+
+	t.Run("reports stale files", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		if _, err := captureStdout(t, func() error { return InstallClaudeSkills("project") }); err != nil {
+			t.Fatalf("InstallClaudeSkills() error = %v", err)
+		}
+		os.WriteFile(planPath, []byte("# Edited plan skill"), 0644)
+		os.WriteFile(claudeSettingsPath, []byte(`{"hooks": {"PreToolUse": [{"matcher": "ExitPlanMode",
+			"hooks": [{"type": "command", "command": "jig hook exit-plan-mode"}]}]}}`), 0644)
+
+		got := statusByPath(t)
+		want := map[string]installStatus{
+			claudeSettingsPath: installStatusStale,
+			planPath:           installStatusStale,
+			implementPath:      installStatusCurrent,
+		}
+		for path, status := range want {
+			if got[path] != status {
+				t.Errorf("%s status = %s, want %s", path, got[path], status)
+			}
+		}
+	})
+}
+
+func TestInstallClaudeSkills_Summary(t *testing.T) {
+	t.Chdir(t.TempDir())
+	originalForce := initForce
+	defer func() { initForce = originalForce }()
+	initForce = false
+	planPath := filepath.Join(".claude", "commands", "jig", "plan.md")
+
+	out, err := captureStdout(t, func() error { return InstallClaudeSkills("project") })
+	if err != nil {
+		t.Fatalf("InstallClaudeSkills() error = %v", err)
+	}
+	if !strings.Contains(out, "Installed "+claudeSettingsPath) || !strings.Contains(out, "Installed "+planPath) {
+		t.Errorf("expected every file reported as installed, got:\n%s", out)
+	}
+
+	// Re-running changes nothing
+	settingsBefore, _ := os.ReadFile(claudeSettingsPath)
+	out, _ = captureStdout(t, func() error { return InstallClaudeSkills("project") })
+	if strings.Contains(out, "Installed") || strings.Contains(out, "Updated") || !strings.Contains(out, "Unchanged "+claudeSettingsPath) {
+		t.Errorf("expected every file reported as unchanged, got:\n%s", out)
+	}
+	if settingsAfter, _ := os.ReadFile(claudeSettingsPath); string(settingsAfter) != string(settingsBefore) {
+		t.Error("expected settings.json to be left untouched")
+	}
+
+	// An edited skill file is kept without --force, and updated with it
+	os.WriteFile(planPath, []byte("# Edited plan skill"), 0644)
+	out, _ = captureStdout(t, func() error { return InstallClaudeSkills("project") })
+	if !strings.Contains(out, "Kept "+planPath) {
+		t.Errorf("expected the edited skill to be kept, got:\n%s", out)
+	}
+	initForce = true
+	out, _ = captureStdout(t, func() error { return InstallClaudeSkills("project") })
+	if !strings.Contains(out, "Updated "+planPath) {
+		t.Errorf("expected the edited skill to be updated, got:\n%s", out)
+	}
+}
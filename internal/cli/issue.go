@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/ui"
+)
+
+var issueCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "Work with a tracker issue",
+}
+
+var issueStatusCmd = &cobra.Command{
+	Use:   "status <ISSUE_ID> [STATUS]",
+	Short: "Show or change an issue's status",
+	Long: `Show the statuses an issue can move to, or move it to one.
+
+Only the statuses available to the issue's team are offered. Without a
+STATUS, the available statuses are listed; in interactive mode you pick one
+from a menu and the issue is moved to it. A STATUS the team doesn't have is
+rejected before anything changes.
+
+Statuses: backlog, todo, in_progress, in_review, done, canceled (dashes work
+too, e.g. in-progress).
+
+Examples:
+  jig issue status NUM-42
+  jig issue status NUM-42 in-review`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runIssueStatus,
+}
+
+func init() {
+	issueCmd.AddCommand(issueStatusCmd)
+}
+
+// issueStatusDeps holds the dependencies of 'jig issue status'
+type issueStatusDeps struct {
+	getTracker    func() (tracker.Tracker, error)
+	isInteractive func() bool
+	selectStatus  func(title string, options []ui.SelectOption) (string, error)
+}
+
+// issueStatusResult is the outcome of 'jig issue status'
+type issueStatusResult struct {
+	Issue        *tracker.Issue
+	Available    []tracker.Status // Statuses the issue can move to
+	Status       tracker.Status   // Status the issue was moved to; empty if none was chosen
+	Transitioned bool             // False if the issue was already in Status
+}
+
+func runIssueStatus(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	deps := issueStatusDeps{
+		getTracker:    func() (tracker.Tracker, error) { return getTracker(cfg) },
+		isInteractive: ui.IsInteractive,
+		selectStatus:  ui.RunSelect,
+	}
+
+	var status string
+	if len(args) > 1 {
+		status = args[1]
+	}
+
+	result, err := setIssueStatusWithDeps(context.Background(), args[0], status, deps)
+	if err != nil {
+		return err
+	}
+
+	identifier := result.Issue.Identifier
+	switch {
+	case result.Transitioned:
+		printSuccess(fmt.Sprintf("Moved %s to %s", identifier, result.Status))
+	case result.Status != "":
+		printInfo(fmt.Sprintf("%s is already %s", identifier, result.Status))
+	default:
+		fmt.Printf("Available statuses for %s:\n", identifier)
+		for _, s := range result.Available {
+			if s == result.Issue.Status {
+				fmt.Printf("  %s (current)\n", s)
+			} else {
+				fmt.Printf("  %s\n", s)
+			}
+		}
+	}
+	return nil
+}
+
+// setIssueStatusWithDeps moves an issue to status, which must be one of the
+// statuses available to it. Without a status, the user picks one from the
+// available statuses in interactive mode; otherwise they're only returned.
+func setIssueStatusWithDeps(ctx context.Context, issueID, status string, deps issueStatusDeps) (*issueStatusResult, error) {
+	t, err := deps.getTracker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracker: %w", err)
+	}
+
+	issue, err := t.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	available, err := t.GetAvailableStatuses(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available statuses for %s: %w", issue.Identifier, err)
+	}
+	result := &issueStatusResult{Issue: issue, Available: available}
+
+	if status == "" {
+		if !deps.isInteractive() {
+			return result, nil
+		}
+		options := make([]ui.SelectOption, len(available))
+		for i, s := range available {
+			options[i] = ui.SelectOption{Label: string(s), Value: string(s)}
+			if s == issue.Status {
+				options[i].Description = "current"
+			}
+		}
+		selected, err := deps.selectStatus(fmt.Sprintf("Move %s to:", issue.Identifier), options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select status: %w", err)
+		}
+		if selected == "" {
+			return nil, fmt.Errorf("no status selected")
+		}
+		status = selected
+	}
+
+	target, err := parseAvailableStatus(status, available, issue.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = target
+	if target == issue.Status {
+		return result, nil
+	}
+
+	if err := t.TransitionIssue(ctx, issue.ID, target); err != nil {
+		return nil, fmt.Errorf("failed to move %s to %s: %w", issue.Identifier, target, err)
+	}
+	result.Transitioned = true
+	return result, nil
+}
+
+// parseAvailableStatus parses a status name, accepting dashes for
+// underscores, and checks it is one of the statuses available to an issue
+func parseAvailableStatus(s string, available []tracker.Status, issue string) (tracker.Status, error) {
+	normalized := tracker.Status(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_"))
+	for _, status := range available {
+		if status == normalized {
+			return status, nil
+		}
+	}
+
+	names := make([]string, len(available))
+	for i, status := range available {
+		names[i] = string(status)
+	}
+	return "", fmt.Errorf("status %q is not available for %s (available: %s)", s, issue, strings.Join(names, ", "))
+}
+
+// checkStatusAvailable returns an error naming the available statuses if
+// status isn't one of them for the issue. Trackers that report no statuses
+// aren't checked.
+func checkStatusAvailable(ctx context.Context, t tracker.Tracker, issueID string, status tracker.Status) error {
+	available, err := t.GetAvailableStatuses(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get available statuses for %s: %w", issueID, err)
+	}
+	if len(available) == 0 {
+		return nil
+	}
+	_, err = parseAvailableStatus(string(status), available, issueID)
+	return err
+}
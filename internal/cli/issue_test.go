@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+	"github.com/charleslr/jig/internal/ui"
+)
+
+func TestSetIssueStatusWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newStatusTracker returns a mock tracker with a todo issue, for a team
+	// without an in-review state
+	newStatusTracker := func(t *testing.T) (*mock.Client, *tracker.Issue) {
+		t.Helper()
+		client := mock.NewClient()
+		client.SetAvailableStatuses(tracker.StatusTodo, tracker.StatusInProgress, tracker.StatusDone)
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		return client, issue
+	}
+
+	newDeps := func(client tracker.Tracker, interactive bool) issueStatusDeps {
+		return issueStatusDeps{
+			getTracker:    func() (tracker.Tracker, error) { return client, nil },
+			isInteractive: func() bool { return interactive },
+			selectStatus: func(title string, options []ui.SelectOption) (string, error) {
+				t.Error("selectStatus should not be called")
+				return "", nil
+			},
+		}
+	}
+
+	t.Run("lists available statuses", func(t *testing.T) {
+		client, issue := newStatusTracker(t)
+
+		result, err := setIssueStatusWithDeps(ctx, issue.Identifier, "", newDeps(client, false))
+		if err != nil {
+			t.Fatalf("setIssueStatusWithDeps() error = %v", err)
+		}
+		if got := len(result.Available); got != 3 || result.Available[1] != tracker.StatusInProgress {
+			t.Errorf("expected the team's 3 statuses, got %v", result.Available)
+		}
+		if result.Status != "" || result.Transitioned {
+			t.Errorf("expected no transition, got %+v", result)
+		}
+	})
+
+	t.Run("offers only available statuses in interactive mode", func(t *testing.T) {
+		client, issue := newStatusTracker(t)
+
+		var offered []string
+		deps := newDeps(client, true)
+		deps.selectStatus = func(title string, options []ui.SelectOption) (string, error) {
+			for _, o := range options {
+				offered = append(offered, o.Value+":"+o.Description)
+			}
+			return "in_progress", nil
+		}
+
+		result, err := setIssueStatusWithDeps(ctx, issue.Identifier, "", deps)
+		if err != nil {
+			t.Fatalf("setIssueStatusWithDeps() error = %v", err)
+		}
+		if got := strings.Join(offered, ","); got != "todo:current,in_progress:,done:" {
+			t.Errorf("expected the available statuses with the current one marked, got %s", got)
+		}
+		if !result.Transitioned || result.Status != tracker.StatusInProgress {
+			t.Errorf("expected a transition to in_progress, got %+v", result)
+		}
+		if got, _ := client.GetIssue(ctx, issue.ID); got.Status != tracker.StatusInProgress {
+			t.Errorf("expected issue status in_progress, got %s", got.Status)
+		}
+	})
+
+	t.Run("transitions to a given status", func(t *testing.T) {
+		client, issue := newStatusTracker(t)
+
+		result, err := setIssueStatusWithDeps(ctx, issue.Identifier, "in-progress", newDeps(client, true))
+		if err != nil {
+			t.Fatalf("setIssueStatusWithDeps() error = %v", err)
+		}
+		if !result.Transitioned || result.Status != tracker.StatusInProgress {
+			t.Errorf("expected a transition to in_progress, got %+v", result)
+		}
+	})
+
+	t.Run("leaves an issue already in the status alone", func(t *testing.T) {
+		client, issue := newStatusTracker(t)
+
+		result, err := setIssueStatusWithDeps(ctx, issue.Identifier, "todo", newDeps(client, false))
+		if err != nil {
+			t.Fatalf("setIssueStatusWithDeps() error = %v", err)
+		}
+		if result.Transitioned || result.Status != tracker.StatusTodo {
+			t.Errorf("expected no transition, got %+v", result)
+		}
+	})
+
+	t.Run("rejects an unavailable status", func(t *testing.T) {
+		client, issue := newStatusTracker(t)
+
+		_, err := setIssueStatusWithDeps(ctx, issue.Identifier, "in-review", newDeps(client, false))
+		if err == nil {
+			t.Fatal("expected an error for an unavailable status")
+		}
+		want := `status "in-review" is not available for MOCK-1 (available: todo, in_progress, done)`
+		if err.Error() != want {
+			t.Errorf("error = %q, want %q", err, want)
+		}
+		if got, _ := client.GetIssue(ctx, issue.ID); got.Status != tracker.StatusTodo {
+			t.Errorf("expected the issue left as todo, got %s", got.Status)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+var issuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "List your open issues",
+	Long: `List the open issues assigned to you in the configured tracker.
+
+Completed and canceled issues are not shown. Use --status to only show
+issues in a given status (backlog, todo, in_progress, in_review).
+
+Examples:
+  jig issues
+  jig issues --status in_progress
+  jig issues --json`,
+	Args: cobra.NoArgs,
+	RunE: runIssues,
+}
+
+var (
+	issuesStatus string
+	issuesJSON   bool
+)
+
+func init() {
+	issuesCmd.Flags().StringVarP(&issuesStatus, "status", "s", "", "only show issues in this status")
+	issuesCmd.Flags().BoolVar(&issuesJSON, "json", false, "output as JSON")
+}
+
+// issueStatuses lists the statuses accepted by --status. Completed and
+// canceled issues are never listed, so their statuses are not accepted.
+var issueStatuses = []tracker.Status{
+	tracker.StatusBacklog,
+	tracker.StatusTodo,
+	tracker.StatusInProgress,
+	tracker.StatusInReview,
+}
+
+// issuesDeps holds the dependencies of 'jig issues'
+type issuesDeps struct {
+	getTracker func() (tracker.Tracker, error)
+}
+
+func runIssues(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	deps := issuesDeps{
+		getTracker: func() (tracker.Tracker, error) { return getTracker(cfg) },
+	}
+
+	issues, err := listMyIssuesWithDeps(context.Background(), issuesStatus, deps)
+	if err != nil {
+		return err
+	}
+
+	if issuesJSON {
+		return writeIssuesJSON(os.Stdout, issues)
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No open issues assigned to you.")
+		return nil
+	}
+
+	writeIssuesText(os.Stdout, issues)
+	return nil
+}
+
+// listMyIssuesWithDeps returns the open issues assigned to the current user,
+// keeping only those in the given status if status is non-empty
+func listMyIssuesWithDeps(ctx context.Context, status string, deps issuesDeps) ([]*tracker.Issue, error) {
+	var want tracker.Status
+	if status != "" {
+		parsed, err := parseIssueStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		want = parsed
+	}
+
+	t, err := deps.getTracker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracker: %w", err)
+	}
+
+	issues, err := t.ListAssignedToMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	if want == "" {
+		return issues, nil
+	}
+	var filtered []*tracker.Issue
+	for _, issue := range issues {
+		if issue.Status == want {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered, nil
+}
+
+// parseIssueStatus parses a --status value, accepting dashes for underscores
+// (e.g. "in-progress")
+func parseIssueStatus(s string) (tracker.Status, error) {
+	normalized := tracker.Status(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_"))
+	for _, status := range issueStatuses {
+		if status == normalized {
+			return status, nil
+		}
+	}
+
+	names := make([]string, len(issueStatuses))
+	for i, status := range issueStatuses {
+		names[i] = string(status)
+	}
+	return "", fmt.Errorf("invalid status %q (must be one of: %s)", s, strings.Join(names, ", "))
+}
+
+// issueListEntry is the JSON representation of an issue in `jig issues --json`
+type issueListEntry struct {
+	Identifier string    `json:"identifier"`
+	Title      string    `json:"title"`
+	Status     string    `json:"status"`
+	URL        string    `json:"url,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// writeIssuesJSON writes issues as a JSON array, emitting [] when there are none
+func writeIssuesJSON(w io.Writer, issues []*tracker.Issue) error {
+	entries := make([]issueListEntry, 0, len(issues))
+	for _, issue := range issues {
+		entries = append(entries, issueListEntry{
+			Identifier: issue.Identifier,
+			Title:      issue.Title,
+			Status:     string(issue.Status),
+			URL:        issue.URL,
+			UpdatedAt:  issue.UpdatedAt,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+	return nil
+}
+
+// writeIssuesText writes one line per issue with its identifier, status and title
+func writeIssuesText(w io.Writer, issues []*tracker.Issue) {
+	idWidth, statusWidth := 0, 0
+	for _, issue := range issues {
+		idWidth = max(idWidth, len(issue.Identifier))
+		statusWidth = max(statusWidth, len(issue.Status))
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%-*s  %-*s  %s\n", idWidth, issue.Identifier, statusWidth, issue.Status, issue.Title)
+	}
+}
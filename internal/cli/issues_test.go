@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestListMyIssuesWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newIssuesTracker returns a mock tracker with issues assigned to the viewer
+	// in several statuses, plus one assigned to someone else
+	newIssuesTracker := func(t *testing.T) *mock.Client {
+		t.Helper()
+		client := mock.NewClient()
+		client.SetViewer("me")
+
+		for _, spec := range []struct {
+			title    string
+			assignee string
+			status   tracker.Status
+		}{
+			{"Todo issue", "me", tracker.StatusTodo},
+			{"Started issue", "me", tracker.StatusInProgress},
+			{"Finished issue", "me", tracker.StatusDone},
+			{"Someone else's issue", "other", tracker.StatusInProgress},
+		} {
+			issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: spec.title, Assignee: spec.assignee})
+			if err != nil {
+				t.Fatalf("failed to create issue: %v", err)
+			}
+			if err := client.TransitionIssue(ctx, issue.ID, spec.status); err != nil {
+				t.Fatalf("failed to transition issue: %v", err)
+			}
+		}
+		return client
+	}
+
+	newDeps := func(client tracker.Tracker) issuesDeps {
+		return issuesDeps{
+			getTracker: func() (tracker.Tracker, error) { return client, nil },
+		}
+	}
+
+	titles := func(issues []*tracker.Issue) string {
+		names := make([]string, len(issues))
+		for i, issue := range issues {
+			names[i] = issue.Title
+		}
+		return strings.Join(names, ",")
+	}
+
+	t.Run("lists open issues assigned to the viewer", func(t *testing.T) {
+		issues, err := listMyIssuesWithDeps(ctx, "", newDeps(newIssuesTracker(t)))
+		if err != nil {
+			t.Fatalf("listMyIssuesWithDeps() error = %v", err)
+		}
+		if got := titles(issues); got != "Todo issue,Started issue" {
+			t.Errorf("expected open issues assigned to me, got %q", got)
+		}
+	})
+
+	t.Run("filters by status", func(t *testing.T) {
+		for _, status := range []string{"in_progress", "In-Progress"} {
+			issues, err := listMyIssuesWithDeps(ctx, status, newDeps(newIssuesTracker(t)))
+			if err != nil {
+				t.Fatalf("listMyIssuesWithDeps(%q) error = %v", status, err)
+			}
+			if got := titles(issues); got != "Started issue" {
+				t.Errorf("status %q: expected only the started issue, got %q", status, got)
+			}
+		}
+	})
+
+	t.Run("rejects unknown status before calling the tracker", func(t *testing.T) {
+		deps := issuesDeps{
+			getTracker: func() (tracker.Tracker, error) {
+				t.Error("tracker should not be created for an invalid status")
+				return nil, nil
+			},
+		}
+
+		_, err := listMyIssuesWithDeps(ctx, "done", deps)
+		if err == nil || !strings.Contains(err.Error(), `invalid status "done"`) {
+			t.Errorf("expected invalid status error, got %v", err)
+		}
+	})
+
+	t.Run("propagates tracker errors", func(t *testing.T) {
+		deps := issuesDeps{
+			getTracker: func() (tracker.Tracker, error) {
+				return nil, errors.New("Linear API key not configured")
+			},
+		}
+
+		_, err := listMyIssuesWithDeps(ctx, "", deps)
+		if err == nil || !strings.Contains(err.Error(), "API key not configured") {
+			t.Errorf("expected tracker error, got %v", err)
+		}
+	})
+}
+
+func TestWriteIssues(t *testing.T) {
+	issues := []*tracker.Issue{
+		{Identifier: "NUM-1", Title: "First", Status: tracker.StatusTodo},
+		{Identifier: "NUM-100", Title: "Second", Status: tracker.StatusInProgress},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		writeIssuesText(&buf, issues)
+
+		want := "NUM-1    todo         First\nNUM-100  in_progress  Second\n"
+		if buf.String() != want {
+			t.Errorf("writeIssuesText() =\n%q\nwant:\n%q", buf.String(), want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeIssuesJSON(&buf, issues); err != nil {
+			t.Fatalf("writeIssuesJSON() error = %v", err)
+		}
+
+		var entries []issueListEntry
+		if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+			t.Fatalf("failed to parse JSON output: %v", err)
+		}
+		if len(entries) != 2 || entries[1].Identifier != "NUM-100" || entries[1].Status != "in_progress" {
+			t.Errorf("unexpected entries: %+v", entries)
+		}
+	})
+
+	t.Run("empty json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writeIssuesJSON(&buf, nil); err != nil {
+			t.Fatalf("writeIssuesJSON() error = %v", err)
+		}
+		if strings.TrimSpace(buf.String()) != "[]" {
+			t.Errorf("expected [], got %q", buf.String())
+		}
+	})
+}
@@ -214,14 +214,8 @@ func runMerge(cmd *cobra.Command, args []string) error {
 		// Initialize state and try to update the plan (supports both plan ID and issue ID)
 		if err := state.Init(); err == nil {
 			if p, _, err := lookupPlanByID(issueID); err == nil && p != nil {
-				// Create tracker syncer if configured
-				var syncer state.TrackerSyncer
-				if cfg.Default.Tracker == "linear" {
-					syncer = getLinearSyncer(cfg)
-				}
-
 				// Use PlanStatusManager to complete the plan
-				mgr := state.NewPlanStatusManager(state.DefaultCache, syncer)
+				mgr := state.NewPlanStatusManager(state.DefaultCache, getTrackerSyncer(cfg))
 				result, err := mgr.Complete(ctx, p)
 				if err != nil {
 					printWarning(fmt.Sprintf("Could not update plan status: %v", err))
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -30,10 +31,58 @@ type OnboardingResult struct {
 	SkillsLocation string // "global" or "project"
 }
 
-// RunOnboarding runs the interactive onboarding wizard
-func RunOnboarding() (*OnboardingResult, error) {
+// loadOnboardingDefaults returns the current configuration and stored API key
+// as onboarding defaults, for reconfiguring an existing setup
+func loadOnboardingDefaults() (*OnboardingResult, error) {
+	cfg := config.Get()
+	apiKey, _, err := resolveLinearAPIKey(cfg, config.NewStore)
+	if err != nil {
+		return nil, err
+	}
+	return onboardingDefaults(cfg, apiKey), nil
+}
+
+// onboardingDefaults seeds onboarding results from an existing configuration
+func onboardingDefaults(cfg *config.Config, apiKey string) *OnboardingResult {
+	return &OnboardingResult{
+		Tracker:        cfg.Default.Tracker,
+		LinearAPIKey:   apiKey,
+		TeamID:         cfg.Linear.TeamID,
+		ProjectID:      cfg.Linear.DefaultProject,
+		BranchPattern:  cfg.Git.BranchPattern,
+		WorktreeDir:    cfg.Git.WorktreeDir,
+		SkillsLocation: cfg.Claude.SkillsLocation,
+	}
+}
+
+// withCurrentFirst moves the option whose value is current to the front and
+// marks it, so accepting the first option keeps the current setting
+func withCurrentFirst(options []ui.SelectOption, current string) []ui.SelectOption {
+	if current == "" {
+		return options
+	}
+	reordered := make([]ui.SelectOption, 0, len(options))
+	for _, opt := range options {
+		if opt.Value == current {
+			opt.Label += " (current)"
+			reordered = append([]ui.SelectOption{opt}, reordered...)
+			continue
+		}
+		reordered = append(reordered, opt)
+	}
+	return reordered
+}
+
+// onboardingTimeout bounds each Linear request made during onboarding. It is
+// set by jig init --timeout.
+var onboardingTimeout = 10 * time.Second
+
+// RunOnboarding runs the interactive onboarding wizard. When defaults is
+// non-nil (reconfiguring), each step starts from the given values and API key
+// entry is skipped if the stored key is still accepted by Linear.
+func RunOnboarding(defaults *OnboardingResult) (*OnboardingResult, error) {
 	if !ui.IsInteractive() {
-		return nil, fmt.Errorf("onboarding requires an interactive terminal")
+		return nil, fmt.Errorf("onboarding requires an interactive terminal (use --non-interactive for scripted setup)")
 	}
 
 	result := &OnboardingResult{}
@@ -43,6 +92,26 @@ func RunOnboarding() (*OnboardingResult, error) {
 	var teams []tracker.Team
 	var projects []tracker.Project
 
+	// Reuse a stored API key if Linear still accepts it
+	storedKeyValid := false
+	if defaults != nil && defaults.LinearAPIKey != "" {
+		var storedTeams []tracker.Team
+		client, err := newOnboardingClient(defaults.LinearAPIKey)
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), onboardingTimeout)
+			storedTeams, err = client.GetTeams(ctx)
+			cancel()
+		}
+		if err == nil && len(storedTeams) > 0 {
+			linearClient = client
+			teams = storedTeams
+			result.LinearAPIKey = defaults.LinearAPIKey
+			storedKeyValid = true
+		} else {
+			printWarning("The stored Linear API key could not be validated; you'll be asked for a new one")
+		}
+	}
+
 	steps := []ui.WizardStep{
 		// Step 1: Welcome
 		{
@@ -82,7 +151,7 @@ func RunOnboarding() (*OnboardingResult, error) {
 				return nil
 			},
 			ShouldSkip: func(results map[string]string) bool {
-				return results["tracker"] != "linear"
+				return results["tracker"] != "linear" || storedKeyValid
 			},
 		},
 
@@ -92,18 +161,26 @@ func RunOnboarding() (*OnboardingResult, error) {
 			Title:       "Validating API Key",
 			Type:        ui.StepTypeSpinner,
 			ActionLabel: "Connecting to Linear...",
-			Action: func() error {
+			Action: func(ctx context.Context) error {
 				apiKey := result.LinearAPIKey
 				if apiKey == "" {
 					return fmt.Errorf("no API key provided")
 				}
 
-				linearClient = linear.NewClient(apiKey, "", "")
-				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				var err error
+				if linearClient, err = newOnboardingClient(apiKey); err != nil {
+					return err
+				}
+				ctx, cancel := context.WithTimeout(ctx, onboardingTimeout)
 				defer cancel()
 
-				var err error
 				teams, err = linearClient.GetTeams(ctx)
+				if linear.IsAuthError(err) {
+					return fmt.Errorf("%s: %w", linearAuthHint, err)
+				}
+				if errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf("timed out after %s connecting to Linear (use --timeout to wait longer): %w", onboardingTimeout, err)
+				}
 				if err != nil {
 					return fmt.Errorf("failed to connect to Linear: %w", err)
 				}
@@ -119,7 +196,7 @@ func RunOnboarding() (*OnboardingResult, error) {
 				if key, ok := results["linear_api_key"]; ok {
 					result.LinearAPIKey = key
 				}
-				return results["tracker"] != "linear"
+				return results["tracker"] != "linear" || storedKeyValid
 			},
 		},
 
@@ -141,16 +218,17 @@ func RunOnboarding() (*OnboardingResult, error) {
 			Title:       "Loading Projects",
 			Type:        ui.StepTypeSpinner,
 			ActionLabel: "Fetching projects...",
-			Action: func() error {
+			Action: func(ctx context.Context) error {
 				if linearClient == nil || result.TeamID == "" {
 					return nil
 				}
 
-				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				ctx, cancel := context.WithTimeout(ctx, onboardingTimeout)
 				defer cancel()
 
 				var err error
-				projects, err = linearClient.GetProjects(ctx, result.TeamID)
+				// Closed projects aren't worth offering as the default
+				projects, err = linearClient.ListProjects(ctx, result.TeamID, linear.ProjectFilter{ExcludeClosed: true})
 				if err != nil {
 					return fmt.Errorf("failed to fetch projects: %w", err)
 				}
@@ -287,8 +365,12 @@ func RunOnboarding() (*OnboardingResult, error) {
 		},
 	}
 
+	if defaults != nil {
+		seedOnboardingSteps(steps, defaults)
+	}
+
 	// Create a custom wizard that can update options dynamically
-	results, completed, err := runOnboardingWizard(steps, result, &teams, &projects)
+	results, completed, err := runOnboardingWizard(steps, result, defaults, &teams, &projects, defaultOnboardingWizardDeps())
 	if err != nil {
 		return nil, err
 	}
@@ -334,8 +416,240 @@ func RunOnboarding() (*OnboardingResult, error) {
 	return result, nil
 }
 
-// runOnboardingWizard runs a wizard with dynamic option updates
-func runOnboardingWizard(steps []ui.WizardStep, result *OnboardingResult, teams *[]tracker.Team, projects *[]tracker.Project) (map[string]string, bool, error) {
+// seedOnboardingSteps makes the current settings the default answer of each
+// static step. Team and project options are seeded once they're fetched.
+func seedOnboardingSteps(steps []ui.WizardStep, defaults *OnboardingResult) {
+	for i := range steps {
+		step := &steps[i]
+		switch step.ID {
+		case "tracker":
+			step.Options = withCurrentFirst(step.Options, defaults.Tracker)
+		case "branch_pattern":
+			if defaults.BranchPattern != "" {
+				step.Placeholder = defaults.BranchPattern
+			}
+		case "worktree_dir":
+			if defaults.WorktreeDir != "" {
+				step.Placeholder = defaults.WorktreeDir
+			}
+		case "skills_location":
+			step.Options = withCurrentFirst(step.Options, defaults.SkillsLocation)
+		}
+	}
+}
+
+// linearAPIKeyEnv is the environment variable non-interactive onboarding
+// reads the Linear API key from, so it never appears in shell history
+const linearAPIKeyEnv = "JIG_LINEAR_API_KEY"
+
+// NonInteractiveOptions holds the settings for non-interactive onboarding
+type NonInteractiveOptions struct {
+	Tracker        string // "linear" or "none" (required)
+	LinearTeam     string // team ID or key (required for linear)
+	LinearProject  string // optional default project ID or name
+	BranchPattern  string
+	WorktreeDir    string
+	InstallSkills  bool
+	SkillsLocation string // "global" or "project"
+}
+
+// onboardDeps holds dependencies for non-interactive onboarding (for testability)
+type onboardDeps struct {
+	getenv      func(key string) string
+	getTeams    func(ctx context.Context, apiKey string) ([]tracker.Team, error)
+	getProjects func(ctx context.Context, apiKey, teamID string) ([]tracker.Project, error)
+	save        func(result *OnboardingResult) error
+}
+
+// newOnboardingClient creates a Linear client for checking an API key and
+// listing teams and projects, sending requests to the configured
+// linear.api_url if one is set
+func newOnboardingClient(apiKey string) (*linear.Client, error) {
+	return linear.NewClientWithURL(apiKey, "", "", config.Get().Linear.APIURL)
+}
+
+// RunNonInteractiveOnboarding validates opts, resolves the Linear team and
+// project, and saves the configuration without prompting
+func RunNonInteractiveOnboarding(ctx context.Context, opts NonInteractiveOptions) (*OnboardingResult, error) {
+	return runNonInteractiveOnboardingWithDeps(ctx, opts, onboardDeps{
+		getenv: os.Getenv,
+		getTeams: func(ctx context.Context, apiKey string) ([]tracker.Team, error) {
+			client, err := newOnboardingClient(apiKey)
+			if err != nil {
+				return nil, err
+			}
+			ctx, cancel := context.WithTimeout(ctx, onboardingTimeout)
+			defer cancel()
+			return client.GetTeams(ctx)
+		},
+		getProjects: func(ctx context.Context, apiKey, teamID string) ([]tracker.Project, error) {
+			client, err := newOnboardingClient(apiKey)
+			if err != nil {
+				return nil, err
+			}
+			ctx, cancel := context.WithTimeout(ctx, onboardingTimeout)
+			defer cancel()
+			return client.GetProjects(ctx, teamID)
+		},
+		save: SaveOnboardingResult,
+	})
+}
+
+// runNonInteractiveOnboardingWithDeps is the testable implementation of
+// RunNonInteractiveOnboarding
+func runNonInteractiveOnboardingWithDeps(ctx context.Context, opts NonInteractiveOptions, deps onboardDeps) (*OnboardingResult, error) {
+	result := &OnboardingResult{
+		Tracker:        opts.Tracker,
+		BranchPattern:  opts.BranchPattern,
+		WorktreeDir:    opts.WorktreeDir,
+		InstallSkills:  opts.InstallSkills,
+		SkillsLocation: opts.SkillsLocation,
+	}
+	if result.BranchPattern == "" {
+		result.BranchPattern = "{issue_id}-{slug}"
+	}
+	if result.WorktreeDir == "" {
+		result.WorktreeDir = getDefaultWorktreeDir()
+	}
+	if result.SkillsLocation == "" {
+		result.SkillsLocation = "global"
+	}
+	if result.SkillsLocation != "global" && result.SkillsLocation != "project" {
+		return nil, fmt.Errorf("invalid --skills-location %q (must be global or project)", result.SkillsLocation)
+	}
+
+	switch opts.Tracker {
+	case "":
+		return nil, fmt.Errorf("--tracker is required with --non-interactive (linear or none)")
+	case "none":
+		if opts.LinearTeam != "" || opts.LinearProject != "" {
+			return nil, fmt.Errorf("--linear-team and --linear-project require --tracker linear")
+		}
+	case "linear":
+		if err := resolveLinearOnboarding(ctx, opts, result, deps); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid --tracker %q (must be linear or none)", opts.Tracker)
+	}
+
+	if err := deps.save(result); err != nil {
+		return nil, fmt.Errorf("failed to save configuration: %w", err)
+	}
+	return result, nil
+}
+
+// resolveLinearOnboarding checks the API key against Linear and resolves the
+// team and project flags to IDs
+func resolveLinearOnboarding(ctx context.Context, opts NonInteractiveOptions, result *OnboardingResult, deps onboardDeps) error {
+	apiKey := deps.getenv(linearAPIKeyEnv)
+	if apiKey == "" {
+		return fmt.Errorf("%s must be set when --tracker is linear", linearAPIKeyEnv)
+	}
+	if opts.LinearTeam == "" {
+		return fmt.Errorf("--linear-team is required when --tracker is linear")
+	}
+	result.LinearAPIKey = apiKey
+
+	teams, err := deps.getTeams(ctx, apiKey)
+	if linear.IsAuthError(err) {
+		return fmt.Errorf("%s: %w", linearAuthHint, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to Linear: %w", err)
+	}
+	for _, team := range teams {
+		if team.ID == opts.LinearTeam || strings.EqualFold(team.Key, opts.LinearTeam) {
+			result.TeamID = team.ID
+			result.TeamName = team.Name
+			break
+		}
+	}
+	if result.TeamID == "" {
+		keys := make([]string, len(teams))
+		for i, team := range teams {
+			keys[i] = team.Key
+		}
+		return fmt.Errorf("no accessible Linear team matches --linear-team %q (available: %s)", opts.LinearTeam, strings.Join(keys, ", "))
+	}
+
+	if opts.LinearProject == "" {
+		return nil
+	}
+	projects, err := deps.getProjects(ctx, apiKey, result.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	for _, project := range projects {
+		if project.ID == opts.LinearProject || strings.EqualFold(project.Name, opts.LinearProject) {
+			result.ProjectID = project.ID
+			result.ProjectName = project.Name
+			return nil
+		}
+	}
+	return fmt.Errorf("no project in team %s matches --linear-project %q", result.TeamName, opts.LinearProject)
+}
+
+// Choices offered when validating the Linear API key fails
+const (
+	validationRetry     = "retry"
+	validationChangeKey = "change_key"
+	validationQuit      = "quit"
+)
+
+// onboardingWizardDeps holds the prompts of the onboarding wizard (for testability)
+type onboardingWizardDeps struct {
+	runStep func(step ui.WizardStep, results map[string]string) (string, bool, error)
+	// chooseRecovery asks what to do after the API key failed to validate,
+	// returning one of the validation* choices
+	chooseRecovery func(err error) (string, error)
+}
+
+// defaultOnboardingWizardDeps returns the interactive wizard prompts
+func defaultOnboardingWizardDeps() onboardingWizardDeps {
+	return onboardingWizardDeps{
+		runStep:        runSingleStep,
+		chooseRecovery: chooseValidationRecovery,
+	}
+}
+
+// chooseValidationRecovery shows why the API key failed to validate and asks
+// whether to retry, enter a different key or quit
+func chooseValidationRecovery(err error) (string, error) {
+	fmt.Println()
+	printWarning(fmt.Sprintf("Could not validate the API key: %v", err))
+	fmt.Println()
+
+	choice, err := ui.RunSelect("What would you like to do?", []ui.SelectOption{
+		{Label: "Retry", Value: validationRetry, Description: "Try connecting to Linear again"},
+		{Label: "Change key", Value: validationChangeKey, Description: "Enter a different API key"},
+		{Label: "Quit", Value: validationQuit, Description: "Exit setup without saving"},
+	})
+	if err != nil {
+		return "", err
+	}
+	if choice == "" {
+		return validationQuit, nil
+	}
+	return choice, nil
+}
+
+// stepIndex returns the index of the step with the given ID, or -1
+func stepIndex(steps []ui.WizardStep, id string) int {
+	for i, step := range steps {
+		if step.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// runOnboardingWizard runs a wizard with dynamic option updates. Defaults, if
+// non-nil, put the current team and project first in their options.
+//
+// If the API key fails to validate, the user can retry, enter a different key
+// or quit; cancelling validation goes straight back to key entry.
+func runOnboardingWizard(steps []ui.WizardStep, result *OnboardingResult, defaults *OnboardingResult, teams *[]tracker.Team, projects *[]tracker.Project, deps onboardingWizardDeps) (map[string]string, bool, error) {
 	// We need to run the wizard in a way that allows dynamic updates
 	// For now, we'll run it step by step
 
@@ -359,6 +673,9 @@ func runOnboardingWizard(steps []ui.WizardStep, result *OnboardingResult, teams
 					Description: fmt.Sprintf("Team key: %s", t.Key),
 				}
 			}
+			if defaults != nil {
+				options = withCurrentFirst(options, defaults.TeamID)
+			}
 			step.Options = options
 		}
 
@@ -375,6 +692,9 @@ func runOnboardingWizard(steps []ui.WizardStep, result *OnboardingResult, teams
 					Value: p.ID,
 				}
 			}
+			if defaults != nil {
+				options = withCurrentFirst(options, defaults.ProjectID)
+			}
 			step.Options = options
 		} else if step.ID == "project" && len(*projects) == 0 {
 			// No projects found, skip this step
@@ -382,7 +702,30 @@ func runOnboardingWizard(steps []ui.WizardStep, result *OnboardingResult, teams
 		}
 
 		// Run the individual step
-		value, cancelled, err := runSingleStep(*step, results)
+		value, cancelled, err := deps.runStep(*step, results)
+		if err != nil && step.ID == "validate_linear" {
+			keyStep := stepIndex(steps, "linear_api_key")
+			if errors.Is(err, context.Canceled) {
+				printInfo("Validation cancelled")
+				i = keyStep - 1
+				continue
+			}
+
+			choice, chooseErr := deps.chooseRecovery(err)
+			if chooseErr != nil {
+				return results, false, chooseErr
+			}
+			switch choice {
+			case validationRetry:
+				i--
+				continue
+			case validationChangeKey:
+				i = keyStep - 1
+				continue
+			default:
+				return results, false, nil
+			}
+		}
 		if err != nil {
 			return results, false, err
 		}
@@ -522,7 +865,7 @@ func runSpinnerStep(step ui.WizardStep, results map[string]string) (string, bool
 		actionLabel = "Processing..."
 	}
 
-	err := ui.RunWithSpinner(actionLabel, step.Action)
+	err := ui.RunWithSpinnerContext(context.Background(), actionLabel, step.Action)
 	if err != nil {
 		return "", false, err
 	}
@@ -566,24 +909,39 @@ func getDefaultWorktreeDir() string {
 	return filepath.Join(jigDir, "worktrees")
 }
 
-// SaveOnboardingResult saves the onboarding result to configuration
+// SaveOnboardingResult saves the onboarding result to configuration. Fields
+// left empty in result keep their current values, so a reconfigure that
+// skips a step doesn't wipe the existing setting.
 func SaveOnboardingResult(result *OnboardingResult) error {
-	// Save credentials securely
+	// Save credentials securely, leaving an unchanged key untouched
 	if result.LinearAPIKey != "" {
 		store, err := config.NewStore()
 		if err != nil {
 			return fmt.Errorf("failed to create credential store: %w", err)
 		}
-		if err := store.SetLinearAPIKey(result.LinearAPIKey); err != nil {
-			return fmt.Errorf("failed to save API key: %w", err)
+		stored, err := store.GetLinearAPIKey()
+		if err != nil {
+			return fmt.Errorf("failed to read stored API key: %w", err)
+		}
+		if stored != result.LinearAPIKey {
+			if err := store.SetLinearAPIKey(result.LinearAPIKey); err != nil {
+				return fmt.Errorf("failed to save API key: %w", err)
+			}
 		}
 	}
 
 	// Save configuration
-	if err := config.Set("default.tracker", result.Tracker); err != nil {
-		return fmt.Errorf("failed to set tracker: %w", err)
+	if result.Tracker != "" {
+		if err := config.Set("default.tracker", result.Tracker); err != nil {
+			return fmt.Errorf("failed to set tracker: %w", err)
+		}
+	}
+	// Onboarding sets up Claude Code, but a reconfigure keeps another runner
+	runner := config.Get().Default.Runner
+	if runner == "" {
+		runner = "claude"
 	}
-	if err := config.Set("default.runner", "claude"); err != nil {
+	if err := config.Set("default.runner", runner); err != nil {
 		return fmt.Errorf("failed to set runner: %w", err)
 	}
 
@@ -598,11 +956,15 @@ func SaveOnboardingResult(result *OnboardingResult) error {
 		}
 	}
 
-	if err := config.Set("git.branch_pattern", result.BranchPattern); err != nil {
-		return fmt.Errorf("failed to set branch_pattern: %w", err)
+	if result.BranchPattern != "" {
+		if err := config.Set("git.branch_pattern", result.BranchPattern); err != nil {
+			return fmt.Errorf("failed to set branch_pattern: %w", err)
+		}
 	}
-	if err := config.Set("git.worktree_dir", result.WorktreeDir); err != nil {
-		return fmt.Errorf("failed to set worktree_dir: %w", err)
+	if result.WorktreeDir != "" {
+		if err := config.Set("git.worktree_dir", result.WorktreeDir); err != nil {
+			return fmt.Errorf("failed to set worktree_dir: %w", err)
+		}
 	}
 
 	// Save skills location preference
@@ -623,21 +985,14 @@ func SaveOnboardingResult(result *OnboardingResult) error {
 // location can be "global" (user-level) or "project" (project-level)
 func InstallClaudeSkills(location string) error {
 	// Run the existing init logic to set up hooks
-	if err := setupClaudeHooks(); err != nil {
+	hooks, err := setupClaudeHooks()
+	if err != nil {
 		return fmt.Errorf("failed to set up Claude hooks: %w", err)
 	}
 
-	// Determine commands directory based on location
-	var commandsDir string
-	if location == "global" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-		commandsDir = filepath.Join(homeDir, ".claude", "commands", "jig")
-	} else {
-		// Default to project-level
-		commandsDir = ".claude/commands/jig"
+	commandsDir, err := skillsCommandsDir(location)
+	if err != nil {
+		return err
 	}
 
 	// Ensure directory exists
@@ -646,79 +1001,116 @@ func InstallClaudeSkills(location string) error {
 	}
 
 	// Write embedded skill files
-	if err := installSkillFiles(commandsDir, initForce); err != nil {
+	skillFiles, err := installSkillFiles(commandsDir, initForce)
+	if err != nil {
 		return fmt.Errorf("failed to install skill files: %w", err)
 	}
 
+	printInstallSummary(append([]installedFile{hooks}, skillFiles...))
 	return nil
 }
 
 // InstallSkillFiles installs Claude Code skill files (exported for use in init.go)
 // location can be "global" (user-level) or "project" (project-level)
 func InstallSkillFiles(location string, force bool) error {
-	// Determine commands directory based on location
-	var commandsDir string
-	if location == "global" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-		commandsDir = filepath.Join(homeDir, ".claude", "commands", "jig")
-	} else {
-		// Default to project-level
-		commandsDir = ".claude/commands/jig"
+	commandsDir, err := skillsCommandsDir(location)
+	if err != nil {
+		return err
 	}
 
 	if err := os.MkdirAll(commandsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create commands directory: %w", err)
 	}
 
-	return installSkillFiles(commandsDir, force)
+	skillFiles, err := installSkillFiles(commandsDir, force)
+	if err != nil {
+		return err
+	}
+	printInstallSummary(skillFiles)
+	return nil
 }
 
-// installSkillFiles writes embedded skill files to the commands directory
-func installSkillFiles(commandsDir string, force bool) error {
-	skillFiles := []string{"plan.md", "implement.md"}
+// skillsCommandsDir returns the directory skill files are installed in for
+// location: under the home directory for "global", else under the project
+func skillsCommandsDir(location string) (string, error) {
+	if location == "global" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		return filepath.Join(homeDir, ".claude", "commands", "jig"), nil
+	}
+	// Default to project-level
+	return ".claude/commands/jig", nil
+}
+
+// skillFileNames are the embedded skill files installed for Claude Code
+var skillFileNames = []string{"plan.md", "implement.md"}
 
-	for _, skillFile := range skillFiles {
+// installSkillFiles writes embedded skill files to the commands directory.
+// Files that differ from jig's version are only overwritten with force.
+func installSkillFiles(commandsDir string, force bool) ([]installedFile, error) {
+	var installed []installedFile
+	for _, skillFile := range skillFileNames {
 		content, err := skills.EmbeddedSkills.ReadFile(skillFile)
 		if err != nil {
-			return fmt.Errorf("failed to read embedded skill %s: %w", skillFile, err)
+			return nil, fmt.Errorf("failed to read embedded skill %s: %w", skillFile, err)
 		}
 
 		destPath := filepath.Join(commandsDir, skillFile)
+		status, err := skillFileStatus(destPath, content)
+		if err != nil {
+			return nil, err
+		}
 
-		// Check if file exists (skip unless force)
-		if _, err := os.Stat(destPath); err == nil && !force {
-			continue // File exists, don't overwrite
+		action := installActionInstalled
+		switch {
+		case status == installStatusCurrent:
+			installed = append(installed, installedFile{Path: destPath, Action: installActionUnchanged})
+			continue
+		case status == installStatusStale && !force:
+			installed = append(installed, installedFile{Path: destPath, Action: installActionKept})
+			continue
+		case status == installStatusStale:
+			action = installActionUpdated
 		}
 
 		if err := os.WriteFile(destPath, content, 0644); err != nil {
-			return fmt.Errorf("failed to write skill %s: %w", skillFile, err)
+			return nil, fmt.Errorf("failed to write skill %s: %w", skillFile, err)
 		}
+		installed = append(installed, installedFile{Path: destPath, Action: action})
 	}
 
-	return nil
+	return installed, nil
 }
 
-// setupClaudeHooks sets up the Claude Code hooks for jig integration
-func setupClaudeHooks() error {
+// claudeSettingsPath is the project's Claude Code settings file, which holds
+// jig's hook and permissions
+var claudeSettingsPath = filepath.Join(".claude", "settings.json")
+
+// setupClaudeHooks sets up the Claude Code hooks for jig integration. The
+// settings file is left untouched if the hook and permissions are current.
+func setupClaudeHooks() (installedFile, error) {
 	// Ensure .claude directory exists
 	claudeDir := ".claude"
 	if err := os.MkdirAll(claudeDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .claude directory: %w", err)
+		return installedFile{}, fmt.Errorf("failed to create .claude directory: %w", err)
 	}
 
-	settingsPath := filepath.Join(claudeDir, "settings.json")
+	settingsPath := claudeSettingsPath
 
 	// Read existing settings if present
-	var rawSettings map[string]interface{}
-	if data, err := os.ReadFile(settingsPath); err == nil {
-		if err := json.Unmarshal(data, &rawSettings); err != nil {
-			return fmt.Errorf("failed to parse existing settings.json: %w", err)
-		}
-	} else {
-		rawSettings = make(map[string]interface{})
+	rawSettings, err := readClaudeSettings(settingsPath)
+	if err != nil {
+		return installedFile{}, err
+	}
+
+	action := installActionInstalled
+	switch claudeHooksStatus(rawSettings) {
+	case installStatusCurrent:
+		return installedFile{Path: settingsPath, Action: installActionUnchanged}, nil
+	case installStatusStale:
+		action = installActionUpdated
 	}
 
 	// Add or update the hooks
@@ -747,14 +1139,35 @@ func setupClaudeHooks() error {
 	// Write back
 	data, err := json.MarshalIndent(rawSettings, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to serialize settings: %w", err)
+		return installedFile{}, fmt.Errorf("failed to serialize settings: %w", err)
 	}
 
 	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write settings.json: %w", err)
+		return installedFile{}, fmt.Errorf("failed to write settings.json: %w", err)
 	}
 
-	return nil
+	return installedFile{Path: settingsPath, Action: action}, nil
+}
+
+// readClaudeSettings reads a Claude Code settings file, returning empty
+// settings if it doesn't exist
+func readClaudeSettings(settingsPath string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(settingsPath)
+	if os.IsNotExist(err) {
+		return make(map[string]interface{}), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings.json: %w", err)
+	}
+
+	var rawSettings map[string]interface{}
+	if err := json.Unmarshal(data, &rawSettings); err != nil {
+		return nil, fmt.Errorf("failed to parse existing settings.json: %w", err)
+	}
+	if rawSettings == nil {
+		rawSettings = make(map[string]interface{})
+	}
+	return rawSettings, nil
 }
 
 // getOrCreateHooksMap gets or creates the hooks section
@@ -1,9 +1,21 @@
 package cli
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/linear"
+	"github.com/charleslr/jig/internal/ui"
 )
 
 func TestInstallSkillFiles(t *testing.T) {
@@ -218,3 +230,428 @@ func TestInstallClaudeSkills(t *testing.T) {
 		}
 	})
 }
+
+// setupOnboardingConfig points jig at a temp directory holding the given
+// config.toml and stored API key, and loads it
+func setupOnboardingConfig(t *testing.T, configContent, apiKey string) string {
+	t.Helper()
+	jigHome := t.TempDir()
+	t.Setenv("JIG_HOME", jigHome)
+
+	if err := os.WriteFile(filepath.Join(jigHome, "config.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if apiKey != "" {
+		store, err := config.NewStore()
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		if err := store.SetLinearAPIKey(apiKey); err != nil {
+			t.Fatalf("failed to store API key: %v", err)
+		}
+	}
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	return jigHome
+}
+
+const existingOnboardingConfig = `[default]
+tracker = "linear"
+runner = "codex"
+
+[linear]
+team_id = "team-2"
+default_project = "project-1"
+plan_label_name = "planned"
+
+[git]
+branch_pattern = "{issue_id}"
+worktree_dir = "/tmp/trees"
+
+[claude]
+skills_location = "project"
+`
+
+func TestLoadOnboardingDefaults(t *testing.T) {
+	setupOnboardingConfig(t, existingOnboardingConfig, "lin_api_stored")
+
+	defaults, err := loadOnboardingDefaults()
+	if err != nil {
+		t.Fatalf("loadOnboardingDefaults() error = %v", err)
+	}
+
+	want := OnboardingResult{
+		Tracker:        "linear",
+		LinearAPIKey:   "lin_api_stored",
+		TeamID:         "team-2",
+		ProjectID:      "project-1",
+		BranchPattern:  "{issue_id}",
+		WorktreeDir:    "/tmp/trees",
+		SkillsLocation: "project",
+	}
+	if *defaults != want {
+		t.Errorf("loadOnboardingDefaults() = %+v, want %+v", *defaults, want)
+	}
+
+	t.Run("seeds the wizard steps", func(t *testing.T) {
+		steps := []ui.WizardStep{
+			{ID: "tracker", Options: []ui.SelectOption{{Label: "Linear", Value: "linear"}, {Label: "None", Value: "none"}}},
+			{ID: "branch_pattern", Placeholder: "{issue_id}-{slug}"},
+			{ID: "worktree_dir", Placeholder: "/default/trees"},
+			{ID: "skills_location", Options: []ui.SelectOption{{Label: "Global", Value: "global"}, {Label: "Project", Value: "project"}}},
+		}
+		seedOnboardingSteps(steps, defaults)
+
+		if got := steps[0].Options[0].Label; got != "Linear (current)" {
+			t.Errorf("expected current tracker first, got %q", got)
+		}
+		if steps[1].Placeholder != "{issue_id}" {
+			t.Errorf("expected branch pattern default %q, got %q", "{issue_id}", steps[1].Placeholder)
+		}
+		if steps[2].Placeholder != "/tmp/trees" {
+			t.Errorf("expected worktree dir default %q, got %q", "/tmp/trees", steps[2].Placeholder)
+		}
+		if got := steps[3].Options[0].Value; got != "project" {
+			t.Errorf("expected current skills location first, got %q", got)
+		}
+	})
+}
+
+func TestWithCurrentFirst(t *testing.T) {
+	options := []ui.SelectOption{
+		{Label: "Alpha", Value: "a"},
+		{Label: "Beta", Value: "b"},
+		{Label: "Gamma", Value: "c"},
+	}
+
+	got := withCurrentFirst(options, "b")
+	var labels []string
+	for _, opt := range got {
+		labels = append(labels, opt.Label)
+	}
+	if strings.Join(labels, ",") != "Beta (current),Alpha,Gamma" {
+		t.Errorf("withCurrentFirst() labels = %v", labels)
+	}
+	if options[1].Label != "Beta" {
+		t.Error("withCurrentFirst() must not modify the given options")
+	}
+
+	if got := withCurrentFirst(options, "missing"); len(got) != 3 || got[0].Label != "Alpha" {
+		t.Errorf("expected options unchanged for unknown value, got %+v", got)
+	}
+}
+
+func TestSaveOnboardingResult_Merges(t *testing.T) {
+	jigHome := setupOnboardingConfig(t, existingOnboardingConfig, "lin_api_stored")
+	credPath := filepath.Join(jigHome, ".credentials")
+
+	// Backdate the credentials file to detect rewrites
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(credPath, old, old); err != nil {
+		t.Fatalf("failed to backdate credentials: %v", err)
+	}
+
+	// A reconfigure that changed the team and kept everything else
+	err := SaveOnboardingResult(&OnboardingResult{
+		Tracker:      "linear",
+		LinearAPIKey: "lin_api_stored",
+		TeamID:       "team-3",
+	})
+	if err != nil {
+		t.Fatalf("SaveOnboardingResult() error = %v", err)
+	}
+
+	info, err := os.Stat(credPath)
+	if err != nil {
+		t.Fatalf("failed to stat credentials: %v", err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Error("expected unchanged API key not to be rewritten")
+	}
+
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to reload config: %v", err)
+	}
+	cfg := config.Get()
+	if cfg.Linear.TeamID != "team-3" {
+		t.Errorf("expected team to be updated, got %q", cfg.Linear.TeamID)
+	}
+	checks := map[string][2]string{
+		"default.runner":         {cfg.Default.Runner, "codex"},
+		"linear.default_project": {cfg.Linear.DefaultProject, "project-1"},
+		"linear.plan_label_name": {cfg.Linear.PlanLabelName, "planned"},
+		"git.branch_pattern":     {cfg.Git.BranchPattern, "{issue_id}"},
+		"git.worktree_dir":       {cfg.Git.WorktreeDir, "/tmp/trees"},
+		"claude.skills_location": {cfg.Claude.SkillsLocation, "project"},
+	}
+	for key, c := range checks {
+		if c[0] != c[1] {
+			t.Errorf("expected %s to be preserved as %q, got %q", key, c[1], c[0])
+		}
+	}
+
+	// Switching to no tracker leaves the stored key alone
+	if err := SaveOnboardingResult(&OnboardingResult{Tracker: "none"}); err != nil {
+		t.Fatalf("SaveOnboardingResult() error = %v", err)
+	}
+	store, _ := config.NewStore()
+	if key, _ := store.GetLinearAPIKey(); key != "lin_api_stored" {
+		t.Errorf("expected stored API key to be preserved, got %q", key)
+	}
+}
+
+func TestSaveOnboardingResult_SetsRunner(t *testing.T) {
+	jigHome := setupOnboardingConfig(t, "", "")
+	// Clear the runner explicitly, as earlier tests may have set it in viper
+	if err := config.Set("default.runner", ""); err != nil {
+		t.Fatalf("config.Set() error = %v", err)
+	}
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	if err := SaveOnboardingResult(&OnboardingResult{Tracker: "linear"}); err != nil {
+		t.Fatalf("SaveOnboardingResult() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(jigHome, "config.toml"))
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if !strings.Contains(string(data), "runner = 'claude'") && !strings.Contains(string(data), `runner = "claude"`) {
+		t.Errorf("expected the claude runner written to the config, got:\n%s", data)
+	}
+}
+
+func TestNewOnboardingClient_UsesConfiguredURL(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":{"teams":{"nodes":[{"id":"team-1","name":"Engineering","key":"ENG"}]}}}`))
+	}))
+	defer server.Close()
+
+	setupOnboardingConfig(t, "", "")
+	if err := config.Set("linear.api_url", server.URL); err != nil {
+		t.Fatalf("config.Set() error = %v", err)
+	}
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	t.Cleanup(func() {
+		config.Set("linear.api_url", "")
+		config.Init("")
+	})
+
+	client, err := newOnboardingClient("lin_api_stored")
+	if err != nil {
+		t.Fatalf("newOnboardingClient() error = %v", err)
+	}
+	teams, err := client.GetTeams(context.Background())
+	if err != nil {
+		t.Fatalf("GetTeams() error = %v", err)
+	}
+	if requests != 1 || len(teams) != 1 {
+		t.Errorf("expected the teams fetched from the configured URL, got %d request(s) and %v", requests, teams)
+	}
+}
+
+func TestRunNonInteractiveOnboardingWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newDeps returns deps backed by a fake Linear workspace. The saved result
+	// is recorded in saved.
+	newDeps := func(env map[string]string, saved **OnboardingResult) onboardDeps {
+		return onboardDeps{
+			getenv: func(key string) string { return env[key] },
+			getTeams: func(ctx context.Context, apiKey string) ([]tracker.Team, error) {
+				if apiKey != "lin_api_good" {
+					return nil, &linear.APIError{Code: "AUTHENTICATION_ERROR", Message: "Authentication required"}
+				}
+				return []tracker.Team{
+					{ID: "team-1", Key: "ENG", Name: "Engineering"},
+					{ID: "team-2", Key: "OPS", Name: "Operations"},
+				}, nil
+			},
+			getProjects: func(ctx context.Context, apiKey, teamID string) ([]tracker.Project, error) {
+				return []tracker.Project{{ID: "project-1", Name: "Platform", TeamID: teamID}}, nil
+			},
+			save: func(result *OnboardingResult) error {
+				*saved = result
+				return nil
+			},
+		}
+	}
+	goodEnv := map[string]string{linearAPIKeyEnv: "lin_api_good"}
+
+	t.Run("complete linear setup", func(t *testing.T) {
+		var saved *OnboardingResult
+		_, err := runNonInteractiveOnboardingWithDeps(ctx, NonInteractiveOptions{
+			Tracker:       "linear",
+			LinearTeam:    "ops",
+			LinearProject: "platform",
+			BranchPattern: "{issue_id}",
+			WorktreeDir:   "/work/trees",
+		}, newDeps(goodEnv, &saved))
+		if err != nil {
+			t.Fatalf("runNonInteractiveOnboardingWithDeps() error = %v", err)
+		}
+		if saved == nil {
+			t.Fatal("expected the result to be saved")
+		}
+
+		want := OnboardingResult{
+			Tracker:        "linear",
+			LinearAPIKey:   "lin_api_good",
+			TeamID:         "team-2",
+			TeamName:       "Operations",
+			ProjectID:      "project-1",
+			ProjectName:    "Platform",
+			BranchPattern:  "{issue_id}",
+			WorktreeDir:    "/work/trees",
+			SkillsLocation: "global",
+		}
+		if *saved != want {
+			t.Errorf("saved %+v, want %+v", *saved, want)
+		}
+	})
+
+	t.Run("no tracker uses defaults", func(t *testing.T) {
+		var saved *OnboardingResult
+		_, err := runNonInteractiveOnboardingWithDeps(ctx, NonInteractiveOptions{Tracker: "none"}, newDeps(nil, &saved))
+		if err != nil {
+			t.Fatalf("runNonInteractiveOnboardingWithDeps() error = %v", err)
+		}
+		if saved.BranchPattern != "{issue_id}-{slug}" || saved.WorktreeDir != getDefaultWorktreeDir() || saved.LinearAPIKey != "" {
+			t.Errorf("unexpected result: %+v", saved)
+		}
+	})
+
+	errorTests := []struct {
+		name    string
+		opts    NonInteractiveOptions
+		env     map[string]string
+		wantErr string
+	}{
+		{"missing tracker", NonInteractiveOptions{}, goodEnv, "--tracker is required"},
+		{"unknown tracker", NonInteractiveOptions{Tracker: "jira"}, goodEnv, `invalid --tracker "jira"`},
+		{"missing team", NonInteractiveOptions{Tracker: "linear"}, goodEnv, "--linear-team is required"},
+		{"missing API key", NonInteractiveOptions{Tracker: "linear", LinearTeam: "ENG"}, nil, linearAPIKeyEnv + " must be set"},
+		{"rejected API key", NonInteractiveOptions{Tracker: "linear", LinearTeam: "ENG"}, map[string]string{linearAPIKeyEnv: "lin_api_bad"}, "jig config set linear.api_key"},
+		{"unknown team", NonInteractiveOptions{Tracker: "linear", LinearTeam: "MKT"}, goodEnv, "available: ENG, OPS"},
+		{"unknown project", NonInteractiveOptions{Tracker: "linear", LinearTeam: "ENG", LinearProject: "Mobile"}, goodEnv, `--linear-project "Mobile"`},
+		{"linear flags without linear", NonInteractiveOptions{Tracker: "none", LinearTeam: "ENG"}, goodEnv, "require --tracker linear"},
+		{"bad skills location", NonInteractiveOptions{Tracker: "none", SkillsLocation: "home"}, nil, "invalid --skills-location"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *OnboardingResult
+			_, err := runNonInteractiveOnboardingWithDeps(ctx, tt.opts, newDeps(tt.env, &saved))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if saved != nil {
+				t.Error("expected nothing to be saved")
+			}
+		})
+	}
+}
+
+func TestRunOnboardingWizard_ValidationRecovery(t *testing.T) {
+	networkErr := errors.New("failed to connect to Linear: connection reset")
+
+	// run runs a key entry, validation and summary wizard. Key entry returns
+	// keys in turn, validation returns validateErrs in turn, and recovery
+	// prompts return choices in turn. It returns the IDs of the steps run.
+	run := func(t *testing.T, keys []string, validateErrs []error, choices []string) ([]string, *OnboardingResult, bool) {
+		t.Helper()
+		steps := []ui.WizardStep{
+			{ID: "linear_api_key", Type: ui.StepTypeInput},
+			{ID: "validate_linear", Type: ui.StepTypeSpinner},
+			{ID: "summary", Type: ui.StepTypeSummary},
+		}
+
+		var ran []string
+		deps := onboardingWizardDeps{
+			runStep: func(step ui.WizardStep, results map[string]string) (string, bool, error) {
+				ran = append(ran, step.ID)
+				switch step.ID {
+				case "linear_api_key":
+					key := keys[0]
+					keys = keys[1:]
+					return key, false, nil
+				case "validate_linear":
+					err := validateErrs[0]
+					validateErrs = validateErrs[1:]
+					return "", false, err
+				}
+				return "", false, nil
+			},
+			chooseRecovery: func(err error) (string, error) {
+				if len(choices) == 0 {
+					t.Fatalf("unexpected recovery prompt for %v", err)
+				}
+				choice := choices[0]
+				choices = choices[1:]
+				return choice, nil
+			},
+		}
+
+		result := &OnboardingResult{}
+		var teams []tracker.Team
+		var projects []tracker.Project
+		_, completed, err := runOnboardingWizard(steps, result, nil, &teams, &projects, deps)
+		if err != nil {
+			t.Fatalf("runOnboardingWizard() error = %v", err)
+		}
+		return ran, result, completed
+	}
+
+	t.Run("retry validates the same key again", func(t *testing.T) {
+		ran, result, completed := run(t, []string{"lin_api_1"}, []error{networkErr, nil}, []string{validationRetry})
+
+		want := "linear_api_key,validate_linear,validate_linear,summary"
+		if got := strings.Join(ran, ","); got != want {
+			t.Errorf("steps run = %s, want %s", got, want)
+		}
+		if !completed || result.LinearAPIKey != "lin_api_1" {
+			t.Errorf("expected completed wizard with the first key, got completed=%v key=%q", completed, result.LinearAPIKey)
+		}
+	})
+
+	t.Run("change key returns to key entry", func(t *testing.T) {
+		ran, result, completed := run(t, []string{"lin_api_1", "lin_api_2"}, []error{networkErr, nil}, []string{validationChangeKey})
+
+		want := "linear_api_key,validate_linear,linear_api_key,validate_linear,summary"
+		if got := strings.Join(ran, ","); got != want {
+			t.Errorf("steps run = %s, want %s", got, want)
+		}
+		if !completed || result.LinearAPIKey != "lin_api_2" {
+			t.Errorf("expected completed wizard with the new key, got completed=%v key=%q", completed, result.LinearAPIKey)
+		}
+	})
+
+	t.Run("quit ends the wizard", func(t *testing.T) {
+		ran, _, completed := run(t, []string{"lin_api_1"}, []error{networkErr}, []string{validationQuit})
+
+		if completed {
+			t.Error("expected wizard not to complete")
+		}
+		if got := strings.Join(ran, ","); got != "linear_api_key,validate_linear" {
+			t.Errorf("steps run = %s", got)
+		}
+	})
+
+	t.Run("cancelled validation returns to key entry", func(t *testing.T) {
+		cancelled := fmt.Errorf("failed to connect to Linear: %w", context.Canceled)
+		ran, result, completed := run(t, []string{"lin_api_1", "lin_api_2"}, []error{cancelled, nil}, nil)
+
+		want := "linear_api_key,validate_linear,linear_api_key,validate_linear,summary"
+		if got := strings.Join(ran, ","); got != want {
+			t.Errorf("steps run = %s, want %s", got, want)
+		}
+		if !completed || result.LinearAPIKey != "lin_api_2" {
+			t.Errorf("expected completed wizard with the new key, got completed=%v key=%q", completed, result.LinearAPIKey)
+		}
+	})
+}
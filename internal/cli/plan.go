@@ -1,22 +1,38 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/git"
+	"github.com/charleslr/jig/internal/logging"
 	"github.com/charleslr/jig/internal/plan"
 	"github.com/charleslr/jig/internal/runner"
+	"github.com/charleslr/jig/internal/skills"
 	"github.com/charleslr/jig/internal/state"
 	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/gitlab"
 	"github.com/charleslr/jig/internal/tracker/linear"
 	"github.com/charleslr/jig/internal/ui"
 )
@@ -28,14 +44,29 @@ var planCmd = &cobra.Command{
 
 When run without a subcommand, creates a new plan (same as 'jig plan new').
 If ISSUE_ID is provided, the plan will be seeded with context from the
-existing Linear issue.
+existing Linear issue. ISSUE_ID can also be the issue's URL, as copied from
+the Linear app.
 
 Subcommands:
   new      Create a new plan
   list     List cached plans
   show     Show a cached plan
   save     Save a plan from file or stdin
-  import   Import a plan from a file`,
+  validate Check a plan file without saving it
+  import   Import a plan from a file
+  search   Search cached plans by text
+  pull     Pull issue status from Linear
+  unsync   Mark a plan as not synced
+  open     Open a plan's linked issue in the browser
+  phase    Manage plan phases
+  status   Set a plan's status and its issue's
+  rename   Rename a plan and its issue
+  breakdown Create sub-issues for a plan's phases
+  export   Export a plan to a standalone file
+  diff     Compare a plan with its Linear comment
+  archive  Hide a plan from default listings
+  template Manage plan templates
+  delete   Delete a cached plan`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPlanNew,
 }
@@ -46,10 +77,33 @@ var planNewCmd = &cobra.Command{
 	Long: `Create a new implementation plan, optionally from an existing issue.
 
 If ISSUE_ID is provided, the plan will be seeded with context from the
-existing Linear issue. Otherwise, a blank planning session is started.
+existing Linear issue. ISSUE_ID can also be the issue's URL. Otherwise, a
+blank planning session is started. Issues fetched in the last 15 minutes
+are read from the local cache; use --no-cache to fetch the issue again.
+
+Plans without an issue get a new Linear issue when saved. Use --team and
+--project to create it in another team or project than the configured one,
+or --no-issue to never create one for a throwaway plan.
+
+Use --interactive-fields to fill in the plan's title, goal, labels and team
+in a form before planning starts. They are kept with the planning session
+and applied when the plan is saved, overriding the generated title.
+
+Use --from-template to start from one of your templates in ~/.jig/templates
+(see 'jig plan template list'). {{title}} and {{issue_id}} in the template
+are replaced with the plan's title and issue ID.
 
 After creating the plan, jig launches your configured coding tool
-for an interactive planning session.`,
+for an interactive planning session. With --print-prompt, jig writes the
+session context and prints the full planning prompt instead, so it can be
+pasted into any coding tool.
+
+Use --resume with a session ID to reattach to a planning session that was
+interrupted. The runner is relaunched with the session's existing context;
+if the session already saved a plan, its next steps are shown instead.
+
+Examples:
+  jig plan new --resume 1718900000000000000`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPlanNew,
 }
@@ -59,6 +113,14 @@ var (
 	planNewGoal     string
 	planNewRunner   string
 	planNewNoLaunch bool
+	planNewTemplate    string
+	planNewPrintPrompt bool
+	planNewNoCache     bool
+	planNewTeam        string
+	planNewProject     string
+	planNewInteractiveFields bool
+	planNewResume            string
+	planNewNoIssue           bool
 )
 
 var planSaveCmd = &cobra.Command{
@@ -75,13 +137,53 @@ Examples:
   jig plan save plan.md
   cat plan.md | jig plan save
   jig plan save < plan.md
-  jig plan save --session 12345 plan.md`,
+  jig plan save --session 12345 plan.md
+  jig plan save --dry-run plan.md
+  jig plan save --team OPS --project "Q3 Roadmap" plan.md
+
+When a Linear issue is created for the plan, --team and --project override
+the configured team and project. Teams can be given by ID or key, projects
+by ID or name. --issue-template creates the issue from a Linear issue
+template (by ID or name) instead of linear.issue_template; the plan's title
+and description are used over the template's.
+
+Before creating a Linear issue for a plan without one, an interactive save
+asks for confirmation; pass --yes to create it without asking. Saves that
+aren't interactive, such as from Claude Code, create it as before unless
+--no-create is passed. --no-sync skips both creating and syncing.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPlanSave,
 }
 
 var planSaveSessionID string
 var planSaveNoSync bool
+var planSaveDryRun bool
+var planSaveTeam string
+var planSaveProject string
+var planSaveYes bool
+var planSaveIssueTemplate string
+var planSaveNoCreate bool
+
+var planValidateCmd = &cobra.Command{
+	Use:   "validate [FILE]",
+	Short: "Check a plan file without saving it",
+	Long: `Check that a plan is valid, without saving it or contacting Linear.
+
+Runs the same checks as 'jig plan save': the frontmatter schema, the
+required frontmatter fields and sections, parsing, and the consistency of
+the parsed plan, such as unique phase IDs and well-formed issue IDs. Prints a summary of
+the plan if it is valid, or every problem found if not, in which case the
+command exits with an error.
+
+If FILE is provided, reads the plan from that file.
+If no FILE is provided, reads from stdin.
+
+Examples:
+  jig plan validate plan.md
+  cat plan.md | jig plan validate`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlanValidate,
+}
 
 var planImportCmd = &cobra.Command{
 	Use:   "import <FILE>",
@@ -90,95 +192,571 @@ var planImportCmd = &cobra.Command{
 
 This is a convenience alias for 'jig plan save <FILE>'.
 
-Example:
-  jig plan import ./my-plan.md`,
-	Args: cobra.ExactArgs(1),
+With --stdin-json, the plan is read from stdin as a JSON object instead, for
+tools that don't write jig's markdown format. title, problem and solution
+are required; status defaults to draft and author to your git identity:
+
+  {
+    "title": "Add rate limiting",
+    "problem": "Clients can overload the API.",
+    "solution": "Add a token bucket per API key.",
+    "status": "draft",
+    "labels": ["backend"],
+    "phases": [{"id": "1", "title": "Limiter"}],
+    "sections": [{"title": "Acceptance Criteria", "content": "- [ ] Limits apply"}]
+  }
+
+id, issue_id, estimate, related_issues, links, blocks and blocked_by are also
+accepted.
+
+Examples:
+  jig plan import ./my-plan.md
+  other-tool --json | jig plan import --stdin-json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runPlanImport,
 }
 
+var planImportStdinJSON bool
+
 var planShowCmd = &cobra.Command{
 	Use:   "show <PLAN_ID>",
 	Short: "Show a cached plan",
 	Long: `Display a cached plan in an interactive viewer.
 
-Use --raw to output the raw markdown instead.`,
+The plan body is rendered as markdown, with syntax-highlighted code blocks.
+Use --no-color to render it without colors.
+
+PLAN_ID may be a plan ID or the identifier of the linked issue (e.g., NUM-41).
+Use --raw to output the raw markdown instead; raw markdown is also output when
+not running in a terminal.
+
+Use --format json or --format yaml to output the parsed plan instead, with
+its phases and progress, for scripts and integrations. --format markdown is
+the same as --raw.
+
+Use --comments to show the linked issue's comments below the plan, with
+their author and when they were posted. Plans without a linked issue are
+shown without comments.
+
+Use --web to render the plan as an HTML page in a temporary file and open
+it in the browser, for a quick preview to share.
+
+Examples:
+  jig plan show PLAN-1234567890
+  jig plan show NUM-41 --comments
+  jig plan show NUM-41 --web
+  jig plan show NUM-41 --raw
+  jig plan show NUM-41 --format json | jq .progress`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPlanShow,
 }
 
-var planShowRaw bool
+var (
+	planShowRaw      bool
+	planShowNoColor  bool
+	planShowFormat   string
+	planShowComments bool
+	planShowWeb      bool
+)
 
 var planListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List cached plans",
-	Long:  `List all plans in jig's cache.`,
-	RunE:  runPlanList,
+	Long: `List all plans in jig's cache.
+
+Archived plans are hidden unless --all is passed.
+Use --since to only show plans updated recently, either within a duration
+(e.g. 72h, 7d) or since a date (e.g. 2024-01-01).
+Plans are listed most recently updated first. Use --sort to order them by
+created, updated, title or status (in workflow order) instead, --reverse to
+flip the order, and --limit to only show the first N.
+Use --json for machine-readable output.
+
+Examples:
+  jig plan list
+  jig plan list --sort title
+  jig plan list --sort created --reverse --limit 10 --json`,
+	RunE: runPlanList,
+}
+
+var (
+	planListJSON    bool
+	planListAll     bool
+	planListSince   string
+	planListSort    string
+	planListReverse bool
+	planListLimit   int
+)
+
+var planSearchCmd = &cobra.Command{
+	Use:   "search <QUERY>",
+	Short: "Search cached plans by text",
+	Long: `Find cached plans whose title, problem statement, proposed solution,
+or content contains QUERY (case-insensitive).
+
+Use --json for machine-readable output.
+
+Examples:
+  jig plan search "rate limit"
+  jig plan search auth --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanSearch,
+}
+
+var planSearchJSON bool
+
+var planPullCmd = &cobra.Command{
+	Use:   "pull [PLAN_ID]",
+	Short: "Pull issue status from Linear into cached plans",
+	Long: `Update the status of cached plans from their linked Linear issues.
+
+With PLAN_ID, pulls the status for that plan only. Without arguments,
+pulls the status for every cached plan that has a linked issue.
+
+Only the plan status is updated; local plan content is left untouched.
+
+Examples:
+  jig plan pull                    # Pull all linked plans
+  jig plan pull PLAN-1234567890    # Pull a specific plan`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlanPull,
 }
 
 var planSyncCmd = &cobra.Command{
 	Use:   "sync [PLAN_ID]",
-	Short: "Sync plans to Linear",
-	Long: `Sync implementation plans to their associated Linear issues.
+	Short: "Sync plans to their issues",
+	Long: `Sync implementation plans to their associated Linear or GitLab issues.
 
 Without arguments, shows an interactive multi-select of all unsynced plans.
-With PLAN_ID, syncs that specific plan immediately.
+With PLAN_ID, syncs that specific plan immediately. With --all, syncs every
+unsynced plan without prompting, so it can run from cron or CI.
 
 Plans are considered "unsynced" if they have a linked issue and either:
 - Have never been synced
 - Have been updated after the last sync
 
+If the plan comment on the issue was edited since jig last wrote it, the sync
+is refused so manual edits aren't lost. Pass --force to overwrite them.
+
+Exit codes:
+  0  All plans synced, or nothing to sync
+  1  Invalid usage or configuration
+  3  Some plans synced, others failed
+  4  No plans could be synced (e.g., authentication failure)
+
+With --watch, keeps running and syncs plans as they are saved: whenever a
+cached plan changes and needs a sync, it is synced after a short pause to let
+further saves settle. With PLAN_ID, only that plan is watched. Press Ctrl-C to
+stop.
+
 Examples:
   jig plan sync                    # Interactive multi-select
-  jig plan sync NUM-123            # Sync specific plan`,
+  jig plan sync NUM-123            # Sync specific plan
+  jig plan sync NUM-123 --force    # Overwrite edits made in Linear
+  jig plan sync --all              # Sync every unsynced plan
+  jig plan sync --watch            # Sync plans as they are saved`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPlanSync,
 }
 
+var (
+	planSyncForce bool
+	planSyncWatch bool
+	planSyncAll   bool
+)
+
+// Exit codes reported by 'jig plan sync' (documented in planSyncCmd.Long)
+const (
+	exitCodeSyncPartial = 3
+	exitCodeSyncFailed  = 4
+)
+
+var planUnsyncCmd = &cobra.Command{
+	Use:   "unsync <PLAN_ID>",
+	Short: "Mark a plan as not synced",
+	Long: `Forget that a cached plan has been synced to its Linear issue.
+
+The plan stays linked to its issue, and the plan comment is left on the
+issue. The next 'jig plan sync' treats the plan as never synced.
+
+With --remove-label, the plan label (linear.plan_label_name, "jig-plan" by
+default) is also removed from the linked issue, keeping its other labels.
+This is useful when an issue is reopened or rescoped and no longer has a plan.
+
+Examples:
+  jig plan unsync PLAN-1234567890
+  jig plan unsync PLAN-1234567890 --remove-label`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanUnsync,
+}
+
+var planUnsyncRemoveLabel bool
+
+var planOpenCmd = &cobra.Command{
+	Use:   "open <PLAN_ID>",
+	Short: "Open a plan's linked issue in the browser",
+	Long: `Open the issue linked to a cached plan in the default browser.
+
+In a non-interactive environment, or with --print, the issue URL is printed
+instead of opened.
+
+Examples:
+  jig plan open PLAN-1234567890
+  jig plan open PLAN-1234567890 --print`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanOpen,
+}
+
+var planOpenPrint bool
+
 var planLinkCmd = &cobra.Command{
-	Use:   "link <PLAN_ID> <ISSUE_ID>",
-	Short: "Link a plan to an existing issue",
-	Long: `Link an existing plan to an existing Linear issue.
+	Use:   "link <PLAN_ID> <ISSUE_ID> [RELATED_ISSUE_ID...]",
+	Short: "Link a plan to existing issues",
+	Long: `Link an existing plan to existing Linear issues.
 
 This is useful for:
 - Fixing orphaned plans that failed to link during creation
 - Manually associating a plan with a different issue
 - Recovering from failed issue creation
+- Covering an epic and its sub-issues with a single plan
+
+The first issue is the plan's primary issue. Any further issues are recorded
+as related issues, replacing the plan's previous related issues. After linking,
+the plan content will be synced to the primary issue, with a reference to the
+related issues.
 
-After linking, the plan content will be synced to the issue.
+With --unlink, the plan is detached from its issues instead: its primary and
+related issues are cleared and it is marked as not synced. The plan comment
+is left on the issue. Add --remove-label to also remove the plan label
+(linear.plan_label_name, "jig-plan" by default) from the previously linked
+issue.
 
 Examples:
   jig plan link PLAN-1234567890 NUM-123
-  jig plan link my-plan-id NUM-456`,
-	Args: cobra.ExactArgs(2),
+  jig plan link my-plan-id NUM-456
+  jig plan link PLAN-1234567890 NUM-1 NUM-2 NUM-3
+  jig plan link PLAN-1234567890 --unlink
+  jig plan link PLAN-1234567890 --unlink --remove-label`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if planLinkUnlink {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.MinimumNArgs(2)(cmd, args)
+	},
 	RunE: runPlanLink,
 }
 
+var planLinkUnlink bool
+var planLinkRemoveLabel bool
+
+var planDeleteCmd = &cobra.Command{
+	Use:   "delete [PLAN_ID]",
+	Short: "Delete a cached plan",
+	Long: `Remove a plan from jig's local cache.
+
+This only deletes the cached copy; the linked issue (if any) is left untouched.
+In interactive mode, asks for confirmation unless --force is passed.
+
+If no PLAN_ID is provided and the terminal is interactive, shows a
+multi-select of cached plans to delete several at once.
+
+Examples:
+  jig plan delete PLAN-1234567890
+  jig plan delete PLAN-1234567890 --force
+  jig plan delete`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlanDelete,
+}
+
+var planDeleteForce bool
+
+var planArchiveCmd = &cobra.Command{
+	Use:   "archive <PLAN_ID>",
+	Short: "Hide a plan from default listings",
+	Long: `Archive a cached plan so it no longer clutters 'jig plan list'.
+
+Archived plans are kept in the cache and can still be shown, exported and
+looked up by ID. Use 'jig plan list --all' to include them in listings and
+'jig plan unarchive' to restore them.
+
+Examples:
+  jig plan archive PLAN-1234567890`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanArchive,
+}
+
+var planUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <PLAN_ID>",
+	Short: "Restore an archived plan to default listings",
+	Long: `Unarchive a cached plan so it shows up in 'jig plan list' again.
+
+Examples:
+  jig plan unarchive PLAN-1234567890`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanUnarchive,
+}
+
+var planTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage plan templates",
+	Long: `Manage reusable plan templates.
+
+Templates are markdown files stored in ~/.jig/templates, e.g.
+~/.jig/templates/feature.md. Start a plan from one with
+'jig plan new --from-template feature'.`,
+}
+
+var planTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available plan templates",
+	Args:  cobra.NoArgs,
+	RunE:  runPlanTemplateList,
+}
+
+var planExportCmd = &cobra.Command{
+	Use:   "export <PLAN_ID>",
+	Short: "Export a plan to a standalone file",
+	Long: `Write a cached plan to a file outside jig's cache.
+
+If --output is an existing directory, the file is named <PLAN_ID>.md
+(or <PLAN_ID>.json with --format json). Parent directories are created
+as needed. Existing files are not overwritten unless --force is passed.
+
+Examples:
+  jig plan export PLAN-1234567890 --output plan.md
+  jig plan export PLAN-1234567890 --output ./docs/plans/
+  jig plan export PLAN-1234567890 --output plan.json --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanExport,
+}
+
+var (
+	planExportOutput string
+	planExportFormat string
+	planExportForce  bool
+)
+
+var planPhaseCmd = &cobra.Command{
+	Use:   "phase",
+	Short: "Manage plan phases",
+	Long: `Manage the phases declared in a plan's frontmatter.
+
+Subcommands:
+  set      Set the status of a phase`,
+}
+
+var planPhaseSetCmd = &cobra.Command{
+	Use:   "set <PLAN_ID> <PHASE_ID> <STATUS>",
+	Short: "Set the status of a plan phase",
+	Long: `Set the status of a phase in a cached plan.
+
+STATUS must be one of: pending, in-progress, done.
+
+The plan is marked as updated, so it will be picked up by the next
+'jig plan sync'.
+
+Examples:
+  jig plan phase set PLAN-1234567890 phase-1 done
+  jig plan phase set PLAN-1234567890 phase-2 in-progress`,
+	Args: cobra.ExactArgs(3),
+	RunE: runPlanPhaseSet,
+}
+
+var planStatusCmd = &cobra.Command{
+	Use:   "status <PLAN_ID> <STATUS>",
+	Short: "Set a plan's status and its issue's",
+	Long: `Set the status of a cached plan and move its linked issue to match.
+
+STATUS must be one of: draft, reviewing, approved, in-progress, in-review,
+complete. The status is set as given, without checking that the plan can
+move there from its current status.
+
+If the plan is linked to an issue and Linear is configured, the issue is
+transitioned to the matching workflow state (draft, reviewing and approved
+map to todo, complete maps to done). If that fails, the plan keeps its new
+status and the command exits with an error.
+
+Examples:
+  jig plan status PLAN-1234567890 in-review
+  jig plan status PLAN-1234567890 complete`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPlanStatus,
+}
+
+var planRenameCmd = &cobra.Command{
+	Use:   "rename <PLAN_ID> <TITLE>",
+	Short: "Rename a plan and its issue",
+	Long: `Change the title of a cached plan and of its linked issue.
+
+The plan's frontmatter title is updated, along with its top-level heading if
+that matches the old title. The plan is marked as changed, so the next sync
+updates its comment on the issue.
+
+If the plan is linked to an issue and Linear is configured, the issue's title
+is updated too. If that fails, the plan keeps its new title and the command
+exits with an error.
+
+Examples:
+  jig plan rename PLAN-1234567890 "Add rate limiting to the API"
+  jig plan rename PLAN-1234567890 Add rate limiting to the API`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runPlanRename,
+}
+
+var planCopyCmd = &cobra.Command{
+	Use:   "copy <PLAN_ID> [NEW_ID]",
+	Short: "Duplicate a plan",
+	Long: `Save a copy of a cached plan as a new draft plan, to start from it. Its
+phases start over as pending.
+
+The copy gets NEW_ID, or a generated ID (see plan.id_format) if none is given.
+It is not linked to an issue and has never been synced, so saving or syncing
+it later creates its own issue. Its content is kept as is, apart from the
+frontmatter.
+
+Examples:
+  jig plan copy PLAN-1234567890
+  jig plan copy PLAN-1234567890 plan-rate-limiting-v2`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPlanCopy,
+}
+
+var planBreakdownCmd = &cobra.Command{
+	Use:   "breakdown <PLAN_ID>",
+	Short: "Create sub-issues for a plan's phases",
+	Long: `Create a sub-issue under the plan's linked issue for each of its phases.
+
+Each sub-issue is titled after its phase, and described by the section of the
+plan whose heading names the phase, if there is one. The sub-issue is recorded
+on the phase as issue_id, so running the command again only creates sub-issues
+for phases added since.
+
+Examples:
+  jig plan breakdown PLAN-1234567890`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanBreakdown,
+}
+
+var planDiffCmd = &cobra.Command{
+	Use:   "diff <PLAN_ID>",
+	Short: "Compare a plan with its Linear comment",
+	Long: `Show what 'jig plan sync' would change in Linear.
+
+Prints a unified diff from the plan comment on the linked Linear issue to
+the cached plan. If the plan has never been synced, the whole plan is shown
+as added.
+
+Exit codes:
+  0  The plan matches its Linear comment
+  1  Invalid usage or configuration
+  2  The comparison failed
+  5  The plan differs from its Linear comment
+
+Examples:
+  jig plan diff PLAN-1234567890`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanDiff,
+}
+
+// Exit codes reported by 'jig plan diff' (documented in planDiffCmd.Long).
+// A difference has its own code, so scripts can tell it from an error.
+const (
+	exitCodeDiffFailed = 2
+	exitCodeDiffFound  = 5
+)
+
 func init() {
 	// Add flags to both planCmd and planNewCmd
 	planCmd.Flags().StringVarP(&planNewTitle, "title", "t", "", "plan title (optional, will be generated if not provided)")
 	planCmd.Flags().StringVarP(&planNewGoal, "goal", "g", "", "what you want to plan (can also be provided interactively)")
 	planCmd.Flags().StringVarP(&planNewRunner, "runner", "r", "", "coding tool to use (default from config)")
 	planCmd.Flags().BoolVar(&planNewNoLaunch, "no-launch", false, "don't launch the coding tool")
+	planCmd.Flags().StringVar(&planNewTemplate, "from-template", "", "start the plan from a template in ~/.jig/templates")
+	planCmd.Flags().BoolVar(&planNewPrintPrompt, "print-prompt", false, "print the planning prompt instead of launching the coding tool")
+	planCmd.Flags().BoolVar(&planNewNoCache, "no-cache", false, "fetch the issue from the tracker even if a cached copy is fresh")
+	planCmd.Flags().StringVar(&planNewTeam, "team", "", "team (ID or key) to create the plan's issue in, instead of the configured one")
+	planCmd.Flags().StringVar(&planNewProject, "project", "", "project (ID or name) to create the plan's issue in, instead of the configured one")
+	planCmd.Flags().BoolVar(&planNewInteractiveFields, "interactive-fields", false, "fill in the plan's title, goal, labels and team in a form before planning")
+	planCmd.Flags().StringVar(&planNewResume, "resume", "", "reattach to an interrupted planning session by its session ID")
+	planCmd.Flags().BoolVar(&planNewNoIssue, "no-issue", false, "never create an issue for the plan when it's saved")
 
 	planNewCmd.Flags().StringVarP(&planNewTitle, "title", "t", "", "plan title (optional, will be generated if not provided)")
 	planNewCmd.Flags().StringVarP(&planNewGoal, "goal", "g", "", "what you want to plan (can also be provided interactively)")
 	planNewCmd.Flags().StringVarP(&planNewRunner, "runner", "r", "", "coding tool to use (default from config)")
 	planNewCmd.Flags().BoolVar(&planNewNoLaunch, "no-launch", false, "don't launch the coding tool")
+	planNewCmd.Flags().StringVar(&planNewTemplate, "from-template", "", "start the plan from a template in ~/.jig/templates")
+	planNewCmd.Flags().BoolVar(&planNewPrintPrompt, "print-prompt", false, "print the planning prompt instead of launching the coding tool")
+	planNewCmd.Flags().BoolVar(&planNewNoCache, "no-cache", false, "fetch the issue from the tracker even if a cached copy is fresh")
+	planNewCmd.Flags().StringVar(&planNewTeam, "team", "", "team (ID or key) to create the plan's issue in, instead of the configured one")
+	planNewCmd.Flags().StringVar(&planNewProject, "project", "", "project (ID or name) to create the plan's issue in, instead of the configured one")
+	planNewCmd.Flags().BoolVar(&planNewInteractiveFields, "interactive-fields", false, "fill in the plan's title, goal, labels and team in a form before planning")
+	planNewCmd.Flags().StringVar(&planNewResume, "resume", "", "reattach to an interrupted planning session by its session ID")
+	planNewCmd.Flags().BoolVar(&planNewNoIssue, "no-issue", false, "never create an issue for the plan when it's saved")
 
 	planCmd.AddCommand(planNewCmd)
 	planCmd.AddCommand(planSaveCmd)
+	planCmd.AddCommand(planValidateCmd)
 	planCmd.AddCommand(planImportCmd)
 	planCmd.AddCommand(planShowCmd)
 	planCmd.AddCommand(planListCmd)
 	planCmd.AddCommand(planSyncCmd)
 	planCmd.AddCommand(planLinkCmd)
+	planCmd.AddCommand(planUnsyncCmd)
+	planCmd.AddCommand(planOpenCmd)
+	planCmd.AddCommand(planDeleteCmd)
+	planCmd.AddCommand(planSearchCmd)
+	planCmd.AddCommand(planPullCmd)
+	planCmd.AddCommand(planPhaseCmd)
+	planCmd.AddCommand(planStatusCmd)
+	planCmd.AddCommand(planRenameCmd)
+	planCmd.AddCommand(planCopyCmd)
+	planCmd.AddCommand(planBreakdownCmd)
+	planCmd.AddCommand(planExportCmd)
+	planCmd.AddCommand(planDiffCmd)
+	planCmd.AddCommand(planArchiveCmd)
+	planCmd.AddCommand(planUnarchiveCmd)
+	planCmd.AddCommand(planTemplateCmd)
+	planTemplateCmd.AddCommand(planTemplateListCmd)
+	planPhaseCmd.AddCommand(planPhaseSetCmd)
 
 	planSaveCmd.Flags().StringVar(&planSaveSessionID, "session", "", "session ID for tracking the saved plan (used by jig plan)")
-	planSaveCmd.Flags().BoolVar(&planSaveNoSync, "no-sync", false, "don't sync plan to Linear")
+	planSaveCmd.Flags().BoolVar(&planSaveNoSync, "no-sync", false, "don't sync plan to the tracker")
+	planSaveCmd.Flags().BoolVar(&planSaveDryRun, "dry-run", false, "show what would be saved and synced without writing anything")
+	planSaveCmd.Flags().StringVar(&planSaveTeam, "team", "", "team (ID or key) to create the plan's issue in, instead of the configured one")
+	planSaveCmd.Flags().StringVar(&planSaveProject, "project", "", "project (ID or name) to create the plan's issue in, instead of the configured one")
+	planSaveCmd.Flags().StringVar(&planSaveIssueTemplate, "issue-template", "", "Linear issue template (ID or name) to create the plan's issue from, instead of the configured one")
+	planSaveCmd.Flags().BoolVarP(&planSaveYes, "yes", "y", false, "create an issue for the plan without asking")
+	planSaveCmd.Flags().BoolVar(&planSaveNoCreate, "no-create", false, "don't create an issue for a plan without one")
+	planImportCmd.Flags().BoolVar(&planImportStdinJSON, "stdin-json", false, "read the plan from stdin as a JSON object")
 	planShowCmd.Flags().BoolVar(&planShowRaw, "raw", false, "output raw markdown instead of interactive view")
+	planShowCmd.Flags().BoolVar(&planShowNoColor, "no-color", false, "render the plan without colors or syntax highlighting")
+	planShowCmd.Flags().StringVar(&planShowFormat, "format", "", "output format: markdown, json or yaml")
+	planShowCmd.Flags().BoolVar(&planShowComments, "comments", false, "show the linked issue's comments below the plan")
+	planShowCmd.Flags().BoolVar(&planShowWeb, "web", false, "render the plan as HTML and open it in the browser")
+	planListCmd.Flags().BoolVar(&planListJSON, "json", false, "output as JSON")
+	planListCmd.Flags().BoolVarP(&planListAll, "all", "a", false, "include archived plans")
+	planListCmd.Flags().StringVar(&planListSince, "since", "", "only show plans updated within a duration (72h, 7d) or since a date (2024-01-01)")
+	planListCmd.Flags().StringVar(&planListSort, "sort", "updated", "order plans by created, updated, title or status")
+	planListCmd.Flags().BoolVar(&planListReverse, "reverse", false, "reverse the sort order")
+	planListCmd.Flags().IntVarP(&planListLimit, "limit", "n", 0, "only show the first N plans (0 shows all)")
+	planSearchCmd.Flags().BoolVar(&planSearchJSON, "json", false, "output as JSON")
+	planDeleteCmd.Flags().BoolVarP(&planDeleteForce, "force", "f", false, "delete without asking for confirmation")
+	planExportCmd.Flags().StringVarP(&planExportOutput, "output", "o", "", "file or directory to write the plan to (required)")
+	planExportCmd.Flags().StringVar(&planExportFormat, "format", "markdown", "output format: markdown or json")
+	planExportCmd.Flags().BoolVarP(&planExportForce, "force", "f", false, "overwrite an existing file")
+	planExportCmd.MarkFlagRequired("output")
+	planSyncCmd.Flags().BoolVarP(&planSyncForce, "force", "f", false, "overwrite plan comments edited in the tracker since the last sync")
+	planSyncCmd.Flags().BoolVarP(&planSyncWatch, "watch", "w", false, "keep running and sync plans as they are saved")
+	planSyncCmd.Flags().BoolVar(&planSyncAll, "all", false, "sync every unsynced plan without prompting")
+	planUnsyncCmd.Flags().BoolVar(&planUnsyncRemoveLabel, "remove-label", false, "also remove the plan label from the linked issue")
+	planLinkCmd.Flags().BoolVar(&planLinkUnlink, "unlink", false, "detach the plan from its issues instead of linking it")
+	planLinkCmd.Flags().BoolVar(&planLinkRemoveLabel, "remove-label", false, "with --unlink, also remove the plan label from the previously linked issue")
+	planOpenCmd.Flags().BoolVar(&planOpenPrint, "print", false, "print the issue URL instead of opening it")
 }
 
-func runPlanSave(cmd *cobra.Command, args []string) error {
+// readPlanInput reads plan content from the file in args, or from stdin if
+// there is none
+func readPlanInput(args []string) ([]byte, error) {
 	var content []byte
 	var err error
 
@@ -186,21 +764,130 @@ func runPlanSave(cmd *cobra.Command, args []string) error {
 		// Read from file
 		content, err = os.ReadFile(args[0])
 		if err != nil {
-			return fmt.Errorf("failed to read file: %w", err)
+			return nil, fmt.Errorf("failed to read file: %w", err)
 		}
 	} else {
 		// Read from stdin
 		content, err = io.ReadAll(os.Stdin)
 		if err != nil {
-			return fmt.Errorf("failed to read from stdin: %w", err)
+			return nil, fmt.Errorf("failed to read from stdin: %w", err)
 		}
 	}
 
 	if len(content) == 0 {
-		return fmt.Errorf("no plan content provided")
+		return nil, fmt.Errorf("no plan content provided")
+	}
+	return content, nil
+}
+
+func runPlanValidate(cmd *cobra.Command, args []string) error {
+	content, err := readPlanInput(args)
+	if err != nil {
+		return err
+	}
+
+	p, problems := validatePlanContent(content)
+	writePlanValidation(os.Stdout, p, problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("plan is invalid (%d problem(s))", len(problems))
+	}
+	return nil
+}
+
+// validatePlanContent runs the checks 'jig plan save' runs before saving a
+// plan and returns the parsed plan, or every problem found if any check fails
+func validatePlanContent(content []byte) (*plan.Plan, []string) {
+	var problems []string
+
+	if err := plan.ValidateFrontmatter(content); err != nil {
+		var schemaErr *plan.SchemaError
+		if errors.As(err, &schemaErr) {
+			problems = append(problems, schemaErr.Problems...)
+		} else {
+			problems = append(problems, err.Error())
+		}
+	}
+	if err := plan.ValidateStructure(content); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	p, err := plan.Parse(content)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	for _, err := range p.Validate() {
+		// Plans without an ID get one generated on save
+		var fieldErr *plan.FieldError
+		if p.ID == "" && errors.As(err, &fieldErr) && fieldErr.Field == "id" {
+			continue
+		}
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return p, nil
+}
+
+// writePlanValidation writes the outcome of validatePlanContent: a summary of
+// the plan if it is valid, or the problems found
+func writePlanValidation(w io.Writer, p *plan.Plan, problems []string) {
+	if len(problems) > 0 {
+		fmt.Fprintln(w, "Plan is invalid:")
+		for _, problem := range problems {
+			fmt.Fprintf(w, "  - %s\n", problem)
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "Plan is valid")
+	if p.ID != "" {
+		fmt.Fprintf(w, "  ID: %s\n", p.ID)
+	} else {
+		fmt.Fprintf(w, "  ID: none (generated on save)\n")
+	}
+	fmt.Fprintf(w, "  Title: %s\n", p.Title)
+	fmt.Fprintf(w, "  Status: %s\n", p.Status)
+	fmt.Fprintf(w, "  Author: %s\n", p.Author)
+	if p.HasLinkedIssue() {
+		fmt.Fprintf(w, "  Issue: %s\n", p.IssueID)
+	}
+	if len(p.Phases) > 0 {
+		fmt.Fprintf(w, "  Phases: %d\n", len(p.Phases))
+	}
+	if len(p.Labels) > 0 {
+		fmt.Fprintf(w, "  Labels: %s\n", strings.Join(p.Labels, ", "))
+	}
+}
+
+// newPlanID generates an ID for a new plan in the configured plan.id_format
+// that no cached plan has
+func newPlanID(cfg *config.Config, title string) (string, error) {
+	id, err := plan.GenerateID(cfg.Plan.GetIDFormat(), title, state.PlanExists)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate plan ID: %w", err)
+	}
+	return id, nil
+}
+
+func runPlanSave(cmd *cobra.Command, args []string) error {
+	content, err := readPlanInput(args)
+	if err != nil {
+		return err
 	}
+	return savePlanContent(content)
+}
 
-	// Validate the plan structure before parsing
+// savePlanContent validates, saves and syncs a plan's markdown, as
+// 'jig plan save' does
+func savePlanContent(content []byte) error {
+	// Validate frontmatter values and the plan structure before parsing
+	if err := plan.ValidateFrontmatter(content); err != nil {
+		return fmt.Errorf("invalid plan format: %w", err)
+	}
 	if err := plan.ValidateStructure(content); err != nil {
 		return fmt.Errorf("invalid plan format: %w", err)
 	}
@@ -211,65 +898,101 @@ func runPlanSave(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse plan: %w", err)
 	}
 
-	// Auto-generate plan ID if not provided
-	if p.ID == "" {
-		p.ID = fmt.Sprintf("PLAN-%d", time.Now().Unix())
+	// Issues shouldn't be created titled after the placeholder
+	if err := resolvePlanTitle(p); err != nil {
+		return err
 	}
 
-	// Initialize cache
-	if err := state.Init(); err != nil {
-		return fmt.Errorf("failed to initialize cache: %w", err)
+	cfg := config.Get()
+
+	// Auto-generate plan ID if not provided
+	generatedID := p.ID == ""
+	if generatedID {
+		if p.ID, err = newPlanID(cfg, p.Title); err != nil {
+			return err
+		}
+	}
+	if errs := p.Validate(); len(errs) > 0 {
+		return fmt.Errorf("invalid plan: %w", errors.Join(errs...))
 	}
 
-	cfg := config.Get()
 	ctx := context.Background()
 
-	// Read session metadata to check for linked issue
-	sessionIssueID := ""
+	// Read session metadata to check for linked issue and issue target
+	var session state.SessionMetadata
 	if planSaveSessionID != "" {
-		sessionIssueID = readSessionIssueID(planSaveSessionID)
+		session = readSessionMetadata(planSaveSessionID)
 	}
 
-	// Link to issue from session metadata if provided
-	if sessionIssueID != "" {
-		p.IssueID = sessionIssueID
+	// A session started with --no-issue never creates an issue, even when the
+	// plan is saved by the hook
+	noCreate := planSaveNoCreate || session.NoIssue
+
+	// Link to issue and apply the fields given when the session started
+	if err := applySessionMetadata(p, session); err != nil {
+		return err
 	}
 
-	// Create Linear issue if plan has no linked issue and creation is enabled
-	if !planSaveNoSync && shouldCreateIssueForPlan(cfg, p) {
-		issueID, err := createIssueForPlan(ctx, cfg, p)
-		if err != nil {
-			printWarning(fmt.Sprintf("Could not create Linear issue: %v", err))
-		} else {
-			p.IssueID = issueID
-			printSuccess(fmt.Sprintf("Created Linear issue %s", issueID))
-		}
+	// Flags override the team and project chosen when the session started
+	target := sessionIssueTarget(session, planSaveTeam, planSaveProject)
+	target.Template = planSaveIssueTemplate
+
+	// Report what would happen before anything touches the cache or Linear
+	if planSaveDryRun {
+		printPlanSavePreview(p, previewPlanSave(cfg, p, generatedID, planSaveNoSync, noCreate))
+		return nil
 	}
 
-	// Save to cache (with potentially updated IssueID)
-	if err := state.DefaultCache.SavePlan(p); err != nil {
-		return fmt.Errorf("failed to save plan: %w", err)
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	// Create Linear issue if plan has no linked issue and creation is enabled,
+	// then save to cache (with potentially updated IssueID)
+	deps := planSaveIssueDeps{
+		shouldCreateIssue: func(p *plan.Plan) bool {
+			return !planSaveNoSync && !noCreate && shouldCreateIssueForPlan(cfg, p)
+		},
+		createIssue: func(ctx context.Context, p *plan.Plan) (string, error) {
+			return createIssueForPlan(ctx, cfg, p, target)
+		},
+		savePlan: state.DefaultCache.SavePlan,
+	}
+	if !planSaveYes && ui.IsInteractive() {
+		deps.confirmCreate = func(p *plan.Plan) (bool, error) {
+			return ui.RunConfirmWithDefault(fmt.Sprintf("Create a %s issue for plan %q?", trackerName(cfg), p.Title), false)
+		}
+	}
+	record := createdIssueRecord(planSaveSessionID, p.ID, generatedID)
+	if err := createIssueAndSavePlanWithDeps(ctx, p, record, deps); err != nil {
+		return err
+	}
+	// The cached plan holds its issue now. A session keeps its record, as
+	// its plan file is saved again without the issue.
+	if planSaveSessionID == "" && record != "" {
+		if err := os.Remove(record); err != nil && !os.IsNotExist(err) {
+			printWarning(fmt.Sprintf("Could not remove the created issue record: %v", err))
+		}
 	}
 
 	// Sync to Linear if applicable (adds comment to existing linked issue)
-	if !planSaveNoSync && shouldSyncToLinear(cfg, p) {
-		// Get cached hash for deduplication (empty string if not previously synced)
-		cachedHash := ""
-		if cached, err := state.DefaultCache.GetCachedPlan(p.ID); err == nil && cached != nil {
-			cachedHash = cached.SyncedContentHash
+	if !planSaveNoSync && shouldSyncPlan(cfg, p) {
+		// Use the cached sync state for deduplication and conflict detection
+		cached, err := state.DefaultCache.GetCachedPlan(p.ID)
+		if err != nil || cached == nil {
+			cached = &state.CachedPlan{Plan: p}
 		}
 
-		result, err := syncPlanToLinearWithDedup(ctx, cfg, p, cachedHash)
+		result, err := syncPlanToTrackerWithDedup(ctx, cfg, cached, false)
 		if err != nil {
-			printWarning(fmt.Sprintf("Could not sync to Linear: %v", err))
+			printWarning(fmt.Sprintf("Could not sync to %s: %v", trackerName(cfg), err))
 		} else if result.Skipped {
 			printInfo("Plan content unchanged, skipping sync")
+		} else if result.ConflictDetected {
+			printWarning(planCommentConflictMessage(p))
 		} else {
-			// Mark plan as synced with the content hash
-			if err := state.DefaultCache.MarkPlanSyncedWithHash(p.ID, result.ContentHash); err != nil {
-				printWarning(fmt.Sprintf("Plan synced but failed to update sync timestamp: %v", err))
-			}
-			printSuccess(fmt.Sprintf("Plan synced to Linear issue %s", p.IssueID))
+			printSuccess(fmt.Sprintf("Plan synced to %s issue %s", trackerName(cfg), p.IssueID))
 		}
 	}
 
@@ -279,7 +1002,9 @@ func runPlanSave(cmd *cobra.Command, args []string) error {
 	// Write the plan ID to the session directory for tracking
 	// This allows runPlanNew to know which plan was saved during this session
 	if planSaveSessionID != "" {
-		writeSavedPlanID(planSaveSessionID, p.ID)
+		if err := writeSavedPlanID(planSaveSessionID, p.ID); err != nil {
+			printWarning(fmt.Sprintf("Could not record the saved plan in the session: %v", err))
+		}
 	}
 
 	printSuccess(fmt.Sprintf("Plan saved: %s", p.ID))
@@ -291,77 +1016,239 @@ func runPlanSave(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// markPlanSaved creates a marker file indicating the plan has been saved
-func markPlanSaved() {
-	jigDir := ".jig"
-	os.MkdirAll(jigDir, 0755)
-	os.WriteFile(filepath.Join(jigDir, "plan-saved.marker"), []byte{}, 0644)
-}
-
-// writeSavedPlanID writes the plan ID to the session directory for tracking
-// This allows the parent planning session to know which plan was saved
-func writeSavedPlanID(sessionID, planID string) {
-	sessionDir := filepath.Join(".jig", "sessions", sessionID)
-	os.MkdirAll(sessionDir, 0755)
-	os.WriteFile(filepath.Join(sessionDir, "saved-plan-id"), []byte(planID), 0644)
+// planSaveIssueDeps holds dependencies for creating a plan's issue and
+// saving the plan (for testability)
+type planSaveIssueDeps struct {
+	shouldCreateIssue func(p *plan.Plan) bool
+	confirmCreate     func(p *plan.Plan) (bool, error) // Asks before creating an issue; nil creates without asking
+	createIssue       func(ctx context.Context, p *plan.Plan) (string, error)
+	savePlan          func(p *plan.Plan) error
 }
 
-// readSavedPlanID reads the plan ID that was saved during a planning session
-// Returns empty string if no plan was saved or the file doesn't exist
-func readSavedPlanID(sessionID string) string {
-	sessionDir := filepath.Join(".jig", "sessions", sessionID)
-	data, err := os.ReadFile(filepath.Join(sessionDir, "saved-plan-id"))
-	if err != nil {
-		return ""
+// createIssueAndSavePlanWithDeps creates an issue for a plan that needs one
+// and saves the plan. The new issue is recorded in record as soon as it is
+// created, so if saving then fails, re-running the save links that issue
+// instead of creating a second one. An issue recorded by an earlier save is
+// linked without creating one. If the user declines to create an issue, the
+// plan is saved without one. A failed issue creation only warns.
+func createIssueAndSavePlanWithDeps(ctx context.Context, p *plan.Plan, record string, deps planSaveIssueDeps) error {
+	if !p.HasLinkedIssue() {
+		if issueID := readCreatedIssueID(record); issueID != "" {
+			p.IssueID = issueID
+			printInfo(fmt.Sprintf("Linking issue %s created by an earlier save", issueID))
+		}
 	}
-	return strings.TrimSpace(string(data))
-}
 
-// readSessionIssueID reads the issue ID from session metadata
-// Returns empty string if no issue ID is set in the session
-func readSessionIssueID(sessionID string) string {
-	sessionDir := filepath.Join(".jig", "sessions", sessionID)
-	metadataPath := filepath.Join(sessionDir, "metadata.json")
-	data, err := os.ReadFile(metadataPath)
-	if err != nil {
+	create := deps.shouldCreateIssue(p)
+	if create && deps.confirmCreate != nil {
+		confirmed, err := deps.confirmCreate(p)
+		if err != nil {
+			return fmt.Errorf("failed to confirm issue creation: %w", err)
+		}
+		if !confirmed {
+			printInfo("Saving the plan without an issue")
+			create = false
+		}
+	}
+
+	created := ""
+	if create {
+		issueID, err := deps.createIssue(ctx, p)
+		if err != nil {
+			printWarning(fmt.Sprintf("Could not create issue: %v", err))
+		} else {
+			p.IssueID = issueID
+			created = issueID
+			printSuccess(fmt.Sprintf("Created issue %s", issueID))
+			if err := writeCreatedIssueID(record, issueID); err != nil {
+				printWarning(fmt.Sprintf("Could not record the created issue: %v", err))
+			}
+		}
+	}
+
+	if err := deps.savePlan(p); err != nil {
+		if created != "" && record != "" {
+			return fmt.Errorf("failed to save plan (issue %s was created and will be linked when you run the save again): %w", created, err)
+		}
+		if created != "" {
+			return fmt.Errorf("failed to save plan (issue %s was created; add issue_id: %s to the plan before saving it again): %w", created, created, err)
+		}
+		return fmt.Errorf("failed to save plan: %w", err)
+	}
+	return nil
+}
+
+// createdIssueRecord returns the file recording the issue created for a plan
+// by 'jig plan save'. Plans saved from a planning session are recorded in the
+// session directory, other plans by their ID until they are saved. Returns ""
+// for a plan with a generated ID outside a session, as a re-run can't be
+// matched to it, and for an ID that can't name a file.
+func createdIssueRecord(sessionID, planID string, generatedID bool) string {
+	if sessionID != "" {
+		return filepath.Join(".jig", "sessions", sessionID, "created-issue-id")
+	}
+	if generatedID || plan.ValidateID(planID) != nil {
 		return ""
 	}
+	return filepath.Join(".jig", "created-issues", planID)
+}
 
-	var metadata struct {
-		IssueID string `json:"issue_id"`
+// readCreatedIssueID reads the issue ID recorded in record.
+// Returns empty string if there is no record.
+func readCreatedIssueID(record string) string {
+	if record == "" {
+		return ""
 	}
-	if err := json.Unmarshal(data, &metadata); err != nil {
+	data, err := os.ReadFile(record)
+	if err != nil {
 		return ""
 	}
-	return metadata.IssueID
+	return strings.TrimSpace(string(data))
 }
 
-// writeSessionMetadata writes session metadata to track the linked issue
-func writeSessionMetadata(sessionID, issueID string) error {
-	sessionDir := filepath.Join(".jig", "sessions", sessionID)
-	if err := os.MkdirAll(sessionDir, 0755); err != nil {
-		return fmt.Errorf("failed to create session directory: %w", err)
+// writeCreatedIssueID records the issue created for a plan in record
+func writeCreatedIssueID(record, issueID string) error {
+	if record == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(record), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(record, []byte(issueID), 0644)
+}
+
+// markPlanSaved creates a marker file indicating the plan has been saved
+func markPlanSaved() {
+	jigDir := ".jig"
+	os.MkdirAll(jigDir, 0755)
+	path := filepath.Join(jigDir, "plan-saved.marker")
+	logging.Debug("creating marker", "marker", path)
+	writeMarker(path)
+}
+
+// clearPlanMarkers removes the markers a previous planning session may have
+// left in .jig, so they can't let a new session exit plan mode
+func clearPlanMarkers() {
+	for _, name := range []string{"plan-saved", "skip-save"} {
+		path := filepath.Join(".jig", name+".marker")
+		if err := os.Remove(path); err == nil {
+			logging.Debug("removed leftover marker", "marker", path)
+		}
+	}
+}
+
+// planSessionDir returns the directory of a planning session
+func planSessionDir(sessionID string) string {
+	return filepath.Join(".jig", "sessions", sessionID)
+}
+
+// writeSavedPlanID records the plan saved during a planning session in its
+// metadata, so the parent planning session knows which plan was saved
+func writeSavedPlanID(sessionID, planID string) error {
+	return state.UpdateSessionMetadata(planSessionDir(sessionID), func(m *state.SessionMetadata) {
+		m.PlanID = planID
+		m.Status = state.SessionStatusSaved
+	})
+}
+
+// readSavedPlanID reads the plan ID that was saved during a planning session
+// Returns empty string if no plan was saved or the session doesn't exist
+func readSavedPlanID(sessionID string) string {
+	return readSessionMetadata(sessionID).PlanID
+}
+
+// readSessionMetadata reads a planning session's metadata.
+// Returns empty metadata if the session has none or it can't be read.
+func readSessionMetadata(sessionID string) state.SessionMetadata {
+	m, err := state.ReadSessionMetadata(planSessionDir(sessionID))
+	if err != nil {
+		logging.Debug("failed to read session metadata", "session", sessionID, "error", err)
+		return state.SessionMetadata{}
+	}
+	return *m
+}
+
+// sessionMetadataForFields returns session metadata with the fields given up
+// front with --interactive-fields, which override the flags
+func sessionMetadataForFields(session state.SessionMetadata, fields ui.PlanFields) state.SessionMetadata {
+	session.Title = fields.Title
+	session.Goal = fields.Goal
+	session.Labels = fields.Labels
+	if fields.Team != "" {
+		session.Team = fields.Team
+	}
+	return session
+}
+
+// applySessionMetadata links a plan to its session's issue and applies the
+// title and labels given when the session started. A given title overrides
+// the generated one; given labels are added to the plan's own.
+func applySessionMetadata(p *plan.Plan, session state.SessionMetadata) error {
+	if session.IssueID != "" {
+		p.IssueID = session.IssueID
 	}
 
-	metadata := map[string]interface{}{
-		"issue_id":   issueID,
-		"created_at": time.Now().Format(time.RFC3339),
-		"command":    fmt.Sprintf("jig plan %s", issueID),
+	changed := false
+	if session.Title != "" && session.Title != p.Title {
+		p.RawContent = renamePlanHeading(p.RawContent, p.Title, session.Title)
+		p.Title = session.Title
+		changed = true
+	}
+	for _, label := range session.Labels {
+		if !slices.ContainsFunc(p.Labels, func(l string) bool { return strings.EqualFold(l, label) }) {
+			p.Labels = append(p.Labels, label)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
 	}
 
-	data, err := json.MarshalIndent(metadata, "", "  ")
+	// Refresh the raw content so the given fields are what gets saved and synced
+	data, err := plan.Serialize(p)
 	if err != nil {
-		return fmt.Errorf("failed to serialize metadata: %w", err)
+		return fmt.Errorf("failed to serialize plan: %w", err)
 	}
+	p.RawContent = string(data)
+	return nil
+}
 
-	metadataPath := filepath.Join(sessionDir, "metadata.json")
-	if err := os.WriteFile(metadataPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write metadata: %w", err)
+// resolvePlanTitle trims the plan's title and replaces the placeholder that
+// 'jig plan new' starts plans with by a title derived from the problem
+// statement. A blank title, or a placeholder with nothing to derive a title
+// from, is an error.
+func resolvePlanTitle(p *plan.Plan) error {
+	title := strings.TrimSpace(p.Title)
+	if title == "" {
+		return fmt.Errorf("invalid plan: title is required")
+	}
+	if title == plan.PlaceholderTitle {
+		if title = p.DeriveTitle(); title == "" {
+			return fmt.Errorf("invalid plan: title is still the placeholder %q; give the plan a title", plan.PlaceholderTitle)
+		}
+		printInfo(fmt.Sprintf("Titled the plan %q after its problem statement", title))
+	}
+	if title == p.Title {
+		return nil
 	}
 
+	p.RawContent = renamePlanHeading(p.RawContent, strings.TrimSpace(p.Title), title)
+	p.Title = title
+	data, err := plan.Serialize(p)
+	if err != nil {
+		return fmt.Errorf("failed to serialize plan: %w", err)
+	}
+	p.RawContent = string(data)
 	return nil
 }
 
+// writeSessionMetadata writes session metadata to track the linked issue,
+// the team and project to create a new issue in, and the plan fields given
+// when the session started
+func writeSessionMetadata(sessionID string, session state.SessionMetadata) error {
+	return state.WriteSessionMetadata(planSessionDir(sessionID), &session)
+}
+
 // displaySavedPlanNextSteps checks if a plan was saved during the session and displays next steps
 // Returns true if a plan was found and displayed, false otherwise
 func displaySavedPlanNextSteps(sessionID string) bool {
@@ -377,13 +1264,82 @@ func displaySavedPlanNextSteps(sessionID string) bool {
 }
 
 func runPlanImport(cmd *cobra.Command, args []string) error {
-	// Just delegate to save with the file argument
-	return runPlanSave(cmd, args)
+	if !planImportStdinJSON {
+		if len(args) == 0 {
+			return fmt.Errorf("FILE argument is required (or use --stdin-json)")
+		}
+		// Just delegate to save with the file argument
+		return runPlanSave(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("--stdin-json reads the plan from stdin and takes no FILE argument")
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read from stdin: %w", err)
+	}
+	content, err := planJSONToMarkdown(data, getGitAuthor)
+	if err != nil {
+		return err
+	}
+	return savePlanContent(content)
+}
+
+// sessionIssueTarget returns the team and project to create a plan's issue in:
+// those chosen when the session started, overridden field by field by team and
+// project if set. A new team drops the session's project, which belongs to
+// the session's team, unless project is set too.
+func sessionIssueTarget(session state.SessionMetadata, team, project string) linear.IssueTarget {
+	target := linear.IssueTarget{Team: session.Team, Project: session.Project}
+	if team != "" {
+		target.Team = team
+		target.Project = ""
+	}
+	if project != "" {
+		target.Project = project
+	}
+	return target
+}
+
+// planJSONToMarkdown converts a JSON plan payload into plan markdown, with
+// the author from getAuthor if the payload has none
+func planJSONToMarkdown(data []byte, getAuthor func() string) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("no plan content provided")
+	}
+	var jp plan.JSONPlan
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return nil, fmt.Errorf("invalid plan JSON: %w", err)
+	}
+	if jp.Author == "" {
+		jp.Author = getAuthor()
+	}
+	p, err := jp.Plan()
+	if err != nil {
+		return nil, fmt.Errorf("invalid plan: %w", err)
+	}
+	return []byte(p.RawContent), nil
 }
 
 func runPlanShow(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
+	switch planShowFormat {
+	case "", "markdown", "json", "yaml":
+	default:
+		return fmt.Errorf("invalid format %q (must be markdown, json or yaml)", planShowFormat)
+	}
+	if planShowRaw && planShowFormat != "" && planShowFormat != "markdown" {
+		return fmt.Errorf("--raw can't be combined with --format %s", planShowFormat)
+	}
+	if planShowComments && (planShowFormat == "json" || planShowFormat == "yaml") {
+		return fmt.Errorf("--comments can't be combined with --format %s", planShowFormat)
+	}
+	if planShowWeb && (planShowRaw || planShowFormat != "") {
+		return fmt.Errorf("--web can't be combined with --raw or --format")
+	}
+
 	// Initialize cache
 	if err := state.Init(); err != nil {
 		return fmt.Errorf("failed to initialize cache: %w", err)
@@ -398,8 +1354,38 @@ func runPlanShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("plan not found: %s", id)
 	}
 
+	// Structured output of the parsed plan
+	if planShowFormat == "json" || planShowFormat == "yaml" {
+		return writePlanShow(os.Stdout, p, planShowFormat)
+	}
+
+	var comments []*tracker.Comment
+	if planShowComments && !trackerOffline() {
+		cfg := config.Get()
+		var getIssueTracker func() (tracker.Tracker, error)
+		if trackerSyncsPlans(cfg) {
+			getIssueTracker = func() (tracker.Tracker, error) { return getTracker(cfg) }
+		}
+		comments, err = fetchPlanComments(context.Background(), p, getIssueTracker)
+		if err != nil {
+			printWarning(fmt.Sprintf("Could not fetch comments for %s: %v", p.IssueID, err))
+		}
+	}
+
+	if planShowWeb {
+		path, err := writePlanHTML("", p, comments)
+		if err != nil {
+			return err
+		}
+		printSuccess(fmt.Sprintf("Rendered %s to %s", planID, path))
+		if err := openInBrowser("file://" + path); err != nil {
+			printWarning(fmt.Sprintf("Could not open the browser: %v", err))
+		}
+		return nil
+	}
+
 	// If --raw flag or non-interactive, output raw markdown
-	if planShowRaw || !ui.IsInteractive() {
+	if planShowRaw || planShowFormat == "markdown" || !ui.IsInteractive() {
 		content, err := state.DefaultCache.GetPlanMarkdown(planID)
 		if err != nil {
 			return fmt.Errorf("failed to read plan: %w", err)
@@ -408,11 +1394,125 @@ func runPlanShow(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("plan not found: %s", id)
 		}
 		fmt.Println(content)
+		if len(comments) > 0 {
+			fmt.Printf("\n%s", ui.CommentsMarkdown(comments))
+		}
 		return nil
 	}
 
 	// Show interactive plan view
-	return ui.ShowPlan(p)
+	return ui.ShowPlan(p, ui.PlanViewOptions{NoColor: planShowNoColor, Comments: comments})
+}
+
+// writePlanHTML renders a plan as an HTML page in a new file in dir, or the
+// system's temporary directory if dir is empty, and returns its path
+func writePlanHTML(dir string, p *plan.Plan, comments []*tracker.Comment) (string, error) {
+	page, err := ui.PlanHTML(p, comments)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(dir, "jig-"+p.ID+"-*.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to create HTML file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(page); err != nil {
+		return "", fmt.Errorf("failed to write HTML file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// fetchPlanComments fetches the comments on a plan's linked issue, oldest
+// first. Returns no comments if the plan has no linked issue or getTracker
+// is nil, as it is when no tracker is configured.
+func fetchPlanComments(ctx context.Context, p *plan.Plan, getTracker func() (tracker.Tracker, error)) ([]*tracker.Comment, error) {
+	if p.IssueID == "" || getTracker == nil {
+		return nil, nil
+	}
+
+	t, err := getTracker()
+	if err != nil {
+		return nil, err
+	}
+	issue, err := t.GetIssue(ctx, p.IssueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	return t.GetComments(ctx, issue.ID)
+}
+
+// planShowDocument is the parsed plan output by 'jig plan show --format json|yaml'
+type planShowDocument struct {
+	ID               string            `json:"id" yaml:"id"`
+	IssueID          string            `json:"issue_id,omitempty" yaml:"issue_id,omitempty"`
+	Title            string            `json:"title" yaml:"title"`
+	Status           plan.Status       `json:"status" yaml:"status"`
+	Author           string            `json:"author" yaml:"author"`
+	Created          time.Time         `json:"created" yaml:"created"`
+	Updated          time.Time         `json:"updated,omitempty" yaml:"updated,omitempty"`
+	Phases           []plan.Phase      `json:"phases,omitempty" yaml:"phases,omitempty"`
+	Estimate         *int              `json:"estimate,omitempty" yaml:"estimate,omitempty"`
+	Labels           []string          `json:"labels,omitempty" yaml:"labels,omitempty"`
+	RelatedIssues    []string          `json:"related_issues,omitempty" yaml:"related_issues,omitempty"`
+	Links            []string          `json:"links,omitempty" yaml:"links,omitempty"`
+	Blocks           []string          `json:"blocks,omitempty" yaml:"blocks,omitempty"`
+	BlockedBy        []string          `json:"blocked_by,omitempty" yaml:"blocked_by,omitempty"`
+	ProblemStatement string            `json:"problem_statement" yaml:"problem_statement"`
+	ProposedSolution string            `json:"proposed_solution" yaml:"proposed_solution"`
+	QuestionsAnswers map[string]string `json:"questions_answers,omitempty" yaml:"questions_answers,omitempty"`
+	Progress         planShowProgress  `json:"progress" yaml:"progress"`
+}
+
+// planShowProgress counts a plan's completed phases and acceptance criteria
+type planShowProgress struct {
+	PhasesDone    int `json:"phases_done" yaml:"phases_done"`
+	PhasesTotal   int `json:"phases_total" yaml:"phases_total"`
+	CriteriaDone  int `json:"criteria_done" yaml:"criteria_done"`
+	CriteriaTotal int `json:"criteria_total" yaml:"criteria_total"`
+}
+
+// newPlanShowDocument builds the structured output for a plan
+func newPlanShowDocument(p *plan.Plan) planShowDocument {
+	doc := planShowDocument{
+		ID:               p.ID,
+		IssueID:          p.IssueID,
+		Title:            p.Title,
+		Status:           p.Status,
+		Author:           p.Author,
+		Created:          p.Created,
+		Updated:          p.Updated,
+		Phases:           p.Phases,
+		Estimate:         p.Estimate,
+		Labels:           p.Labels,
+		RelatedIssues:    p.RelatedIssues,
+		Links:            p.Links,
+		Blocks:           p.Blocks,
+		BlockedBy:        p.BlockedBy,
+		ProblemStatement: p.ProblemStatement,
+		ProposedSolution: p.ProposedSolution,
+		QuestionsAnswers: p.QuestionsAnswers,
+	}
+	doc.Progress.PhasesDone, doc.Progress.PhasesTotal = p.PhaseProgress()
+	doc.Progress.CriteriaDone, doc.Progress.CriteriaTotal = p.Progress()
+	return doc
+}
+
+// writePlanShow writes a plan as JSON or YAML
+func writePlanShow(w io.Writer, p *plan.Plan, format string) error {
+	doc := newPlanShowDocument(p)
+	if format == "yaml" {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode plan: %w", err)
+		}
+		return enc.Close()
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
 }
 
 func runPlanList(cmd *cobra.Command, args []string) error {
@@ -421,31 +1521,233 @@ func runPlanList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize cache: %w", err)
 	}
 
-	cachedPlans, err := state.DefaultCache.ListCachedPlans()
+	listPlans := state.DefaultCache.ListCachedPlans
+	if planListAll {
+		listPlans = state.DefaultCache.ListAllCachedPlans
+	}
+	var since time.Time
+	if planListSince != "" {
+		var err error
+		since, err = parseSince(planListSince, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	cachedPlans, err := listPlans()
 	if err != nil {
 		return fmt.Errorf("failed to list plans: %w", err)
 	}
+	if !since.IsZero() {
+		cachedPlans = filterPlansSince(cachedPlans, since)
+	}
+	if cachedPlans, err = orderPlanList(cachedPlans, planListSort, planListReverse, planListLimit); err != nil {
+		return err
+	}
+
+	// JSON output (explicit flag only, bypasses the interactive table)
+	if planListJSON {
+		return writePlanListJSON(os.Stdout, cachedPlans)
+	}
 
 	if len(cachedPlans) == 0 {
+		if !since.IsZero() {
+			fmt.Printf("No plans updated since %s.\n", since.Format("2006-01-02 15:04"))
+			return nil
+		}
 		fmt.Println("No plans cached.")
 		return nil
 	}
 
+	return displayCachedPlans("Cached plans", cachedPlans)
+}
+
+// parseSince parses a --since value relative to now. It accepts a duration,
+// including whole days (e.g. "72h", "7d"), or a date ("2024-01-01", local
+// midnight) or RFC 3339 timestamp, and returns the earliest time to include.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since value %q (use a duration like 72h or 7d, or a date like 2024-01-01)", value)
+}
+
+// filterPlansSince keeps the plans updated at or after since. Plans without an
+// update time are judged by when they were cached.
+func filterPlansSince(cachedPlans []*state.CachedPlan, since time.Time) []*state.CachedPlan {
+	var recent []*state.CachedPlan
+	for _, cp := range cachedPlans {
+		if !cachedPlanUpdatedAt(cp).Before(since) {
+			recent = append(recent, cp)
+		}
+	}
+	return recent
+}
+
+// cachedPlanUpdatedAt returns when a cached plan was last updated, or when it
+// was cached if it has no update time
+func cachedPlanUpdatedAt(cp *state.CachedPlan) time.Time {
+	if cp.UpdatedAt.IsZero() {
+		return cp.CachedAt
+	}
+	return cp.UpdatedAt
+}
+
+// planListSortKeys lists the keys accepted by --sort
+var planListSortKeys = []string{"created", "updated", "title", "status"}
+
+// planStatusOrder lists plan statuses in workflow order, for --sort status
+var planStatusOrder = []plan.Status{
+	plan.StatusDraft,
+	plan.StatusReviewing,
+	plan.StatusApproved,
+	plan.StatusInProgress,
+	plan.StatusInReview,
+	plan.StatusComplete,
+}
+
+// orderPlanList sorts cached plans for `jig plan list` and keeps the first
+// limit of them, or all of them if limit is 0
+func orderPlanList(cachedPlans []*state.CachedPlan, key string, reverse bool, limit int) ([]*state.CachedPlan, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("invalid --limit %d: must not be negative", limit)
+	}
+	if err := sortCachedPlans(cachedPlans, key, reverse); err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(cachedPlans) > limit {
+		cachedPlans = cachedPlans[:limit]
+	}
+	return cachedPlans, nil
+}
+
+// sortCachedPlans sorts cached plans in place by key: created and updated
+// put the newest first, title sorts alphabetically and status in workflow
+// order. Ties are broken by plan ID; reverse flips the whole order.
+func sortCachedPlans(cachedPlans []*state.CachedPlan, key string, reverse bool) error {
+	var compare func(a, b *state.CachedPlan) int
+	switch key {
+	case "created":
+		compare = func(a, b *state.CachedPlan) int { return cachedPlanCreatedAt(b).Compare(cachedPlanCreatedAt(a)) }
+	case "updated":
+		compare = func(a, b *state.CachedPlan) int { return cachedPlanUpdatedAt(b).Compare(cachedPlanUpdatedAt(a)) }
+	case "title":
+		compare = func(a, b *state.CachedPlan) int {
+			return strings.Compare(strings.ToLower(cachedPlanTitle(a)), strings.ToLower(cachedPlanTitle(b)))
+		}
+	case "status":
+		compare = func(a, b *state.CachedPlan) int { return cachedPlanStatusRank(a) - cachedPlanStatusRank(b) }
+	default:
+		return fmt.Errorf("invalid --sort value %q (must be one of: %s)", key, strings.Join(planListSortKeys, ", "))
+	}
+
+	slices.SortStableFunc(cachedPlans, func(a, b *state.CachedPlan) int {
+		c := compare(a, b)
+		if c == 0 {
+			c = strings.Compare(cachedPlanID(a), cachedPlanID(b))
+		}
+		if reverse {
+			return -c
+		}
+		return c
+	})
+	return nil
+}
+
+// cachedPlanCreatedAt returns when a cached plan was created, or when it was
+// cached if it has no creation time
+func cachedPlanCreatedAt(cp *state.CachedPlan) time.Time {
+	if cp.Plan == nil || cp.Plan.Created.IsZero() {
+		return cp.CachedAt
+	}
+	return cp.Plan.Created
+}
+
+// cachedPlanID returns a cached plan's ID, or "" if it has no plan
+func cachedPlanID(cp *state.CachedPlan) string {
+	if cp.Plan == nil {
+		return ""
+	}
+	return cp.Plan.ID
+}
+
+// cachedPlanTitle returns a cached plan's title, or "" if it has no plan
+func cachedPlanTitle(cp *state.CachedPlan) string {
+	if cp.Plan == nil {
+		return ""
+	}
+	return cp.Plan.Title
+}
+
+// cachedPlanStatusRank returns the position of a cached plan's status in
+// workflow order. A plan without a status is a draft; unknown statuses sort last.
+func cachedPlanStatusRank(cp *state.CachedPlan) int {
+	status := plan.StatusDraft
+	if cp.Plan != nil && cp.Plan.Status != "" {
+		status = cp.Plan.Status
+	}
+	if i := slices.Index(planStatusOrder, status); i >= 0 {
+		return i
+	}
+	return len(planStatusOrder)
+}
+
+func runPlanSearch(cmd *cobra.Command, args []string) error {
+	query := args[0]
+
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	matches, err := state.DefaultCache.SearchPlans(query)
+	if err != nil {
+		return fmt.Errorf("failed to search plans: %w", err)
+	}
+
+	if planSearchJSON {
+		return writePlanListJSON(os.Stdout, matches)
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No plans match %q.\n", query)
+		return nil
+	}
+
+	return displayCachedPlans("Matching plans", matches)
+}
+
+// displayCachedPlans shows cached plans as an interactive table, or as a
+// plain text list when not running in a terminal
+func displayCachedPlans(heading string, cachedPlans []*state.CachedPlan) error {
 	// If interactive, show table with selection
 	if ui.IsInteractive() {
-		selectedPlan, ok, err := ui.RunCachedPlanTable("Cached plans:", cachedPlans)
+		selectedPlan, ok, err := ui.RunCachedPlanTable(heading+":", cachedPlans)
 		if err != nil {
 			return fmt.Errorf("failed to display plans: %w", err)
 		}
 		if ok && selectedPlan != nil {
 			// Show the selected plan's details
-			return ui.ShowPlan(selectedPlan)
+			return ui.ShowPlan(selectedPlan, ui.PlanViewOptions{})
 		}
 		return nil
 	}
 
 	// Non-interactive: print plain text list
-	fmt.Printf("Cached plans (%d):\n\n", len(cachedPlans))
+	fmt.Printf("%s (%d):\n\n", heading, len(cachedPlans))
 	for _, cp := range cachedPlans {
 		if cp.Plan == nil {
 			continue
@@ -468,34 +1770,321 @@ func runPlanList(cmd *cobra.Command, args []string) error {
 		fmt.Printf("    Title: %s\n", cp.Plan.Title)
 		fmt.Printf("    Status: %s\n", status)
 		fmt.Printf("    Synced: %s\n", syncStatus)
+		if progress := ui.CriteriaProgress(cp.Plan); progress != "" {
+			fmt.Printf("    Progress: %s\n", progress)
+		}
+		if cp.Archived {
+			fmt.Printf("    Archived: yes\n")
+		}
 		fmt.Println()
 	}
 
 	return nil
 }
 
-func runPlanNew(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
-	cfg := config.Get()
+// planListEntry is the JSON representation of a cached plan in `jig plan list --json`
+type planListEntry struct {
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	Status    string            `json:"status"`
+	IssueID   string            `json:"issue_id,omitempty"`
+	CachedAt  time.Time         `json:"cached_at"`
+	SyncedAt  *time.Time        `json:"synced_at,omitempty"`
+	NeedsSync bool              `json:"needs_sync"`
+	Archived  bool              `json:"archived,omitempty"`
+	Criteria  *planListCriteria `json:"acceptance_criteria,omitempty"`
+}
 
-	var issueID string
-	var issueContext string
-	var additionalInstructions string
-	var issue *tracker.Issue
+// planListCriteria is a plan's acceptance criteria progress in `jig plan list --json`
+type planListCriteria struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
 
-	// Get issue context if provided
-	if len(args) > 0 {
-		issueID = args[0]
+// writePlanListJSON writes cached plans as a JSON array, emitting [] for an empty cache
+func writePlanListJSON(w io.Writer, cachedPlans []*state.CachedPlan) error {
+	entries := make([]planListEntry, 0, len(cachedPlans))
+	for _, cp := range cachedPlans {
+		if cp.Plan == nil {
+			continue
+		}
+		status := string(cp.Plan.Status)
+		if status == "" {
+			status = string(plan.StatusDraft)
+		}
+		entry := planListEntry{
+			ID:        cp.Plan.ID,
+			Title:     cp.Plan.Title,
+			Status:    status,
+			IssueID:   cp.Plan.IssueID,
+			CachedAt:  cp.CachedAt,
+			SyncedAt:  cp.SyncedAt,
+			NeedsSync: cp.NeedsSync(),
+			Archived:  cp.Archived,
+		}
+		if done, total := cp.Plan.Progress(); total > 0 {
+			entry.Criteria = &planListCriteria{Done: done, Total: total}
+		}
+		entries = append(entries, entry)
+	}
 
-		// Fetch issue with spinner for better UX during API latency
-		fetchResult := fetchIssueWithDeps(ctx, issueID, defaultIssueFetchDeps(cfg))
-		issue = fetchResult.Issue
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		return fmt.Errorf("failed to encode plans: %w", err)
+	}
+	return nil
+}
 
-		if fetchResult.Err != nil {
-			printWarning(fmt.Sprintf("Could not fetch issue: %v", fetchResult.Err))
-		} else {
-			// Show interactive menu if we have an issue and are in interactive mode
-			if ui.IsInteractive() {
+func runPlanDelete(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !ui.IsInteractive() {
+		return fmt.Errorf("PLAN_ID argument is required in non-interactive mode")
+	}
+
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	if len(args) == 0 {
+		deps := planDeleteDeps{
+			listCachedPlans: state.DefaultCache.ListAllCachedPlans,
+			selectPlans:     ui.RunMultiSelect,
+			confirm:         ui.RunConfirm,
+			deletePlan:      state.DefaultCache.DeletePlan,
+		}
+		return deletePlansInteractiveWithDeps(planDeleteForce, deps)
+	}
+
+	planID := args[0]
+
+	cached, err := state.DefaultCache.GetCachedPlan(planID)
+	if err != nil {
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+
+	if !planDeleteForce && ui.IsInteractive() {
+		confirmed, err := ui.RunConfirm(fmt.Sprintf("Delete plan %s (%s)?", planID, cached.Plan.Title))
+		if err != nil {
+			return fmt.Errorf("failed to confirm deletion: %w", err)
+		}
+		if !confirmed {
+			printInfo("Deletion cancelled")
+			return nil
+		}
+	}
+
+	if err := state.DefaultCache.DeletePlan(planID); err != nil {
+		return fmt.Errorf("failed to delete plan: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Deleted plan %s", planID))
+	return nil
+}
+
+// planDeleteDeps holds dependencies for deleting plans interactively (for testability)
+type planDeleteDeps struct {
+	listCachedPlans func() ([]*state.CachedPlan, error)
+	selectPlans     func(title string, options []ui.SelectOption) ([]string, error)
+	confirm         func(question string) (bool, error)
+	deletePlan      func(id string) error
+}
+
+// deletePlansInteractiveWithDeps shows a multi-select of cached plans and
+// deletes the chosen ones, asking for confirmation first unless force is set
+func deletePlansInteractiveWithDeps(force bool, deps planDeleteDeps) error {
+	cachedPlans, err := deps.listCachedPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list plans: %w", err)
+	}
+	if len(cachedPlans) == 0 {
+		fmt.Println("No cached plans found.")
+		return nil
+	}
+
+	// Archived plans are listed too, as they're the likeliest to be dead
+	options := planSelectOptions(cachedPlans, func(cp *state.CachedPlan) string {
+		status := string(cp.Plan.Status)
+		if cp.Archived {
+			status += ", archived"
+		}
+		if cp.Plan.IssueID == "" {
+			return fmt.Sprintf("Status: %s", status)
+		}
+		return fmt.Sprintf("Issue: %s (%s)", cp.Plan.IssueID, status)
+	})
+
+	selectedIDs, err := deps.selectPlans("Select plans to delete:", options)
+	if err != nil {
+		return fmt.Errorf("failed to run multi-select: %w", err)
+	}
+	if len(selectedIDs) == 0 {
+		fmt.Println("No plans selected.")
+		return nil
+	}
+
+	if !force {
+		confirmed, err := deps.confirm(fmt.Sprintf("Delete %d plan(s)?", len(selectedIDs)))
+		if err != nil {
+			return fmt.Errorf("failed to confirm deletion: %w", err)
+		}
+		if !confirmed {
+			printInfo("Deletion cancelled")
+			return nil
+		}
+	}
+
+	var deleted int
+	var failures []string
+	for _, planID := range selectedIDs {
+		if err := deps.deletePlan(planID); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", planID, err))
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		printSuccess(fmt.Sprintf("Deleted %d plan(s)", deleted))
+	}
+	if len(failures) > 0 {
+		fmt.Printf("\nFailed to delete %d plan(s):\n", len(failures))
+		for _, f := range failures {
+			fmt.Printf("  - %s\n", f)
+		}
+		return fmt.Errorf("failed to delete %d plan(s)", len(failures))
+	}
+	return nil
+}
+
+func runPlanArchive(cmd *cobra.Command, args []string) error {
+	return setPlanArchived(args[0], true)
+}
+
+func runPlanUnarchive(cmd *cobra.Command, args []string) error {
+	return setPlanArchived(args[0], false)
+}
+
+// setPlanArchived archives or unarchives a cached plan
+func setPlanArchived(planID string, archived bool) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cached, err := state.DefaultCache.GetCachedPlan(planID)
+	if err != nil {
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+
+	if cached.Archived == archived {
+		if archived {
+			printInfo(fmt.Sprintf("Plan %s is already archived", planID))
+		} else {
+			printInfo(fmt.Sprintf("Plan %s is not archived", planID))
+		}
+		return nil
+	}
+
+	if err := state.DefaultCache.SetArchived(planID, archived); err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+
+	if archived {
+		printSuccess(fmt.Sprintf("Archived plan %s", planID))
+	} else {
+		printSuccess(fmt.Sprintf("Unarchived plan %s", planID))
+	}
+	return nil
+}
+
+func runPlanTemplateList(cmd *cobra.Command, args []string) error {
+	templatesDir, err := config.TemplatesDir()
+	if err != nil {
+		return err
+	}
+
+	names, err := plan.ListTemplates(templatesDir)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		printInfo(fmt.Sprintf("No plan templates found in %s", templatesDir))
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// loadPlanTemplate reads the named plan template from the templates directory
+func loadPlanTemplate(name string) (string, error) {
+	templatesDir, err := config.TemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	return plan.LoadTemplate(templatesDir, name)
+}
+
+func runPlanNew(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	cfg := config.Get()
+
+	var issueID string
+	var issueContext string
+	var additionalInstructions string
+	var issue *tracker.Issue
+
+	if planNewResume != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--resume can't be combined with an ISSUE_ID")
+		}
+		runnerName := planNewRunner
+		if runnerName == "" {
+			runnerName = cfg.Default.Runner
+		}
+		if runnerName == "" {
+			runnerName = "claude"
+		}
+		return resumePlanSession(ctx, deps, planNewResume, runnerName)
+	}
+
+	// Load the template up front so a missing one fails before any prompts
+	var planTemplate string
+	if planNewTemplate != "" {
+		var err error
+		if planTemplate, err = loadPlanTemplate(planNewTemplate); err != nil {
+			return err
+		}
+	}
+
+	// Get issue context if provided
+	if len(args) > 0 {
+		issueID = args[0]
+
+		// Fetch issue with spinner for better UX during API latency
+		fetchResult := fetchIssueWithDeps(ctx, issueID, defaultIssueFetchDeps(cfg, planNewNoCache))
+		issue = fetchResult.Issue
+
+		// Use the identifier from here on, e.g. for the plan's issue ID
+		var err error
+		if issueID, err = issueIDFromRef(issueID, fetchResult); err != nil {
+			return err
+		}
+
+		if fetchResult.Err != nil {
+			printWarning(fmt.Sprintf("Could not fetch issue: %v", fetchResult.Err))
+		} else {
+			// Show interactive menu if we have an issue and are in interactive mode
+			if ui.IsInteractive() {
 				result, err := ui.RunPlanPrompt(issue)
 				if err != nil {
 					return fmt.Errorf("failed to run plan prompt: %w", err)
@@ -523,6 +2112,27 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 
 	// Get planning goal - prompt interactively if not provided
 	planGoal := planNewGoal
+
+	// Collect the title, goal, labels and team up front if asked, starting
+	// from the flags and the issue
+	var fields *ui.PlanFields
+	if planNewInteractiveFields {
+		if !ui.IsInteractive() {
+			return fmt.Errorf("--interactive-fields requires an interactive terminal")
+		}
+		result, saved, err := ui.RunPlanFieldsForm(ui.PlanFields{Title: planNewTitle, Goal: planGoal, Team: planNewTeam})
+		if err != nil {
+			return fmt.Errorf("failed to run plan fields form: %w", err)
+		}
+		if !saved {
+			return nil // User cancelled
+		}
+		fields = result
+		if fields.Title != "" {
+			planNewTitle = fields.Title
+		}
+		planGoal = fields.Goal
+	}
 	if planGoal == "" && issueContext == "" {
 		if ui.IsInteractive() {
 			goal, err := ui.RunTextArea("What would you like to plan?")
@@ -547,12 +2157,15 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// The plan ID is generated from the given title, not the placeholder
+	idTitle := planNewTitle
+
 	// Use title if provided, otherwise use a placeholder (LLM will generate proper title)
 	if planNewTitle == "" {
 		if issueID != "" {
 			// Title was already set from issue above
 		} else {
-			planNewTitle = "New Plan"
+			planNewTitle = plan.PlaceholderTitle
 		}
 	}
 
@@ -560,7 +2173,10 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 	author := getGitAuthor()
 
 	// Always generate a local plan ID
-	planID := fmt.Sprintf("PLAN-%d", time.Now().Unix())
+	planID, err := newPlanID(cfg, idTitle)
+	if err != nil {
+		return err
+	}
 
 	// Create initial plan metadata (the actual plan content is created by Claude)
 	p := plan.NewPlan(planID, planNewTitle, author)
@@ -569,6 +2185,9 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 	if issueID != "" {
 		p.IssueID = issueID
 	}
+	if fields != nil {
+		p.Labels = fields.Labels
+	}
 	p.ProblemStatement = "TODO: Define the problem being solved"
 	p.ProposedSolution = "TODO: Describe the proposed solution"
 
@@ -586,16 +2205,6 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 		runnerName = "claude"
 	}
 
-	// Get the runner from the injected registry
-	r, err := deps.RunnerRegistry.Get(runnerName)
-	if err != nil {
-		return fmt.Errorf("runner not found: %s", runnerName)
-	}
-
-	if !r.Available() {
-		return fmt.Errorf("runner '%s' is not available (not installed or not in PATH)", runnerName)
-	}
-
 	// Get current working directory for the planning session
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -611,14 +2220,31 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// A fresh session starts without markers from an earlier one
+	clearPlanMarkers()
+
 	// Generate a unique session ID for parallel planning support
 	sessionID := fmt.Sprintf("%d", time.Now().UnixNano())
 
-	// Write session metadata if planning for an existing issue
-	if issueID != "" {
-		if err := writeSessionMetadata(sessionID, issueID); err != nil {
-			printWarning(fmt.Sprintf("Could not write session metadata: %v", err))
-		}
+	// Write session metadata: the linked issue, the team and project a new
+	// issue should go to (or that none should be created), and the fields
+	// given up front
+	session := state.SessionMetadata{
+		IssueID: issueID,
+		Team:    planNewTeam,
+		Project: planNewProject,
+		NoIssue: planNewNoIssue,
+		Runner:  runnerName,
+		Status:  state.SessionStatusPlanning,
+	}
+	if fields != nil {
+		session = sessionMetadataForFields(session, *fields)
+	}
+	if session.Goal == "" {
+		session.Goal = planGoal
+	}
+	if err := writeSessionMetadata(sessionID, session); err != nil {
+		printWarning(fmt.Sprintf("Could not write session metadata: %v", err))
 	}
 
 	// Prepare the runner context (writes planning context files to .jig/sessions/<session-id>/)
@@ -630,6 +2256,29 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 		IssueContext: issueContext,
 		SessionID:    sessionID,
 	}
+	if planTemplate != "" {
+		prepOpts.PlanTemplate = plan.RenderTemplate(planTemplate, plan.TemplateVars{Title: p.Title, IssueID: p.IssueID})
+	}
+
+	// Print the prompt for pasting into a tool jig can't launch
+	if planNewPrintPrompt {
+		if err := writePlanPrompt(os.Stdout, prepOpts); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "\nRun your coding tool from %s so it can read the session context.\n", cwd)
+		return nil
+	}
+
+	// Get the runner from the injected registry
+	r, err := deps.RunnerRegistry.Get(runnerName)
+	if err != nil {
+		return fmt.Errorf("runner not found: %s", runnerName)
+	}
+
+	if !r.Available() {
+		return fmt.Errorf("runner '%s' is not available (not installed or not in PATH)", runnerName)
+	}
+
 	if err := r.Prepare(ctx, prepOpts); err != nil {
 		return fmt.Errorf("failed to prepare runner: %w", err)
 	}
@@ -663,67 +2312,215 @@ func runPlanNew(cmd *cobra.Command, args []string) error {
 	// No plan saved - provide manual instructions
 	fmt.Printf("\nIf you created a plan, save it with:\n")
 	fmt.Printf("  jig plan save <plan-file.md>\n")
+	fmt.Printf("\nOr resume the session with:\n")
+	fmt.Printf("  jig plan new --resume %s\n", sessionID)
 
 	return nil
 }
 
-// getTracker returns the configured tracker client
-func getTracker(cfg *config.Config) (tracker.Tracker, error) {
-	switch cfg.Default.Tracker {
-	case "linear":
-		store, err := config.NewStore()
-		if err != nil {
-			return nil, err
-		}
-		apiKey, err := store.GetLinearAPIKey()
-		if err != nil {
-			return nil, err
-		}
-		if apiKey == "" {
-			apiKey = cfg.Linear.APIKey
-		}
-		if apiKey == "" {
-			return nil, fmt.Errorf("Linear API key not configured")
-		}
-		return linear.NewClient(apiKey, cfg.Linear.TeamID, cfg.Linear.DefaultProject), nil
-	default:
-		return nil, fmt.Errorf("unknown tracker: %s", cfg.Default.Tracker)
+// resumePlanSession reattaches to an interrupted planning session. The
+// session's context files were written when it started, so the runner is
+// relaunched with the same /jig:plan prompt without preparing them again. If
+// the session already saved a plan, its next steps are shown instead.
+func resumePlanSession(ctx context.Context, d *Deps, sessionID, runnerName string) error {
+	// Session IDs name a directory under .jig/sessions, so paths and hidden
+	// names can't refer to one
+	if sessionID == "" || filepath.Base(sessionID) != sessionID || strings.HasPrefix(sessionID, ".") {
+		return fmt.Errorf("invalid planning session ID %q", sessionID)
+	}
+	sessionDir := filepath.Join(".jig", "sessions", sessionID)
+	if info, err := os.Stat(sessionDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("planning session %s not found in %s", sessionID, filepath.Join(".jig", "sessions"))
 	}
-}
-
-// issueResult holds the result of processing a fetched issue
-type issueResult struct {
-	IssueContext string
-	Title        string // Updated title (from issue if original was empty)
-}
 
-// issueFetchDeps holds injectable dependencies for fetching issues
-type issueFetchDeps struct {
-	isInteractive func() bool
-	runSpinner    func(message string, fn func() error) error
-	getTracker    func() (tracker.Tracker, error)
-}
+	if displaySavedPlanNextSteps(sessionID) {
+		return nil
+	}
 
-// fetchIssueResult holds the result of fetching an issue
-type fetchIssueResult struct {
-	Issue *tracker.Issue
-	Err   error
-}
+	session := readSessionMetadata(sessionID)
+	if session.IssueID != "" {
+		printInfo(fmt.Sprintf("Resuming planning session %s for %s", sessionID, session.IssueID))
+	} else {
+		printInfo(fmt.Sprintf("Resuming planning session %s", sessionID))
+	}
+	if session.Goal != "" {
+		fmt.Printf("Goal: %s\n", session.Goal)
+	}
 
-// fetchIssueWithDeps fetches an issue using the provided dependencies.
-// In interactive mode, it uses a spinner for better UX during API latency.
-// In non-interactive mode, it fetches directly.
-func fetchIssueWithDeps(ctx context.Context, issueID string, deps issueFetchDeps) fetchIssueResult {
-	var issue *tracker.Issue
-	var fetchErr error
+	r, err := d.RunnerRegistry.Get(runnerName)
+	if err != nil {
+		return fmt.Errorf("runner not found: %s", runnerName)
+	}
+	if !r.Available() {
+		return fmt.Errorf("runner '%s' is not available (not installed or not in PATH)", runnerName)
+	}
 
-	if deps.isInteractive() {
-		fetchErr = deps.runSpinner(fmt.Sprintf("Fetching issue %s", issueID), func() error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	printInfo(fmt.Sprintf("Launching %s for planning...", runnerName))
+	fmt.Println()
+
+	_, err = r.Launch(ctx, &runner.LaunchOpts{
+		WorktreeDir:   cwd,
+		InitialPrompt: fmt.Sprintf("/jig:plan %s", sessionID),
+		Interactive:   true,
+		PlanMode:      true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to launch runner: %w", err)
+	}
+
+	fmt.Println()
+	printInfo("Planning session ended")
+
+	if displaySavedPlanNextSteps(sessionID) {
+		return nil
+	}
+
+	fmt.Printf("\nIf you created a plan, save it with:\n")
+	fmt.Printf("  jig plan save <plan-file.md>\n")
+	fmt.Printf("\nOr resume the session with:\n")
+	fmt.Printf("  jig plan new --resume %s\n", sessionID)
+	return nil
+}
+
+// writePlanPrompt writes the planning session context files and prints the
+// /jig:plan prompt with the session's goal, issue context and template inlined
+func writePlanPrompt(w io.Writer, opts *runner.PrepareOpts) error {
+	if err := runner.WritePlanningContext(opts.WorktreeDir, opts); err != nil {
+		return err
+	}
+
+	skill, err := skills.EmbeddedSkills.ReadFile("plan.md")
+	if err != nil {
+		return fmt.Errorf("failed to read plan skill: %w", err)
+	}
+	prompt := strings.ReplaceAll(stripSkillFrontmatter(string(skill)), "$ARGUMENTS", opts.SessionID)
+
+	fmt.Fprint(w, strings.TrimSpace(prompt)+"\n")
+	if opts.PlanGoal != "" {
+		fmt.Fprintf(w, "\n---\n\n# Planning Goal\n\n%s\n", strings.TrimSpace(opts.PlanGoal))
+	}
+	if opts.IssueContext != "" {
+		fmt.Fprintf(w, "\n---\n\n%s\n", strings.TrimSpace(opts.IssueContext))
+	}
+	if opts.PlanTemplate != "" {
+		fmt.Fprintf(w, "\n---\n\n# Plan Template\n\n%s\n", strings.TrimSpace(opts.PlanTemplate))
+	}
+	return nil
+}
+
+// stripSkillFrontmatter removes the YAML frontmatter from a skill file
+func stripSkillFrontmatter(content string) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	if end := strings.Index(content[4:], "\n---\n"); end >= 0 {
+		return content[4+end+5:]
+	}
+	return content
+}
+
+// getTracker returns the configured tracker client
+func getTracker(cfg *config.Config) (tracker.Tracker, error) {
+	if trackerOffline() {
+		return nil, errOffline
+	}
+	switch cfg.Default.Tracker {
+	case "linear":
+		store, err := config.NewStore()
+		if err != nil {
+			return nil, err
+		}
+		apiKey, err := store.GetLinearAPIKey()
+		if err != nil {
+			return nil, err
+		}
+		if apiKey == "" {
+			apiKey = cfg.Linear.APIKey
+		}
+		if apiKey == "" {
+			return nil, fmt.Errorf("Linear API key not configured")
+		}
+		client, err := newLinearClient(cfg, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case "gitlab":
+		store, err := config.NewStore()
+		if err != nil {
+			return nil, err
+		}
+		token, err := store.GetGitLabToken()
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			token = cfg.GitLab.Token
+		}
+		if token == "" {
+			return nil, fmt.Errorf("GitLab token not configured")
+		}
+		client, err := gitlab.NewClient(token, cfg.GitLab.URL, cfg.GitLab.Project)
+		if err != nil {
+			return nil, err
+		}
+		client.Group = cfg.GitLab.Group
+		client.IssueKey = cfg.GitLab.GetIssueKey()
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown tracker: %s", cfg.Default.Tracker)
+	}
+}
+
+// issueResult holds the result of processing a fetched issue
+type issueResult struct {
+	IssueContext string
+	Title        string // Updated title (from issue if original was empty)
+}
+
+// issueFetchDeps holds injectable dependencies for fetching issues
+type issueFetchDeps struct {
+	isInteractive  func() bool
+	runSpinner     func(message string, fn func() error) error
+	getTracker     func() (tracker.Tracker, error)
+	getCachedIssue func(id string) (*tracker.Issue, error) // nil to always fetch
+	saveIssue      func(issue *tracker.Issue) error        // nil to skip caching
+}
+
+// fetchIssueResult holds the result of fetching an issue
+type fetchIssueResult struct {
+	Issue *tracker.Issue
+	Err   error
+}
+
+// fetchIssueWithDeps fetches an issue using the provided dependencies.
+// A fresh cached copy is returned without contacting the tracker; otherwise
+// the fetched issue is cached for next time.
+// In interactive mode, it uses a spinner for better UX during API latency.
+// In non-interactive mode, it fetches directly.
+func fetchIssueWithDeps(ctx context.Context, issueID string, deps issueFetchDeps) fetchIssueResult {
+	if deps.getCachedIssue != nil {
+		if issue, err := deps.getCachedIssue(issueID); err == nil && issue != nil {
+			logging.Debug("issue cache hit", "issue", issueID)
+			return fetchIssueResult{Issue: issue}
+		}
+	}
+
+	var issue *tracker.Issue
+	var fetchErr error
+
+	if deps.isInteractive() {
+		fetchErr = deps.runSpinner(fmt.Sprintf("Fetching issue %s", issueID), func() error {
 			t, err := deps.getTracker()
 			if err != nil {
 				return err
 			}
-			issue, err = t.GetIssue(ctx, issueID)
+			issue, err = getIssueByRef(ctx, t, issueID)
 			return err
 		})
 	} else {
@@ -731,20 +2528,75 @@ func fetchIssueWithDeps(ctx context.Context, issueID string, deps issueFetchDeps
 		if err != nil {
 			fetchErr = err
 		} else {
-			issue, fetchErr = t.GetIssue(ctx, issueID)
+			issue, fetchErr = getIssueByRef(ctx, t, issueID)
+		}
+	}
+
+	if fetchErr == nil && issue != nil && deps.saveIssue != nil {
+		if err := deps.saveIssue(issue); err != nil {
+			logging.Debug("failed to cache issue", "issue", issueID, "error", err)
 		}
 	}
 
 	return fetchIssueResult{Issue: issue, Err: fetchErr}
 }
 
-// defaultIssueFetchDeps returns the production dependencies for fetching issues
-func defaultIssueFetchDeps(cfg *config.Config) issueFetchDeps {
-	return issueFetchDeps{
+// isIssueURL reports whether ref is a web URL rather than an issue ID
+func isIssueURL(ref string) bool {
+	return strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://")
+}
+
+// issueIDFromRef returns the issue ID to link a plan to, given the reference
+// the issue was fetched by. A URL is replaced by the fetched issue's
+// identifier, or by the Linear identifier it contains if the fetch failed;
+// the fetch error is returned for any other URL.
+func issueIDFromRef(ref string, result fetchIssueResult) (string, error) {
+	if !isIssueURL(ref) {
+		return ref, nil
+	}
+	if result.Issue != nil {
+		return result.Issue.Identifier, nil
+	}
+	id, err := linear.ParseIssueURL(ref)
+	if err != nil {
+		if result.Err != nil {
+			return "", fmt.Errorf("could not fetch issue: %w", result.Err)
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// getIssueByRef fetches an issue by ID, identifier or, for trackers that
+// support it, web URL
+func getIssueByRef(ctx context.Context, t tracker.Tracker, ref string) (*tracker.Issue, error) {
+	if !isIssueURL(ref) {
+		return t.GetIssue(ctx, ref)
+	}
+	getter, ok := t.(tracker.IssueURLGetter)
+	if !ok {
+		return nil, fmt.Errorf("tracker does not support issue URLs; pass the issue ID instead")
+	}
+	return getter.GetIssueByURL(ctx, ref)
+}
+
+// defaultIssueFetchDeps returns the production dependencies for fetching
+// issues. Issues are read from the cache unless noCache is set, and are
+// cached once fetched either way.
+func defaultIssueFetchDeps(cfg *config.Config, noCache bool) issueFetchDeps {
+	deps := issueFetchDeps{
 		isInteractive: ui.IsInteractive,
 		runSpinner:    ui.RunWithSpinner,
 		getTracker:    func() (tracker.Tracker, error) { return getTracker(cfg) },
 	}
+	if err := state.Init(); err != nil {
+		return deps
+	}
+	deps.saveIssue = state.DefaultCache.SaveIssue
+	if !noCache {
+		deps.getCachedIssue = state.DefaultCache.GetIssue
+	}
+	return deps
 }
 
 // processIssueForPlan processes a successfully fetched issue and returns
@@ -781,25 +2633,54 @@ func formatIssueContext(issue *tracker.Issue) string {
 	return ctx
 }
 
-// getGitAuthor returns the git author name
+// authorDeps holds the lookups used to resolve a plan author
+type authorDeps struct {
+	gitConfig func(key string) (string, error)
+	getenv    func(key string) string
+}
+
+// getGitAuthor returns the plan author from git config, falling back to $USER
 func getGitAuthor() string {
-	// Try to get from git config
-	// For now, return a placeholder
-	if name := os.Getenv("USER"); name != "" {
+	return resolveAuthor(authorDeps{
+		gitConfig: git.GetConfig,
+		getenv:    os.Getenv,
+	})
+}
+
+// resolveAuthor returns the git identity as "Name <email>" (or whichever of
+// user.name and user.email is set), then $USER, then "unknown". A missing git
+// binary or an unset identity falls through to the next source.
+func resolveAuthor(deps authorDeps) string {
+	// Errors mean git isn't available, which is the same as no identity
+	name, _ := deps.gitConfig("user.name")
+	email, _ := deps.gitConfig("user.email")
+	name, email = strings.TrimSpace(name), strings.TrimSpace(email)
+
+	switch {
+	case name != "" && email != "":
+		return fmt.Sprintf("%s <%s>", name, email)
+	case name != "":
 		return name
+	case email != "":
+		return email
+	}
+
+	if user := deps.getenv("USER"); user != "" {
+		return user
 	}
 	return "unknown"
 }
 
-// shouldSyncToLinear returns true if the plan should be synced to Linear
-func shouldSyncToLinear(cfg *config.Config, p *plan.Plan) bool {
-	// Check if Linear is the configured tracker
-	if cfg.Default.Tracker != "linear" {
+// shouldSyncPlan returns true if the plan should be synced to the configured
+// tracker
+func shouldSyncPlan(cfg *config.Config, p *plan.Plan) bool {
+	// Never sync while offline
+	if trackerOffline() {
 		return false
 	}
 
-	// Check if sync is enabled in config
-	if !cfg.Linear.ShouldSyncPlanOnSave() {
+	// Check if sync is enabled in config (and the tracker can sync plans)
+	if !cfg.ShouldSyncPlanOnSave() {
 		return false
 	}
 
@@ -808,137 +2689,463 @@ func shouldSyncToLinear(cfg *config.Config, p *plan.Plan) bool {
 		return false
 	}
 
-	// Check if Linear API key is configured
+	// Check if the tracker's credentials are configured
+	return trackerCredential(cfg) != ""
+}
+
+// trackerSyncsPlans reports whether the configured tracker can create issues
+// from plans and sync plans to them
+func trackerSyncsPlans(cfg *config.Config) bool {
+	return cfg.Default.Tracker == "linear" || cfg.Default.Tracker == "gitlab"
+}
+
+// trackerName returns the display name of the configured tracker
+func trackerName(cfg *config.Config) string {
+	switch cfg.Default.Tracker {
+	case "linear":
+		return "Linear"
+	case "gitlab":
+		return "GitLab"
+	}
+	return "the tracker"
+}
+
+// trackerCredential returns the configured tracker's API key or token, from
+// the credential store or else the config, or "" if there is none
+func trackerCredential(cfg *config.Config) string {
 	store, err := config.NewStore()
 	if err != nil {
-		return false
-	}
-	apiKey, _ := store.GetLinearAPIKey()
-	if apiKey == "" {
-		apiKey = cfg.Linear.APIKey
+		return ""
 	}
-	if apiKey == "" {
-		return false
+	switch cfg.Default.Tracker {
+	case "linear":
+		if apiKey, _ := store.GetLinearAPIKey(); apiKey != "" {
+			return apiKey
+		}
+		return cfg.Linear.APIKey
+	case "gitlab":
+		if token, _ := store.GetGitLabToken(); token != "" {
+			return token
+		}
+		return cfg.GitLab.Token
 	}
-
-	return true
+	return ""
 }
 
-// syncPlanToLinear syncs a plan to its associated Linear issue
-func syncPlanToLinear(ctx context.Context, cfg *config.Config, p *plan.Plan) error {
-	syncer, err := getLinearPlanSyncer(cfg)
-	if err != nil {
+// syncPlanWithSyncer syncs a plan using the provided PlanSyncer (for testability).
+// If the syncer can relate issues, the plan's blocking relations are set too;
+// the plan is already synced by then, so failing to set them is only a warning.
+func syncPlanWithSyncer(ctx context.Context, syncer tracker.PlanSyncer, p *plan.Plan, labelName string) error {
+	if err := syncer.SyncPlanToIssue(ctx, p, labelName); err != nil {
 		return err
 	}
-	labelName := cfg.Linear.GetPlanLabelName()
-	return syncPlanWithSyncer(ctx, syncer, p, labelName)
+	if relator, ok := syncer.(tracker.IssueRelator); ok {
+		if err := syncPlanRelations(ctx, relator, p); err != nil {
+			printWarning(fmt.Sprintf("Could not set blocking relations: %v", err))
+		}
+	}
+	return nil
 }
 
-// syncPlanWithSyncer syncs a plan using the provided PlanSyncer (for testability)
-func syncPlanWithSyncer(ctx context.Context, syncer tracker.PlanSyncer, p *plan.Plan, labelName string) error {
-	return syncer.SyncPlanToIssue(ctx, p, labelName)
+// syncPlanRelations makes the plan's issue block the issues in p.Blocks and
+// be blocked by those in p.BlockedBy, resolving identifiers to tracker IDs.
+// Relations that already exist are skipped, so syncing again changes nothing.
+func syncPlanRelations(ctx context.Context, t tracker.IssueRelator, p *plan.Plan) error {
+	if len(p.Blocks) == 0 && len(p.BlockedBy) == 0 {
+		return nil
+	}
+
+	resolved := make(map[string]string)
+	resolve := func(identifier string) (string, error) {
+		if id, ok := resolved[identifier]; ok {
+			return id, nil
+		}
+		issue, err := t.GetIssue(ctx, identifier)
+		if err != nil {
+			return "", fmt.Errorf("failed to get issue %s: %w", identifier, err)
+		}
+		resolved[identifier] = issue.ID
+		return issue.ID, nil
+	}
+
+	// setBlocking relates the two issues unless blocker already blocks blocked
+	setBlocking := func(blocker, blocked string) error {
+		blockerID, err := resolve(blocker)
+		if err != nil {
+			return err
+		}
+		blockedID, err := resolve(blocked)
+		if err != nil {
+			return err
+		}
+
+		existing, err := t.GetBlockedBy(ctx, blockedID)
+		if err != nil {
+			return fmt.Errorf("failed to get issues blocking %s: %w", blocked, err)
+		}
+		for _, issue := range existing {
+			if issue.ID == blockerID {
+				return nil
+			}
+		}
+
+		if err := t.SetBlocking(ctx, blockerID, blockedID); err != nil {
+			return fmt.Errorf("failed to make %s block %s: %w", blocker, blocked, err)
+		}
+		return nil
+	}
+
+	for _, blocked := range p.Blocks {
+		if err := setBlocking(p.IssueID, blocked); err != nil {
+			return err
+		}
+	}
+	for _, blocker := range p.BlockedBy {
+		if err := setBlocking(blocker, p.IssueID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // syncResult represents the result of a plan sync operation
 type syncResult struct {
-	Synced      bool   // true if sync was performed
-	ContentHash string // the content hash (set regardless of whether sync was performed)
-	Skipped     bool   // true if sync was skipped due to unchanged content
+	Synced           bool   // true if sync was performed
+	ContentHash      string // the content hash (set regardless of whether sync was performed)
+	Skipped          bool   // true if sync was skipped due to unchanged content
+	ConflictDetected bool   // true if the plan comment was edited in the tracker since the last sync
 }
 
-// syncPlanToLinearWithDedup syncs a plan to Linear with content-based deduplication.
-// Returns syncResult indicating whether sync was performed or skipped.
-func syncPlanToLinearWithDedup(ctx context.Context, cfg *config.Config, p *plan.Plan, cachedHash string) (syncResult, error) {
-	// Compute the content hash
-	contentHash := linear.ComputePlanContentHash(p)
+// syncPlanToTrackerWithDedup syncs a cached plan to the configured tracker with
+// content-based deduplication and conflict detection. Returns syncResult
+// indicating whether sync was performed or skipped.
+func syncPlanToTrackerWithDedup(ctx context.Context, cfg *config.Config, cached *state.CachedPlan, force bool) (syncResult, error) {
+	syncer, err := getPlanSyncer(cfg)
+	if err != nil {
+		return syncResult{}, err
+	}
+	labelName := cfg.GetPlanLabelName()
+
+	deps := newPlanSyncDeps(syncer, labelName)
+	return syncPlanWithDedup(ctx, cached, force, deps)
+}
+
+// syncPlanWithDedup syncs a cached plan unless its content is unchanged since the last
+// sync or the plan comment was edited in the tracker (and force is false). On success
+// the plan is marked synced and the hash of the written comment is recorded.
+func syncPlanWithDedup(ctx context.Context, cached *state.CachedPlan, force bool, deps planSyncDeps) (syncResult, error) {
+	p := cached.Plan
+	contentHash := deps.computeContentHash(p)
 
 	// Check if content is unchanged
-	if cachedHash != "" && cachedHash == contentHash {
+	if cached.SyncedContentHash != "" && cached.SyncedContentHash == contentHash {
 		return syncResult{Synced: false, ContentHash: contentHash, Skipped: true}, nil
 	}
 
-	// Content changed or never synced, proceed with sync
-	if err := syncPlanToLinear(ctx, cfg, p); err != nil {
-		return syncResult{ContentHash: contentHash}, err
-	}
-
-	return syncResult{Synced: true, ContentHash: contentHash, Skipped: false}, nil
-}
-
-// getLinearPlanSyncer creates a Linear client configured as a PlanSyncer
-func getLinearPlanSyncer(cfg *config.Config) (tracker.PlanSyncer, error) {
-	store, err := config.NewStore()
+	// Check whether the comment we last wrote was edited outside jig
+	conflict, err := planCommentDiverged(ctx, cached, deps)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get config store: %w", err)
+		return syncResult{ContentHash: contentHash}, err
 	}
-	apiKey, err := store.GetLinearAPIKey()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get Linear API key: %w", err)
+	if conflict && !force {
+		return syncResult{ContentHash: contentHash, ConflictDetected: true}, nil
 	}
-	if apiKey == "" {
-		apiKey = cfg.Linear.APIKey
+
+	// Content changed or never synced, proceed with sync
+	if err := deps.syncPlan(ctx, p); err != nil {
+		return syncResult{ContentHash: contentHash, ConflictDetected: conflict}, err
 	}
-	if apiKey == "" {
-		return nil, fmt.Errorf("Linear API key not configured")
+
+	// Mark as synced with content hash
+	if err := deps.markPlanSyncedWithHash(p.ID, contentHash); err != nil {
+		printWarning(fmt.Sprintf("Plan %s synced but failed to update sync timestamp: %v", p.ID, err))
 	}
+	recordPlanCommentHash(ctx, p, deps)
 
-	return linear.NewClient(apiKey, cfg.Linear.TeamID, cfg.Linear.DefaultProject), nil
+	return syncResult{Synced: true, ContentHash: contentHash, Skipped: false, ConflictDetected: conflict}, nil
 }
 
-// shouldCreateIssueForPlan returns true if a new Linear issue should be created for this plan
-func shouldCreateIssueForPlan(cfg *config.Config, p *plan.Plan) bool {
-	// Don't create if plan already has a linked issue
-	if p.HasLinkedIssue() {
-		return false
+// planCommentDiverged reports whether the plan comment on the linked issue no longer
+// matches the body jig last wrote. Plans synced before comment hashes were recorded,
+// and issues whose plan comment was removed, are never treated as conflicts.
+func planCommentDiverged(ctx context.Context, cached *state.CachedPlan, deps planSyncDeps) (bool, error) {
+	if cached.SyncedCommentHash == "" || deps.getPlanComment == nil {
+		return false, nil
 	}
 
-	// Check if Linear is the configured tracker
-	if cfg.Default.Tracker != "linear" {
-		return false
+	comment, err := deps.getPlanComment(ctx, cached.Plan.IssueID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check plan comment for edits: %w", err)
+	}
+	if comment == nil {
+		return false, nil
 	}
 
-	// Check if issue creation is enabled in config
-	if !cfg.Linear.ShouldCreateIssueOnSave() {
-		return false
+	if hashCommentBody(comment.Body) == cached.SyncedCommentHash {
+		return false, nil
+	}
+	// Hashes recorded before the sync timestamp was excluded cover the whole body
+	legacy := sha256.Sum256([]byte(comment.Body))
+	return hex.EncodeToString(legacy[:]) != cached.SyncedCommentHash, nil
+}
+
+// recordPlanCommentHash stores the hash of the plan comment as it now reads in the
+// tracker. Failures only produce a warning since the sync itself succeeded.
+func recordPlanCommentHash(ctx context.Context, p *plan.Plan, deps planSyncDeps) {
+	if deps.getPlanComment == nil || deps.setSyncedCommentHash == nil {
+		return
 	}
 
-	// Check if Linear API key is configured
-	store, err := config.NewStore()
+	comment, err := deps.getPlanComment(ctx, p.IssueID)
 	if err != nil {
-		return false
+		printWarning(fmt.Sprintf("Plan %s synced but failed to read back the plan comment: %v", p.ID, err))
+		return
 	}
-	apiKey, _ := store.GetLinearAPIKey()
-	if apiKey == "" {
-		apiKey = cfg.Linear.APIKey
+	if comment == nil {
+		return
 	}
-	if apiKey == "" {
-		return false
+
+	if err := deps.setSyncedCommentHash(p.ID, hashCommentBody(comment.Body)); err != nil {
+		printWarning(fmt.Sprintf("Plan %s synced but failed to record the comment hash: %v", p.ID, err))
 	}
+}
 
-	return true
+// hashCommentBody returns a hex-encoded SHA256 hash of a comment body. The
+// sync timestamp jig stamps on plan comments is left out, so a comment only
+// reads as edited when its content changes.
+func hashCommentBody(body string) string {
+	hash := sha256.Sum256([]byte(linear.StripSyncTimestamp(body)))
+	return hex.EncodeToString(hash[:])
 }
 
-// issueCreator is an interface for creating issues from plans (for testability)
-type issueCreator interface {
-	CreateIssueFromPlan(ctx context.Context, p *plan.Plan) (*tracker.Issue, error)
+// planCommentConflictMessage explains how to resolve a sync refused due to remote edits
+func planCommentConflictMessage(p *plan.Plan) string {
+	return fmt.Sprintf("Plan comment on %s was edited in the tracker since the last sync; run 'jig plan sync %s --force' to overwrite it", p.IssueID, p.ID)
 }
 
-// createIssueForPlan creates a new Linear issue from the plan and returns the issue identifier
-func createIssueForPlan(ctx context.Context, cfg *config.Config, p *plan.Plan) (string, error) {
-	client, err := getLinearClient(cfg)
+// getPlanSyncer creates a client for the configured tracker as a PlanSyncer.
+// Returns an error if the tracker can't sync plans.
+func getPlanSyncer(cfg *config.Config) (tracker.PlanSyncer, error) {
+	t, err := getTracker(cfg)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	return createIssueForPlanWithCreator(ctx, client, p)
+	syncer, ok := t.(tracker.PlanSyncer)
+	if !ok {
+		return nil, fmt.Errorf("the %s tracker can't sync plans", cfg.Default.Tracker)
+	}
+	return syncer, nil
 }
 
-// createIssueForPlanWithCreator creates an issue using the provided creator (for testability)
-func createIssueForPlanWithCreator(ctx context.Context, creator issueCreator, p *plan.Plan) (string, error) {
+// newLinearClient creates a Linear client from the configured team, project, API URL,
+// request timeout, label, status, plan overflow and description sync settings
+func newLinearClient(cfg *config.Config, apiKey string) (*linear.Client, error) {
+	client, err := linear.NewClientWithURL(apiKey, cfg.Linear.TeamID, cfg.Linear.DefaultProject, cfg.Linear.APIURL)
+	if err != nil {
+		return nil, err
+	}
+	client.RequestTimeout = cfg.Linear.RequestTimeout
+	client.CreateMissingLabels = cfg.Linear.CreateMissingLabels
+	client.StatusMap, err = linear.ParseStatusMap(cfg.Linear.StatusMap)
+	if err != nil {
+		return nil, fmt.Errorf("invalid linear.status_map: %w", err)
+	}
+	client.PlanOverflowMode, err = linear.ParsePlanOverflowMode(cfg.Linear.PlanOverflowMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid linear.plan_overflow_mode: %w", err)
+	}
+	client.SyncToDescription = cfg.Linear.SyncToDescription
+	client.IssueTemplate = cfg.Linear.IssueTemplate
+	if err := linear.ValidatePlanCommentTemplate(cfg.Linear.PlanCommentTemplate); err != nil {
+		return nil, fmt.Errorf("invalid linear.plan_comment_template: %w", err)
+	}
+	client.PlanCommentTemplate = cfg.Linear.PlanCommentTemplate
+	return client, nil
+}
+
+// planSavePreview describes what 'jig plan save' would do with a plan
+type planSavePreview struct {
+	Tracker     string // Display name of the tracker issues are created in and synced to
+	GeneratedID bool
+	CreateIssue bool
+	Sync        bool
+}
+
+// previewPlanSave decides whether saving p would create or sync an issue,
+// using the same checks as runPlanSave but without side effects
+func previewPlanSave(cfg *config.Config, p *plan.Plan, generatedID, noSync, noCreate bool) planSavePreview {
+	preview := planSavePreview{Tracker: trackerName(cfg), GeneratedID: generatedID}
+	if noSync {
+		return preview
+	}
+
+	preview.CreateIssue = !noCreate && shouldCreateIssueForPlan(cfg, p)
+	if preview.CreateIssue {
+		// The created issue is linked before syncing, so only the config matters
+		preview.Sync = cfg.ShouldSyncPlanOnSave()
+	} else {
+		preview.Sync = shouldSyncPlan(cfg, p)
+	}
+	return preview
+}
+
+// printPlanSavePreview prints the outcome of a dry-run save
+func printPlanSavePreview(p *plan.Plan, preview planSavePreview) {
+	printInfo("Dry run: no changes were made")
+
+	id := p.ID
+	if preview.GeneratedID {
+		id += " (generated)"
+	}
+	fmt.Printf("  Plan ID: %s\n", id)
+	fmt.Printf("  Title: %s\n", p.Title)
+	fmt.Printf("  Status: %s\n", p.Status)
+	if p.HasLinkedIssue() {
+		fmt.Printf("  Issue: %s\n", p.IssueID)
+	} else {
+		fmt.Printf("  Issue: none\n")
+	}
+	if len(p.Phases) > 0 {
+		done, total := p.PhaseProgress()
+		fmt.Printf("  Phases: %d/%d done\n", done, total)
+	}
+
+	fmt.Printf("\nWould:\n")
+	fmt.Printf("  - Save the plan to the cache\n")
+	if preview.CreateIssue {
+		fmt.Printf("  - Create a %s issue for the plan\n", preview.Tracker)
+	}
+	if preview.Sync {
+		fmt.Printf("  - Sync the plan to its %s issue\n", preview.Tracker)
+	}
+}
+
+// shouldCreateIssueForPlan returns true if a new issue should be created in
+// the configured tracker for this plan
+func shouldCreateIssueForPlan(cfg *config.Config, p *plan.Plan) bool {
+	// Don't create if plan already has a linked issue
+	if p.HasLinkedIssue() {
+		return false
+	}
+
+	// Never create issues while offline
+	if trackerOffline() {
+		return false
+	}
+
+	// Check if issue creation is enabled in config (and the tracker can create
+	// issues from plans)
+	if !cfg.ShouldCreateIssueOnSave() {
+		return false
+	}
+
+	// Check if the tracker's credentials are configured
+	return trackerCredential(cfg) != ""
+}
+
+// issueCreator is an interface for creating issues from plans (for testability)
+type issueCreator interface {
+	CreateIssueFromPlan(ctx context.Context, p *plan.Plan) (*tracker.Issue, error)
+}
+
+// createIssueForPlan creates a new issue in the configured tracker from the plan
+// and returns the issue identifier. A non-zero target overrides the configured
+// Linear team and project; other trackers create the issue where configured.
+func createIssueForPlan(ctx context.Context, cfg *config.Config, p *plan.Plan, target linear.IssueTarget) (string, error) {
+	if cfg.Default.Tracker != "linear" {
+		t, err := getTracker(cfg)
+		if err != nil {
+			return "", err
+		}
+		creator, ok := t.(issueCreator)
+		if !ok {
+			return "", fmt.Errorf("the %s tracker can't create issues from plans", cfg.Default.Tracker)
+		}
+		if !target.IsZero() {
+			printWarning(fmt.Sprintf("--team, --project and --issue-template only apply to Linear; creating the issue in the configured %s project", trackerName(cfg)))
+		}
+		return createIssueForPlanWithCreator(ctx, creator, p)
+	}
+
+	client, err := getLinearClient(cfg)
+	if err != nil {
+		return "", err
+	}
+	if target.Team == "" && !client.HasTeam() && ui.IsInteractive() {
+		// Linear requires a team; offer a choice rather than failing
+		team, err := selectTeam(ctx, client.GetTeams, ui.RunSelect)
+		if err != nil {
+			return "", err
+		}
+		target.Team = team
+	}
+	if !target.IsZero() {
+		if client, err = client.WithTarget(ctx, target); err != nil {
+			return "", err
+		}
+	}
+
+	return createIssueForPlanWithCreator(ctx, client, p)
+}
+
+// selectTeam prompts for the team to create an issue in and returns its ID.
+// Returns linear.ErrNoTeam if there are no teams or the prompt is cancelled.
+func selectTeam(ctx context.Context, getTeams func(ctx context.Context) ([]tracker.Team, error), selectOne func(title string, options []ui.SelectOption) (string, error)) (string, error) {
+	teams, err := getTeams(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get teams: %w", err)
+	}
+	if len(teams) == 0 {
+		return "", linear.ErrNoTeam
+	}
+
+	options := make([]ui.SelectOption, len(teams))
+	for i, team := range teams {
+		options[i] = ui.SelectOption{
+			Label:       team.Name,
+			Value:       team.ID,
+			Description: team.Key,
+		}
+	}
+	selected, err := selectOne("No team configured. Select a team for the issue:", options)
+	if err != nil {
+		return "", fmt.Errorf("failed to select team: %w", err)
+	}
+	if selected == "" {
+		return "", linear.ErrNoTeam
+	}
+	return selected, nil
+}
+
+// linkAttacher is implemented by creators that can attach links to issues
+type linkAttacher interface {
+	CreateAttachment(ctx context.Context, issueID, url, title string) (*linear.LinearAttachment, error)
+}
+
+// createIssueForPlanWithCreator creates an issue using the provided creator (for testability).
+// The plan's links are attached to the new issue; a link that can't be
+// attached is reported as a warning and doesn't fail issue creation.
+func createIssueForPlanWithCreator(ctx context.Context, creator issueCreator, p *plan.Plan) (string, error) {
 	issue, err := creator.CreateIssueFromPlan(ctx, p)
 	if err != nil {
 		return "", err
 	}
+	if estimate, ok := p.TotalEstimate(); ok && issue.Estimate == nil {
+		printWarning(fmt.Sprintf("Estimate of %d points was not set on %s: the team doesn't use estimates", estimate, issue.Identifier))
+	}
+
+	if len(p.Links) > 0 {
+		attacher, ok := creator.(linkAttacher)
+		if !ok {
+			printWarning(fmt.Sprintf("Links were not attached to %s: the tracker doesn't support attachments", issue.Identifier))
+			return issue.Identifier, nil
+		}
+		for _, link := range p.Links {
+			if _, err := attacher.CreateAttachment(ctx, issue.ID, link, ""); err != nil {
+				printWarning(fmt.Sprintf("Could not attach %s to %s: %v", link, issue.Identifier, err))
+			}
+		}
+	}
 	return issue.Identifier, nil
 }
 
@@ -964,16 +3171,30 @@ func getLinearClientWithStore(cfg *config.Config, newStore func() (*config.Store
 		return nil, fmt.Errorf("Linear API key not configured")
 	}
 
-	return linear.NewClient(apiKey, cfg.Linear.TeamID, cfg.Linear.DefaultProject), nil
+	client, err := newLinearClient(cfg, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
 }
 
 func runPlanSync(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	// Stop syncing on Ctrl-C or SIGTERM, including plans being synced
+	// concurrently and watch mode
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	cfg := config.Get()
 
-	// Validate that Linear is configured as the tracker
-	if cfg.Default.Tracker != "linear" {
-		return fmt.Errorf("Linear is not configured as the tracker (current: %s)", cfg.Default.Tracker)
+	if planSyncAll && len(args) > 0 {
+		return fmt.Errorf("--all cannot be used with a plan ID")
+	}
+	if planSyncAll && planSyncWatch {
+		return fmt.Errorf("--all cannot be used with --watch")
+	}
+
+	// Validate that the configured tracker can sync plans
+	if !trackerSyncsPlans(cfg) {
+		return fmt.Errorf("the %s tracker can't sync plans (default.tracker must be linear or gitlab)", cfg.Default.Tracker)
 	}
 
 	// Initialize cache
@@ -981,6 +3202,18 @@ func runPlanSync(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize cache: %w", err)
 	}
 
+	if planSyncAll {
+		return syncAllPlans(ctx, cfg)
+	}
+
+	if planSyncWatch {
+		planID := ""
+		if len(args) > 0 {
+			planID = args[0]
+		}
+		return watchPlanSync(ctx, cfg, planID)
+	}
+
 	// If a plan ID is provided, sync that specific plan
 	if len(args) > 0 {
 		return syncSinglePlan(ctx, cfg, args[0])
@@ -988,7 +3221,7 @@ func runPlanSync(cmd *cobra.Command, args []string) error {
 
 	// Interactive mode: show multi-select of unsynced plans
 	if !ui.IsInteractive() {
-		return fmt.Errorf("interactive mode required (no plan ID provided)")
+		return fmt.Errorf("interactive mode required (no plan ID provided; use --all to sync every unsynced plan)")
 	}
 
 	return syncPlansInteractive(ctx, cfg)
@@ -996,22 +3229,18 @@ func runPlanSync(cmd *cobra.Command, args []string) error {
 
 // syncSinglePlan syncs a specific plan by ID
 func syncSinglePlan(ctx context.Context, cfg *config.Config, planID string) error {
-	syncer, err := getLinearPlanSyncer(cfg)
+	syncer, err := getPlanSyncer(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to get syncer: %w", err)
+		return withExitCode(exitCodeSyncFailed, fmt.Errorf("failed to get syncer: %w", err))
 	}
-	labelName := cfg.Linear.GetPlanLabelName()
+	labelName := cfg.GetPlanLabelName()
 
-	deps := planSyncDeps{
-		getCachedPlan:        state.DefaultCache.GetCachedPlan,
-		markPlanSyncedWithHash: state.DefaultCache.MarkPlanSyncedWithHash,
-		syncPlan: func(ctx context.Context, p *plan.Plan) error {
-			return syncPlanWithSyncer(ctx, syncer, p, labelName)
-		},
-		computeContentHash: linear.ComputePlanContentHash,
-	}
+	deps := newPlanSyncDeps(syncer, labelName)
 
-	return syncSinglePlanWithDeps(ctx, planID, deps)
+	if err := syncSinglePlanWithDeps(ctx, planID, planSyncForce, deps); err != nil {
+		return withExitCode(exitCodeSyncFailed, err)
+	}
+	return nil
 }
 
 // planSyncDeps holds dependencies for sync operations (for testability)
@@ -1020,10 +3249,45 @@ type planSyncDeps struct {
 	markPlanSyncedWithHash func(id, hash string) error
 	syncPlan             func(ctx context.Context, p *plan.Plan) error
 	computeContentHash   func(p *plan.Plan) string
+	getPlanComment       func(ctx context.Context, issueID string) (*tracker.Comment, error)
+	setSyncedCommentHash func(id, hash string) error
+	concurrency          int           // Plans synced at once by syncSelectedPlansWithDeps; 0 syncs one at a time
+	requestDelay         time.Duration // Pause between a worker's syncs, to stay under Linear's rate limits
+	isInteractive        func() bool   // nil syncs selected plans without progress feedback
+	runProgress          func(ctx context.Context, message string, fn func(ctx context.Context, setMessage func(string)) error) error
+}
+
+// syncRequestDelay is the pause between the plans each sync worker syncs
+const syncRequestDelay = 200 * time.Millisecond
+
+// planContentHasher is implemented by syncers whose comments depend on more
+// than the plan itself, such as a Linear client with a comment template
+type planContentHasher interface {
+	PlanContentHash(p *plan.Plan) string
+}
+
+// newPlanSyncDeps builds sync dependencies backed by the plan cache and the given syncer
+func newPlanSyncDeps(syncer tracker.PlanSyncer, labelName string) planSyncDeps {
+	computeContentHash := linear.ComputePlanContentHash
+	if hasher, ok := syncer.(planContentHasher); ok {
+		computeContentHash = hasher.PlanContentHash
+	}
+	return planSyncDeps{
+		getCachedPlan:          state.DefaultCache.GetCachedPlan,
+		markPlanSyncedWithHash: state.DefaultCache.MarkPlanSyncedWithHash,
+		syncPlan: func(ctx context.Context, p *plan.Plan) error {
+			return syncPlanWithSyncer(ctx, syncer, p, labelName)
+		},
+		computeContentHash:   computeContentHash,
+		getPlanComment:       syncer.GetPlanComment,
+		setSyncedCommentHash: state.DefaultCache.SetSyncedCommentHash,
+		isInteractive:        ui.IsInteractive,
+		runProgress:          ui.RunWithProgressSpinner,
+	}
 }
 
 // syncSinglePlanWithDeps syncs a specific plan using injected dependencies
-func syncSinglePlanWithDeps(ctx context.Context, planID string, deps planSyncDeps) error {
+func syncSinglePlanWithDeps(ctx context.Context, planID string, force bool, deps planSyncDeps) error {
 	cached, err := deps.getCachedPlan(planID)
 	if err != nil {
 		return fmt.Errorf("failed to get plan: %w", err)
@@ -1036,27 +3300,177 @@ func syncSinglePlanWithDeps(ctx context.Context, planID string, deps planSyncDep
 		return fmt.Errorf("plan has no linked issue")
 	}
 
-	// Compute content hash for deduplication
-	contentHash := deps.computeContentHash(cached.Plan)
+	result, err := syncPlanWithDedup(ctx, cached, force, deps)
+	if err != nil {
+		return fmt.Errorf("failed to sync plan: %w", err)
+	}
 
-	// Check if content is unchanged
-	if cached.SyncedContentHash != "" && cached.SyncedContentHash == contentHash {
+	if result.Skipped {
 		printInfo("Plan content unchanged, skipping sync")
 		return nil
 	}
+	if !result.Synced && result.ConflictDetected {
+		return fmt.Errorf("plan comment on %s was edited in the tracker since the last sync (use --force to overwrite)", cached.Plan.IssueID)
+	}
+	if result.ConflictDetected {
+		printWarning(fmt.Sprintf("Overwrote edits to the plan comment on %s", cached.Plan.IssueID))
+	}
 
-	// Sync to Linear
-	if err := deps.syncPlan(ctx, cached.Plan); err != nil {
-		return fmt.Errorf("failed to sync plan: %w", err)
+	printSuccess(fmt.Sprintf("Plan synced to issue %s", cached.Plan.IssueID))
+	return nil
+}
+
+// planWatchDebounce is how long watch mode waits after a plan changes before
+// syncing it, so a burst of saves results in a single sync
+const planWatchDebounce = 500 * time.Millisecond
+
+// planWatchDeps holds dependencies for watch mode (for testability)
+type planWatchDeps struct {
+	changes  <-chan string // IDs of plans whose cache files changed
+	errs     <-chan error
+	debounce time.Duration
+	sync     planSyncDeps
+}
+
+// watchPlanSync syncs plans as their cache files change until ctx is done
+func watchPlanSync(ctx context.Context, cfg *config.Config, planID string) error {
+	syncer, err := getPlanSyncer(cfg)
+	if err != nil {
+		return withExitCode(exitCodeSyncFailed, fmt.Errorf("failed to get syncer: %w", err))
 	}
 
-	// Mark as synced with content hash
-	if err := deps.markPlanSyncedWithHash(planID, contentHash); err != nil {
-		printWarning(fmt.Sprintf("Plan synced but failed to update sync timestamp: %v", err))
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch the plan cache: %w", err)
+	}
+	defer watcher.Close()
+	plansDir := filepath.Join(cacheDir, "plans")
+	if err := watcher.Add(plansDir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", plansDir, err)
 	}
 
-	printSuccess(fmt.Sprintf("Plan synced to Linear issue %s", cached.Plan.IssueID))
-	return nil
+	if planID != "" {
+		printInfo(fmt.Sprintf("Watching plan %s for changes (Ctrl-C to stop)", planID))
+	} else {
+		printInfo("Watching plans for changes (Ctrl-C to stop)")
+	}
+	return watchPlansWithDeps(ctx, planID, planSyncForce, planWatchDeps{
+		changes:  planCacheChanges(ctx, watcher),
+		errs:     watcher.Errors,
+		debounce: planWatchDebounce,
+		sync:     newPlanSyncDeps(syncer, cfg.GetPlanLabelName()),
+	})
+}
+
+// planCacheChanges turns file events in the plan cache directory into the IDs
+// of the changed plans. Lock and temporary files are ignored.
+func planCacheChanges(ctx context.Context, watcher *fsnotify.Watcher) <-chan string {
+	changes := make(chan string)
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+					continue
+				}
+				name := filepath.Base(event.Name)
+				ext := filepath.Ext(name)
+				if ext != ".json" && ext != ".md" {
+					continue
+				}
+				select {
+				case changes <- strings.TrimSuffix(name, ext):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// watchPlansWithDeps syncs plans as changes to them arrive until ctx is done.
+// Changes are collected until none arrive for the debounce period, then each
+// changed plan that needs a sync is synced once. With planID, other plans are
+// ignored. A failed sync is reported and watching continues.
+func watchPlansWithDeps(ctx context.Context, planID string, force bool, deps planWatchDeps) error {
+	pending := make(map[string]bool)
+	timer := time.NewTimer(deps.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	changes := deps.changes
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id, ok := <-changes:
+			if !ok {
+				// Sync what is pending, then wait to be stopped
+				changes = nil
+				if len(pending) > 0 {
+					timer.Reset(0)
+				}
+				continue
+			}
+			if planID != "" && !strings.EqualFold(id, planID) {
+				continue
+			}
+			pending[id] = true
+			timer.Reset(deps.debounce)
+		case err, ok := <-deps.errs:
+			if !ok {
+				deps.errs = nil
+				continue
+			}
+			printWarning(fmt.Sprintf("Error watching the plan cache: %v", err))
+		case <-timer.C:
+			ids := make([]string, 0, len(pending))
+			for id := range pending {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			pending = make(map[string]bool)
+			for _, id := range ids {
+				syncChangedPlan(ctx, id, force, deps.sync)
+			}
+		}
+	}
+}
+
+// syncChangedPlan syncs a plan whose cache file changed if it needs a sync,
+// printing the result
+func syncChangedPlan(ctx context.Context, id string, force bool, deps planSyncDeps) {
+	cached, err := deps.getCachedPlan(id)
+	if err != nil {
+		printWarning(fmt.Sprintf("Could not read plan %s: %v", id, err))
+		return
+	}
+	if cached == nil || !cached.NeedsSync() {
+		return
+	}
+
+	result, err := syncPlanWithDedup(ctx, cached, force, deps)
+	switch {
+	case err != nil:
+		printWarning(fmt.Sprintf("Failed to sync %s: %v", id, err))
+	case result.Skipped:
+		return
+	case !result.Synced && result.ConflictDetected:
+		printWarning(planCommentConflictMessage(cached.Plan))
+	default:
+		printSuccess(fmt.Sprintf("Synced %s to %s", id, cached.Plan.IssueID))
+	}
 }
 
 // syncPlansInteractive shows an interactive multi-select of unsynced plans
@@ -1067,32 +3481,20 @@ func syncPlansInteractive(ctx context.Context, cfg *config.Config) error {
 		return fmt.Errorf("failed to list plans: %w", err)
 	}
 
-	// Filter to plans that need sync
-	var unsyncedPlans []*state.CachedPlan
-	for _, cp := range cachedPlans {
-		if cp.NeedsSync() {
-			unsyncedPlans = append(unsyncedPlans, cp)
-		}
-	}
-
+	unsyncedPlans := filterUnsyncedPlans(cachedPlans)
 	if len(unsyncedPlans) == 0 {
 		fmt.Println("No unsynced plans found.")
 		return nil
 	}
 
 	// Build options for multi-select
-	options := make([]ui.SelectOption, len(unsyncedPlans))
-	for i, cp := range unsyncedPlans {
+	options := planSelectOptions(unsyncedPlans, func(cp *state.CachedPlan) string {
 		syncStatus := "never synced"
 		if cp.SyncedAt != nil {
 			syncStatus = "updated since last sync"
 		}
-		options[i] = ui.SelectOption{
-			Label:       fmt.Sprintf("%s - %s", cp.Plan.ID, cp.Plan.Title),
-			Value:       cp.Plan.ID,
-			Description: fmt.Sprintf("Issue: %s (%s)", cp.Plan.IssueID, syncStatus),
-		}
-	}
+		return fmt.Sprintf("Issue: %s (%s)", cp.Plan.IssueID, syncStatus)
+	})
 
 	// Show multi-select
 	selectedIDs, err := ui.RunMultiSelect("Select plans to sync:", options)
@@ -1112,60 +3514,125 @@ func syncPlansInteractive(ctx context.Context, cfg *config.Config) error {
 	}
 
 	// Sync selected plans
-	syncer, err := getLinearPlanSyncer(cfg)
+	deps, err := newBatchPlanSyncDeps(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to get syncer: %w", err)
+		return err
 	}
-	labelName := cfg.Linear.GetPlanLabelName()
+	return syncSelectedPlansWithDeps(ctx, selectedIDs, idToPlan, planSyncForce, deps)
+}
 
-	deps := planSyncDeps{
-		getCachedPlan:        state.DefaultCache.GetCachedPlan,
-		markPlanSyncedWithHash: state.DefaultCache.MarkPlanSyncedWithHash,
-		syncPlan: func(ctx context.Context, p *plan.Plan) error {
-			return syncPlanWithSyncer(ctx, syncer, p, labelName)
-		},
-		computeContentHash: linear.ComputePlanContentHash,
+// syncAllPlans syncs every unsynced plan without prompting
+func syncAllPlans(ctx context.Context, cfg *config.Config) error {
+	cachedPlans, err := state.DefaultCache.ListCachedPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list plans: %w", err)
+	}
+	if len(filterUnsyncedPlans(cachedPlans)) == 0 {
+		fmt.Println("No unsynced plans found.")
+		return nil
 	}
 
-	return syncSelectedPlansWithDeps(ctx, selectedIDs, idToPlan, deps)
+	deps, err := newBatchPlanSyncDeps(cfg)
+	if err != nil {
+		return err
+	}
+	return syncAllPlansWithDeps(ctx, cachedPlans, planSyncForce, deps)
 }
 
-// syncSelectedPlansWithDeps syncs the selected plans using injected dependencies
-func syncSelectedPlansWithDeps(ctx context.Context, planIDs []string, idToPlan map[string]*state.CachedPlan, deps planSyncDeps) error {
-	var successCount int
-	var skippedCount int
-	var failures []string
+// syncAllPlansWithDeps syncs the cached plans that need a sync, in the order
+// given, using injected dependencies. See syncSelectedPlansWithDeps for the
+// report and exit codes.
+func syncAllPlansWithDeps(ctx context.Context, cachedPlans []*state.CachedPlan, force bool, deps planSyncDeps) error {
+	unsyncedPlans := filterUnsyncedPlans(cachedPlans)
+	if len(unsyncedPlans) == 0 {
+		fmt.Println("No unsynced plans found.")
+		return nil
+	}
 
-	for _, planID := range planIDs {
-		cp, ok := idToPlan[planID]
-		if !ok {
-			failures = append(failures, fmt.Sprintf("%s: plan not found", planID))
-			continue
+	planIDs := make([]string, len(unsyncedPlans))
+	idToPlan := make(map[string]*state.CachedPlan, len(unsyncedPlans))
+	for i, cp := range unsyncedPlans {
+		planIDs[i] = cp.Plan.ID
+		idToPlan[cp.Plan.ID] = cp
+	}
+	return syncSelectedPlansWithDeps(ctx, planIDs, idToPlan, force, deps)
+}
+
+// filterUnsyncedPlans returns the cached plans that need a sync
+func filterUnsyncedPlans(cachedPlans []*state.CachedPlan) []*state.CachedPlan {
+	var unsynced []*state.CachedPlan
+	for _, cp := range cachedPlans {
+		if cp.NeedsSync() {
+			unsynced = append(unsynced, cp)
 		}
+	}
+	return unsynced
+}
 
-		// Compute content hash for deduplication
-		contentHash := deps.computeContentHash(cp.Plan)
+// newBatchPlanSyncDeps builds the dependencies for syncing several plans at
+// once, with the configured concurrency and a delay between requests
+func newBatchPlanSyncDeps(cfg *config.Config) (planSyncDeps, error) {
+	syncer, err := getPlanSyncer(cfg)
+	if err != nil {
+		return planSyncDeps{}, withExitCode(exitCodeSyncFailed, fmt.Errorf("failed to get syncer: %w", err))
+	}
 
-		// Check if content is unchanged
-		if cp.SyncedContentHash != "" && cp.SyncedContentHash == contentHash {
-			skippedCount++
-			printInfo(fmt.Sprintf("Skipped %s (content unchanged)", planID))
-			continue
-		}
+	deps := newPlanSyncDeps(syncer, cfg.GetPlanLabelName())
+	deps.concurrency = cfg.Linear.GetSyncConcurrency()
+	deps.requestDelay = syncRequestDelay
+	return deps, nil
+}
 
-		// Sync to Linear
-		if err := deps.syncPlan(ctx, cp.Plan); err != nil {
-			failures = append(failures, fmt.Sprintf("%s: %v", planID, err))
-			continue
+// planSelectOptions builds multi-select options for cached plans, labelled
+// with their ID and title and described by describe
+func planSelectOptions(plans []*state.CachedPlan, describe func(cp *state.CachedPlan) string) []ui.SelectOption {
+	options := make([]ui.SelectOption, len(plans))
+	for i, cp := range plans {
+		options[i] = ui.SelectOption{
+			Label:       fmt.Sprintf("%s - %s", cp.Plan.ID, cp.Plan.Title),
+			Value:       cp.Plan.ID,
+			Description: describe(cp),
 		}
+	}
+	return options
+}
 
-		// Mark as synced with content hash
-		if err := deps.markPlanSyncedWithHash(planID, contentHash); err != nil {
-			printWarning(fmt.Sprintf("Plan %s synced but failed to update sync timestamp: %v", planID, err))
+// syncSelectedPlansWithDeps syncs the selected plans using injected dependencies.
+// Up to deps.concurrency plans are synced at once, each worker pausing
+// deps.requestDelay between plans; results are reported in planIDs order.
+// In interactive mode, a spinner counts the plans synced so far.
+// Failures carry exitCodeSyncPartial if any plan synced or was skipped, else exitCodeSyncFailed.
+func syncSelectedPlansWithDeps(ctx context.Context, planIDs []string, idToPlan map[string]*state.CachedPlan, force bool, deps planSyncDeps) error {
+	var successCount int
+	var skippedCount int
+	var failures []string
+
+	var outcomes []planSyncOutcome
+	if deps.isInteractive != nil && deps.isInteractive() {
+		progress := func(done int) string { return fmt.Sprintf("Syncing %d/%d…", done, len(planIDs)) }
+		if err := deps.runProgress(ctx, progress(0), func(ctx context.Context, setMessage func(string)) error {
+			outcomes = syncPlansConcurrently(ctx, planIDs, idToPlan, force, deps, func(done int) {
+				setMessage(progress(done))
+			})
+			return nil
+		}); err != nil && outcomes == nil {
+			return withExitCode(exitCodeSyncFailed, err)
 		}
+	} else {
+		outcomes = syncPlansConcurrently(ctx, planIDs, idToPlan, force, deps, nil)
+	}
 
-		successCount++
-		printSuccess(fmt.Sprintf("Synced %s to issue %s", planID, cp.Plan.IssueID))
+	for i, planID := range planIDs {
+		switch outcome := outcomes[i]; {
+		case outcome.failure != "":
+			failures = append(failures, fmt.Sprintf("%s: %s", planID, outcome.failure))
+		case outcome.skipped:
+			skippedCount++
+			printInfo(fmt.Sprintf("Skipped %s (content unchanged)", planID))
+		default:
+			successCount++
+			printSuccess(fmt.Sprintf("Synced %s to issue %s", planID, idToPlan[planID].Plan.IssueID))
+		}
 	}
 
 	// Report results
@@ -1181,22 +3648,284 @@ func syncSelectedPlansWithDeps(ctx context.Context, planIDs []string, idToPlan m
 		for _, f := range failures {
 			fmt.Printf("  - %s\n", f)
 		}
-		return fmt.Errorf("failed to sync %d plan(s)", len(failures))
+		err := fmt.Errorf("failed to sync %d plan(s)", len(failures))
+		if successCount == 0 && skippedCount == 0 {
+			return withExitCode(exitCodeSyncFailed, err)
+		}
+		return withExitCode(exitCodeSyncPartial, err)
 	}
 
 	return nil
 }
 
-func runPlanLink(cmd *cobra.Command, args []string) error {
-	planID := args[0]
-	issueID := args[1]
-
-	ctx := context.Background()
-	cfg := config.Get()
+// planSyncOutcome is the result of syncing one of several selected plans
+type planSyncOutcome struct {
+	skipped bool
+	failure string // Why the plan failed to sync, empty if it didn't
+}
 
-	// Initialize cache
-	if err := state.Init(); err != nil {
-		return fmt.Errorf("failed to initialize cache: %w", err)
+// syncPlansConcurrently syncs plans with a pool of deps.concurrency workers
+// and returns each plan's outcome at its index in planIDs. If progress is
+// set, it is called with the number of plans finished after each one,
+// never from two workers at once.
+func syncPlansConcurrently(ctx context.Context, planIDs []string, idToPlan map[string]*state.CachedPlan, force bool, deps planSyncDeps, progress func(done int)) []planSyncOutcome {
+	outcomes := make([]planSyncOutcome, len(planIDs))
+	workers := min(max(deps.concurrency, 1), len(planIDs))
+
+	var mu sync.Mutex
+	done := 0
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			first := true
+			for i := range jobs {
+				if !first && deps.requestDelay > 0 {
+					select {
+					case <-time.After(deps.requestDelay):
+					case <-ctx.Done():
+					}
+				}
+				first = false
+				outcomes[i] = syncSelectedPlan(ctx, planIDs[i], idToPlan, force, deps)
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range planIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return outcomes
+}
+
+// syncSelectedPlan syncs one of several selected plans
+func syncSelectedPlan(ctx context.Context, planID string, idToPlan map[string]*state.CachedPlan, force bool, deps planSyncDeps) planSyncOutcome {
+	cp, ok := idToPlan[planID]
+	if !ok {
+		return planSyncOutcome{failure: "plan not found"}
+	}
+	if err := ctx.Err(); err != nil {
+		return planSyncOutcome{failure: err.Error()}
+	}
+
+	result, err := syncPlanWithDedup(ctx, cp, force, deps)
+	if err != nil {
+		return planSyncOutcome{failure: err.Error()}
+	}
+	if result.Skipped {
+		return planSyncOutcome{skipped: true}
+	}
+	if !result.Synced && result.ConflictDetected {
+		return planSyncOutcome{failure: "plan comment was edited in the tracker since the last sync (use --force to overwrite)"}
+	}
+	return planSyncOutcome{}
+}
+
+func runPlanUnsync(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planUnsyncDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		markUnsynced:  state.DefaultCache.MarkPlanUnsynced,
+		getTracker:    func() (tracker.Tracker, error) { return getTracker(cfg) },
+	}
+
+	labelName := ""
+	if planUnsyncRemoveLabel {
+		labelName = cfg.GetPlanLabelName()
+	}
+
+	result, err := unsyncPlanWithDeps(context.Background(), args[0], labelName, deps)
+	if err != nil {
+		return err
+	}
+
+	if labelName != "" {
+		if result.LabelRemoved {
+			printSuccess(fmt.Sprintf("Removed label %q from %s", labelName, result.Plan.IssueID))
+		} else {
+			printInfo(fmt.Sprintf("%s does not have the %q label", result.Plan.IssueID, labelName))
+		}
+	}
+	printSuccess(fmt.Sprintf("Marked plan %s as not synced", result.Plan.ID))
+	return nil
+}
+
+// planUnsyncDeps holds dependencies for 'jig plan unsync' (for testability)
+type planUnsyncDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	markUnsynced  func(id string) error
+	getTracker    func() (tracker.Tracker, error)
+}
+
+// planUnsyncResult describes the outcome of unsyncing a plan
+type planUnsyncResult struct {
+	Plan         *plan.Plan
+	LabelRemoved bool
+}
+
+// unsyncPlanWithDeps clears a cached plan's sync state. If labelName is set,
+// that label is first removed from the linked issue; if removing it fails, the
+// plan's sync state is left untouched.
+func unsyncPlanWithDeps(ctx context.Context, planID, labelName string, deps planUnsyncDeps) (*planUnsyncResult, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	result := &planUnsyncResult{Plan: cached.Plan}
+
+	if labelName != "" {
+		issueID := cached.Plan.IssueID
+		if issueID == "" {
+			return nil, fmt.Errorf("plan %s is not linked to an issue, so there is no label to remove", planID)
+		}
+
+		t, err := deps.getTracker()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tracker: %w", err)
+		}
+		finder, ok := t.(tracker.LabelFinder)
+		if !ok {
+			return nil, fmt.Errorf("tracker does not support looking up issue labels")
+		}
+
+		labelID, err := finder.FindIssueLabel(ctx, issueID, labelName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up label %q on %s: %w", labelName, issueID, err)
+		}
+		if labelID != "" {
+			if err := t.RemoveLabel(ctx, issueID, labelID); err != nil {
+				return nil, fmt.Errorf("failed to remove label %q from %s: %w", labelName, issueID, err)
+			}
+			result.LabelRemoved = true
+		}
+	}
+
+	if err := deps.markUnsynced(planID); err != nil {
+		return nil, fmt.Errorf("failed to mark plan as not synced: %w", err)
+	}
+
+	return result, nil
+}
+
+func runPlanOpen(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planOpenDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		getTracker:    func() (tracker.Tracker, error) { return getTracker(cfg) },
+		openURL:       openInBrowser,
+	}
+	if planOpenPrint || !ui.IsInteractive() {
+		deps.openURL = nil
+	}
+
+	result, err := openPlanIssueWithDeps(context.Background(), args[0], deps)
+	if err != nil {
+		return err
+	}
+
+	if !result.Opened {
+		fmt.Println(result.URL)
+		return nil
+	}
+	printSuccess(fmt.Sprintf("Opened %s in your browser", result.Issue.Identifier))
+	return nil
+}
+
+// planOpenDeps holds dependencies for 'jig plan open' (for testability)
+type planOpenDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	getTracker    func() (tracker.Tracker, error)
+	// openURL opens a URL in the browser; nil means the URL is only resolved
+	openURL func(url string) error
+}
+
+// planOpenResult describes the outcome of opening a plan's issue
+type planOpenResult struct {
+	Issue  *tracker.Issue
+	URL    string
+	Opened bool
+}
+
+// openPlanIssueWithDeps resolves the URL of the issue linked to a cached plan
+// and opens it if deps.openURL is set
+func openPlanIssueWithDeps(ctx context.Context, planID string, deps planOpenDeps) (*planOpenResult, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	issueID := cached.Plan.IssueID
+	if issueID == "" {
+		return nil, fmt.Errorf("plan %s is not linked to an issue (link one with 'jig plan link %s <ISSUE_ID>')", planID, planID)
+	}
+
+	t, err := deps.getTracker()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracker: %w", err)
+	}
+	issue, err := t.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issue %s: %w", issueID, err)
+	}
+	if issue.URL == "" {
+		return nil, fmt.Errorf("issue %s has no URL", issueID)
+	}
+
+	result := &planOpenResult{Issue: issue, URL: issue.URL}
+	if deps.openURL == nil {
+		return result, nil
+	}
+	if err := deps.openURL(issue.URL); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", issue.URL, err)
+	}
+	result.Opened = true
+	return result, nil
+}
+
+func runPlanLink(cmd *cobra.Command, args []string) error {
+	if planLinkUnlink {
+		return runPlanUnlink(args[0])
+	}
+	if planLinkRemoveLabel {
+		return fmt.Errorf("--remove-label can only be used with --unlink")
+	}
+
+	planID := args[0]
+	issueIDs := args[1:]
+	issueID := issueIDs[0]
+
+	ctx := context.Background()
+	cfg := config.Get()
+
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
 	}
 
 	// Load plan from cache
@@ -1208,48 +3937,979 @@ func runPlanLink(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("plan not found: %s", planID)
 	}
 
-	// Validate that the issue exists in Linear
-	if cfg.Default.Tracker == "linear" {
+	// Validate that the issues exist in the tracker
+	if trackerSyncsPlans(cfg) {
 		t, err := getTracker(cfg)
 		if err != nil {
 			printWarning(fmt.Sprintf("Could not connect to tracker: %v", err))
 		} else {
-			issue, err := t.GetIssue(ctx, issueID)
-			if err != nil {
-				return fmt.Errorf("Linear issue not found: %s (%w)", issueID, err)
+			for _, id := range issueIDs {
+				issue, err := t.GetIssue(ctx, id)
+				if err != nil {
+					return fmt.Errorf("%s issue not found: %s (%w)", trackerName(cfg), id, err)
+				}
+				printInfo(fmt.Sprintf("Found issue: %s - %s", issue.Identifier, issue.Title))
 			}
-			printInfo(fmt.Sprintf("Found issue: %s - %s", issue.Identifier, issue.Title))
 		}
 	}
 
-	// Link the plan to the issue
-	cached.Plan.IssueID = issueID
-	cached.IssueID = issueID
+	// Warn if the issue is already linked to other plans
+	if linked, err := state.DefaultCache.GetPlansByIssueID(issueID); err == nil {
+		for _, other := range linked {
+			if other.Plan.ID != planID {
+				printWarning(fmt.Sprintf("Issue %s is also linked to plan %s", issueID, other.Plan.ID))
+			}
+		}
+	}
 
-	// Save the updated plan
-	if err := state.DefaultCache.SavePlan(cached.Plan); err != nil {
+	// Link the plan to the issues
+	err = state.DefaultCache.UpdatePlan(planID, func(p *plan.Plan) error {
+		linkPlanIssues(p, issueIDs)
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save plan: %w", err)
 	}
 
-	printSuccess(fmt.Sprintf("Linked plan %s to issue %s", planID, issueID))
+	cached, err = state.DefaultCache.GetCachedPlan(planID)
+	if err != nil {
+		return fmt.Errorf("failed to reload plan: %w", err)
+	}
 
-	// Sync plan content to issue if Linear sync is enabled
-	if cfg.Default.Tracker == "linear" && cfg.Linear.ShouldSyncPlanOnSave() {
-		// Get cached hash for deduplication
-		cachedHash := cached.SyncedContentHash
+	printSuccess(fmt.Sprintf("Linked plan %s to issue %s", planID, issueID))
+	if len(cached.Plan.RelatedIssues) > 0 {
+		printInfo(fmt.Sprintf("Related issues: %s", strings.Join(cached.Plan.RelatedIssues, ", ")))
+	}
 
-		result, err := syncPlanToLinearWithDedup(ctx, cfg, cached.Plan, cachedHash)
+	// Sync plan content to issue if sync is enabled
+	if cfg.ShouldSyncPlanOnSave() {
+		result, err := syncPlanToTrackerWithDedup(ctx, cfg, cached, false)
 		if err != nil {
-			printWarning(fmt.Sprintf("Could not sync plan to Linear: %v", err))
+			printWarning(fmt.Sprintf("Could not sync plan to %s: %v", trackerName(cfg), err))
 		} else if result.Skipped {
 			printInfo("Plan content unchanged, skipping sync")
+		} else if result.ConflictDetected {
+			printWarning(planCommentConflictMessage(cached.Plan))
 		} else {
-			if err := state.DefaultCache.MarkPlanSyncedWithHash(planID, result.ContentHash); err != nil {
-				printWarning(fmt.Sprintf("Plan synced but failed to update sync timestamp: %v", err))
-			}
-			printSuccess(fmt.Sprintf("Plan synced to Linear issue %s", issueID))
+			printSuccess(fmt.Sprintf("Plan synced to %s issue %s", trackerName(cfg), issueID))
+		}
+	}
+
+	return nil
+}
+
+// runPlanUnlink detaches a plan from its issues, for 'jig plan link --unlink'
+func runPlanUnlink(planID string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planUnlinkDeps{
+		planUnsyncDeps: planUnsyncDeps{
+			getCachedPlan: state.DefaultCache.GetCachedPlan,
+			markUnsynced:  state.DefaultCache.MarkPlanUnsynced,
+			getTracker:    func() (tracker.Tracker, error) { return getTracker(cfg) },
+		},
+		updatePlan: state.DefaultCache.UpdatePlan,
+	}
+
+	labelName := ""
+	if planLinkRemoveLabel {
+		labelName = cfg.GetPlanLabelName()
+	}
+
+	result, err := unlinkPlanWithDeps(context.Background(), planID, labelName, deps)
+	if err != nil {
+		return err
+	}
+
+	if labelName != "" {
+		if result.LabelRemoved {
+			printSuccess(fmt.Sprintf("Removed label %q from %s", labelName, result.IssueID))
+		} else {
+			printInfo(fmt.Sprintf("%s does not have the %q label", result.IssueID, labelName))
+		}
+	}
+	printSuccess(fmt.Sprintf("Unlinked plan %s from issue %s", planID, result.IssueID))
+	return nil
+}
+
+// planUnlinkDeps holds dependencies for 'jig plan link --unlink' (for testability)
+type planUnlinkDeps struct {
+	planUnsyncDeps
+	updatePlan func(id string, fn func(*plan.Plan) error) error
+}
+
+// planUnlinkResult describes the outcome of unlinking a plan
+type planUnlinkResult struct {
+	IssueID      string // The issue the plan was linked to
+	LabelRemoved bool
+}
+
+// unlinkPlanWithDeps clears a cached plan's primary and related issues and
+// its sync state. If labelName is set, that label is first removed from the
+// linked issue; if removing it fails, the plan is left linked.
+func unlinkPlanWithDeps(ctx context.Context, planID, labelName string, deps planUnlinkDeps) (*planUnlinkResult, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+	if !cached.Plan.HasLinkedIssue() {
+		return nil, fmt.Errorf("plan %s is not linked to an issue", planID)
+	}
+	issueID := cached.Plan.IssueID
+
+	unsynced, err := unsyncPlanWithDeps(ctx, planID, labelName, deps.planUnsyncDeps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = deps.updatePlan(planID, func(p *plan.Plan) error {
+		p.IssueID = ""
+		p.RelatedIssues = nil
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	return &planUnlinkResult{IssueID: issueID, LabelRemoved: unsynced.LabelRemoved}, nil
+}
+
+// linkPlanIssues makes the first issue the plan's primary issue and the rest
+// its related issues, dropping duplicates and repeats of the primary issue
+func linkPlanIssues(p *plan.Plan, issueIDs []string) {
+	p.IssueID = issueIDs[0]
+	p.RelatedIssues = nil
+	for _, id := range issueIDs[1:] {
+		if id != p.IssueID && !slices.Contains(p.RelatedIssues, id) {
+			p.RelatedIssues = append(p.RelatedIssues, id)
+		}
+	}
+}
+
+func runPlanPull(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	cfg := config.Get()
+
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	t, err := getTracker(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to tracker: %w", err)
+	}
+
+	deps := planPullDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		savePlan:      state.DefaultCache.SavePlan,
+		getIssue:      t.GetIssue,
+	}
+
+	if len(args) > 0 {
+		result, err := pullPlanStatusWithDeps(ctx, args[0], deps)
+		if err != nil {
+			return err
+		}
+		reportPullResult(result)
+		return nil
+	}
+
+	cachedPlans, err := state.DefaultCache.ListCachedPlans()
+	if err != nil {
+		return fmt.Errorf("failed to list plans: %w", err)
+	}
+
+	var failures int
+	for _, cp := range cachedPlans {
+		if cp.Plan == nil || !cp.Plan.HasLinkedIssue() {
+			continue
+		}
+		result, err := pullPlanStatusWithDeps(ctx, cp.Plan.ID, deps)
+		if err != nil {
+			printWarning(fmt.Sprintf("%s: %v", cp.Plan.ID, err))
+			failures++
+			continue
 		}
+		reportPullResult(result)
 	}
 
+	if failures > 0 {
+		return fmt.Errorf("failed to pull %d plan(s)", failures)
+	}
 	return nil
 }
+
+// planPullDeps holds dependencies for pull operations (for testability)
+type planPullDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	savePlan      func(p *plan.Plan) error
+	getIssue      func(ctx context.Context, id string) (*tracker.Issue, error)
+}
+
+// pullResult describes the outcome of pulling a plan's status from its issue
+type pullResult struct {
+	PlanID         string
+	IssueID        string
+	PreviousStatus plan.Status
+	NewStatus      plan.Status
+	Changed        bool
+}
+
+// pullPlanStatusWithDeps updates a cached plan's status from its linked issue
+func pullPlanStatusWithDeps(ctx context.Context, planID string, deps planPullDeps) (*pullResult, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	p := cached.Plan
+	if !p.HasLinkedIssue() {
+		return nil, fmt.Errorf("plan %s has no linked issue (link one with 'jig plan link')", planID)
+	}
+
+	issue, err := deps.getIssue(ctx, p.IssueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", p.IssueID, err)
+	}
+
+	result := &pullResult{
+		PlanID:         p.ID,
+		IssueID:        p.IssueID,
+		PreviousStatus: p.Status,
+		NewStatus:      trackerStatusToPlanStatus(issue.Status, p.Status),
+	}
+	if result.NewStatus == result.PreviousStatus {
+		return result, nil
+	}
+
+	p.Status = result.NewStatus
+	p.Updated = time.Now()
+	if err := deps.savePlan(p); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	result.Changed = true
+	return result, nil
+}
+
+// trackerStatusToPlanStatus maps an issue status to a plan status.
+// Several plan statuses share a tracker status (draft, reviewing and approved are
+// all "todo"), so the current status is kept when it is already consistent with
+// the issue. Statuses with no plan equivalent (canceled) also keep the current status.
+func trackerStatusToPlanStatus(status tracker.Status, current plan.Status) plan.Status {
+	switch status {
+	case tracker.StatusBacklog, tracker.StatusTodo:
+		switch current {
+		case plan.StatusDraft, plan.StatusReviewing, plan.StatusApproved:
+			return current
+		}
+		return plan.StatusDraft
+	case tracker.StatusInProgress:
+		return plan.StatusInProgress
+	case tracker.StatusInReview:
+		return plan.StatusInReview
+	case tracker.StatusDone:
+		return plan.StatusComplete
+	default:
+		return current
+	}
+}
+
+// reportPullResult prints the outcome of a status pull
+func reportPullResult(result *pullResult) {
+	if !result.Changed {
+		printInfo(fmt.Sprintf("%s is up to date (%s)", result.PlanID, result.NewStatus))
+		return
+	}
+	printSuccess(fmt.Sprintf("%s: %s → %s (from %s)", result.PlanID, result.PreviousStatus, result.NewStatus, result.IssueID))
+}
+
+func runPlanPhaseSet(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	deps := planPhaseDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		savePlan:      state.DefaultCache.SavePlan,
+	}
+
+	p, err := setPlanPhaseStatusWithDeps(args[0], args[1], args[2], deps)
+	if err != nil {
+		return err
+	}
+
+	done, total := p.PhaseProgress()
+	printSuccess(fmt.Sprintf("Set phase %s of %s to %s (%d/%d phases done)", args[1], p.ID, args[2], done, total))
+	return nil
+}
+
+// planPhaseDeps holds dependencies for phase operations (for testability)
+type planPhaseDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	savePlan      func(p *plan.Plan) error
+}
+
+// setPlanPhaseStatusWithDeps updates a phase's status on a cached plan and saves it
+func setPlanPhaseStatusWithDeps(planID, phaseID, status string, deps planPhaseDeps) (*plan.Plan, error) {
+	phaseStatus, err := plan.ParsePhaseStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	p := cached.Plan
+	if err := p.SetPhaseStatus(phaseID, phaseStatus); err != nil {
+		return nil, fmt.Errorf("%w in plan %s", err, planID)
+	}
+
+	// Refresh the raw content so the updated frontmatter is what gets synced
+	data, err := plan.Serialize(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize plan: %w", err)
+	}
+	p.RawContent = string(data)
+
+	if err := deps.savePlan(p); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	return p, nil
+}
+
+func runPlanStatus(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planStatusDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		savePlan:      state.DefaultCache.SavePlan,
+	}
+	if trackerSyncsPlans(cfg) {
+		deps.getTracker = func() (tracker.Tracker, error) { return getTracker(cfg) }
+	}
+
+	result, err := setPlanStatusWithDeps(context.Background(), args[0], args[1], deps)
+	if err != nil {
+		return err
+	}
+
+	p := result.Plan
+	printSuccess(fmt.Sprintf("Set plan %s to %s", p.ID, p.Status))
+	if result.TrackerError != nil {
+		return fmt.Errorf("plan status was saved, but issue %s was not updated: %w", p.IssueID, result.TrackerError)
+	}
+	if result.IssueTransitioned {
+		printSuccess(fmt.Sprintf("Moved %s to %s", p.IssueID, tracker.StatusForPlan(p.Status)))
+	}
+	return nil
+}
+
+// planStatusDeps holds dependencies for 'jig plan status' (for testability)
+type planStatusDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	savePlan      func(p *plan.Plan) error
+	getTracker    func() (tracker.Tracker, error) // nil when no tracker is configured
+}
+
+// planStatusResult describes the outcome of setting a plan's status
+type planStatusResult struct {
+	Plan              *plan.Plan
+	IssueTransitioned bool
+	TrackerError      error // Set if the plan was saved but its issue could not be transitioned
+}
+
+// setPlanStatusWithDeps sets a cached plan's status, saves it, and transitions
+// its linked issue to the matching status. A failed transition doesn't undo the
+// local change; it is reported in the result's TrackerError.
+func setPlanStatusWithDeps(ctx context.Context, planID, status string, deps planStatusDeps) (*planStatusResult, error) {
+	planStatus, err := plan.ParseStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	p := cached.Plan
+	p.Status = planStatus
+	p.Updated = time.Now()
+
+	// Refresh the raw content so the updated frontmatter is what gets synced
+	data, err := plan.Serialize(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize plan: %w", err)
+	}
+	p.RawContent = string(data)
+
+	if err := deps.savePlan(p); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	result := &planStatusResult{Plan: p}
+	if p.IssueID == "" || deps.getTracker == nil {
+		return result, nil
+	}
+
+	t, err := deps.getTracker()
+	if err != nil {
+		result.TrackerError = err
+		return result, nil
+	}
+	issueStatus := tracker.StatusForPlan(planStatus)
+	if err := checkStatusAvailable(ctx, t, p.IssueID, issueStatus); err != nil {
+		result.TrackerError = err
+		return result, nil
+	}
+	if err := t.TransitionIssue(ctx, p.IssueID, issueStatus); err != nil {
+		result.TrackerError = err
+		return result, nil
+	}
+	result.IssueTransitioned = true
+	return result, nil
+}
+
+func runPlanRename(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planRenameDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		savePlan:      state.DefaultCache.SavePlan,
+	}
+	if trackerSyncsPlans(cfg) {
+		deps.getTracker = func() (tracker.Tracker, error) { return getTracker(cfg) }
+	}
+
+	title := strings.Join(args[1:], " ")
+	result, err := renamePlanWithDeps(context.Background(), args[0], title, deps)
+	if err != nil {
+		return err
+	}
+
+	p := result.Plan
+	printSuccess(fmt.Sprintf("Renamed plan %s to %q", p.ID, p.Title))
+	if result.TrackerError != nil {
+		return fmt.Errorf("plan title was saved, but issue %s was not updated: %w", p.IssueID, result.TrackerError)
+	}
+	if result.IssueUpdated {
+		printSuccess(fmt.Sprintf("Renamed %s", p.IssueID))
+	}
+	return nil
+}
+
+// planRenameDeps holds dependencies for 'jig plan rename' (for testability)
+type planRenameDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	savePlan      func(p *plan.Plan) error
+	getTracker    func() (tracker.Tracker, error) // nil when no tracker is configured
+}
+
+// planRenameResult describes the outcome of renaming a plan
+type planRenameResult struct {
+	Plan         *plan.Plan
+	IssueUpdated bool
+	TrackerError error // Set if the plan was saved but its issue could not be renamed
+}
+
+// renamePlanWithDeps sets a cached plan's title, saves it, and renames its
+// linked issue to match. A failed issue update doesn't undo the local change;
+// it is reported in the result's TrackerError.
+func renamePlanWithDeps(ctx context.Context, planID, title string, deps planRenameDeps) (*planRenameResult, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("title must not be empty")
+	}
+
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	p := cached.Plan
+	oldTitle := p.Title
+	p.Title = title
+	p.Updated = time.Now()
+
+	// Refresh the raw content so the new title is what gets synced
+	p.RawContent = renamePlanHeading(p.RawContent, oldTitle, title)
+	data, err := plan.Serialize(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize plan: %w", err)
+	}
+	p.RawContent = string(data)
+
+	if err := deps.savePlan(p); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+
+	result := &planRenameResult{Plan: p}
+	if p.IssueID == "" || deps.getTracker == nil {
+		return result, nil
+	}
+
+	t, err := deps.getTracker()
+	if err != nil {
+		result.TrackerError = err
+		return result, nil
+	}
+	if err := t.UpdateIssue(ctx, p.IssueID, &tracker.IssueUpdate{Title: &title}); err != nil {
+		result.TrackerError = err
+		return result, nil
+	}
+	result.IssueUpdated = true
+	return result, nil
+}
+
+func runPlanCopy(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planCopyDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		planExists:    state.PlanExists,
+		savePlan:      state.DefaultCache.SavePlan,
+		newID:         func(title string) (string, error) { return newPlanID(cfg, title) },
+	}
+
+	newID := ""
+	if len(args) > 1 {
+		newID = args[1]
+	}
+	p, err := copyPlanWithDeps(args[0], newID, deps)
+	if err != nil {
+		return err
+	}
+
+	printSuccess(fmt.Sprintf("Copied plan %s to %s", args[0], p.ID))
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  - View plan: jig plan show %s\n", p.ID)
+	fmt.Printf("  - Export it to edit: jig plan export %s -o %s.md\n", p.ID, p.ID)
+	return nil
+}
+
+// planCopyDeps holds dependencies for 'jig plan copy' (for testability)
+type planCopyDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	planExists    func(id string) bool
+	savePlan      func(p *plan.Plan) error
+	newID         func(title string) (string, error)
+}
+
+// copyPlanWithDeps saves a copy of a cached plan as a new draft with newID,
+// or a generated ID if newID is empty. The copy isn't linked or related to any
+// issue, and its phases are pending with no sub-issues; its body is kept and
+// its frontmatter rewritten.
+func copyPlanWithDeps(planID, newID string, deps planCopyDeps) (*plan.Plan, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+	src := cached.Plan
+
+	if newID == "" {
+		if newID, err = deps.newID(src.Title); err != nil {
+			return nil, err
+		}
+	} else if err := plan.ValidateID(newID); err != nil {
+		return nil, fmt.Errorf("invalid plan ID: %w", err)
+	} else if newID == src.ID || deps.planExists(newID) {
+		return nil, fmt.Errorf("plan %s already exists", newID)
+	}
+
+	p := *src
+	p.ID = newID
+	p.IssueID = ""
+	p.RelatedIssues = nil
+	p.Blocks = nil
+	p.BlockedBy = nil
+	p.Status = plan.StatusDraft
+	p.Created = time.Now()
+	p.Updated = p.Created
+	p.FilePath = ""
+	p.Phases = slices.Clone(src.Phases)
+	for i := range p.Phases {
+		p.Phases[i].Status = plan.PhasePending
+		p.Phases[i].IssueID = ""
+	}
+
+	// Rewrite the frontmatter, keeping the body as written
+	data, err := plan.Serialize(&p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize plan: %w", err)
+	}
+	p.RawContent = string(data)
+
+	if err := deps.savePlan(&p); err != nil {
+		return nil, fmt.Errorf("failed to save plan: %w", err)
+	}
+	return &p, nil
+}
+
+func runPlanBreakdown(cmd *cobra.Command, args []string) error {
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cfg := config.Get()
+	deps := planBreakdownDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		setPhaseIssue: func(planID, phaseID, issueID string) error {
+			return state.DefaultCache.UpdatePlan(planID, func(p *plan.Plan) error {
+				return p.SetPhaseIssue(phaseID, issueID)
+			})
+		},
+		getTracker: func() (tracker.Tracker, error) { return getTracker(cfg) },
+	}
+
+	result, err := breakdownPlanWithDeps(context.Background(), args[0], deps)
+	if result != nil {
+		for _, sub := range result.Created {
+			printSuccess(fmt.Sprintf("Created %s for phase %s", sub.Issue.Identifier, sub.PhaseID))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(result.Created) == 0 {
+		printInfo("Every phase already has a sub-issue")
+	} else if len(result.Skipped) > 0 {
+		printInfo(fmt.Sprintf("Skipped %d phase(s) that already have a sub-issue", len(result.Skipped)))
+	}
+	return nil
+}
+
+// planBreakdownDeps holds dependencies for 'jig plan breakdown' (for testability)
+type planBreakdownDeps struct {
+	getCachedPlan func(id string) (*state.CachedPlan, error)
+	setPhaseIssue func(planID, phaseID, issueID string) error
+	getTracker    func() (tracker.Tracker, error)
+}
+
+// phaseSubIssue is a sub-issue created for a plan phase
+type phaseSubIssue struct {
+	PhaseID string
+	Issue   *tracker.Issue
+}
+
+// planBreakdownResult describes the outcome of breaking a plan down
+type planBreakdownResult struct {
+	Created []phaseSubIssue
+	Skipped []string // IDs of phases that already had a sub-issue
+}
+
+// breakdownPlanWithDeps creates a sub-issue under a plan's linked issue for
+// each phase that doesn't have one yet, recording each on its phase as soon
+// as it is created. If creating a sub-issue fails, the sub-issues created
+// before it are still returned alongside the error.
+func breakdownPlanWithDeps(ctx context.Context, planID string, deps planBreakdownDeps) (*planBreakdownResult, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return nil, fmt.Errorf("plan not found: %s", planID)
+	}
+
+	p := cached.Plan
+	if !p.HasLinkedIssue() {
+		return nil, fmt.Errorf("plan %s is not linked to an issue (use 'jig plan link' first)", p.ID)
+	}
+	if len(p.Phases) == 0 {
+		return nil, fmt.Errorf("plan %s has no phases", p.ID)
+	}
+
+	result := &planBreakdownResult{}
+	var pending []plan.Phase
+	for _, phase := range p.Phases {
+		if phase.IssueID != "" {
+			result.Skipped = append(result.Skipped, phase.ID)
+			continue
+		}
+		pending = append(pending, phase)
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	t, err := deps.getTracker()
+	if err != nil {
+		return nil, err
+	}
+	parent, err := t.GetIssue(ctx, p.IssueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", p.IssueID, err)
+	}
+
+	for _, phase := range pending {
+		title := phase.Title
+		if title == "" {
+			title = phase.ID
+		}
+		sub, err := t.CreateSubIssue(ctx, parent.ID, &tracker.Issue{
+			Title:       title,
+			Description: p.PhaseBody(phase),
+			TeamID:      parent.TeamID,
+			Estimate:    phase.Estimate,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to create sub-issue for phase %s: %w", phase.ID, err)
+		}
+		result.Created = append(result.Created, phaseSubIssue{PhaseID: phase.ID, Issue: sub})
+
+		subID := sub.Identifier
+		if subID == "" {
+			subID = sub.ID
+		}
+		if err := deps.setPhaseIssue(p.ID, phase.ID, subID); err != nil {
+			return result, fmt.Errorf("created %s but failed to record it on phase %s: %w", subID, phase.ID, err)
+		}
+	}
+	return result, nil
+}
+
+// renamePlanHeading replaces the first top-level heading of a plan's markdown
+// with newTitle if it reads oldTitle. Headings the author changed by hand are
+// left alone.
+func renamePlanHeading(content, oldTitle, newTitle string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if strings.TrimSpace(strings.TrimPrefix(line, "# ")) == oldTitle {
+			lines[i] = "# " + newTitle
+			return strings.Join(lines, "\n")
+		}
+		return content
+	}
+	return content
+}
+
+func runPlanExport(cmd *cobra.Command, args []string) error {
+	planID := args[0]
+
+	if planExportFormat != "markdown" && planExportFormat != "json" {
+		return fmt.Errorf("invalid format %q (must be markdown or json)", planExportFormat)
+	}
+
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	cached, err := state.DefaultCache.GetCachedPlan(planID)
+	if err != nil {
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+
+	markdown, err := state.DefaultCache.GetPlanMarkdown(planID)
+	if err != nil {
+		return fmt.Errorf("failed to read plan: %w", err)
+	}
+	if markdown == "" {
+		return fmt.Errorf("plan not found: %s", planID)
+	}
+
+	var data []byte
+	ext := ".md"
+	if planExportFormat == "json" {
+		ext = ".json"
+		data, err = json.MarshalIndent(newPlanExport(cached.Plan, markdown), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize plan: %w", err)
+		}
+		data = append(data, '\n')
+	} else {
+		data = []byte(markdown)
+	}
+
+	path := resolveExportPath(planExportOutput, planID, ext)
+	if !planExportForce {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	printSuccess(fmt.Sprintf("Exported plan %s to %s", planID, path))
+	return nil
+}
+
+// resolveExportPath returns the file to export to. If output is an existing
+// directory (or ends with a path separator), the file is named after the plan.
+func resolveExportPath(output, planID, ext string) string {
+	if strings.HasSuffix(output, string(os.PathSeparator)) || strings.HasSuffix(output, "/") {
+		return filepath.Join(output, planID+ext)
+	}
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		return filepath.Join(output, planID+ext)
+	}
+	return output
+}
+
+// planExport is the JSON representation of an exported plan
+type planExport struct {
+	ID               string       `json:"id"`
+	IssueID          string       `json:"issue_id,omitempty"`
+	Title            string       `json:"title"`
+	Status           string       `json:"status"`
+	Author           string       `json:"author"`
+	Phases           []plan.Phase `json:"phases,omitempty"`
+	ProblemStatement string       `json:"problem_statement,omitempty"`
+	ProposedSolution string       `json:"proposed_solution,omitempty"`
+	Markdown         string       `json:"markdown"`
+}
+
+// newPlanExport builds the JSON export for a plan and its serialized markdown
+func newPlanExport(p *plan.Plan, markdown string) planExport {
+	return planExport{
+		ID:               p.ID,
+		IssueID:          p.IssueID,
+		Title:            p.Title,
+		Status:           string(p.Status),
+		Author:           p.Author,
+		Phases:           p.Phases,
+		ProblemStatement: p.ProblemStatement,
+		ProposedSolution: p.ProposedSolution,
+		Markdown:         markdown,
+	}
+}
+
+func runPlanDiff(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	cfg := config.Get()
+
+	// Initialize cache
+	if err := state.Init(); err != nil {
+		return withExitCode(exitCodeDiffFailed, fmt.Errorf("failed to initialize cache: %w", err))
+	}
+
+	deps := planDiffDeps{
+		getCachedPlan: state.DefaultCache.GetCachedPlan,
+		// The syncer is only needed for plans with a linked issue
+		getPlanComment: func(ctx context.Context, issueID string) (*tracker.Comment, error) {
+			syncer, err := getPlanSyncer(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get syncer: %w", err)
+			}
+			return syncer.GetPlanComment(ctx, issueID)
+		},
+		planBodyContent: func(p *plan.Plan) string {
+			if syncer, err := getPlanSyncer(cfg); err == nil {
+				if renderer, ok := syncer.(planBodyRenderer); ok {
+					return renderer.PlanBodyContent(p)
+				}
+			}
+			return linear.PlanBodyContent(p)
+		},
+	}
+
+	diff, err := diffPlanWithDeps(ctx, args[0], deps)
+	if err != nil {
+		return withExitCode(exitCodeDiffFailed, err)
+	}
+	if diff == "" {
+		printInfo(fmt.Sprintf("%s matches its plan comment", args[0]))
+		return nil
+	}
+
+	fmt.Print(diff)
+	return withExitCode(exitCodeDiffFound, fmt.Errorf("%s differs from its plan comment", args[0]))
+}
+
+// planDiffDeps holds dependencies for diff operations (for testability)
+type planDiffDeps struct {
+	getCachedPlan   func(id string) (*state.CachedPlan, error)
+	getPlanComment  func(ctx context.Context, issueID string) (*tracker.Comment, error)
+	planBodyContent func(p *plan.Plan) string // nil renders the built-in comment layout
+}
+
+// planBodyRenderer is implemented by syncers that lay plan comments out their
+// own way, such as a Linear client with a comment template
+type planBodyRenderer interface {
+	PlanBodyContent(p *plan.Plan) string
+}
+
+// diffPlanWithDeps returns a unified diff from a plan's Linear comment to the
+// content a sync would write, or an empty string if they match. Plans without
+// a linked issue or plan comment are diffed against nothing.
+func diffPlanWithDeps(ctx context.Context, planID string, deps planDiffDeps) (string, error) {
+	cached, err := deps.getCachedPlan(planID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return "", fmt.Errorf("plan not found: %s", planID)
+	}
+
+	p := cached.Plan
+	fromName := "linear/(not synced)"
+	var remote string
+	if p.HasLinkedIssue() {
+		comment, err := deps.getPlanComment(ctx, p.IssueID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get plan comment for %s: %w", p.IssueID, err)
+		}
+		if comment != nil {
+			fromName = "linear/" + p.IssueID
+			remote = linear.ConvertCommentToBodyContent(comment.Body)
+		}
+	}
+
+	planBodyContent := deps.planBodyContent
+	if planBodyContent == nil {
+		planBodyContent = linear.PlanBodyContent
+	}
+	return unifiedDiff(fromName, "local/"+p.ID, remote, planBodyContent(p)), nil
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestBreakdownPlanWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newBreakdownDeps returns deps serving p as a cached plan and recording
+	// sub-issues on its phases
+	newBreakdownDeps := func(p *plan.Plan, tr tracker.Tracker) planBreakdownDeps {
+		return planBreakdownDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			setPhaseIssue: func(planID, phaseID, issueID string) error {
+				return p.SetPhaseIssue(phaseID, issueID)
+			},
+			getTracker: func() (tracker.Tracker, error) { return tr, nil },
+		}
+	}
+
+	newPlan := func(issueID string) *plan.Plan {
+		return &plan.Plan{
+			ID:      "PLAN-1",
+			IssueID: issueID,
+			Title:   "Test Plan",
+			Phases: []plan.Phase{
+				{ID: "phase-1", Title: "Schema"},
+				{ID: "phase-2", Title: "API"},
+				{ID: "phase-3", Title: "UI"},
+			},
+			RawContent: "---\ntitle: Test Plan\n---\n\n# Test Plan\n\n## Schema\n\nAdd the tables.\n\n## API\n\nAdd the endpoints.\n",
+		}
+	}
+
+	t.Run("creates a sub-issue per phase", func(t *testing.T) {
+		client := mock.NewClient()
+		parent, _ := client.CreateIssue(ctx, &tracker.Issue{Title: "Parent"})
+		p := newPlan(parent.Identifier)
+
+		result, err := breakdownPlanWithDeps(ctx, "PLAN-1", newBreakdownDeps(p, client))
+		if err != nil {
+			t.Fatalf("breakdownPlanWithDeps() error = %v", err)
+		}
+		if len(result.Created) != 3 || len(result.Skipped) != 0 {
+			t.Fatalf("expected 3 created and 0 skipped, got %+v", result)
+		}
+
+		children, _ := client.GetSubIssues(ctx, parent.ID)
+		if len(children) != 3 {
+			t.Fatalf("expected 3 sub-issues under %s, got %d", parent.ID, len(children))
+		}
+		for i, sub := range result.Created {
+			phase := p.Phases[i]
+			if sub.PhaseID != phase.ID || sub.Issue.Title != phase.Title {
+				t.Errorf("sub-issue %d = %+v, want one for %s", i, sub.Issue, phase.ID)
+			}
+			if phase.IssueID != sub.Issue.Identifier {
+				t.Errorf("expected phase %s to record %s, got %q", phase.ID, sub.Issue.Identifier, phase.IssueID)
+			}
+		}
+		if got := result.Created[1].Issue.Description; got != "Add the endpoints." {
+			t.Errorf("expected description from the phase section, got %q", got)
+		}
+		if got := result.Created[2].Issue.Description; got != "" {
+			t.Errorf("expected empty description for a phase without a section, got %q", got)
+		}
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		client := mock.NewClient()
+		parent, _ := client.CreateIssue(ctx, &tracker.Issue{Title: "Parent"})
+		p := newPlan(parent.Identifier)
+		p.Phases[0].IssueID = "MOCK-99"
+		deps := newBreakdownDeps(p, client)
+
+		result, err := breakdownPlanWithDeps(ctx, "PLAN-1", deps)
+		if err != nil {
+			t.Fatalf("first breakdownPlanWithDeps() error = %v", err)
+		}
+		if len(result.Created) != 2 || len(result.Skipped) != 1 || result.Skipped[0] != "phase-1" {
+			t.Fatalf("expected 2 created and phase-1 skipped, got %+v", result)
+		}
+
+		deps.getTracker = func() (tracker.Tracker, error) {
+			t.Error("tracker should not be used when every phase has a sub-issue")
+			return client, nil
+		}
+		result, err = breakdownPlanWithDeps(ctx, "PLAN-1", deps)
+		if err != nil {
+			t.Fatalf("second breakdownPlanWithDeps() error = %v", err)
+		}
+		if len(result.Created) != 0 || len(result.Skipped) != 3 {
+			t.Errorf("expected nothing created on re-run, got %+v", result)
+		}
+
+		children, _ := client.GetSubIssues(ctx, parent.ID)
+		if len(children) != 2 {
+			t.Errorf("expected 2 sub-issues in total, got %d", len(children))
+		}
+	})
+
+	t.Run("keeps created sub-issues when one fails", func(t *testing.T) {
+		client := mock.NewClient()
+		parent, _ := client.CreateIssue(ctx, &tracker.Issue{Title: "Parent"})
+		p := newPlan(parent.Identifier)
+		deps := newBreakdownDeps(p, client)
+		deps.setPhaseIssue = func(planID, phaseID, issueID string) error {
+			if phaseID == "phase-2" {
+				return state.ErrPlanNotFound
+			}
+			return p.SetPhaseIssue(phaseID, issueID)
+		}
+
+		result, err := breakdownPlanWithDeps(ctx, "PLAN-1", deps)
+		if err == nil || !strings.Contains(err.Error(), "failed to record it on phase phase-2") {
+			t.Fatalf("expected record error, got %v", err)
+		}
+		if result == nil || len(result.Created) != 2 {
+			t.Errorf("expected the 2 created sub-issues to be reported, got %+v", result)
+		}
+	})
+
+	t.Run("rejects an unlinked plan", func(t *testing.T) {
+		_, err := breakdownPlanWithDeps(ctx, "PLAN-1", newBreakdownDeps(newPlan(""), mock.NewClient()))
+		if err == nil || !strings.Contains(err.Error(), "is not linked to an issue") {
+			t.Errorf("expected unlinked plan error, got %v", err)
+		}
+	})
+
+	t.Run("rejects a plan without phases", func(t *testing.T) {
+		p := newPlan("MOCK-1")
+		p.Phases = nil
+
+		_, err := breakdownPlanWithDeps(ctx, "PLAN-1", newBreakdownDeps(p, mock.NewClient()))
+		if err == nil || !strings.Contains(err.Error(), "has no phases") {
+			t.Errorf("expected no phases error, got %v", err)
+		}
+	})
+}
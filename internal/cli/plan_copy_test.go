@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+)
+
+func TestCopyPlanWithDeps(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	synced := created.Add(time.Hour)
+	body := "# Rate Limiting\n\n## Problem Statement\n\nToo many requests.\n\n## Proposed Solution\n\nLimit them.\n\n" +
+		"## Acceptance Criteria\n\n- [x] Limits apply\n"
+
+	// newCopyDeps returns deps serving a synced plan linked to an issue, and
+	// recording the plans passed to savePlan
+	newCopyDeps := func() (planCopyDeps, *[]*plan.Plan) {
+		src := &plan.Plan{
+			ID:               "PLAN-1",
+			IssueID:          "NUM-1",
+			Title:            "Rate Limiting",
+			Status:           plan.StatusInProgress,
+			Author:           "tester",
+			Created:          created,
+			Labels:           []string{"backend"},
+			RelatedIssues:    []string{"NUM-3"},
+			Blocks:           []string{"NUM-4"},
+			BlockedBy:        []string{"NUM-5"},
+			Phases:           []plan.Phase{{ID: "1", Title: "Limiter", Status: plan.PhaseDone, IssueID: "NUM-2"}},
+			ProblemStatement: "Too many requests.",
+			ProposedSolution: "Limit them.",
+			RawContent:       "---\nid: PLAN-1\nissue_id: NUM-1\ntitle: Rate Limiting\n---\n\n" + body,
+		}
+
+		var saved []*plan.Plan
+		deps := planCopyDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != src.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: src, IssueID: src.IssueID, SyncedAt: &synced, SyncedContentHash: "hash"}, nil
+			},
+			planExists: func(id string) bool { return id == src.ID || id == "PLAN-TAKEN" },
+			savePlan: func(p *plan.Plan) error {
+				saved = append(saved, p)
+				return nil
+			},
+			newID: func(title string) (string, error) { return "plan-rate-limiting-a1b2", nil },
+		}
+		return deps, &saved
+	}
+
+	t.Run("copies the plan under a generated ID", func(t *testing.T) {
+		deps, saved := newCopyDeps()
+
+		p, err := copyPlanWithDeps("PLAN-1", "", deps)
+		if err != nil {
+			t.Fatalf("copyPlanWithDeps() error = %v", err)
+		}
+		if len(*saved) != 1 || (*saved)[0] != p {
+			t.Fatalf("expected the copy to be saved once, got %v", *saved)
+		}
+		if p.ID != "plan-rate-limiting-a1b2" || p.IssueID != "" || p.Status != plan.StatusDraft {
+			t.Errorf("expected a new unlinked draft, got ID %q, issue %q, status %q", p.ID, p.IssueID, p.Status)
+		}
+		if p.RelatedIssues != nil || p.Blocks != nil || p.BlockedBy != nil {
+			t.Errorf("expected no related issues, got %v, %v and %v", p.RelatedIssues, p.Blocks, p.BlockedBy)
+		}
+		if len(p.Phases) != 1 || p.Phases[0].IssueID != "" || p.Phases[0].Status != plan.PhasePending {
+			t.Errorf("expected a pending phase without a sub-issue, got %+v", p.Phases)
+		}
+		if p.Title != "Rate Limiting" || p.Author != "tester" || strings.Join(p.Labels, ",") != "backend" {
+			t.Errorf("expected the source's title, author and labels, got %+v", p)
+		}
+
+		// The body sections are identical and the frontmatter names the copy
+		parts := strings.SplitN(p.RawContent, "\n---\n", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) != strings.TrimSpace(body) {
+			t.Errorf("expected the source's body, got:\n%s", p.RawContent)
+		}
+		parsed, err := plan.Parse([]byte(p.RawContent))
+		if err != nil {
+			t.Fatalf("failed to parse the copy: %v", err)
+		}
+		if parsed.ID != p.ID || parsed.IssueID != "" {
+			t.Errorf("expected frontmatter for the copy, got ID %q and issue %q", parsed.ID, parsed.IssueID)
+		}
+	})
+
+	t.Run("leaves the source untouched", func(t *testing.T) {
+		deps, _ := newCopyDeps()
+		if _, err := copyPlanWithDeps("PLAN-1", "", deps); err != nil {
+			t.Fatalf("copyPlanWithDeps() error = %v", err)
+		}
+
+		src, _ := deps.getCachedPlan("PLAN-1")
+		if src.Plan.ID != "PLAN-1" || src.Plan.IssueID != "NUM-1" || src.Plan.Phases[0].IssueID != "NUM-2" {
+			t.Errorf("expected the source plan unchanged, got %+v", src.Plan)
+		}
+	})
+
+	t.Run("uses the given ID", func(t *testing.T) {
+		deps, _ := newCopyDeps()
+
+		p, err := copyPlanWithDeps("PLAN-1", "PLAN-2", deps)
+		if err != nil {
+			t.Fatalf("copyPlanWithDeps() error = %v", err)
+		}
+		if p.ID != "PLAN-2" {
+			t.Errorf("expected ID PLAN-2, got %q", p.ID)
+		}
+	})
+
+	t.Run("refuses an ID that is taken", func(t *testing.T) {
+		deps, saved := newCopyDeps()
+
+		for _, id := range []string{"PLAN-1", "PLAN-TAKEN"} {
+			_, err := copyPlanWithDeps("PLAN-1", id, deps)
+			if err == nil || !strings.Contains(err.Error(), "already exists") {
+				t.Errorf("copyPlanWithDeps(%s) error = %v, want already exists", id, err)
+			}
+		}
+		if len(*saved) != 0 {
+			t.Errorf("expected nothing saved, got %v", *saved)
+		}
+	})
+
+	t.Run("refuses an ID that can't name a file", func(t *testing.T) {
+		deps, saved := newCopyDeps()
+
+		for _, id := range []string{"../escape", "two words", ".hidden"} {
+			_, err := copyPlanWithDeps("PLAN-1", id, deps)
+			if err == nil || !strings.Contains(err.Error(), "invalid plan ID") {
+				t.Errorf("copyPlanWithDeps(%q) error = %v, want invalid plan ID", id, err)
+			}
+		}
+		if len(*saved) != 0 {
+			t.Errorf("expected nothing saved, got %v", *saved)
+		}
+	})
+
+	t.Run("plan not found", func(t *testing.T) {
+		deps, _ := newCopyDeps()
+
+		_, err := copyPlanWithDeps("PLAN-MISSING", "", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+}
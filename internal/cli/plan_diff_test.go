@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/linear"
+)
+
+func TestDiffPlanWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newDiffPlan parses a plan document with the given body sections
+	newDiffPlan := func(t *testing.T, issueID, sections string) *plan.Plan {
+		t.Helper()
+		p, err := plan.Parse([]byte(fmt.Sprintf(`---
+id: PLAN-1
+issue_id: %s
+title: Test Plan
+status: draft
+author: tester
+---
+
+# Test Plan
+
+%s`, issueID, sections)))
+		if err != nil {
+			t.Fatalf("failed to parse plan: %v", err)
+		}
+		return p
+	}
+
+	// syncedComment builds the Linear comment body a sync of p would produce
+	syncedComment := func(p *plan.Plan) *tracker.Comment {
+		return &tracker.Comment{
+			ID: "comment-1",
+			Body: "## 📋 Implementation Plan\n\n**Synced:** 2026-01-01 00:00 UTC\n\n---\n\n" +
+				linear.PlanBodyContent(p) +
+				"---\n\n*This plan was synced by [jig](https://github.com/charleslr/jig)*",
+		}
+	}
+
+	newDiffDeps := func(p *plan.Plan, comment *tracker.Comment) planDiffDeps {
+		return planDiffDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			getPlanComment: func(ctx context.Context, issueID string) (*tracker.Comment, error) {
+				return comment, nil
+			},
+		}
+	}
+
+	const baseSections = `## Problem Statement
+
+The problem.
+
+## Proposed Solution
+
+The solution.
+`
+	const criteriaSection = `
+## Acceptance Criteria
+
+- It works
+`
+
+	t.Run("identical plan has no diff", func(t *testing.T) {
+		p := newDiffPlan(t, "NUM-1", baseSections+criteriaSection)
+
+		diff, err := diffPlanWithDeps(ctx, "PLAN-1", newDiffDeps(p, syncedComment(p)))
+		if err != nil {
+			t.Fatalf("diffPlanWithDeps() error = %v", err)
+		}
+		if diff != "" {
+			t.Errorf("expected no diff, got:\n%s", diff)
+		}
+	})
+
+	t.Run("plan synced through a comment template has no diff", func(t *testing.T) {
+		p := newDiffPlan(t, "NUM-1", baseSections)
+		client := linear.NewClient("test-api-key", "", "")
+		client.PlanCommentTemplate = "### Why\n\n{problem}\n\n### How\n\n{solution}\n\n{synced}"
+		comment := &tracker.Comment{
+			ID:   "comment-1",
+			Body: "## 📋 Implementation Plan\n\n### Why\n\nThe problem.\n\n### How\n\nThe solution.\n\n**Synced:** 2026-01-01 00:00 UTC",
+		}
+
+		deps := newDiffDeps(p, comment)
+		deps.planBodyContent = client.PlanBodyContent
+		diff, err := diffPlanWithDeps(ctx, "PLAN-1", deps)
+		if err != nil {
+			t.Fatalf("diffPlanWithDeps() error = %v", err)
+		}
+		if diff != "" {
+			t.Errorf("expected no diff, got:\n%s", diff)
+		}
+	})
+
+	t.Run("section added locally", func(t *testing.T) {
+		synced := newDiffPlan(t, "NUM-1", baseSections)
+		p := newDiffPlan(t, "NUM-1", baseSections+criteriaSection)
+
+		diff, err := diffPlanWithDeps(ctx, "PLAN-1", newDiffDeps(p, syncedComment(synced)))
+		if err != nil {
+			t.Fatalf("diffPlanWithDeps() error = %v", err)
+		}
+		if !strings.HasPrefix(diff, "--- linear/NUM-1\n+++ local/PLAN-1\n") {
+			t.Errorf("expected diff headers, got:\n%s", diff)
+		}
+		for _, want := range []string{"+## Acceptance Criteria", "+- It works", " The solution."} {
+			if !strings.Contains(diff, want+"\n") {
+				t.Errorf("expected diff to contain %q, got:\n%s", want, diff)
+			}
+		}
+		if strings.Contains(diff, "\n-") {
+			t.Errorf("expected no removed lines, got:\n%s", diff)
+		}
+	})
+
+	t.Run("section removed locally", func(t *testing.T) {
+		synced := newDiffPlan(t, "NUM-1", baseSections+criteriaSection)
+		p := newDiffPlan(t, "NUM-1", baseSections)
+
+		diff, err := diffPlanWithDeps(ctx, "PLAN-1", newDiffDeps(p, syncedComment(synced)))
+		if err != nil {
+			t.Fatalf("diffPlanWithDeps() error = %v", err)
+		}
+		for _, want := range []string{"-## Acceptance Criteria", "-- It works"} {
+			if !strings.Contains(diff, want+"\n") {
+				t.Errorf("expected diff to contain %q, got:\n%s", want, diff)
+			}
+		}
+		if strings.Contains(diff, "\n+#") {
+			t.Errorf("expected no added sections, got:\n%s", diff)
+		}
+	})
+
+	t.Run("never synced plan is shown as added", func(t *testing.T) {
+		for _, issueID := range []string{"", "NUM-1"} {
+			p := newDiffPlan(t, issueID, baseSections)
+
+			diff, err := diffPlanWithDeps(ctx, "PLAN-1", newDiffDeps(p, nil))
+			if err != nil {
+				t.Fatalf("diffPlanWithDeps() error = %v", err)
+			}
+			if !strings.HasPrefix(diff, "--- linear/(not synced)\n") {
+				t.Errorf("issue %q: expected not-synced header, got:\n%s", issueID, diff)
+			}
+			for _, line := range strings.Split(strings.TrimSpace(diff), "\n")[3:] {
+				if !strings.HasPrefix(line, "+") {
+					t.Errorf("issue %q: expected only added lines, got %q", issueID, line)
+				}
+			}
+		}
+	})
+
+	t.Run("plan not found", func(t *testing.T) {
+		p := newDiffPlan(t, "NUM-1", baseSections)
+
+		_, err := diffPlanWithDeps(ctx, "PLAN-404", newDiffDeps(p, nil))
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+}
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+
+	want := `--- from
++++ to
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -8,3 +8,4 @@
+ h
+ i
+ j
++k
+`
+	if got := unifiedDiff("from", "to", from, to); got != want {
+		t.Errorf("unifiedDiff() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := unifiedDiff("from", "to", from, from); got != "" {
+		t.Errorf("expected empty diff for identical input, got:\n%s", got)
+	}
+}
+
+func TestPlanDiffExitCodes(t *testing.T) {
+	// Scripts must be able to tell a difference from a failure or from the
+	// code every other error exits with
+	err := withExitCode(exitCodeDiffFound, errors.New("PLAN-1 differs from its plan comment"))
+	if got := ExitCode(err); got == ExitCode(errors.New("failed")) || got == exitCodeDiffFailed {
+		t.Errorf("ExitCode() for a difference = %d, want a code of its own", got)
+	}
+}
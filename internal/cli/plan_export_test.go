@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setPlanExportFlags sets the export flags for the duration of a test
+func setPlanExportFlags(t *testing.T, output, format string, force bool) {
+	t.Helper()
+	planExportOutput, planExportFormat, planExportForce = output, format, force
+	t.Cleanup(func() {
+		planExportOutput, planExportFormat, planExportForce = "", "markdown", false
+	})
+}
+
+func TestRunPlanExport(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	createTestPlanInCache(t, jigHome, "PLAN-EXPORT")
+	outDir := t.TempDir()
+
+	t.Run("exports markdown to a file, creating parent dirs", func(t *testing.T) {
+		path := filepath.Join(outDir, "nested", "dir", "plan.md")
+		setPlanExportFlags(t, path, "markdown", false)
+
+		if err := runPlanExport(planExportCmd, []string{"PLAN-EXPORT"}); err != nil {
+			t.Fatalf("runPlanExport() error = %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read exported file: %v", err)
+		}
+		if !strings.Contains(string(data), "id: PLAN-EXPORT") {
+			t.Errorf("expected frontmatter in export, got:\n%s", data)
+		}
+		if !strings.Contains(string(data), "Test problem") {
+			t.Errorf("expected plan body in export, got:\n%s", data)
+		}
+	})
+
+	t.Run("names the file after the plan when output is a directory", func(t *testing.T) {
+		dir := filepath.Join(outDir, "dir-output")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		setPlanExportFlags(t, dir, "markdown", false)
+
+		if err := runPlanExport(planExportCmd, []string{"PLAN-EXPORT"}); err != nil {
+			t.Fatalf("runPlanExport() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "PLAN-EXPORT.md")); err != nil {
+			t.Errorf("expected PLAN-EXPORT.md in output directory: %v", err)
+		}
+	})
+
+	t.Run("exports json", func(t *testing.T) {
+		path := filepath.Join(outDir, "plan.json")
+		setPlanExportFlags(t, path, "json", false)
+
+		if err := runPlanExport(planExportCmd, []string{"PLAN-EXPORT"}); err != nil {
+			t.Fatalf("runPlanExport() error = %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read exported file: %v", err)
+		}
+		var exported map[string]interface{}
+		if err := json.Unmarshal(data, &exported); err != nil {
+			t.Fatalf("export is not valid JSON: %v\n%s", err, data)
+		}
+		if exported["id"] != "PLAN-EXPORT" || exported["title"] != "Test Plan" {
+			t.Errorf("unexpected export: %v", exported)
+		}
+		if md, _ := exported["markdown"].(string); !strings.Contains(md, "Test problem") {
+			t.Errorf("expected markdown field to contain plan body, got %q", md)
+		}
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		path := filepath.Join(outDir, "existing.md")
+		if err := os.WriteFile(path, []byte("keep me"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		setPlanExportFlags(t, path, "markdown", false)
+
+		err := runPlanExport(planExportCmd, []string{"PLAN-EXPORT"})
+		if err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Fatalf("expected overwrite error mentioning --force, got: %v", err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "keep me" {
+			t.Error("existing file should not be modified")
+		}
+
+		setPlanExportFlags(t, path, "markdown", true)
+		if err := runPlanExport(planExportCmd, []string{"PLAN-EXPORT"}); err != nil {
+			t.Fatalf("runPlanExport() with force error = %v", err)
+		}
+		if data, _ := os.ReadFile(path); string(data) == "keep me" {
+			t.Error("expected file to be overwritten with --force")
+		}
+	})
+
+	t.Run("returns error for missing plan", func(t *testing.T) {
+		setPlanExportFlags(t, filepath.Join(outDir, "missing.md"), "markdown", false)
+
+		err := runPlanExport(planExportCmd, []string{"PLAN-MISSING"})
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected 'plan not found' error, got: %v", err)
+		}
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		setPlanExportFlags(t, filepath.Join(outDir, "plan.txt"), "txt", false)
+
+		err := runPlanExport(planExportCmd, []string{"PLAN-EXPORT"})
+		if err == nil || !strings.Contains(err.Error(), "invalid format") {
+			t.Errorf("expected 'invalid format' error, got: %v", err)
+		}
+	})
+}
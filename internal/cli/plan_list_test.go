@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "72h", want: now.Add(-72 * time.Hour)},
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: "7d", want: time.Date(2024, 3, 3, 15, 30, 0, 0, time.UTC)},
+		{value: "0d", want: now},
+		{value: "2024-01-01", want: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2024-01-01T12:00:00Z", want: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{value: "-3d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "last week", wantErr: true},
+		{value: "2024-13-01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid --since value") {
+					t.Errorf("parseSince(%q) error = %v, want invalid value error", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSince(%q) error = %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPlansSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cachedPlans := []*state.CachedPlan{
+		{Plan: &plan.Plan{ID: "PLAN-old"}, UpdatedAt: since.Add(-time.Second)},
+		{Plan: &plan.Plan{ID: "PLAN-boundary"}, UpdatedAt: since},
+		{Plan: &plan.Plan{ID: "PLAN-new"}, UpdatedAt: since.Add(time.Hour)},
+		// No update time: judged by CachedAt
+		{Plan: &plan.Plan{ID: "PLAN-cached-recently"}, CachedAt: since.Add(time.Hour)},
+		{Plan: &plan.Plan{ID: "PLAN-cached-long-ago"}, CachedAt: since.Add(-time.Hour)},
+		// An old update wins over a recent CachedAt
+		{Plan: &plan.Plan{ID: "PLAN-recached"}, UpdatedAt: since.Add(-time.Hour), CachedAt: since.Add(time.Hour)},
+	}
+
+	var ids []string
+	for _, cp := range filterPlansSince(cachedPlans, since) {
+		ids = append(ids, cp.Plan.ID)
+	}
+
+	if got := strings.Join(ids, ","); got != "PLAN-boundary,PLAN-new,PLAN-cached-recently" {
+		t.Errorf("filterPlansSince() = %s", got)
+	}
+}
+
+func TestOrderPlanList(t *testing.T) {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// newPlans returns plans whose creation, update, title and status orders
+	// all differ
+	newPlans := func() []*state.CachedPlan {
+		return []*state.CachedPlan{
+			{Plan: &plan.Plan{ID: "PLAN-a", Title: "banana", Status: plan.StatusComplete, Created: base.Add(2 * time.Hour)}, UpdatedAt: base.Add(5 * time.Hour)},
+			{Plan: &plan.Plan{ID: "PLAN-b", Title: "Apple", Status: plan.StatusInProgress, Created: base}, UpdatedAt: base.Add(9 * time.Hour)},
+			{Plan: &plan.Plan{ID: "PLAN-c", Title: "cherry", Status: plan.StatusApproved, Created: base.Add(time.Hour)}, UpdatedAt: base.Add(7 * time.Hour)},
+			// No status is a draft; no times fall back to when it was cached
+			{Plan: &plan.Plan{ID: "PLAN-d", Title: "date"}, CachedAt: base.Add(3 * time.Hour)},
+		}
+	}
+
+	ids := func(cachedPlans []*state.CachedPlan) string {
+		var names []string
+		for _, cp := range cachedPlans {
+			names = append(names, cp.Plan.ID)
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		key     string
+		reverse bool
+		limit   int
+		want    string
+	}{
+		{key: "updated", want: "PLAN-b,PLAN-c,PLAN-a,PLAN-d"},
+		{key: "created", want: "PLAN-d,PLAN-a,PLAN-c,PLAN-b"},
+		{key: "title", want: "PLAN-b,PLAN-a,PLAN-c,PLAN-d"},
+		{key: "status", want: "PLAN-d,PLAN-c,PLAN-b,PLAN-a"},
+		{key: "updated", reverse: true, want: "PLAN-d,PLAN-a,PLAN-c,PLAN-b"},
+		{key: "title", reverse: true, want: "PLAN-d,PLAN-c,PLAN-a,PLAN-b"},
+		{key: "updated", limit: 2, want: "PLAN-b,PLAN-c"},
+		{key: "status", reverse: true, limit: 1, want: "PLAN-a"},
+		{key: "title", limit: 10, want: "PLAN-b,PLAN-a,PLAN-c,PLAN-d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s reverse=%v limit=%d", tt.key, tt.reverse, tt.limit), func(t *testing.T) {
+			got, err := orderPlanList(newPlans(), tt.key, tt.reverse, tt.limit)
+			if err != nil {
+				t.Fatalf("orderPlanList() error = %v", err)
+			}
+			if ids(got) != tt.want {
+				t.Errorf("orderPlanList() = %s, want %s", ids(got), tt.want)
+			}
+		})
+	}
+
+	t.Run("breaks ties by plan ID", func(t *testing.T) {
+		tied := []*state.CachedPlan{
+			{Plan: &plan.Plan{ID: "PLAN-2", Status: plan.StatusDraft}},
+			{Plan: &plan.Plan{ID: "PLAN-1", Status: plan.StatusDraft}},
+		}
+		got, _ := orderPlanList(tied, "status", false, 0)
+		if ids(got) != "PLAN-1,PLAN-2" {
+			t.Errorf("orderPlanList() = %s, want PLAN-1,PLAN-2", ids(got))
+		}
+	})
+
+	t.Run("rejects an unknown sort key", func(t *testing.T) {
+		_, err := orderPlanList(newPlans(), "priority", false, 0)
+		if err == nil || !strings.Contains(err.Error(), "must be one of: created, updated, title, status") {
+			t.Errorf("expected an invalid --sort error, got %v", err)
+		}
+	})
+
+	t.Run("rejects a negative limit", func(t *testing.T) {
+		if _, err := orderPlanList(newPlans(), "updated", false, -1); err == nil {
+			t.Error("expected an error for a negative limit")
+		}
+	})
+}
+
+func TestWritePlanListJSON_Criteria(t *testing.T) {
+	cachedPlans := []*state.CachedPlan{
+		{Plan: &plan.Plan{ID: "PLAN-1", RawContent: "---\ntitle: One\n---\n\n## Acceptance Criteria\n\n- [x] Done\n- [ ] Not done\n"}},
+		{Plan: &plan.Plan{ID: "PLAN-2", RawContent: "---\ntitle: Two\n---\n\n## Acceptance Criteria\n\n- No checkbox\n"}},
+	}
+
+	var buf bytes.Buffer
+	if err := writePlanListJSON(&buf, cachedPlans); err != nil {
+		t.Fatalf("writePlanListJSON() error = %v", err)
+	}
+
+	var entries []planListEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if c := entries[0].Criteria; c == nil || c.Done != 1 || c.Total != 2 {
+		t.Errorf("expected 1/2 criteria for PLAN-1, got %+v", c)
+	}
+	if entries[1].Criteria != nil || strings.Contains(buf.String(), `"acceptance_criteria": null`) {
+		t.Errorf("expected no criteria for PLAN-2, got %+v", entries[1].Criteria)
+	}
+}
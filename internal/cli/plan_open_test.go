@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestOpenPlanIssueWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newOpenDeps returns deps serving a cached plan linked to issueID and
+	// recording the URLs passed to the opener
+	newOpenDeps := func(issueID string, tr tracker.Tracker) (planOpenDeps, *[]string) {
+		p := &plan.Plan{ID: "PLAN-1", IssueID: issueID, Title: "Test Plan"}
+
+		var opened []string
+		deps := planOpenDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			getTracker: func() (tracker.Tracker, error) { return tr, nil },
+			openURL: func(url string) error {
+				opened = append(opened, url)
+				return nil
+			},
+		}
+		return deps, &opened
+	}
+
+	newIssue := func(t *testing.T, client *mock.Client) *tracker.Issue {
+		t.Helper()
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		return issue
+	}
+
+	t.Run("opens the issue URL", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client)
+		deps, opened := newOpenDeps(issue.Identifier, client)
+
+		result, err := openPlanIssueWithDeps(ctx, "PLAN-1", deps)
+		if err != nil {
+			t.Fatalf("openPlanIssueWithDeps() error = %v", err)
+		}
+		if !result.Opened || result.URL != issue.URL {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if len(*opened) != 1 || (*opened)[0] != "https://mock.linear.app/issue/MOCK-1" {
+			t.Errorf("expected issue URL to be opened, got %v", *opened)
+		}
+	})
+
+	t.Run("only resolves the URL without an opener", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client)
+		deps, _ := newOpenDeps(issue.Identifier, client)
+		deps.openURL = nil
+
+		result, err := openPlanIssueWithDeps(ctx, "PLAN-1", deps)
+		if err != nil {
+			t.Fatalf("openPlanIssueWithDeps() error = %v", err)
+		}
+		if result.Opened || result.URL != issue.URL {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("fails for a plan without an issue", func(t *testing.T) {
+		deps, opened := newOpenDeps("", mock.NewClient())
+		deps.getTracker = func() (tracker.Tracker, error) {
+			t.Error("tracker should not be needed for an unlinked plan")
+			return nil, nil
+		}
+
+		_, err := openPlanIssueWithDeps(ctx, "PLAN-1", deps)
+		if err == nil || !strings.Contains(err.Error(), "not linked to an issue") {
+			t.Errorf("expected not linked error, got %v", err)
+		}
+		if len(*opened) != 0 {
+			t.Errorf("expected nothing to be opened, got %v", *opened)
+		}
+	})
+
+	t.Run("fails for an unknown plan", func(t *testing.T) {
+		deps, _ := newOpenDeps("MOCK-1", mock.NewClient())
+
+		_, err := openPlanIssueWithDeps(ctx, "PLAN-404", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+
+	t.Run("propagates opener errors", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client)
+		deps, _ := newOpenDeps(issue.Identifier, client)
+		deps.openURL = func(url string) error { return errors.New("xdg-open not found") }
+
+		_, err := openPlanIssueWithDeps(ctx, "PLAN-1", deps)
+		if err == nil || !strings.Contains(err.Error(), "xdg-open not found") {
+			t.Errorf("expected opener error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+)
+
+const phasedPlanContent = `---
+id: PLAN-1
+title: Phased Plan
+status: in-progress
+author: tester
+phases:
+  - id: phase-1
+    title: Add schema
+    status: in-progress
+  - id: phase-2
+    title: Write docs
+    status: pending
+---
+
+# Phased Plan
+
+## Problem Statement
+
+Problem.
+
+## Proposed Solution
+
+Solution.
+`
+
+// newPhaseDeps returns deps serving a freshly parsed phased plan and
+// recording the plan passed to savePlan
+func newPhaseDeps(t *testing.T) (planPhaseDeps, **plan.Plan) {
+	t.Helper()
+	p, err := plan.Parse([]byte(phasedPlanContent))
+	if err != nil {
+		t.Fatalf("failed to parse plan: %v", err)
+	}
+	p.Updated = time.Now().Add(-time.Hour)
+
+	var saved *plan.Plan
+	deps := planPhaseDeps{
+		getCachedPlan: func(id string) (*state.CachedPlan, error) {
+			if id != p.ID {
+				return nil, nil
+			}
+			return &state.CachedPlan{Plan: p, UpdatedAt: p.Updated}, nil
+		},
+		savePlan: func(p *plan.Plan) error {
+			saved = p
+			return nil
+		},
+	}
+	return deps, &saved
+}
+
+func TestSetPlanPhaseStatusWithDeps(t *testing.T) {
+	t.Run("updates phase status and saves plan", func(t *testing.T) {
+		deps, saved := newPhaseDeps(t)
+		before := time.Now()
+
+		p, err := setPlanPhaseStatusWithDeps("PLAN-1", "phase-1", "done", deps)
+		if err != nil {
+			t.Fatalf("setPlanPhaseStatusWithDeps() error = %v", err)
+		}
+
+		if *saved == nil {
+			t.Fatal("expected plan to be saved")
+		}
+		if p.Phases[0].Status != plan.PhaseDone {
+			t.Errorf("expected phase-1 done, got %s", p.Phases[0].Status)
+		}
+		if p.Updated.Before(before) {
+			t.Error("expected Updated to be bumped so the plan needs sync")
+		}
+
+		// The refreshed raw content should carry the new phase status
+		reparsed, err := plan.Parse([]byte(p.RawContent))
+		if err != nil {
+			t.Fatalf("failed to parse refreshed raw content: %v", err)
+		}
+		if reparsed.Phases[0].Status != plan.PhaseDone {
+			t.Errorf("expected raw content to reflect new status, got %s", reparsed.Phases[0].Status)
+		}
+		if !strings.Contains(p.RawContent, "## Problem Statement") {
+			t.Error("expected markdown body to be preserved")
+		}
+	})
+
+	t.Run("returns error for unknown phase", func(t *testing.T) {
+		deps, saved := newPhaseDeps(t)
+
+		_, err := setPlanPhaseStatusWithDeps("PLAN-1", "phase-9", "done", deps)
+		if err == nil {
+			t.Fatal("expected error for unknown phase")
+		}
+		if !strings.Contains(err.Error(), "phase not found: phase-9") {
+			t.Errorf("expected 'phase not found' error, got: %v", err)
+		}
+		if *saved != nil {
+			t.Error("plan should not be saved")
+		}
+	})
+
+	t.Run("returns error for invalid status", func(t *testing.T) {
+		deps, saved := newPhaseDeps(t)
+
+		_, err := setPlanPhaseStatusWithDeps("PLAN-1", "phase-1", "finished", deps)
+		if err == nil {
+			t.Fatal("expected error for invalid status")
+		}
+		if !strings.Contains(err.Error(), "invalid phase status") {
+			t.Errorf("expected 'invalid phase status' error, got: %v", err)
+		}
+		if *saved != nil {
+			t.Error("plan should not be saved")
+		}
+	})
+
+	t.Run("returns error when plan not found", func(t *testing.T) {
+		deps, _ := newPhaseDeps(t)
+
+		_, err := setPlanPhaseStatusWithDeps("PLAN-missing", "phase-1", "done", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected 'plan not found' error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	trackerMock "github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestPullPlanStatusWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newPullDeps seeds a mock tracker issue with the given status and returns
+	// deps backed by an in-memory plan store
+	newPullDeps := func(t *testing.T, p *plan.Plan, status tracker.Status) (planPullDeps, map[string]*plan.Plan) {
+		t.Helper()
+		mockTracker := trackerMock.NewClient()
+		issue, err := mockTracker.CreateIssue(ctx, &tracker.Issue{Title: "Issue"})
+		if err != nil {
+			t.Fatalf("failed to create mock issue: %v", err)
+		}
+		if err := mockTracker.TransitionIssue(ctx, issue.ID, status); err != nil {
+			t.Fatalf("failed to transition mock issue: %v", err)
+		}
+		if p.IssueID == "" {
+			p.IssueID = issue.Identifier
+		}
+
+		saved := make(map[string]*plan.Plan)
+		deps := planPullDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			savePlan: func(p *plan.Plan) error {
+				saved[p.ID] = p
+				return nil
+			},
+			getIssue: mockTracker.GetIssue,
+		}
+		return deps, saved
+	}
+
+	t.Run("writes issue status through to the plan", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-1", "Test", "tester")
+		p.Status = plan.StatusInProgress
+		p.ProblemStatement = "Local problem statement"
+		deps, saved := newPullDeps(t, p, tracker.StatusDone)
+
+		result, err := pullPlanStatusWithDeps(ctx, "PLAN-1", deps)
+		if err != nil {
+			t.Fatalf("pullPlanStatusWithDeps() error = %v", err)
+		}
+
+		if !result.Changed {
+			t.Error("expected status to change")
+		}
+		if result.PreviousStatus != plan.StatusInProgress || result.NewStatus != plan.StatusComplete {
+			t.Errorf("expected in-progress → complete, got %s → %s", result.PreviousStatus, result.NewStatus)
+		}
+		savedPlan, ok := saved["PLAN-1"]
+		if !ok {
+			t.Fatal("expected plan to be saved")
+		}
+		if savedPlan.Status != plan.StatusComplete {
+			t.Errorf("expected saved status complete, got %s", savedPlan.Status)
+		}
+		if savedPlan.ProblemStatement != "Local problem statement" {
+			t.Errorf("local fields should be preserved, got %q", savedPlan.ProblemStatement)
+		}
+	})
+
+	t.Run("does not save when status is consistent", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-2", "Test", "tester")
+		p.Status = plan.StatusApproved
+		deps, saved := newPullDeps(t, p, tracker.StatusTodo)
+
+		result, err := pullPlanStatusWithDeps(ctx, "PLAN-2", deps)
+		if err != nil {
+			t.Fatalf("pullPlanStatusWithDeps() error = %v", err)
+		}
+		if result.Changed {
+			t.Error("expected no change for approved plan with todo issue")
+		}
+		if len(saved) != 0 {
+			t.Error("expected plan not to be saved")
+		}
+	})
+
+	t.Run("returns error when plan has no linked issue", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-3", "Test", "tester")
+		deps := planPullDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				return &state.CachedPlan{Plan: p}, nil
+			},
+		}
+
+		_, err := pullPlanStatusWithDeps(ctx, "PLAN-3", deps)
+		if err == nil {
+			t.Fatal("expected error for plan without linked issue")
+		}
+		if !strings.Contains(err.Error(), "no linked issue") {
+			t.Errorf("expected 'no linked issue' error, got: %v", err)
+		}
+	})
+
+	t.Run("returns error when plan not found", func(t *testing.T) {
+		deps := planPullDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) { return nil, nil },
+		}
+
+		_, err := pullPlanStatusWithDeps(ctx, "PLAN-missing", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected 'plan not found' error, got: %v", err)
+		}
+	})
+
+	t.Run("returns error when issue fetch fails", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-4", "Test", "tester")
+		p.IssueID = "NUM-404"
+		deps := planPullDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			getIssue: func(ctx context.Context, id string) (*tracker.Issue, error) {
+				return nil, fmt.Errorf("issue not found: %s", id)
+			},
+		}
+
+		_, err := pullPlanStatusWithDeps(ctx, "PLAN-4", deps)
+		if err == nil || !strings.Contains(err.Error(), "NUM-404") {
+			t.Errorf("expected error mentioning issue, got: %v", err)
+		}
+	})
+}
+
+func TestTrackerStatusToPlanStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  tracker.Status
+		current plan.Status
+		want    plan.Status
+	}{
+		{"todo keeps draft", tracker.StatusTodo, plan.StatusDraft, plan.StatusDraft},
+		{"todo keeps approved", tracker.StatusTodo, plan.StatusApproved, plan.StatusApproved},
+		{"backlog resets in-progress to draft", tracker.StatusBacklog, plan.StatusInProgress, plan.StatusDraft},
+		{"in progress", tracker.StatusInProgress, plan.StatusApproved, plan.StatusInProgress},
+		{"in review", tracker.StatusInReview, plan.StatusInProgress, plan.StatusInReview},
+		{"done", tracker.StatusDone, plan.StatusInReview, plan.StatusComplete},
+		{"canceled keeps current", tracker.StatusCanceled, plan.StatusInProgress, plan.StatusInProgress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trackerStatusToPlanStatus(tt.status, tt.current); got != tt.want {
+				t.Errorf("trackerStatusToPlanStatus(%s, %s) = %s, want %s", tt.status, tt.current, got, tt.want)
+			}
+		})
+	}
+}
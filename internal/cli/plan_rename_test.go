@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestRenamePlanWithDeps(t *testing.T) {
+	ctx := context.Background()
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// newRenameDeps returns deps serving a cached plan linked to issueID and
+	// recording the plan passed to savePlan
+	newRenameDeps := func(issueID string, tr tracker.Tracker) (planRenameDeps, **plan.Plan) {
+		p := &plan.Plan{
+			ID:         "PLAN-1",
+			IssueID:    issueID,
+			Title:      "Old Title",
+			Status:     plan.StatusDraft,
+			Author:     "tester",
+			Updated:    updated,
+			RawContent: "---\ntitle: Old Title\n---\n\n# Old Title\n\n## Problem Statement\n\nProblem.\n",
+		}
+
+		var saved *plan.Plan
+		deps := planRenameDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			savePlan: func(p *plan.Plan) error {
+				saved = p
+				return nil
+			},
+		}
+		if tr != nil {
+			deps.getTracker = func() (tracker.Tracker, error) { return tr, nil }
+		}
+		return deps, &saved
+	}
+
+	t.Run("renames the plan locally", func(t *testing.T) {
+		deps, saved := newRenameDeps("", nil)
+
+		result, err := renamePlanWithDeps(ctx, "PLAN-1", "  New Title ", deps)
+		if err != nil {
+			t.Fatalf("renamePlanWithDeps() error = %v", err)
+		}
+
+		if *saved == nil || (*saved).Title != "New Title" {
+			t.Fatalf("expected plan to be saved as New Title, got %+v", *saved)
+		}
+		if !(*saved).Updated.After(updated) {
+			t.Errorf("expected Updated to be bumped, got %v", (*saved).Updated)
+		}
+		for _, want := range []string{"title: New Title", "# New Title\n"} {
+			if !strings.Contains((*saved).RawContent, want) {
+				t.Errorf("expected raw content to contain %q, got:\n%s", want, (*saved).RawContent)
+			}
+		}
+		if strings.Contains((*saved).RawContent, "Old Title") {
+			t.Errorf("expected old title to be gone, got:\n%s", (*saved).RawContent)
+		}
+		if result.IssueUpdated || result.TrackerError != nil {
+			t.Errorf("expected only a local update, got %+v", result)
+		}
+	})
+
+	t.Run("renames the linked issue", func(t *testing.T) {
+		client := mock.NewClient()
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Old Title"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		deps, saved := newRenameDeps(issue.ID, client)
+
+		result, err := renamePlanWithDeps(ctx, "PLAN-1", "New Title", deps)
+		if err != nil {
+			t.Fatalf("renamePlanWithDeps() error = %v", err)
+		}
+		if *saved == nil {
+			t.Fatal("expected plan to be saved")
+		}
+		if !result.IssueUpdated || result.TrackerError != nil {
+			t.Errorf("expected issue to be updated, got %+v", result)
+		}
+
+		got, _ := client.GetIssue(ctx, issue.ID)
+		if got.Title != "New Title" {
+			t.Errorf("expected issue title New Title, got %q", got.Title)
+		}
+	})
+
+	t.Run("reports partial success when the issue update fails", func(t *testing.T) {
+		// The mock tracker has no such issue, so the update fails
+		deps, saved := newRenameDeps("MOCK-404", mock.NewClient())
+
+		result, err := renamePlanWithDeps(ctx, "PLAN-1", "New Title", deps)
+		if err != nil {
+			t.Fatalf("renamePlanWithDeps() error = %v", err)
+		}
+		if *saved == nil {
+			t.Error("expected plan to be saved despite the tracker failure")
+		}
+		if result.IssueUpdated || result.TrackerError == nil {
+			t.Errorf("expected tracker error, got %+v", result)
+		}
+	})
+
+	t.Run("rejects an empty title", func(t *testing.T) {
+		deps, saved := newRenameDeps("", nil)
+
+		_, err := renamePlanWithDeps(ctx, "PLAN-1", "   ", deps)
+		if err == nil || !strings.Contains(err.Error(), "title must not be empty") {
+			t.Errorf("expected empty title error, got %v", err)
+		}
+		if *saved != nil {
+			t.Error("expected plan not to be saved")
+		}
+	})
+
+	t.Run("missing plan", func(t *testing.T) {
+		deps, _ := newRenameDeps("", nil)
+
+		_, err := renamePlanWithDeps(ctx, "PLAN-404", "New Title", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found: PLAN-404") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+}
+
+func TestRenamePlanHeading(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "matching heading",
+			content: "# Old Title\n\nBody\n",
+			want:    "# New Title\n\nBody\n",
+		},
+		{
+			name:    "heading edited by hand",
+			content: "# Something Else\n\n# Old Title\n",
+			want:    "# Something Else\n\n# Old Title\n",
+		},
+		{
+			name:    "no heading",
+			content: "## Old Title\n",
+			want:    "## Old Title\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renamePlanHeading(tt.content, "Old Title", "New Title"); got != tt.want {
+				t.Errorf("renamePlanHeading() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+	"github.com/charleslr/jig/internal/ui"
+)
+
+func TestWritePlanShow(t *testing.T) {
+	estimate := 3
+	p := &plan.Plan{
+		ID:      "PLAN-1",
+		IssueID: "NUM-1",
+		Title:   "Add retries",
+		Status:  plan.StatusInProgress,
+		Author:  "tester",
+		Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Phases: []plan.Phase{
+			{ID: "p1", Title: "Client", Status: plan.PhaseDone, Estimate: &estimate},
+			{ID: "p2", Title: "Server", Status: plan.PhasePending},
+		},
+		Labels:           []string{"backend"},
+		ProblemStatement: "Requests fail under load.",
+		ProposedSolution: "Retry them with backoff.",
+		RawContent:       "---\ntitle: Add retries\n---\n\n## Acceptance Criteria\n\n- [x] Retries are capped\n- [ ] Backoff is jittered\n",
+	}
+	want := newPlanShowDocument(p)
+	if want.Progress != (planShowProgress{PhasesDone: 1, PhasesTotal: 2, CriteriaDone: 1, CriteriaTotal: 2}) {
+		t.Fatalf("unexpected progress %+v", want.Progress)
+	}
+
+	unmarshal := map[string]func([]byte, interface{}) error{
+		"json": json.Unmarshal,
+		"yaml": yaml.Unmarshal,
+	}
+	for format, decode := range unmarshal {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writePlanShow(&buf, p, format); err != nil {
+				t.Fatalf("writePlanShow() error = %v", err)
+			}
+
+			var got planShowDocument
+			if err := decode(buf.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode %s output: %v\n%s", format, err, buf.String())
+			}
+			if !got.Created.Equal(want.Created) {
+				t.Errorf("Created = %v, want %v", got.Created, want.Created)
+			}
+			got.Created, got.Updated = want.Created, want.Updated
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("decoded %s = %+v, want %+v", format, got, want)
+			}
+		})
+	}
+
+	t.Run("json uses snake_case keys", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writePlanShow(&buf, p, "json"); err != nil {
+			t.Fatalf("writePlanShow() error = %v", err)
+		}
+		for _, key := range []string{`"issue_id": "NUM-1"`, `"problem_statement"`, `"criteria_done": 1`} {
+			if !strings.Contains(buf.String(), key) {
+				t.Errorf("expected output to contain %s, got:\n%s", key, buf.String())
+			}
+		}
+	})
+}
+
+func TestRunPlanShow_Format(t *testing.T) {
+	p := plan.NewPlan("PLAN-1", "Add retries", "tester")
+	p.ProblemStatement = "Requests fail under load."
+	setupLookupCache(t, p)
+
+	oldFormat, oldRaw := planShowFormat, planShowRaw
+	defer func() { planShowFormat, planShowRaw = oldFormat, oldRaw }()
+
+	planShowFormat = "json"
+	output, err := captureStdout(t, func() error { return runPlanShow(planShowCmd, []string{"PLAN-1"}) })
+	if err != nil {
+		t.Fatalf("runPlanShow() error = %v", err)
+	}
+	var doc planShowDocument
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("expected JSON output, got %v:\n%s", err, output)
+	}
+	if doc.ID != "PLAN-1" || doc.ProblemStatement != "Requests fail under load." {
+		t.Errorf("unexpected document %+v", doc)
+	}
+
+	planShowFormat = "toml"
+	if _, err := captureStdout(t, func() error { return runPlanShow(planShowCmd, []string{"PLAN-1"}) }); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+
+	planShowFormat, planShowRaw = "yaml", true
+	if _, err := captureStdout(t, func() error { return runPlanShow(planShowCmd, []string{"PLAN-1"}) }); err == nil {
+		t.Error("expected an error for --raw with --format yaml")
+	}
+}
+
+func TestFetchPlanComments(t *testing.T) {
+	ctx := context.Background()
+	client := mock.NewClient()
+	issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Add retries"})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	for _, body := range []string{"Should we cap retries?", "Yes, at three."} {
+		if _, err := client.AddComment(ctx, issue.ID, body); err != nil {
+			t.Fatalf("AddComment() error = %v", err)
+		}
+	}
+	getTracker := func() (tracker.Tracker, error) { return client, nil }
+
+	t.Run("fetches and renders the linked issue's comments", func(t *testing.T) {
+		comments, err := fetchPlanComments(ctx, &plan.Plan{ID: "PLAN-1", IssueID: issue.Identifier}, getTracker)
+		if err != nil {
+			t.Fatalf("fetchPlanComments() error = %v", err)
+		}
+		if len(comments) != 2 {
+			t.Fatalf("expected 2 comments, got %d", len(comments))
+		}
+
+		md := ui.CommentsMarkdown(comments)
+		for _, want := range []string{"## Comments (2)", "**mock-user**", "Should we cap retries?", "Yes, at three."} {
+			if !strings.Contains(md, want) {
+				t.Errorf("expected rendered comments to contain %q, got:\n%s", want, md)
+			}
+		}
+	})
+
+	t.Run("skips plans without a linked issue", func(t *testing.T) {
+		called := false
+		comments, err := fetchPlanComments(ctx, &plan.Plan{ID: "PLAN-1"}, func() (tracker.Tracker, error) {
+			called = true
+			return client, nil
+		})
+		if err != nil || comments != nil || called {
+			t.Errorf("fetchPlanComments() = %v, %v (tracker called: %v), want no comments", comments, err, called)
+		}
+	})
+
+	t.Run("skips when no tracker is configured", func(t *testing.T) {
+		comments, err := fetchPlanComments(ctx, &plan.Plan{ID: "PLAN-1", IssueID: issue.Identifier}, nil)
+		if err != nil || comments != nil {
+			t.Errorf("fetchPlanComments() = %v, %v, want no comments", comments, err)
+		}
+	})
+
+	t.Run("returns tracker errors", func(t *testing.T) {
+		_, err := fetchPlanComments(ctx, &plan.Plan{ID: "PLAN-1", IssueID: issue.Identifier}, func() (tracker.Tracker, error) {
+			return nil, errors.New("Linear API key not configured")
+		})
+		if err == nil || !strings.Contains(err.Error(), "API key") {
+			t.Errorf("fetchPlanComments() error = %v, want the tracker error", err)
+		}
+	})
+}
+
+func TestWritePlanHTML(t *testing.T) {
+	dir := t.TempDir()
+	p := &plan.Plan{
+		ID:         "PLAN-1",
+		Title:      "Add retries",
+		Status:     plan.StatusDraft,
+		RawContent: "---\nid: PLAN-1\ntitle: Add retries\n---\n\n# Add retries\n\n## Proposed Solution\n\nRetry with backoff.\n",
+	}
+
+	path, err := writePlanHTML(dir, p, nil)
+	if err != nil {
+		t.Fatalf("writePlanHTML() error = %v", err)
+	}
+	if filepath.Dir(path) != dir || !strings.HasPrefix(filepath.Base(path), "jig-PLAN-1-") || filepath.Ext(path) != ".html" {
+		t.Errorf("expected a new HTML file for the plan in %s, got %s", dir, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read HTML file: %v", err)
+	}
+	for _, want := range []string{"<title>Add retries</title>", "<h2>Proposed Solution</h2>"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected the page to contain %q, got:\n%s", want, data)
+		}
+	}
+
+	// Each render gets a file of its own
+	again, err := writePlanHTML(dir, p, nil)
+	if err != nil {
+		t.Fatalf("writePlanHTML() error = %v", err)
+	}
+	if again == path {
+		t.Error("expected a new file for each render")
+	}
+
+	t.Run("defaults to the temporary directory", func(t *testing.T) {
+		t.Setenv("TMPDIR", dir)
+		path, err := writePlanHTML("", p, nil)
+		if err != nil {
+			t.Fatalf("writePlanHTML() error = %v", err)
+		}
+		if filepath.Dir(path) != filepath.Clean(os.TempDir()) {
+			t.Errorf("expected the file in %s, got %s", os.TempDir(), path)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestSetPlanStatusWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newStatusDeps returns deps serving a cached plan linked to issueID and
+	// recording the plan passed to savePlan
+	newStatusDeps := func(issueID string, tr tracker.Tracker) (planStatusDeps, **plan.Plan) {
+		p := &plan.Plan{
+			ID:               "PLAN-1",
+			IssueID:          issueID,
+			Title:            "Test Plan",
+			Status:           plan.StatusInProgress,
+			Author:           "tester",
+			ProblemStatement: "Problem.",
+			ProposedSolution: "Solution.",
+		}
+
+		var saved *plan.Plan
+		deps := planStatusDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			savePlan: func(p *plan.Plan) error {
+				saved = p
+				return nil
+			},
+		}
+		if tr != nil {
+			deps.getTracker = func() (tracker.Tracker, error) { return tr, nil }
+		}
+		return deps, &saved
+	}
+
+	t.Run("updates plan and transitions linked issue", func(t *testing.T) {
+		client := mock.NewClient()
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		deps, saved := newStatusDeps(issue.ID, client)
+
+		result, err := setPlanStatusWithDeps(ctx, "PLAN-1", "in-review", deps)
+		if err != nil {
+			t.Fatalf("setPlanStatusWithDeps() error = %v", err)
+		}
+
+		if *saved == nil || (*saved).Status != plan.StatusInReview {
+			t.Fatalf("expected plan to be saved as in-review, got %+v", *saved)
+		}
+		if !strings.Contains((*saved).RawContent, "status: in-review") {
+			t.Errorf("expected raw content to be refreshed, got:\n%s", (*saved).RawContent)
+		}
+		if !result.IssueTransitioned || result.TrackerError != nil {
+			t.Errorf("expected issue to be transitioned, got %+v", result)
+		}
+
+		got, _ := client.GetIssue(ctx, issue.ID)
+		if got.Status != tracker.StatusInReview {
+			t.Errorf("expected issue status in_review, got %s", got.Status)
+		}
+	})
+
+	t.Run("reports partial success when transition fails", func(t *testing.T) {
+		// The mock tracker has no such issue, so the transition fails
+		deps, saved := newStatusDeps("MOCK-404", mock.NewClient())
+
+		result, err := setPlanStatusWithDeps(ctx, "PLAN-1", "complete", deps)
+		if err != nil {
+			t.Fatalf("setPlanStatusWithDeps() error = %v", err)
+		}
+
+		if *saved == nil || (*saved).Status != plan.StatusComplete {
+			t.Error("expected plan to be saved despite the tracker failure")
+		}
+		if result.IssueTransitioned {
+			t.Error("expected issue not to be transitioned")
+		}
+		if result.TrackerError == nil || !strings.Contains(result.TrackerError.Error(), "issue not found") {
+			t.Errorf("expected tracker error, got %v", result.TrackerError)
+		}
+	})
+
+	t.Run("doesn't transition to a status the team lacks", func(t *testing.T) {
+		client := mock.NewClient()
+		client.SetAvailableStatuses(tracker.StatusTodo, tracker.StatusInProgress, tracker.StatusDone)
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue"})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		deps, saved := newStatusDeps(issue.ID, client)
+
+		result, err := setPlanStatusWithDeps(ctx, "PLAN-1", "in-review", deps)
+		if err != nil {
+			t.Fatalf("setPlanStatusWithDeps() error = %v", err)
+		}
+		if *saved == nil || result.IssueTransitioned {
+			t.Errorf("expected only a local update, got %+v", result)
+		}
+		if result.TrackerError == nil || !strings.Contains(result.TrackerError.Error(), "available: todo, in_progress, done") {
+			t.Errorf("expected the available statuses in the tracker error, got %v", result.TrackerError)
+		}
+		if got, _ := client.GetIssue(ctx, issue.ID); got.Status != tracker.StatusTodo {
+			t.Errorf("expected the issue left as todo, got %s", got.Status)
+		}
+	})
+
+	t.Run("reports partial success when tracker is unavailable", func(t *testing.T) {
+		deps, saved := newStatusDeps("NUM-1", nil)
+		deps.getTracker = func() (tracker.Tracker, error) {
+			return nil, errors.New("Linear API key not configured")
+		}
+
+		result, err := setPlanStatusWithDeps(ctx, "PLAN-1", "approved", deps)
+		if err != nil {
+			t.Fatalf("setPlanStatusWithDeps() error = %v", err)
+		}
+		if *saved == nil {
+			t.Error("expected plan to be saved")
+		}
+		if result.TrackerError == nil {
+			t.Error("expected tracker error")
+		}
+	})
+
+	t.Run("unlinked plan only updates locally", func(t *testing.T) {
+		deps, saved := newStatusDeps("", nil)
+		deps.getTracker = func() (tracker.Tracker, error) {
+			t.Error("tracker should not be used for an unlinked plan")
+			return nil, nil
+		}
+
+		result, err := setPlanStatusWithDeps(ctx, "PLAN-1", "approved", deps)
+		if err != nil {
+			t.Fatalf("setPlanStatusWithDeps() error = %v", err)
+		}
+		if *saved == nil || result.IssueTransitioned || result.TrackerError != nil {
+			t.Errorf("expected only a local update, got %+v", result)
+		}
+	})
+
+	t.Run("rejects invalid status", func(t *testing.T) {
+		deps, saved := newStatusDeps("", nil)
+
+		_, err := setPlanStatusWithDeps(ctx, "PLAN-1", "done", deps)
+		if err == nil || !strings.Contains(err.Error(), `invalid plan status "done"`) {
+			t.Errorf("expected invalid status error, got %v", err)
+		}
+		if *saved != nil {
+			t.Error("expected plan not to be saved")
+		}
+	})
+
+	t.Run("missing plan", func(t *testing.T) {
+		deps, _ := newStatusDeps("", nil)
+
+		_, err := setPlanStatusWithDeps(ctx, "PLAN-404", "approved", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found: PLAN-404") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+}
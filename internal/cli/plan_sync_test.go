@@ -2,13 +2,17 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/charleslr/jig/internal/plan"
 	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
 )
 
 func TestCachedPlanNeedsSync(t *testing.T) {
@@ -141,7 +145,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash:     mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "nonexistent", deps)
+		err := syncSinglePlanWithDeps(ctx, "nonexistent", false, deps)
 		if err == nil {
 			t.Error("expected error for nonexistent plan")
 		}
@@ -160,7 +164,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash:     mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err == nil {
 			t.Error("expected error when cache fails")
 		}
@@ -179,7 +183,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash:     mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err == nil {
 			t.Error("expected error for nil plan")
 		}
@@ -200,7 +204,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash:     mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err == nil {
 			t.Error("expected error for plan with no linked issue")
 		}
@@ -223,7 +227,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash: mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err == nil {
 			t.Error("expected error when sync fails")
 		}
@@ -252,7 +256,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash: mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -281,7 +285,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 		}
 
 		// Should succeed despite markPlanSyncedWithHash error (just logs warning)
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err != nil {
 			t.Errorf("sync should succeed even if marking fails: %v", err)
 		}
@@ -304,7 +308,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash: mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -330,7 +334,7 @@ func TestSyncSinglePlanWithDeps(t *testing.T) {
 			computeContentHash: mockHashFunc,
 		}
 
-		err := syncSinglePlanWithDeps(ctx, "test-plan", deps)
+		err := syncSinglePlanWithDeps(ctx, "test-plan", false, deps)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -352,7 +356,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			computeContentHash:     mockHashFunc,
 		}
 
-		err := syncSelectedPlansWithDeps(ctx, []string{}, map[string]*state.CachedPlan{}, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{}, map[string]*state.CachedPlan{}, false, deps)
 		if err != nil {
 			t.Errorf("expected no error for empty plans, got: %v", err)
 		}
@@ -367,7 +371,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 		}
 
 		// Plan ID not in the map
-		err := syncSelectedPlansWithDeps(ctx, []string{"missing-plan"}, map[string]*state.CachedPlan{}, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{"missing-plan"}, map[string]*state.CachedPlan{}, false, deps)
 		if err == nil {
 			t.Error("expected error when plan not found in map")
 		}
@@ -394,7 +398,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			"fail-plan":    {Plan: &plan.Plan{ID: "fail-plan", IssueID: "NUM-2"}},
 		}
 
-		err := syncSelectedPlansWithDeps(ctx, []string{"success-plan", "fail-plan"}, idToPlan, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{"success-plan", "fail-plan"}, idToPlan, false, deps)
 		if err == nil {
 			t.Error("expected error when some plans fail")
 		}
@@ -425,7 +429,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			"plan-2": {Plan: &plan.Plan{ID: "plan-2", IssueID: "NUM-2"}},
 		}
 
-		err := syncSelectedPlansWithDeps(ctx, []string{"plan-1", "plan-2"}, idToPlan, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{"plan-1", "plan-2"}, idToPlan, false, deps)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -459,7 +463,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			"plan-1": {Plan: &plan.Plan{ID: "plan-1", IssueID: "NUM-1"}},
 		}
 
-		err := syncSelectedPlansWithDeps(ctx, []string{"plan-1"}, idToPlan, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{"plan-1"}, idToPlan, false, deps)
 		if err != nil {
 			t.Errorf("batch should succeed even if marking fails: %v", err)
 		}
@@ -488,7 +492,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			"fail-2":    {Plan: &plan.Plan{ID: "fail-2", IssueID: "NUM-4"}},
 		}
 
-		err := syncSelectedPlansWithDeps(ctx, []string{"success-1", "fail-1", "success-2", "fail-2"}, idToPlan, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{"success-1", "fail-1", "success-2", "fail-2"}, idToPlan, false, deps)
 		if err == nil {
 			t.Error("expected error when some plans fail")
 		}
@@ -497,6 +501,156 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 		}
 	})
 
+	t.Run("partial failure carries partial exit code", func(t *testing.T) {
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				if p.ID == "fail" {
+					return fmt.Errorf("sync error")
+				}
+				return nil
+			},
+			computeContentHash: mockHashFunc,
+		}
+		idToPlan := map[string]*state.CachedPlan{
+			"ok":   {Plan: &plan.Plan{ID: "ok", IssueID: "NUM-1"}},
+			"fail": {Plan: &plan.Plan{ID: "fail", IssueID: "NUM-2"}},
+		}
+
+		err := syncSelectedPlansWithDeps(ctx, []string{"ok", "fail"}, idToPlan, false, deps)
+		if got := ExitCode(err); got != exitCodeSyncPartial {
+			t.Errorf("ExitCode() = %d, want %d (err: %v)", got, exitCodeSyncPartial, err)
+		}
+	})
+
+	t.Run("total failure carries failed exit code", func(t *testing.T) {
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				return fmt.Errorf("401 unauthorized")
+			},
+			computeContentHash: mockHashFunc,
+		}
+		idToPlan := map[string]*state.CachedPlan{
+			"plan-1": {Plan: &plan.Plan{ID: "plan-1", IssueID: "NUM-1"}},
+			"plan-2": {Plan: &plan.Plan{ID: "plan-2", IssueID: "NUM-2"}},
+		}
+
+		err := syncSelectedPlansWithDeps(ctx, []string{"plan-1", "plan-2"}, idToPlan, false, deps)
+		if got := ExitCode(err); got != exitCodeSyncFailed {
+			t.Errorf("ExitCode() = %d, want %d (err: %v)", got, exitCodeSyncFailed, err)
+		}
+	})
+
+	t.Run("full success exits zero", func(t *testing.T) {
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan:               func(ctx context.Context, p *plan.Plan) error { return nil },
+			computeContentHash:     mockHashFunc,
+		}
+		idToPlan := map[string]*state.CachedPlan{
+			"plan-1": {Plan: &plan.Plan{ID: "plan-1", IssueID: "NUM-1"}},
+		}
+
+		err := syncSelectedPlansWithDeps(ctx, []string{"plan-1"}, idToPlan, false, deps)
+		if got := ExitCode(err); got != 0 {
+			t.Errorf("ExitCode() = %d, want 0 (err: %v)", got, err)
+		}
+	})
+
+	t.Run("syncs plans with a bounded worker pool in order", func(t *testing.T) {
+		var mu sync.Mutex
+		var inFlight, maxInFlight int
+		synced := make(map[string]bool)
+
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				synced[p.ID] = true
+				mu.Unlock()
+				return nil
+			},
+			computeContentHash: mockHashFunc,
+			concurrency:        3,
+			requestDelay:       time.Millisecond,
+		}
+
+		var planIDs []string
+		idToPlan := make(map[string]*state.CachedPlan)
+		for i := 1; i <= 10; i++ {
+			id := fmt.Sprintf("plan-%02d", i)
+			planIDs = append(planIDs, id)
+			idToPlan[id] = &state.CachedPlan{Plan: &plan.Plan{ID: id, IssueID: fmt.Sprintf("NUM-%d", i)}}
+		}
+
+		out, err := captureStdout(t, func() error {
+			return syncSelectedPlansWithDeps(ctx, planIDs, idToPlan, false, deps)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(synced) != len(planIDs) {
+			t.Errorf("expected %d plans synced, got %d", len(planIDs), len(synced))
+		}
+		if maxInFlight > 3 {
+			t.Errorf("expected at most 3 syncs at once, got %d", maxInFlight)
+		}
+		last := -1
+		for _, id := range planIDs {
+			i := strings.Index(out, "Synced "+id+" ")
+			if i < last {
+				t.Fatalf("expected results in selection order, got:\n%s", out)
+			}
+			last = i
+		}
+	})
+
+	t.Run("failing workers don't stop the others", func(t *testing.T) {
+		var mu sync.Mutex
+		synced := make(map[string]bool)
+
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				if strings.HasPrefix(p.ID, "fail") {
+					return fmt.Errorf("rate limited")
+				}
+				mu.Lock()
+				synced[p.ID] = true
+				mu.Unlock()
+				return nil
+			},
+			computeContentHash: mockHashFunc,
+			concurrency:        2,
+		}
+
+		planIDs := []string{"fail-1", "ok-1", "fail-2", "ok-2", "ok-3"}
+		idToPlan := make(map[string]*state.CachedPlan)
+		for _, id := range planIDs {
+			idToPlan[id] = &state.CachedPlan{Plan: &plan.Plan{ID: id, IssueID: "NUM-1"}}
+		}
+
+		out, err := captureStdout(t, func() error {
+			return syncSelectedPlansWithDeps(ctx, planIDs, idToPlan, false, deps)
+		})
+		if got := ExitCode(err); got != exitCodeSyncPartial {
+			t.Errorf("ExitCode() = %d, want %d (err: %v)", got, exitCodeSyncPartial, err)
+		}
+		if len(synced) != 3 {
+			t.Errorf("expected the 3 other plans synced, got %v", synced)
+		}
+		if !strings.Contains(out, "fail-1: rate limited") || !strings.Contains(out, "fail-2: rate limited") {
+			t.Errorf("expected both failures reported, got:\n%s", out)
+		}
+	})
+
 	t.Run("skips plans with unchanged content hash", func(t *testing.T) {
 		syncedPlans := make(map[string]bool)
 
@@ -521,7 +675,7 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			},
 		}
 
-		err := syncSelectedPlansWithDeps(ctx, []string{"unchanged-plan", "changed-plan"}, idToPlan, deps)
+		err := syncSelectedPlansWithDeps(ctx, []string{"unchanged-plan", "changed-plan"}, idToPlan, false, deps)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -536,4 +690,450 @@ func TestSyncSelectedPlansWithDeps(t *testing.T) {
 			t.Error("changed-plan should be synced")
 		}
 	})
+
+	t.Run("syncs concurrently behind one progress spinner in interactive mode", func(t *testing.T) {
+		const workers = 3
+		var mu sync.Mutex
+		var inFlight, maxInFlight int
+		var messages []string
+
+		// Each sync waits until all workers are busy, so the pool has to
+		// run them at once for the test to finish promptly
+		allBusy := make(chan struct{})
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				mu.Lock()
+				inFlight++
+				if maxInFlight = max(maxInFlight, inFlight); maxInFlight == workers {
+					select {
+					case <-allBusy:
+					default:
+						close(allBusy)
+					}
+				}
+				mu.Unlock()
+				select {
+				case <-allBusy:
+				case <-time.After(time.Second):
+				}
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				if p.ID == "plan-2" {
+					return fmt.Errorf("rate limited")
+				}
+				return nil
+			},
+			computeContentHash: mockHashFunc,
+			concurrency:        workers,
+			isInteractive:      func() bool { return true },
+			runProgress: func(ctx context.Context, message string, fn func(ctx context.Context, setMessage func(string)) error) error {
+				messages = append(messages, message)
+				return fn(ctx, func(message string) { messages = append(messages, message) })
+			},
+		}
+
+		var planIDs []string
+		idToPlan := make(map[string]*state.CachedPlan)
+		for i := 1; i <= 6; i++ {
+			id := fmt.Sprintf("plan-%d", i)
+			planIDs = append(planIDs, id)
+			idToPlan[id] = &state.CachedPlan{Plan: &plan.Plan{ID: id, IssueID: "NUM-1"}}
+		}
+
+		out, err := captureStdout(t, func() error {
+			return syncSelectedPlansWithDeps(ctx, planIDs, idToPlan, false, deps)
+		})
+		if got := ExitCode(err); got != exitCodeSyncPartial {
+			t.Errorf("ExitCode() = %d, want %d (err: %v)", got, exitCodeSyncPartial, err)
+		}
+		if maxInFlight != workers {
+			t.Errorf("expected %d syncs at once, got %d", workers, maxInFlight)
+		}
+		want := []string{"Syncing 0/6…", "Syncing 1/6…", "Syncing 2/6…", "Syncing 3/6…", "Syncing 4/6…", "Syncing 5/6…", "Syncing 6/6…"}
+		if strings.Join(messages, "|") != strings.Join(want, "|") {
+			t.Errorf("expected progress %v, got %v", want, messages)
+		}
+		last := -1
+		for _, id := range []string{"plan-1", "plan-3", "plan-4", "plan-5", "plan-6"} {
+			i := strings.Index(out, "Synced "+id+" ")
+			if i < last {
+				t.Fatalf("expected results in selection order, got:\n%s", out)
+			}
+			last = i
+		}
+		if !strings.Contains(out, "plan-2: rate limited") {
+			t.Errorf("expected the failure reported, got:\n%s", out)
+		}
+	})
+
+	t.Run("shows no spinner in non-interactive mode", func(t *testing.T) {
+		spinnerCalls := 0
+		deps := planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan:               func(ctx context.Context, p *plan.Plan) error { return nil },
+			computeContentHash:     mockHashFunc,
+			isInteractive:          func() bool { return false },
+			runProgress: func(ctx context.Context, message string, fn func(ctx context.Context, setMessage func(string)) error) error {
+				spinnerCalls++
+				return fn(ctx, func(string) {})
+			},
+		}
+
+		idToPlan := map[string]*state.CachedPlan{
+			"plan-1": {Plan: &plan.Plan{ID: "plan-1", IssueID: "NUM-1"}},
+			"plan-2": {Plan: &plan.Plan{ID: "plan-2", IssueID: "NUM-2"}},
+		}
+
+		out, err := captureStdout(t, func() error {
+			return syncSelectedPlansWithDeps(ctx, []string{"plan-1", "plan-2"}, idToPlan, false, deps)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if spinnerCalls != 0 {
+			t.Errorf("expected no spinner, got %d calls", spinnerCalls)
+		}
+		if !strings.Contains(out, "Synced plan-1 to issue NUM-1") || !strings.Contains(out, "Synced plan-2 to issue NUM-2") {
+			t.Errorf("expected plain results, got:\n%s", out)
+		}
+	})
+}
+
+func TestSyncAllPlansWithDeps(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	cachedPlans := []*state.CachedPlan{
+		{Plan: &plan.Plan{ID: "never-synced", IssueID: "NUM-1"}, UpdatedAt: now},
+		{Plan: &plan.Plan{ID: "no-issue"}, UpdatedAt: now},
+		{Plan: &plan.Plan{ID: "up-to-date", IssueID: "NUM-2"}, UpdatedAt: past, SyncedAt: &now},
+		{Plan: &plan.Plan{ID: "updated", IssueID: "NUM-3"}, UpdatedAt: now, SyncedAt: &past},
+	}
+
+	newDeps := func(synced *[]string, failing string) planSyncDeps {
+		var mu sync.Mutex
+		return planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				if p.ID == failing {
+					return fmt.Errorf("sync error")
+				}
+				mu.Lock()
+				*synced = append(*synced, p.ID)
+				mu.Unlock()
+				return nil
+			},
+			computeContentHash: func(p *plan.Plan) string { return "hash-" + p.ID },
+		}
+	}
+
+	t.Run("syncs exactly the unsynced plans", func(t *testing.T) {
+		var synced []string
+		if _, err := captureStdout(t, func() error {
+			return syncAllPlansWithDeps(ctx, cachedPlans, false, newDeps(&synced, ""))
+		}); err != nil {
+			t.Fatalf("syncAllPlansWithDeps() error = %v", err)
+		}
+		if strings.Join(synced, ",") != "never-synced,updated" {
+			t.Errorf("synced %v, want [never-synced updated]", synced)
+		}
+	})
+
+	t.Run("reports partial failures", func(t *testing.T) {
+		var synced []string
+		out, err := captureStdout(t, func() error {
+			return syncAllPlansWithDeps(ctx, cachedPlans, false, newDeps(&synced, "updated"))
+		})
+		if got := ExitCode(err); got != exitCodeSyncPartial {
+			t.Errorf("ExitCode() = %d, want %d (err: %v)", got, exitCodeSyncPartial, err)
+		}
+		for _, want := range []string{"Synced never-synced to issue NUM-1", "Successfully synced 1 plan(s)", "Failed to sync 1 plan(s)", "updated: sync error"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("nothing to sync", func(t *testing.T) {
+		var synced []string
+		out, err := captureStdout(t, func() error {
+			return syncAllPlansWithDeps(ctx, cachedPlans[1:3], false, newDeps(&synced, ""))
+		})
+		if err != nil || len(synced) != 0 || !strings.Contains(out, "No unsynced plans found.") {
+			t.Errorf("expected nothing synced, got %v, %v, output:\n%s", synced, err, out)
+		}
+	})
+}
+
+func TestSyncPlanWithDedup_ConflictDetection(t *testing.T) {
+	ctx := context.Background()
+	mockHashFunc := func(p *plan.Plan) string { return "new-content-hash" }
+	lastWritten := "## Plan\n\nOriginal body"
+
+	// newDeps returns deps whose tracker reports remoteBody as the current plan comment
+	newDeps := func(remoteBody string, syncCalled *bool, recordedHash *string) planSyncDeps {
+		return planSyncDeps{
+			markPlanSyncedWithHash: func(id, hash string) error { return nil },
+			syncPlan: func(ctx context.Context, p *plan.Plan) error {
+				*syncCalled = true
+				return nil
+			},
+			computeContentHash: mockHashFunc,
+			getPlanComment: func(ctx context.Context, issueID string) (*tracker.Comment, error) {
+				if *syncCalled {
+					return &tracker.Comment{ID: "comment-1", Body: "## Plan\n\nNew body"}, nil
+				}
+				return &tracker.Comment{ID: "comment-1", Body: remoteBody}, nil
+			},
+			setSyncedCommentHash: func(id, hash string) error {
+				*recordedHash = hash
+				return nil
+			},
+		}
+	}
+
+	newCached := func() *state.CachedPlan {
+		return &state.CachedPlan{
+			Plan:              &plan.Plan{ID: "test", IssueID: "NUM-123"},
+			SyncedContentHash: "old-content-hash",
+			SyncedCommentHash: hashCommentBody(lastWritten),
+		}
+	}
+
+	t.Run("syncs when remote comment matches last write", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		deps := newDeps(lastWritten, &syncCalled, &recordedHash)
+
+		result, err := syncPlanWithDedup(ctx, newCached(), false, deps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ConflictDetected {
+			t.Error("expected no conflict when remote comment is unchanged")
+		}
+		if !result.Synced || !syncCalled {
+			t.Error("expected plan to be synced")
+		}
+		if recordedHash != hashCommentBody("## Plan\n\nNew body") {
+			t.Errorf("expected hash of the newly written comment to be recorded, got %q", recordedHash)
+		}
+	})
+
+	t.Run("refuses to sync when remote comment diverged", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		deps := newDeps("## Plan\n\nEdited by a human", &syncCalled, &recordedHash)
+
+		result, err := syncPlanWithDedup(ctx, newCached(), false, deps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.ConflictDetected {
+			t.Error("expected conflict to be detected")
+		}
+		if result.Synced || syncCalled {
+			t.Error("expected sync to be refused")
+		}
+		if recordedHash != "" {
+			t.Error("expected comment hash not to be recorded")
+		}
+	})
+
+	t.Run("overwrites diverged comment when forced", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		deps := newDeps("## Plan\n\nEdited by a human", &syncCalled, &recordedHash)
+
+		result, err := syncPlanWithDedup(ctx, newCached(), true, deps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.ConflictDetected {
+			t.Error("expected conflict to still be reported")
+		}
+		if !result.Synced || !syncCalled {
+			t.Error("expected forced sync to proceed")
+		}
+		if recordedHash != hashCommentBody("## Plan\n\nNew body") {
+			t.Errorf("expected hash of the newly written comment to be recorded, got %q", recordedHash)
+		}
+	})
+
+	t.Run("no conflict check without a recorded comment hash", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		deps := newDeps("## Plan\n\nAnything", &syncCalled, &recordedHash)
+		cached := newCached()
+		cached.SyncedCommentHash = ""
+
+		result, err := syncPlanWithDedup(ctx, cached, false, deps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ConflictDetected || !result.Synced {
+			t.Errorf("expected plain sync, got %+v", result)
+		}
+	})
+
+	t.Run("ignores a changed sync timestamp", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		written := "## Plan\n\n**Synced:** 2026-01-01 10:00 UTC\n\nOriginal body"
+		deps := newDeps("## Plan\n\n**Synced:** 2026-02-01 09:00 UTC\n\nOriginal body", &syncCalled, &recordedHash)
+		cached := newCached()
+		cached.SyncedCommentHash = hashCommentBody(written)
+
+		result, err := syncPlanWithDedup(ctx, cached, false, deps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ConflictDetected || !result.Synced {
+			t.Errorf("expected a timestamp-only difference to sync without conflict, got %+v", result)
+		}
+	})
+
+	t.Run("accepts a hash of the whole comment body", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		written := "## Plan\n\n**Synced:** 2026-01-01 10:00 UTC\n\nOriginal body"
+		deps := newDeps(written, &syncCalled, &recordedHash)
+		cached := newCached()
+		legacy := sha256.Sum256([]byte(written))
+		cached.SyncedCommentHash = hex.EncodeToString(legacy[:])
+
+		result, err := syncPlanWithDedup(ctx, cached, false, deps)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ConflictDetected || !result.Synced {
+			t.Errorf("expected a hash recorded before timestamps were excluded to match, got %+v", result)
+		}
+	})
+
+	t.Run("returns error when comment fetch fails", func(t *testing.T) {
+		var syncCalled bool
+		var recordedHash string
+		deps := newDeps(lastWritten, &syncCalled, &recordedHash)
+		deps.getPlanComment = func(ctx context.Context, issueID string) (*tracker.Comment, error) {
+			return nil, fmt.Errorf("network down")
+		}
+
+		_, err := syncPlanWithDedup(ctx, newCached(), false, deps)
+		if err == nil {
+			t.Fatal("expected error when comment fetch fails")
+		}
+		if syncCalled {
+			t.Error("sync should not run when the conflict check fails")
+		}
+	})
+}
+
+func TestSyncSinglePlanWithDeps_Conflict(t *testing.T) {
+	ctx := context.Background()
+	deps := planSyncDeps{
+		getCachedPlan: func(id string) (*state.CachedPlan, error) {
+			return &state.CachedPlan{
+				Plan:              &plan.Plan{ID: "test", IssueID: "NUM-123"},
+				SyncedCommentHash: hashCommentBody("original"),
+			}, nil
+		},
+		markPlanSyncedWithHash: func(id, hash string) error { return nil },
+		syncPlan:               func(ctx context.Context, p *plan.Plan) error { return nil },
+		computeContentHash:     func(p *plan.Plan) string { return "hash" },
+		getPlanComment: func(ctx context.Context, issueID string) (*tracker.Comment, error) {
+			return &tracker.Comment{Body: "edited"}, nil
+		},
+	}
+
+	err := syncSinglePlanWithDeps(ctx, "test", false, deps)
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("expected conflict error suggesting --force, got: %v", err)
+	}
+
+	if err := syncSinglePlanWithDeps(ctx, "test", true, deps); err != nil {
+		t.Errorf("forced sync should succeed, got: %v", err)
+	}
+}
+
+func TestWatchPlansWithDeps(t *testing.T) {
+	// newWatch returns watch deps over a cache of plans that need a sync,
+	// counting syncs per plan
+	newWatch := func(changes chan string, syncs map[string]int) planWatchDeps {
+		plans := map[string]*state.CachedPlan{
+			"PLAN-1": {Plan: &plan.Plan{ID: "PLAN-1", IssueID: "NUM-1"}},
+			"PLAN-2": {Plan: &plan.Plan{ID: "PLAN-2", IssueID: "NUM-2"}},
+			"PLAN-3": {Plan: &plan.Plan{ID: "PLAN-3"}}, // Unlinked, never needs a sync
+		}
+		return planWatchDeps{
+			changes:  changes,
+			debounce: 20 * time.Millisecond,
+			sync: planSyncDeps{
+				getCachedPlan: func(id string) (*state.CachedPlan, error) { return plans[id], nil },
+				syncPlan: func(ctx context.Context, p *plan.Plan) error {
+					syncs[p.ID]++
+					return nil
+				},
+				markPlanSyncedWithHash: func(id, hash string) error {
+					now := time.Now()
+					plans[id].SyncedAt = &now
+					return nil
+				},
+				computeContentHash: func(p *plan.Plan) string { return "hash" },
+			},
+		}
+	}
+
+	// run sends changes to a watcher, closes the channel and returns once
+	// the pending changes have been synced
+	run := func(t *testing.T, planID string, ids ...string) map[string]int {
+		t.Helper()
+		changes := make(chan string)
+		syncs := make(map[string]int)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- watchPlansWithDeps(ctx, planID, false, newWatch(changes, syncs)) }()
+
+		captureStdout(t, func() error {
+			for _, id := range ids {
+				changes <- id
+			}
+			close(changes)
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+			return <-done
+		})
+		return syncs
+	}
+
+	t.Run("a burst of changes syncs a plan once", func(t *testing.T) {
+		syncs := run(t, "", "PLAN-1", "PLAN-1", "PLAN-1")
+		if syncs["PLAN-1"] != 1 || len(syncs) != 1 {
+			t.Errorf("expected exactly one sync of PLAN-1, got %v", syncs)
+		}
+	})
+
+	t.Run("syncs each changed plan that needs it", func(t *testing.T) {
+		syncs := run(t, "", "PLAN-1", "PLAN-2", "PLAN-3", "PLAN-UNKNOWN")
+		if syncs["PLAN-1"] != 1 || syncs["PLAN-2"] != 1 || len(syncs) != 2 {
+			t.Errorf("expected PLAN-1 and PLAN-2 synced once each, got %v", syncs)
+		}
+	})
+
+	t.Run("only watches the given plan", func(t *testing.T) {
+		syncs := run(t, "PLAN-2", "PLAN-1", "PLAN-2")
+		if syncs["PLAN-2"] != 1 || len(syncs) != 1 {
+			t.Errorf("expected only PLAN-2 synced, got %v", syncs)
+		}
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := watchPlansWithDeps(ctx, "", false, newWatch(make(chan string), map[string]int{})); err != nil {
+			t.Errorf("expected a clean stop, got %v", err)
+		}
+	})
 }
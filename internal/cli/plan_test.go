@@ -1,16 +1,33 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
 
 	"github.com/charleslr/jig/internal/config"
 	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/runner"
+	"github.com/charleslr/jig/internal/state"
 	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/linear"
 	trackerMock "github.com/charleslr/jig/internal/tracker/mock"
+	"github.com/charleslr/jig/internal/ui"
 )
 
 func TestFormatIssueContext(t *testing.T) {
@@ -147,23 +164,48 @@ func TestFormatIssueContextStructure(t *testing.T) {
 	}
 }
 
-func TestGetGitAuthor(t *testing.T) {
-	// Save original USER env var
-	originalUser := os.Getenv("USER")
-	defer os.Setenv("USER", originalUser)
+func TestResolveAuthor(t *testing.T) {
+	errNoGit := fmt.Errorf("exec: \"git\": executable file not found in $PATH")
 
 	tests := []struct {
-		name     string
-		userEnv  string
-		expected string
+		name      string
+		gitConfig map[string]string
+		gitErr    error
+		userEnv   string
+		expected  string
 	}{
 		{
-			name:     "with USER env set",
+			name:      "git name and email",
+			gitConfig: map[string]string{"user.name": "Test User", "user.email": "test@example.com"},
+			userEnv:   "testuser",
+			expected:  "Test User <test@example.com>",
+		},
+		{
+			name:      "git name only",
+			gitConfig: map[string]string{"user.name": "Test User"},
+			userEnv:   "testuser",
+			expected:  "Test User",
+		},
+		{
+			name:      "git email only",
+			gitConfig: map[string]string{"user.email": "test@example.com"},
+			userEnv:   "testuser",
+			expected:  "test@example.com",
+		},
+		{
+			name:     "no git identity falls back to USER env",
+			userEnv:  "testuser",
+			expected: "testuser",
+		},
+		{
+			name:     "git not installed falls back to USER env",
+			gitErr:   errNoGit,
 			userEnv:  "testuser",
 			expected: "testuser",
 		},
 		{
-			name:     "with empty USER env",
+			name:     "nothing configured",
+			gitErr:   errNoGit,
 			userEnv:  "",
 			expected: "unknown",
 		},
@@ -171,10 +213,23 @@ func TestGetGitAuthor(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			os.Setenv("USER", tt.userEnv)
-			result := getGitAuthor()
-			if result != tt.expected {
-				t.Errorf("getGitAuthor() = %q, want %q", result, tt.expected)
+			deps := authorDeps{
+				gitConfig: func(key string) (string, error) {
+					if tt.gitErr != nil {
+						return "", tt.gitErr
+					}
+					return tt.gitConfig[key], nil
+				},
+				getenv: func(key string) string {
+					if key != "USER" {
+						t.Errorf("unexpected env lookup: %s", key)
+					}
+					return tt.userEnv
+				},
+			}
+
+			if result := resolveAuthor(deps); result != tt.expected {
+				t.Errorf("resolveAuthor() = %q, want %q", result, tt.expected)
 			}
 		})
 	}
@@ -501,6 +556,110 @@ func TestFetchIssueWithDeps(t *testing.T) {
 			t.Errorf("expected spinner error to be propagated, got %v", result.Err)
 		}
 	})
+
+	t.Run("fresh cached issue skips the tracker", func(t *testing.T) {
+		deps := issueFetchDeps{
+			isInteractive: func() bool { return false },
+			getTracker: func() (tracker.Tracker, error) {
+				t.Error("tracker should not be used on a cache hit")
+				return nil, nil
+			},
+			getCachedIssue: func(id string) (*tracker.Issue, error) {
+				if id != "TEST-123" {
+					return nil, nil
+				}
+				return testIssue, nil
+			},
+			saveIssue: func(issue *tracker.Issue) error {
+				t.Error("a cached issue should not be saved again")
+				return nil
+			},
+		}
+
+		result := fetchIssueWithDeps(ctx, "TEST-123", deps)
+
+		if result.Err != nil || result.Issue != testIssue {
+			t.Errorf("expected cached issue, got %+v", result)
+		}
+	})
+
+	t.Run("cache miss fetches and caches the issue", func(t *testing.T) {
+		var saved *tracker.Issue
+		deps := issueFetchDeps{
+			isInteractive: func() bool { return false },
+			getTracker: func() (tracker.Tracker, error) {
+				return &mockTrackerForFetch{issue: testIssue}, nil
+			},
+			getCachedIssue: func(id string) (*tracker.Issue, error) { return nil, nil },
+			saveIssue: func(issue *tracker.Issue) error {
+				saved = issue
+				return nil
+			},
+		}
+
+		result := fetchIssueWithDeps(ctx, "TEST-123", deps)
+
+		if result.Err != nil || result.Issue != testIssue {
+			t.Errorf("expected fetched issue, got %+v", result)
+		}
+		if saved != testIssue {
+			t.Errorf("expected fetched issue to be cached, got %+v", saved)
+		}
+	})
+
+	t.Run("fetches an issue by URL", func(t *testing.T) {
+		client := trackerMock.NewClient()
+		created, _ := client.CreateIssue(ctx, &tracker.Issue{Title: "From URL"})
+		deps := issueFetchDeps{
+			isInteractive: func() bool { return false },
+			getTracker:    func() (tracker.Tracker, error) { return client, nil },
+		}
+
+		result := fetchIssueWithDeps(ctx, created.URL+"/from-url", deps)
+
+		if result.Err != nil || result.Issue == nil || result.Issue.Identifier != created.Identifier {
+			t.Errorf("expected issue %s, got %+v", created.Identifier, result)
+		}
+	})
+
+	t.Run("URL with a tracker that does not support them", func(t *testing.T) {
+		deps := issueFetchDeps{
+			isInteractive: func() bool { return false },
+			getTracker: func() (tracker.Tracker, error) {
+				return &mockTrackerForFetch{issue: testIssue}, nil
+			},
+		}
+
+		result := fetchIssueWithDeps(ctx, "https://linear.app/acme/issue/TEST-123", deps)
+
+		if result.Err == nil || !strings.Contains(result.Err.Error(), "does not support issue URLs") {
+			t.Errorf("expected unsupported URL error, got %+v", result)
+		}
+	})
+}
+
+func TestIssueIDFromRef(t *testing.T) {
+	fetchErr := errors.New("network down")
+	tests := []struct {
+		name    string
+		ref     string
+		result  fetchIssueResult
+		want    string
+		wantErr bool
+	}{
+		{"issue ID", "NUM-1", fetchIssueResult{Err: fetchErr}, "NUM-1", false},
+		{"fetched URL", "https://linear.app/acme/issue/NUM-1/fix", fetchIssueResult{Issue: &tracker.Issue{Identifier: "NUM-1"}}, "NUM-1", false},
+		{"Linear URL that failed to fetch", "https://linear.app/acme/issue/num-2/fix", fetchIssueResult{Err: fetchErr}, "NUM-2", false},
+		{"other URL that failed to fetch", "https://example.com/issues/3", fetchIssueResult{Err: fetchErr}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := issueIDFromRef(tt.ref, tt.result)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("issueIDFromRef(%q) = %q, %v, want %q (error: %v)", tt.ref, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
 }
 
 // mockTrackerForFetch is a minimal mock tracker for testing fetchIssueWithDeps
@@ -526,6 +685,12 @@ func (m *mockTrackerForFetch) UpdateIssue(ctx context.Context, id string, update
 func (m *mockTrackerForFetch) SearchIssues(ctx context.Context, query string) ([]*tracker.Issue, error) {
 	return nil, nil
 }
+func (m *mockTrackerForFetch) ListAssignedToMe(ctx context.Context) ([]*tracker.Issue, error) {
+	return nil, nil
+}
+func (m *mockTrackerForFetch) GetCurrentUser(ctx context.Context) (*tracker.User, error) {
+	return nil, nil
+}
 func (m *mockTrackerForFetch) CreateSubIssue(ctx context.Context, parentID string, issue *tracker.Issue) (*tracker.Issue, error) {
 	return nil, nil
 }
@@ -544,6 +709,12 @@ func (m *mockTrackerForFetch) TransitionIssue(ctx context.Context, id string, st
 func (m *mockTrackerForFetch) GetAvailableStatuses(ctx context.Context, id string) ([]tracker.Status, error) {
 	return nil, nil
 }
+func (m *mockTrackerForFetch) RemoveLabel(ctx context.Context, issueID, labelID string) error {
+	return nil
+}
+func (m *mockTrackerForFetch) UpdateIssueLabels(ctx context.Context, issueID string, labelIDs []string) error {
+	return nil
+}
 func (m *mockTrackerForFetch) GetTeams(ctx context.Context) ([]tracker.Team, error) { return nil, nil }
 func (m *mockTrackerForFetch) GetProjects(ctx context.Context, teamID string) ([]tracker.Project, error) {
 	return nil, nil
@@ -556,23 +727,7 @@ func (m *mockTrackerForFetch) GetBlockedBy(ctx context.Context, issueID string)
 }
 
 func TestMarkPlanSaved(t *testing.T) {
-	// Create a temp directory for testing
-	tempDir, err := os.MkdirTemp("", "jig-test-*")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
-	}
-	defer os.RemoveAll(tempDir)
-
-	// Change to temp directory
-	originalDir, err := os.Getwd()
-	if err != nil {
-		t.Fatalf("failed to get current dir: %v", err)
-	}
-	defer os.Chdir(originalDir)
-
-	if err := os.Chdir(tempDir); err != nil {
-		t.Fatalf("failed to change to temp dir: %v", err)
-	}
+	t.Chdir(t.TempDir())
 
 	// Call markPlanSaved
 	markPlanSaved()
@@ -582,6 +737,32 @@ func TestMarkPlanSaved(t *testing.T) {
 	if _, err := os.Stat(markerPath); os.IsNotExist(err) {
 		t.Error("expected plan-saved.marker file to be created")
 	}
+	if created, ok := markerCreatedAt(markerPath); !ok || time.Since(created) > time.Minute {
+		t.Errorf("expected the marker to record when it was created, got %v", created)
+	}
+}
+
+func TestClearPlanMarkers(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	os.MkdirAll(".jig", 0755)
+	for _, name := range []string{"plan-saved.marker", "skip-save.marker", "other.marker"} {
+		os.WriteFile(filepath.Join(".jig", name), []byte{}, 0644)
+	}
+
+	clearPlanMarkers()
+
+	for _, name := range []string{"plan-saved.marker", "skip-save.marker"} {
+		if _, err := os.Stat(filepath.Join(".jig", name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(".jig", "other.marker")); err != nil {
+		t.Error("expected other markers to be left alone")
+	}
+
+	// Nothing to clear is fine
+	clearPlanMarkers()
 }
 
 func TestWriteAndReadSavedPlanID(t *testing.T) {
@@ -634,6 +815,120 @@ func TestWriteAndReadSavedPlanID(t *testing.T) {
 	}
 }
 
+func TestWriteAndReadSessionMetadata(t *testing.T) {
+	tempDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get current dir: %v", err)
+	}
+	defer os.Chdir(originalDir)
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("failed to change to temp dir: %v", err)
+	}
+
+	if err := writeSessionMetadata("12345", state.SessionMetadata{Team: "OPS", Project: "Q3 Roadmap"}); err != nil {
+		t.Fatalf("writeSessionMetadata() error = %v", err)
+	}
+	got := readSessionMetadata("12345")
+	if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("expected the write times to be recorded, got %+v", got)
+	}
+	got.CreatedAt, got.UpdatedAt = time.Time{}, time.Time{}
+	want := state.SessionMetadata{Team: "OPS", Project: "Q3 Roadmap"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readSessionMetadata() = %+v, want %+v", got, want)
+	}
+
+	if got := readSessionMetadata("missing"); !reflect.DeepEqual(got, state.SessionMetadata{}) {
+		t.Errorf("readSessionMetadata() for missing session = %+v, want empty", got)
+	}
+}
+
+func TestSessionMetadataForFields(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	fields := ui.PlanFieldsFromForm(ui.PlanFieldsFormFields(ui.PlanFields{
+		Title:  "Add SSO",
+		Goal:   "Support SSO logins",
+		Labels: []string{"auth", "backend"},
+		Team:   "ENG",
+	}))
+	session := sessionMetadataForFields(state.SessionMetadata{IssueID: "NUM-1", Team: "OPS", Project: "Q3 Roadmap"}, fields)
+	if err := writeSessionMetadata("12345", session); err != nil {
+		t.Fatalf("writeSessionMetadata() error = %v", err)
+	}
+
+	// The form's team overrides the flag's; the rest is kept
+	want := state.SessionMetadata{
+		IssueID: "NUM-1",
+		Team:    "ENG",
+		Project: "Q3 Roadmap",
+		Title:   "Add SSO",
+		Goal:    "Support SSO logins",
+		Labels:  []string{"auth", "backend"},
+	}
+	got := readSessionMetadata("12345")
+	got.CreatedAt, got.UpdatedAt = time.Time{}, time.Time{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readSessionMetadata() = %+v, want %+v", got, want)
+	}
+
+	// An empty team in the form keeps the flag's
+	if got := sessionMetadataForFields(state.SessionMetadata{Team: "OPS"}, ui.PlanFields{Title: "T"}); got.Team != "OPS" {
+		t.Errorf("expected team OPS to be kept, got %q", got.Team)
+	}
+}
+
+func TestApplySessionMetadata(t *testing.T) {
+	const generated = "---\ntitle: Generated Title\nlabels: [backend]\n---\n\n# Generated Title\n\n## Problem Statement\n\nLogins fail.\n\n## Proposed Solution\n\nRetry them.\n"
+
+	t.Run("given fields override the generated ones", func(t *testing.T) {
+		p, err := plan.Parse([]byte(generated))
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+
+		session := state.SessionMetadata{IssueID: "NUM-1", Title: "Add SSO", Labels: []string{"Backend", "auth"}}
+		if err := applySessionMetadata(p, session); err != nil {
+			t.Fatalf("applySessionMetadata() error = %v", err)
+		}
+
+		if p.IssueID != "NUM-1" || p.Title != "Add SSO" {
+			t.Errorf("expected issue NUM-1 and title Add SSO, got %q and %q", p.IssueID, p.Title)
+		}
+		if strings.Join(p.Labels, ",") != "backend,auth" {
+			t.Errorf("expected labels backend,auth, got %v", p.Labels)
+		}
+		if !strings.Contains(p.RawContent, "# Add SSO") || strings.Contains(p.RawContent, "Generated Title") {
+			t.Errorf("expected the raw content to use the given title, got:\n%s", p.RawContent)
+		}
+
+		// The saved plan reads back with the given fields
+		reparsed, err := plan.Parse([]byte(p.RawContent))
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if reparsed.Title != "Add SSO" || strings.Join(reparsed.Labels, ",") != "backend,auth" {
+			t.Errorf("expected the given fields after a round trip, got %q and %v", reparsed.Title, reparsed.Labels)
+		}
+	})
+
+	t.Run("leaves the plan alone without given fields", func(t *testing.T) {
+		p, err := plan.Parse([]byte(generated))
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+
+		if err := applySessionMetadata(p, state.SessionMetadata{}); err != nil {
+			t.Fatalf("applySessionMetadata() error = %v", err)
+		}
+
+		if p.Title != "Generated Title" || p.RawContent != generated {
+			t.Errorf("expected the plan unchanged, got title %q and:\n%s", p.Title, p.RawContent)
+		}
+	})
+}
+
 func TestReadSavedPlanID_NotFound(t *testing.T) {
 	// Create a temp directory for testing
 	tempDir, err := os.MkdirTemp("", "jig-test-*")
@@ -790,6 +1085,9 @@ func TestSessionIDIsolation(t *testing.T) {
 }
 
 func TestRunPlanSave_EmptyContent(t *testing.T) {
+	// Saving writes markers to .jig in the working directory
+	t.Chdir(t.TempDir())
+
 	// Save and restore stdin
 	oldStdin := os.Stdin
 	defer func() { os.Stdin = oldStdin }()
@@ -809,6 +1107,8 @@ func TestRunPlanSave_EmptyContent(t *testing.T) {
 }
 
 func TestRunPlanSave_InvalidPlanFormat(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	// Save and restore stdin
 	oldStdin := os.Stdin
 	defer func() { os.Stdin = oldStdin }()
@@ -832,6 +1132,8 @@ func TestRunPlanSave_InvalidPlanFormat(t *testing.T) {
 }
 
 func TestRunPlanSave_FileNotFound(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	err := runPlanSave(planSaveCmd, []string{"/nonexistent/path/plan.md"})
 	if err == nil {
 		t.Error("expected error for nonexistent file")
@@ -842,6 +1144,8 @@ func TestRunPlanSave_FileNotFound(t *testing.T) {
 }
 
 func TestRunPlanSave_MissingRequiredFields(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	// Create a temp file with plan missing required fields
 	tempDir, err := os.MkdirTemp("", "jig-test-*")
 	if err != nil {
@@ -871,7 +1175,42 @@ Some content.
 	}
 }
 
+func TestRunPlanSave_InvalidFrontmatterValues(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	tempDir := t.TempDir()
+
+	invalidPlan := `---
+title: Test Plan
+status: inprogress
+author: testuser
+---
+
+# Test Plan
+
+## Problem Statement
+
+Test problem.
+
+## Proposed Solution
+
+Test solution.
+`
+	planFile := filepath.Join(tempDir, "invalid-plan.md")
+	os.WriteFile(planFile, []byte(invalidPlan), 0644)
+
+	err := runPlanSave(planSaveCmd, []string{planFile})
+	if err == nil {
+		t.Fatal("expected error for invalid status")
+	}
+	if !strings.Contains(err.Error(), `line 3: status "inprogress" is not valid`) {
+		t.Errorf("expected status error with line context, got: %v", err)
+	}
+}
+
 func TestRunPlanSave_FromFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	// This test verifies that reading from a file works
 	// We don't test the full save path since it requires cache setup
 	tempDir, err := os.MkdirTemp("", "jig-test-*")
@@ -923,6 +1262,8 @@ Test phase.
 }
 
 func TestRunPlanSave_FromStdin(t *testing.T) {
+	t.Chdir(t.TempDir())
+
 	// Save and restore stdin
 	oldStdin := os.Stdin
 	defer func() { os.Stdin = oldStdin }()
@@ -1183,7 +1524,7 @@ func TestShouldSyncToLinear(t *testing.T) {
 		}
 		p := &plan.Plan{IssueID: "NUM-41"}
 
-		result := shouldSyncToLinear(cfg, p)
+		result := shouldSyncPlan(cfg, p)
 		if result {
 			t.Error("expected false when tracker is not linear")
 		}
@@ -1201,7 +1542,7 @@ func TestShouldSyncToLinear(t *testing.T) {
 		}
 		p := &plan.Plan{IssueID: "NUM-41"}
 
-		result := shouldSyncToLinear(cfg, p)
+		result := shouldSyncPlan(cfg, p)
 		if result {
 			t.Error("expected false when sync is disabled")
 		}
@@ -1216,7 +1557,7 @@ func TestShouldSyncToLinear(t *testing.T) {
 		}
 		p := &plan.Plan{IssueID: ""} // No linked issue
 
-		result := shouldSyncToLinear(cfg, p)
+		result := shouldSyncPlan(cfg, p)
 		if result {
 			t.Error("expected false when plan has no linked issue")
 		}
@@ -1246,6 +1587,118 @@ func TestPlanSaveCmd_NoSyncFlag(t *testing.T) {
 	}
 }
 
+func TestRunPlanSave_DryRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	planSaveDryRun = true
+	defer func() { planSaveDryRun = false }()
+
+	planFile := filepath.Join(jigHome, "plan.md")
+	os.WriteFile(planFile, []byte(`---
+title: Dry Run Plan
+status: draft
+author: testuser
+---
+
+# Dry Run Plan
+
+## Problem Statement
+
+Test problem.
+
+## Proposed Solution
+
+Test solution.
+`), 0644)
+
+	output, err := captureStdout(t, func() error {
+		return runPlanSave(planSaveCmd, []string{planFile})
+	})
+	if err != nil {
+		t.Fatalf("runPlanSave failed: %v", err)
+	}
+
+	if !strings.Contains(output, "(generated)") {
+		t.Errorf("expected generated plan ID in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Title: Dry Run Plan") {
+		t.Errorf("expected plan title in output, got:\n%s", output)
+	}
+
+	// Nothing may be written to the cache
+	if _, err := os.Stat(filepath.Join(jigHome, "cache")); !os.IsNotExist(err) {
+		t.Errorf("expected no cache directory in dry-run, got err = %v", err)
+	}
+}
+
+func TestPreviewPlanSave(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	// Any request to Linear means an issue-creation or sync path ran
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	newCfg := func() *config.Config {
+		return &config.Config{
+			Default: config.DefaultConfig{Tracker: "linear"},
+			Linear:  config.LinearConfig{APIKey: "test-key", APIURL: server.URL},
+		}
+	}
+
+	t.Run("unlinked plan would create and sync an issue", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-1", "Test", "tester")
+
+		preview := previewPlanSave(newCfg(), p, true, false, false)
+		if !preview.GeneratedID || !preview.CreateIssue || !preview.Sync {
+			t.Errorf("expected generated ID, issue creation and sync, got %+v", preview)
+		}
+		if p.HasLinkedIssue() {
+			t.Errorf("expected plan to stay unlinked, got issue %s", p.IssueID)
+		}
+	})
+
+	t.Run("linked plan would only sync", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-1", "Test", "tester")
+		p.IssueID = "NUM-41"
+
+		preview := previewPlanSave(newCfg(), p, false, false, false)
+		if preview.CreateIssue || !preview.Sync {
+			t.Errorf("expected sync without issue creation, got %+v", preview)
+		}
+	})
+
+	t.Run("no-sync skips Linear entirely", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-1", "Test", "tester")
+
+		preview := previewPlanSave(newCfg(), p, false, true, false)
+		if preview.CreateIssue || preview.Sync {
+			t.Errorf("expected no Linear changes with --no-sync, got %+v", preview)
+		}
+	})
+
+	t.Run("no-create skips creating an issue", func(t *testing.T) {
+		p := plan.NewPlan("PLAN-1", "Test", "tester")
+
+		preview := previewPlanSave(newCfg(), p, false, false, true)
+		if preview.CreateIssue || preview.Sync {
+			t.Errorf("expected no issue creation with --no-create, got %+v", preview)
+		}
+	})
+
+	if requests != 0 {
+		t.Errorf("expected no requests to Linear, got %d", requests)
+	}
+}
+
 func TestDisplaySavedPlanNextSteps(t *testing.T) {
 	// Create a temp directory for testing
 	tempDir, err := os.MkdirTemp("", "jig-test-*")
@@ -1376,6 +1829,10 @@ func (m *mockPlanSyncer) SyncPlanToIssue(ctx context.Context, p *plan.Plan, labe
 	return nil
 }
 
+func (m *mockPlanSyncer) GetPlanComment(ctx context.Context, issueID string) (*tracker.Comment, error) {
+	return nil, nil
+}
+
 func TestSyncPlanWithSyncer_UsingTrackerMock(t *testing.T) {
 	// This test demonstrates that the tracker/mock package can be used
 	// for integration testing of the sync functionality
@@ -1458,17 +1915,107 @@ func TestSyncPlanWithSyncer_UsingTrackerMock(t *testing.T) {
 	})
 }
 
-// mockIssueCreator is a mock implementation of issueCreator for testing
-type mockIssueCreator struct {
-	issue *tracker.Issue
-	err   error
-}
+func TestSyncPlanRelations(t *testing.T) {
+	ctx := context.Background()
+	mockClient := trackerMock.NewClient()
+	var issues []*tracker.Issue
+	for _, title := range []string{"Plan issue", "Blocked issue", "Blocking issue"} {
+		issue, err := mockClient.CreateIssue(ctx, &tracker.Issue{Title: title})
+		if err != nil {
+			t.Fatalf("failed to create mock issue: %v", err)
+		}
+		issues = append(issues, issue)
+	}
+	planIssue, blocked, blocker := issues[0], issues[1], issues[2]
 
-func (m *mockIssueCreator) CreateIssueFromPlan(ctx context.Context, p *plan.Plan) (*tracker.Issue, error) {
-	if m.err != nil {
-		return nil, m.err
+	p := &plan.Plan{
+		ID:               "PLAN-RELATIONS",
+		IssueID:          planIssue.Identifier,
+		Title:            "Relations",
+		ProblemStatement: "Dependencies live in prose.",
+		ProposedSolution: "Relate the issues.",
+		Blocks:           []string{blocked.Identifier},
+		BlockedBy:        []string{blocker.Identifier},
 	}
-	return m.issue, nil
+
+	assertBlockedBy := func(t *testing.T, issue *tracker.Issue, want *tracker.Issue) {
+		t.Helper()
+		got, err := mockClient.GetBlockedBy(ctx, issue.ID)
+		if err != nil {
+			t.Fatalf("GetBlockedBy() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != want.ID {
+			t.Errorf("%s blocked by %v, want only %s", issue.Identifier, got, want.Identifier)
+		}
+	}
+
+	t.Run("establishes blocks and blocked-by relations", func(t *testing.T) {
+		if err := syncPlanWithSyncer(ctx, mockClient, p, "jig-plan"); err != nil {
+			t.Fatalf("syncPlanWithSyncer failed: %v", err)
+		}
+		assertBlockedBy(t, blocked, planIssue)
+		assertBlockedBy(t, planIssue, blocker)
+	})
+
+	t.Run("re-running doesn't duplicate relations", func(t *testing.T) {
+		if err := syncPlanWithSyncer(ctx, mockClient, p, "jig-plan"); err != nil {
+			t.Fatalf("syncPlanWithSyncer failed: %v", err)
+		}
+		assertBlockedBy(t, blocked, planIssue)
+		assertBlockedBy(t, planIssue, blocker)
+	})
+
+	t.Run("unknown issue returns error", func(t *testing.T) {
+		unknown := *p
+		unknown.Blocks = []string{"MOCK-999"}
+		err := syncPlanRelations(ctx, mockClient, &unknown)
+		if err == nil || !strings.Contains(err.Error(), "MOCK-999") {
+			t.Errorf("expected an error naming the unknown issue, got %v", err)
+		}
+	})
+
+	t.Run("relation errors only warn once the plan is synced", func(t *testing.T) {
+		unknown := *p
+		unknown.Blocks = []string{"MOCK-999"}
+		output, err := captureStdout(t, func() error {
+			return syncPlanWithSyncer(ctx, mockClient, &unknown, "jig-plan")
+		})
+		if err != nil {
+			t.Fatalf("syncPlanWithSyncer failed: %v", err)
+		}
+		if !strings.Contains(output, "Could not set blocking relations") || !strings.Contains(output, "MOCK-999") {
+			t.Errorf("expected a warning naming the unknown issue, got %q", output)
+		}
+	})
+}
+
+// mockIssueCreator is a mock implementation of issueCreator for testing
+type mockIssueCreator struct {
+	issue *tracker.Issue
+	err   error
+}
+
+func (m *mockIssueCreator) CreateIssueFromPlan(ctx context.Context, p *plan.Plan) (*tracker.Issue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.issue, nil
+}
+
+// mockAttachingCreator is a mockIssueCreator that also attaches links,
+// recording each attached URL and failing for those in failURLs
+type mockAttachingCreator struct {
+	mockIssueCreator
+	attached []string
+	failURLs map[string]bool
+}
+
+func (m *mockAttachingCreator) CreateAttachment(ctx context.Context, issueID, url, title string) (*linear.LinearAttachment, error) {
+	if m.failURLs[url] {
+		return nil, fmt.Errorf("url not allowed")
+	}
+	m.attached = append(m.attached, issueID+" "+url)
+	return &linear.LinearAttachment{ID: "attachment-" + url, URL: url}, nil
 }
 
 func TestCreateIssueForPlanWithCreator(t *testing.T) {
@@ -1513,6 +2060,136 @@ func TestCreateIssueForPlanWithCreator(t *testing.T) {
 			t.Errorf("expected 'rate limited' error, got: %v", err)
 		}
 	})
+
+	t.Run("warns when estimate was not set", func(t *testing.T) {
+		creator := &mockIssueCreator{
+			issue: &tracker.Issue{ID: "issue-uuid", Identifier: "NUM-123"},
+		}
+		estimate := 5
+		p := &plan.Plan{
+			ID:       "PLAN-123",
+			Title:    "Test Plan",
+			Estimate: &estimate,
+		}
+
+		output, err := captureStdout(t, func() error {
+			_, err := createIssueForPlanWithCreator(ctx, creator, p)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("createIssueForPlanWithCreator failed: %v", err)
+		}
+		if !strings.Contains(output, "Estimate of 5 points was not set on NUM-123") {
+			t.Errorf("expected estimate warning, got: %q", output)
+		}
+
+		creator.issue.Estimate = &estimate
+		output, _ = captureStdout(t, func() error {
+			_, err := createIssueForPlanWithCreator(ctx, creator, p)
+			return err
+		})
+		if strings.Contains(output, "Estimate") {
+			t.Errorf("expected no warning when estimate was set, got: %q", output)
+		}
+	})
+
+	t.Run("attaches each link and warns on failures", func(t *testing.T) {
+		creator := &mockAttachingCreator{
+			mockIssueCreator: mockIssueCreator{
+				issue: &tracker.Issue{ID: "issue-uuid", Identifier: "NUM-123"},
+			},
+			failURLs: map[string]bool{"https://example.com/private": true},
+		}
+		p := &plan.Plan{
+			ID:    "PLAN-123",
+			Title: "Test Plan",
+			Links: []string{"https://example.com/design", "https://example.com/private", "https://example.com/spec"},
+		}
+
+		var identifier string
+		output, err := captureStdout(t, func() error {
+			var err error
+			identifier, err = createIssueForPlanWithCreator(ctx, creator, p)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("createIssueForPlanWithCreator failed: %v", err)
+		}
+		if identifier != "NUM-123" {
+			t.Errorf("expected identifier 'NUM-123', got '%s'", identifier)
+		}
+
+		want := []string{"issue-uuid https://example.com/design", "issue-uuid https://example.com/spec"}
+		if !slices.Equal(creator.attached, want) {
+			t.Errorf("attached = %v, want %v", creator.attached, want)
+		}
+		if !strings.Contains(output, "Could not attach https://example.com/private to NUM-123: url not allowed") {
+			t.Errorf("expected attachment warning, got: %q", output)
+		}
+	})
+
+	t.Run("warns when the creator can't attach links", func(t *testing.T) {
+		creator := &mockIssueCreator{
+			issue: &tracker.Issue{ID: "issue-uuid", Identifier: "NUM-123"},
+		}
+		p := &plan.Plan{ID: "PLAN-123", Title: "Test Plan", Links: []string{"https://example.com/design"}}
+
+		output, err := captureStdout(t, func() error {
+			_, err := createIssueForPlanWithCreator(ctx, creator, p)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("createIssueForPlanWithCreator failed: %v", err)
+		}
+		if !strings.Contains(output, "Links were not attached to NUM-123") {
+			t.Errorf("expected warning, got: %q", output)
+		}
+	})
+}
+
+func TestSelectTeam(t *testing.T) {
+	ctx := context.Background()
+	teams := []tracker.Team{
+		{ID: "team-1", Name: "Engineering", Key: "ENG"},
+		{ID: "team-2", Name: "Operations", Key: "OPS"},
+	}
+	getTeams := func(ctx context.Context) ([]tracker.Team, error) { return teams, nil }
+
+	t.Run("returns the selected team's ID", func(t *testing.T) {
+		var offered []ui.SelectOption
+		team, err := selectTeam(ctx, getTeams, func(title string, options []ui.SelectOption) (string, error) {
+			offered = options
+			return "team-2", nil
+		})
+		if err != nil {
+			t.Fatalf("selectTeam() error = %v", err)
+		}
+		if team != "team-2" {
+			t.Errorf("expected team-2, got %q", team)
+		}
+		if len(offered) != 2 || offered[0].Label != "Engineering" || offered[0].Description != "ENG" {
+			t.Errorf("expected every team offered, got %+v", offered)
+		}
+	})
+
+	t.Run("returns ErrNoTeam when cancelled", func(t *testing.T) {
+		_, err := selectTeam(ctx, getTeams, func(title string, options []ui.SelectOption) (string, error) {
+			return "", nil
+		})
+		if !errors.Is(err, linear.ErrNoTeam) {
+			t.Errorf("expected ErrNoTeam, got %v", err)
+		}
+	})
+
+	t.Run("returns ErrNoTeam without teams", func(t *testing.T) {
+		_, err := selectTeam(ctx, func(ctx context.Context) ([]tracker.Team, error) { return nil, nil }, func(title string, options []ui.SelectOption) (string, error) {
+			t.Error("expected no prompt without teams")
+			return "", nil
+		})
+		if !errors.Is(err, linear.ErrNoTeam) {
+			t.Errorf("expected ErrNoTeam, got %v", err)
+		}
+	})
 }
 
 func TestGetLinearClientWithStore(t *testing.T) {
@@ -1577,3 +2254,1459 @@ func TestGetLinearClientWithStore(t *testing.T) {
 		}
 	})
 }
+
+func TestRunPlanShow_Raw(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	createTestPlanInCache(t, jigHome, "PLAN-SHOW")
+	markdown := "---\nid: PLAN-SHOW\ntitle: Test Plan\n---\n\n## Steps\n\n- One\n  - Nested\n\n```go\nfunc main() {}\n```"
+	os.WriteFile(filepath.Join(jigHome, "cache", "plans", "PLAN-SHOW.md"), []byte(markdown), 0644)
+
+	oldRaw, oldNoColor := planShowRaw, planShowNoColor
+	planShowRaw, planShowNoColor = true, true
+	defer func() { planShowRaw, planShowNoColor = oldRaw, oldNoColor }()
+
+	output, err := captureStdout(t, func() error { return runPlanShow(planShowCmd, []string{"PLAN-SHOW"}) })
+	if err != nil {
+		t.Fatalf("runPlanShow() error = %v", err)
+	}
+	if output != markdown+"\n" {
+		t.Errorf("expected the raw markdown unrendered, got:\n%s", output)
+	}
+}
+
+func TestRunPlanDelete(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	createTestPlanInCache(t, jigHome, "PLAN-DELETE")
+	createTestPlanInCache(t, jigHome, "PLAN-KEEP")
+
+	if err := runPlanDelete(planDeleteCmd, []string{"PLAN-DELETE"}); err != nil {
+		t.Fatalf("runPlanDelete() error = %v", err)
+	}
+
+	cacheDir := filepath.Join(jigHome, "cache", "plans")
+	if _, err := os.Stat(filepath.Join(cacheDir, "PLAN-DELETE.json")); !os.IsNotExist(err) {
+		t.Error("expected PLAN-DELETE.json to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "PLAN-KEEP.json")); err != nil {
+		t.Error("expected PLAN-KEEP.json to remain")
+	}
+}
+
+func TestRunPlanDelete_NotFound(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	err := runPlanDelete(planDeleteCmd, []string{"PLAN-MISSING"})
+	if err == nil {
+		t.Fatal("expected error for missing plan")
+	}
+	if !strings.Contains(err.Error(), "plan not found") {
+		t.Errorf("expected 'plan not found' error, got: %v", err)
+	}
+}
+
+func TestRunPlanDelete_NonInteractiveRequiresID(t *testing.T) {
+	err := runPlanDelete(planDeleteCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "PLAN_ID argument is required") {
+		t.Errorf("expected missing PLAN_ID error, got: %v", err)
+	}
+}
+
+func TestDeletePlansInteractiveWithDeps(t *testing.T) {
+	cachedPlans := []*state.CachedPlan{
+		{Plan: &plan.Plan{ID: "PLAN-1", Title: "First", Status: plan.StatusDraft, IssueID: "NUM-1"}},
+		{Plan: &plan.Plan{ID: "PLAN-2", Title: "Second", Status: plan.StatusComplete}, Archived: true},
+		{Plan: &plan.Plan{ID: "PLAN-3", Title: "Third", Status: plan.StatusDraft}},
+	}
+
+	// newDeleteDeps returns deps that select the given plans and record deletions
+	newDeleteDeps := func(selected []string, deleted *[]string) planDeleteDeps {
+		return planDeleteDeps{
+			listCachedPlans: func() ([]*state.CachedPlan, error) { return cachedPlans, nil },
+			selectPlans: func(title string, options []ui.SelectOption) ([]string, error) {
+				return selected, nil
+			},
+			confirm: func(question string) (bool, error) { return true, nil },
+			deletePlan: func(id string) error {
+				*deleted = append(*deleted, id)
+				return nil
+			},
+		}
+	}
+
+	t.Run("deletes the selected plans", func(t *testing.T) {
+		var deleted []string
+		deps := newDeleteDeps([]string{"PLAN-1", "PLAN-2"}, &deleted)
+		var options []ui.SelectOption
+		deps.selectPlans = func(title string, opts []ui.SelectOption) ([]string, error) {
+			options = opts
+			return []string{"PLAN-1", "PLAN-2"}, nil
+		}
+		var question string
+		deps.confirm = func(q string) (bool, error) {
+			question = q
+			return true, nil
+		}
+
+		output, err := captureStdout(t, func() error { return deletePlansInteractiveWithDeps(false, deps) })
+		if err != nil {
+			t.Fatalf("deletePlansInteractiveWithDeps() error = %v", err)
+		}
+
+		if len(options) != 3 || options[0].Value != "PLAN-1" || options[0].Label != "PLAN-1 - First" {
+			t.Errorf("expected an option per cached plan, got %+v", options)
+		}
+		if options[1].Description != "Status: complete, archived" {
+			t.Errorf("expected archived plan to be marked, got %q", options[1].Description)
+		}
+		if question != "Delete 2 plan(s)?" {
+			t.Errorf("expected confirmation of the count, got %q", question)
+		}
+		if strings.Join(deleted, ",") != "PLAN-1,PLAN-2" {
+			t.Errorf("expected PLAN-1 and PLAN-2 to be deleted, got %v", deleted)
+		}
+		if !strings.Contains(output, "Deleted 2 plan(s)") {
+			t.Errorf("expected deleted count in output, got:\n%s", output)
+		}
+	})
+
+	t.Run("declined confirmation deletes nothing", func(t *testing.T) {
+		var deleted []string
+		deps := newDeleteDeps([]string{"PLAN-1"}, &deleted)
+		deps.confirm = func(question string) (bool, error) { return false, nil }
+
+		if _, err := captureStdout(t, func() error { return deletePlansInteractiveWithDeps(false, deps) }); err != nil {
+			t.Fatalf("deletePlansInteractiveWithDeps() error = %v", err)
+		}
+		if len(deleted) != 0 {
+			t.Errorf("expected nothing to be deleted, got %v", deleted)
+		}
+	})
+
+	t.Run("force skips confirmation", func(t *testing.T) {
+		var deleted []string
+		deps := newDeleteDeps([]string{"PLAN-3"}, &deleted)
+		deps.confirm = func(question string) (bool, error) {
+			t.Error("confirm should not be called with force")
+			return false, nil
+		}
+
+		if _, err := captureStdout(t, func() error { return deletePlansInteractiveWithDeps(true, deps) }); err != nil {
+			t.Fatalf("deletePlansInteractiveWithDeps() error = %v", err)
+		}
+		if len(deleted) != 1 || deleted[0] != "PLAN-3" {
+			t.Errorf("expected PLAN-3 to be deleted, got %v", deleted)
+		}
+	})
+
+	t.Run("nothing selected", func(t *testing.T) {
+		var deleted []string
+		deps := newDeleteDeps(nil, &deleted)
+
+		output, err := captureStdout(t, func() error { return deletePlansInteractiveWithDeps(false, deps) })
+		if err != nil {
+			t.Fatalf("deletePlansInteractiveWithDeps() error = %v", err)
+		}
+		if len(deleted) != 0 || !strings.Contains(output, "No plans selected.") {
+			t.Errorf("expected nothing to be deleted, got %v and output:\n%s", deleted, output)
+		}
+	})
+
+	t.Run("reports failed deletions", func(t *testing.T) {
+		var deleted []string
+		deps := newDeleteDeps([]string{"PLAN-1", "PLAN-3"}, &deleted)
+		deps.deletePlan = func(id string) error {
+			if id == "PLAN-1" {
+				return fmt.Errorf("permission denied")
+			}
+			deleted = append(deleted, id)
+			return nil
+		}
+
+		output, err := captureStdout(t, func() error { return deletePlansInteractiveWithDeps(false, deps) })
+		if err == nil || !strings.Contains(err.Error(), "failed to delete 1 plan(s)") {
+			t.Errorf("expected failure count error, got %v", err)
+		}
+		if len(deleted) != 1 || !strings.Contains(output, "PLAN-1: permission denied") {
+			t.Errorf("expected PLAN-3 deleted and PLAN-1 reported, got %v and output:\n%s", deleted, output)
+		}
+	})
+}
+
+// captureStdout runs fn while capturing everything written to os.Stdout
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+
+	fnErr := fn()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	output, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	return string(output), fnErr
+}
+
+func TestRunPlanList_JSON(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	planListJSON = true
+	defer func() { planListJSON = false }()
+
+	t.Run("empty cache emits empty array", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			return runPlanList(planListCmd, nil)
+		})
+		if err != nil {
+			t.Fatalf("runPlanList() error = %v", err)
+		}
+		if strings.TrimSpace(output) != "[]" {
+			t.Errorf("expected [], got %q", output)
+		}
+	})
+
+	t.Run("serializes cached plans", func(t *testing.T) {
+		createTestPlanInCache(t, jigHome, "PLAN-JSON")
+
+		output, err := captureStdout(t, func() error {
+			return runPlanList(planListCmd, nil)
+		})
+		if err != nil {
+			t.Fatalf("runPlanList() error = %v", err)
+		}
+
+		var entries []map[string]interface{}
+		if err := json.Unmarshal([]byte(output), &entries); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\nOutput was: %s", err, output)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 entry, got %d", len(entries))
+		}
+
+		entry := entries[0]
+		if entry["id"] != "PLAN-JSON" {
+			t.Errorf("expected id PLAN-JSON, got %v", entry["id"])
+		}
+		if entry["title"] != "Test Plan" {
+			t.Errorf("expected title 'Test Plan', got %v", entry["title"])
+		}
+		if entry["status"] != "draft" {
+			t.Errorf("expected status draft, got %v", entry["status"])
+		}
+		for _, key := range []string{"cached_at", "needs_sync"} {
+			if _, ok := entry[key]; !ok {
+				t.Errorf("expected %q field in output", key)
+			}
+		}
+		if _, ok := entry["synced_at"]; ok {
+			t.Error("expected synced_at to be omitted for a never-synced plan")
+		}
+	})
+}
+
+func TestRunPlanList_Archived(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	planListJSON = true
+	defer func() { planListJSON = false }()
+
+	createTestPlanInCache(t, jigHome, "PLAN-ACTIVE")
+	createTestPlanInCache(t, jigHome, "PLAN-DONE")
+
+	if _, err := captureStdout(t, func() error {
+		return runPlanArchive(planArchiveCmd, []string{"PLAN-DONE"})
+	}); err != nil {
+		t.Fatalf("runPlanArchive() error = %v", err)
+	}
+
+	// listIDs runs 'jig plan list --json' and returns the listed plan IDs
+	listIDs := func(t *testing.T) map[string]bool {
+		t.Helper()
+		output, err := captureStdout(t, func() error {
+			return runPlanList(planListCmd, nil)
+		})
+		if err != nil {
+			t.Fatalf("runPlanList() error = %v", err)
+		}
+		var entries []planListEntry
+		if err := json.Unmarshal([]byte(output), &entries); err != nil {
+			t.Fatalf("failed to parse JSON output: %v\nOutput was: %s", err, output)
+		}
+		ids := make(map[string]bool)
+		for _, e := range entries {
+			ids[e.ID] = e.Archived
+		}
+		return ids
+	}
+
+	t.Run("archived plans are hidden by default", func(t *testing.T) {
+		ids := listIDs(t)
+		if _, ok := ids["PLAN-ACTIVE"]; !ok || len(ids) != 1 {
+			t.Errorf("expected only PLAN-ACTIVE, got %v", ids)
+		}
+	})
+
+	t.Run("--all includes archived plans", func(t *testing.T) {
+		planListAll = true
+		defer func() { planListAll = false }()
+
+		ids := listIDs(t)
+		if len(ids) != 2 {
+			t.Fatalf("expected 2 plans, got %v", ids)
+		}
+		if !ids["PLAN-DONE"] || ids["PLAN-ACTIVE"] {
+			t.Errorf("expected only PLAN-DONE to be marked archived, got %v", ids)
+		}
+	})
+
+	t.Run("unarchive restores the plan", func(t *testing.T) {
+		if _, err := captureStdout(t, func() error {
+			return runPlanUnarchive(planUnarchiveCmd, []string{"PLAN-DONE"})
+		}); err != nil {
+			t.Fatalf("runPlanUnarchive() error = %v", err)
+		}
+
+		if ids := listIDs(t); len(ids) != 2 {
+			t.Errorf("expected 2 plans after unarchiving, got %v", ids)
+		}
+	})
+
+	t.Run("unknown plan", func(t *testing.T) {
+		err := runPlanArchive(planArchiveCmd, []string{"PLAN-404"})
+		if err == nil || !strings.Contains(err.Error(), "plan not found") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+}
+
+func TestRunPlanSearch_JSON(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	createTestPlanInCache(t, jigHome, "PLAN-SEARCH")
+
+	planSearchJSON = true
+	defer func() { planSearchJSON = false }()
+
+	output, err := captureStdout(t, func() error {
+		return runPlanSearch(planSearchCmd, []string{"test plan"})
+	})
+	if err != nil {
+		t.Fatalf("runPlanSearch() error = %v", err)
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\nOutput was: %s", err, output)
+	}
+	if len(entries) != 1 || entries[0]["id"] != "PLAN-SEARCH" {
+		t.Errorf("expected PLAN-SEARCH to match, got %v", entries)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return runPlanSearch(planSearchCmd, []string{"no such text"})
+	})
+	if err != nil {
+		t.Fatalf("runPlanSearch() error = %v", err)
+	}
+	if strings.TrimSpace(output) != "[]" {
+		t.Errorf("expected [], got %q", output)
+	}
+}
+
+func TestRunPlanTemplateList(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	output, err := captureStdout(t, func() error {
+		return runPlanTemplateList(planTemplateListCmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("runPlanTemplateList() error = %v", err)
+	}
+	if !strings.Contains(output, "No plan templates found") {
+		t.Errorf("expected empty message, got: %q", output)
+	}
+
+	templatesDir := filepath.Join(jigHome, "templates")
+	os.MkdirAll(templatesDir, 0755)
+	os.WriteFile(filepath.Join(templatesDir, "feature.md"), []byte("# {{title}}"), 0644)
+	os.WriteFile(filepath.Join(templatesDir, "bugfix.md"), []byte("# {{title}}"), 0644)
+
+	output, err = captureStdout(t, func() error {
+		return runPlanTemplateList(planTemplateListCmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("runPlanTemplateList() error = %v", err)
+	}
+	if output != "bugfix\nfeature\n" {
+		t.Errorf("expected template names, got: %q", output)
+	}
+}
+
+func TestRunPlanNew_MissingTemplate(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	planNewTemplate = "missing"
+	defer func() { planNewTemplate = "" }()
+
+	err := runPlanNew(planNewCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "template not found: missing") {
+		t.Errorf("expected template not found error, got %v", err)
+	}
+}
+
+func TestWritePlanPrompt(t *testing.T) {
+	worktreeDir := t.TempDir()
+	opts := &runner.PrepareOpts{
+		WorktreeDir:  worktreeDir,
+		PromptType:   runner.PromptTypePlan,
+		PlanGoal:     "Add rate limiting to the API",
+		IssueContext: "# Issue NUM-42\n\nRequests are not throttled.",
+		SessionID:    "12345",
+	}
+
+	var buf bytes.Buffer
+	if err := writePlanPrompt(&buf, opts); err != nil {
+		t.Fatalf("writePlanPrompt() error = %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"# /jig:plan",
+		"cat .jig/sessions/12345/planning-context.md",
+		"# Planning Goal\n\nAdd rate limiting to the API",
+		"Requests are not throttled.",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(output, "$ARGUMENTS") {
+		t.Error("expected $ARGUMENTS to be replaced with the session ID")
+	}
+	if strings.HasPrefix(output, "---") {
+		t.Error("expected skill frontmatter to be stripped")
+	}
+
+	// The session context files are still written for the skill to read
+	sessionDir := filepath.Join(worktreeDir, ".jig", "sessions", "12345")
+	for _, name := range []string{"planning-context.md", "issue-context.md"} {
+		if _, err := os.Stat(filepath.Join(sessionDir, name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+}
+
+func TestLinkPlanIssues(t *testing.T) {
+	tests := []struct {
+		name        string
+		related     []string
+		issueIDs    []string
+		wantPrimary string
+		wantRelated string
+	}{
+		{
+			name:        "single issue",
+			issueIDs:    []string{"NUM-1"},
+			wantPrimary: "NUM-1",
+		},
+		{
+			name:        "multiple issues",
+			issueIDs:    []string{"NUM-1", "NUM-2", "NUM-3"},
+			wantPrimary: "NUM-1",
+			wantRelated: "NUM-2,NUM-3",
+		},
+		{
+			name:        "duplicates are dropped",
+			issueIDs:    []string{"NUM-1", "NUM-2", "NUM-1", "NUM-2"},
+			wantPrimary: "NUM-1",
+			wantRelated: "NUM-2",
+		},
+		{
+			name:        "relinking replaces related issues",
+			related:     []string{"NUM-8", "NUM-9"},
+			issueIDs:    []string{"NUM-1"},
+			wantPrimary: "NUM-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &plan.Plan{ID: "PLAN-1", IssueID: "NUM-OLD", RelatedIssues: tt.related}
+
+			linkPlanIssues(p, tt.issueIDs)
+
+			if p.IssueID != tt.wantPrimary {
+				t.Errorf("IssueID = %q, want %q", p.IssueID, tt.wantPrimary)
+			}
+			if got := strings.Join(p.RelatedIssues, ","); got != tt.wantRelated {
+				t.Errorf("RelatedIssues = %q, want %q", got, tt.wantRelated)
+			}
+		})
+	}
+}
+
+func TestCreateIssueAndSavePlanWithDeps(t *testing.T) {
+	t.Chdir(t.TempDir())
+	record := createdIssueRecord("session-1", "", true)
+
+	var created int
+	saveErr := errors.New("disk full")
+	deps := planSaveIssueDeps{
+		shouldCreateIssue: func(p *plan.Plan) bool { return !p.HasLinkedIssue() },
+		createIssue: func(ctx context.Context, p *plan.Plan) (string, error) {
+			created++
+			return fmt.Sprintf("NUM-%d", created), nil
+		},
+		savePlan: func(p *plan.Plan) error { return saveErr },
+	}
+
+	// The issue is created but the plan can't be saved
+	_, err := captureStdout(t, func() error {
+		return createIssueAndSavePlanWithDeps(context.Background(), &plan.Plan{ID: "PLAN-1"}, record, deps)
+	})
+	if err == nil || !errors.Is(err, saveErr) || !strings.Contains(err.Error(), "NUM-1") {
+		t.Fatalf("expected the save error mentioning NUM-1, got %v", err)
+	}
+	if got := readCreatedIssueID(record); got != "NUM-1" {
+		t.Fatalf("expected NUM-1 to be recorded, got %q", got)
+	}
+
+	// Re-running the save links the issue instead of creating another
+	var saved *plan.Plan
+	deps.savePlan = func(p *plan.Plan) error {
+		saved = p
+		return nil
+	}
+	output, err := captureStdout(t, func() error {
+		return createIssueAndSavePlanWithDeps(context.Background(), &plan.Plan{ID: "PLAN-2"}, record, deps)
+	})
+	if err != nil {
+		t.Fatalf("createIssueAndSavePlanWithDeps() error = %v", err)
+	}
+	if created != 1 {
+		t.Errorf("expected one issue created, got %d", created)
+	}
+	if saved == nil || saved.IssueID != "NUM-1" {
+		t.Errorf("expected the plan saved with NUM-1, got %+v", saved)
+	}
+	if !strings.Contains(output, "Linking issue NUM-1") {
+		t.Errorf("expected the reused issue to be reported, got:\n%s", output)
+	}
+
+	// A plan that already has an issue keeps it
+	if err := createIssueAndSavePlanWithDeps(context.Background(), &plan.Plan{ID: "PLAN-3", IssueID: "NUM-9"}, record, deps); err != nil {
+		t.Fatalf("createIssueAndSavePlanWithDeps() error = %v", err)
+	}
+	if saved.IssueID != "NUM-9" || created != 1 {
+		t.Errorf("expected NUM-9 kept and no issue created, got %q and %d created", saved.IssueID, created)
+	}
+}
+
+func TestCreateIssueAndSavePlanWithDeps_Confirm(t *testing.T) {
+	ctx := context.Background()
+
+	// newDeps returns deps that answer the confirmation with confirmed (or
+	// don't ask if ask is false), counting the prompts and created issues
+	newDeps := func(ask, confirmed bool) (planSaveIssueDeps, *int, *int, **plan.Plan) {
+		var prompts, created int
+		var saved *plan.Plan
+		deps := planSaveIssueDeps{
+			shouldCreateIssue: func(p *plan.Plan) bool { return !p.HasLinkedIssue() },
+			createIssue: func(ctx context.Context, p *plan.Plan) (string, error) {
+				created++
+				return "NUM-1", nil
+			},
+			savePlan: func(p *plan.Plan) error {
+				saved = p
+				return nil
+			},
+		}
+		if ask {
+			deps.confirmCreate = func(p *plan.Plan) (bool, error) {
+				prompts++
+				return confirmed, nil
+			}
+		}
+		return deps, &prompts, &created, &saved
+	}
+
+	tests := []struct {
+		name        string
+		ask         bool
+		confirmed   bool
+		wantCreated int
+		wantIssue   string
+	}{
+		{"confirmed", true, true, 1, "NUM-1"},
+		{"declined", true, false, 0, ""},
+		{"auto-yes doesn't ask", false, false, 1, "NUM-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, prompts, created, saved := newDeps(tt.ask, tt.confirmed)
+
+			_, err := captureStdout(t, func() error {
+				return createIssueAndSavePlanWithDeps(ctx, &plan.Plan{ID: "PLAN-1", Title: "Test"}, "", deps)
+			})
+			if err != nil {
+				t.Fatalf("createIssueAndSavePlanWithDeps() error = %v", err)
+			}
+
+			wantPrompts := 0
+			if tt.ask {
+				wantPrompts = 1
+			}
+			if *prompts != wantPrompts {
+				t.Errorf("expected %d prompts, got %d", wantPrompts, *prompts)
+			}
+			if *created != tt.wantCreated {
+				t.Errorf("expected %d issues created, got %d", tt.wantCreated, *created)
+			}
+			if *saved == nil || (*saved).IssueID != tt.wantIssue {
+				t.Errorf("expected the plan saved with issue %q, got %+v", tt.wantIssue, *saved)
+			}
+		})
+	}
+
+	t.Run("doesn't ask for a plan that won't get an issue", func(t *testing.T) {
+		deps, prompts, _, _ := newDeps(true, true)
+
+		if err := createIssueAndSavePlanWithDeps(ctx, &plan.Plan{ID: "PLAN-1", IssueID: "NUM-9"}, "", deps); err != nil {
+			t.Fatalf("createIssueAndSavePlanWithDeps() error = %v", err)
+		}
+		if *prompts != 0 {
+			t.Errorf("expected no prompt, got %d", *prompts)
+		}
+	})
+
+	t.Run("a failed prompt doesn't save", func(t *testing.T) {
+		deps, _, created, saved := newDeps(true, true)
+		deps.confirmCreate = func(p *plan.Plan) (bool, error) { return false, errors.New("no terminal") }
+
+		err := createIssueAndSavePlanWithDeps(ctx, &plan.Plan{ID: "PLAN-1"}, "", deps)
+		if err == nil || !strings.Contains(err.Error(), "no terminal") {
+			t.Errorf("expected the prompt error, got %v", err)
+		}
+		if *created != 0 || *saved != nil {
+			t.Errorf("expected nothing created or saved, got %d created and %+v", *created, *saved)
+		}
+	})
+}
+
+func TestCreatedIssueRecord(t *testing.T) {
+	tests := []struct {
+		sessionID   string
+		planID      string
+		generatedID bool
+		want        string
+	}{
+		{"session-1", "PLAN-1", true, filepath.Join(".jig", "sessions", "session-1", "created-issue-id")},
+		{"", "PLAN-1", false, filepath.Join(".jig", "created-issues", "PLAN-1")},
+		{"", "PLAN-1", true, ""},
+		{"", "../PLAN-1", false, ""},
+	}
+	for _, tt := range tests {
+		if got := createdIssueRecord(tt.sessionID, tt.planID, tt.generatedID); got != tt.want {
+			t.Errorf("createdIssueRecord(%q, %q, %v) = %q, want %q", tt.sessionID, tt.planID, tt.generatedID, got, tt.want)
+		}
+	}
+}
+
+func TestSessionIssueTarget(t *testing.T) {
+	session := state.SessionMetadata{Team: "WEB", Project: "Checkout"}
+
+	tests := []struct {
+		name          string
+		team, project string
+		want          linear.IssueTarget
+	}{
+		{"no flags keep the session's target", "", "", linear.IssueTarget{Team: "WEB", Project: "Checkout"}},
+		{"--project keeps the session's team", "", "Payments", linear.IssueTarget{Team: "WEB", Project: "Payments"}},
+		{"--team drops the session's project", "API", "", linear.IssueTarget{Team: "API"}},
+		{"both flags replace the target", "API", "Gateway", linear.IssueTarget{Team: "API", Project: "Gateway"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionIssueTarget(session, tt.team, tt.project); got != tt.want {
+				t.Errorf("sessionIssueTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanJSONToMarkdown(t *testing.T) {
+	getAuthor := func() string { return "git-author" }
+
+	t.Run("fills in the author", func(t *testing.T) {
+		content, err := planJSONToMarkdown([]byte(`{"title": "T", "problem": "P", "solution": "S"}`), getAuthor)
+		if err != nil {
+			t.Fatalf("planJSONToMarkdown() error = %v", err)
+		}
+		p, err := plan.Parse(content)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if p.Author != "git-author" {
+			t.Errorf("Author = %q, want %q", p.Author, "git-author")
+		}
+	})
+
+	t.Run("keeps the payload's author", func(t *testing.T) {
+		content, _ := planJSONToMarkdown([]byte(`{"title": "T", "problem": "P", "solution": "S", "author": "alice"}`), getAuthor)
+		if p, err := plan.Parse(content); err != nil || p.Author != "alice" {
+			t.Errorf("expected author alice, got %+v, %v", p, err)
+		}
+	})
+
+	for _, data := range []string{"", "  \n", `{"title": "T"}`, `[1, 2]`} {
+		if _, err := planJSONToMarkdown([]byte(data), getAuthor); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func TestSavePlanContent_JSONMatchesMarkdown(t *testing.T) {
+	t.Setenv("JIG_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	oldNoSync := planSaveNoSync
+	planSaveNoSync = true
+	defer func() { planSaveNoSync = oldNoSync }()
+
+	markdown := `---
+id: PLAN-MD
+title: Add retries
+status: draft
+author: alice
+labels:
+    - backend
+---
+
+# Add retries
+
+## Problem Statement
+
+Requests fail under load.
+
+## Proposed Solution
+
+Retry them with backoff.
+`
+	payload := `{"id": "PLAN-JSON", "title": "Add retries", "author": "alice", "labels": ["backend"],
+		"problem": "Requests fail under load.", "solution": "Retry them with backoff."}`
+
+	content, err := planJSONToMarkdown([]byte(payload), func() string { return "" })
+	if err != nil {
+		t.Fatalf("planJSONToMarkdown() error = %v", err)
+	}
+	for _, c := range [][]byte{[]byte(markdown), content} {
+		if _, err := captureStdout(t, func() error { return savePlanContent(c) }); err != nil {
+			t.Fatalf("savePlanContent() error = %v", err)
+		}
+	}
+
+	fromMarkdown, err := state.DefaultCache.GetPlan("PLAN-MD")
+	if err != nil {
+		t.Fatalf("GetPlan(PLAN-MD) error = %v", err)
+	}
+	fromJSON, err := state.DefaultCache.GetPlan("PLAN-JSON")
+	if err != nil {
+		t.Fatalf("GetPlan(PLAN-JSON) error = %v", err)
+	}
+	if fromJSON.Title != fromMarkdown.Title || fromJSON.Author != fromMarkdown.Author || fromJSON.Status != fromMarkdown.Status ||
+		fromJSON.ProblemStatement != fromMarkdown.ProblemStatement || fromJSON.ProposedSolution != fromMarkdown.ProposedSolution ||
+		!reflect.DeepEqual(fromJSON.Labels, fromMarkdown.Labels) {
+		t.Errorf("JSON import = %+v, want the same fields as %+v", fromJSON, fromMarkdown)
+	}
+}
+
+func TestSavePlanContent_RemovesCreatedIssueRecord(t *testing.T) {
+	t.Setenv("JIG_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	oldNoSync := planSaveNoSync
+	planSaveNoSync = true
+	defer func() { planSaveNoSync = oldNoSync }()
+
+	// An earlier save created NUM-9 but failed to save the plan
+	record := createdIssueRecord("", "PLAN-REC", false)
+	if err := writeCreatedIssueID(record, "NUM-9"); err != nil {
+		t.Fatalf("writeCreatedIssueID() error = %v", err)
+	}
+
+	content := []byte("---\nid: PLAN-REC\ntitle: Add retries\nstatus: draft\nauthor: alice\n---\n\n" +
+		"# Add retries\n\n## Problem Statement\n\nRequests fail.\n\n## Proposed Solution\n\nRetry them.\n")
+	if _, err := captureStdout(t, func() error { return savePlanContent(content) }); err != nil {
+		t.Fatalf("savePlanContent() error = %v", err)
+	}
+
+	p, err := state.DefaultCache.GetPlan("PLAN-REC")
+	if err != nil {
+		t.Fatalf("GetPlan() error = %v", err)
+	}
+	if p.IssueID != "NUM-9" {
+		t.Errorf("expected the recorded issue linked, got %q", p.IssueID)
+	}
+	if _, err := os.Stat(record); !os.IsNotExist(err) {
+		t.Errorf("expected the record removed once the plan was saved, got %v", err)
+	}
+}
+
+func TestResolvePlanTitle(t *testing.T) {
+	newPlan := func(title, problem string) *plan.Plan {
+		p := plan.NewPlan("PLAN-1", title, "alice")
+		p.ProblemStatement = problem
+		p.RawContent = "---\nid: PLAN-1\ntitle: " + title + "\n---\n\n# " + title + "\n\n## Problem Statement\n\n" + problem + "\n"
+		return p
+	}
+
+	t.Run("derives a title from the problem statement", func(t *testing.T) {
+		p := newPlan(plan.PlaceholderTitle, "Requests fail under load.\n\nMore detail.")
+		if _, err := captureStdout(t, func() error { return resolvePlanTitle(p) }); err != nil {
+			t.Fatalf("resolvePlanTitle() error = %v", err)
+		}
+		if p.Title != "Requests fail under load" {
+			t.Errorf("expected the derived title, got %q", p.Title)
+		}
+
+		// The saved markdown carries the new title too
+		parsed, err := plan.Parse([]byte(p.RawContent))
+		if err != nil {
+			t.Fatalf("failed to parse plan: %v", err)
+		}
+		if parsed.Title != p.Title || !strings.Contains(p.RawContent, "# Requests fail under load\n") || strings.Contains(p.RawContent, plan.PlaceholderTitle) {
+			t.Errorf("expected the raw content retitled, got:\n%s", p.RawContent)
+		}
+	})
+
+	t.Run("rejects a placeholder with nothing to derive from", func(t *testing.T) {
+		p := newPlan(plan.PlaceholderTitle, "TODO: Define the problem being solved")
+		err := resolvePlanTitle(p)
+		if err == nil || !strings.Contains(err.Error(), "placeholder") {
+			t.Errorf("expected a placeholder error, got %v", err)
+		}
+	})
+
+	t.Run("rejects a whitespace title", func(t *testing.T) {
+		p := newPlan("   ", "Requests fail under load.")
+		err := resolvePlanTitle(p)
+		if err == nil || !strings.Contains(err.Error(), "title is required") {
+			t.Errorf("expected a title error, got %v", err)
+		}
+	})
+
+	t.Run("trims the title", func(t *testing.T) {
+		p := newPlan("Add retries", "Requests fail under load.")
+		p.Title = "  Add retries "
+		if err := resolvePlanTitle(p); err != nil {
+			t.Fatalf("resolvePlanTitle() error = %v", err)
+		}
+		if p.Title != "Add retries" {
+			t.Errorf("expected the trimmed title, got %q", p.Title)
+		}
+	})
+
+	t.Run("leaves a real title alone", func(t *testing.T) {
+		p := newPlan("Add retries", "Requests fail under load.")
+		raw := p.RawContent
+		if err := resolvePlanTitle(p); err != nil {
+			t.Fatalf("resolvePlanTitle() error = %v", err)
+		}
+		if p.Title != "Add retries" || p.RawContent != raw {
+			t.Errorf("expected the plan unchanged, got %q", p.Title)
+		}
+	})
+}
+
+func TestSavePlanContent_RejectsBlankTitle(t *testing.T) {
+	t.Setenv("JIG_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	content := "---\nid: PLAN-BLANK\ntitle: \"   \"\nstatus: draft\nauthor: alice\n---\n\n" +
+		"## Problem Statement\n\nRequests fail.\n\n## Proposed Solution\n\nRetry.\n"
+	_, err := captureStdout(t, func() error { return savePlanContent([]byte(content)) })
+	if err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("expected a title error, got %v", err)
+	}
+}
+
+// launchRecorder is a runner that records its launches and runs onLaunch,
+// e.g. to save a plan as the planning session would
+type launchRecorder struct {
+	mockRunner
+	launches []*runner.LaunchOpts
+	onLaunch func()
+}
+
+func (r *launchRecorder) Launch(ctx context.Context, opts *runner.LaunchOpts) (*runner.LaunchResult, error) {
+	r.launches = append(r.launches, opts)
+	if r.onLaunch != nil {
+		r.onLaunch()
+	}
+	return &runner.LaunchResult{}, nil
+}
+
+func TestResumePlanSession(t *testing.T) {
+	newDeps := func() (*Deps, *launchRecorder) {
+		r := &launchRecorder{mockRunner: mockRunner{name: "claude", available: true}}
+		reg := newMockRegistry()
+		reg.Register(r)
+		return &Deps{RunnerRegistry: reg}, r
+	}
+	startSession := func(t *testing.T, sessionID string) {
+		t.Helper()
+		if err := writeSessionMetadata(sessionID, state.SessionMetadata{IssueID: "NUM-41", Goal: "Retry logins"}); err != nil {
+			t.Fatalf("writeSessionMetadata() error = %v", err)
+		}
+	}
+
+	t.Run("relaunches the runner with the session's prompt", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		startSession(t, "1234")
+		d, r := newDeps()
+		r.onLaunch = func() { writeSavedPlanID("1234", "NUM-41") }
+
+		out, err := captureStdout(t, func() error {
+			return resumePlanSession(context.Background(), d, "1234", "claude")
+		})
+		if err != nil {
+			t.Fatalf("resumePlanSession() error = %v", err)
+		}
+		if len(r.launches) != 1 {
+			t.Fatalf("expected 1 launch, got %d", len(r.launches))
+		}
+		if got := r.launches[0]; got.InitialPrompt != "/jig:plan 1234" || !got.PlanMode || !got.Interactive {
+			t.Errorf("Launch() opts = %+v, want an interactive plan mode launch of /jig:plan 1234", got)
+		}
+		for _, want := range []string{"NUM-41", "Retry logins", "Plan saved: NUM-41"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("shows next steps when a plan was already saved", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		startSession(t, "1234")
+		writeSavedPlanID("1234", "NUM-41")
+		d, r := newDeps()
+
+		out, err := captureStdout(t, func() error {
+			return resumePlanSession(context.Background(), d, "1234", "claude")
+		})
+		if err != nil {
+			t.Fatalf("resumePlanSession() error = %v", err)
+		}
+		if len(r.launches) != 0 {
+			t.Errorf("expected no launch, got %d", len(r.launches))
+		}
+		if !strings.Contains(out, "jig implement NUM-41") {
+			t.Errorf("expected next steps for NUM-41, got:\n%s", out)
+		}
+	})
+
+	t.Run("errors for an unknown session", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		d, r := newDeps()
+
+		if err := resumePlanSession(context.Background(), d, "missing", "claude"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("resumePlanSession() error = %v, want a not found error", err)
+		}
+		if len(r.launches) != 0 {
+			t.Errorf("expected no launch, got %d", len(r.launches))
+		}
+	})
+
+	t.Run("rejects session IDs outside the sessions directory", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		d, r := newDeps()
+		// A directory the IDs would reach if joined to .jig/sessions as is
+		if err := os.MkdirAll(filepath.Join(".jig", "sessions", "nested"), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, id := range []string{"..", ".", "../sessions", "nested/..", "/tmp", ".hidden"} {
+			if err := resumePlanSession(context.Background(), d, id, "claude"); err == nil || !strings.Contains(err.Error(), "invalid planning session ID") {
+				t.Errorf("resumePlanSession(%q) error = %v, want an invalid ID error", id, err)
+			}
+		}
+		if len(r.launches) != 0 {
+			t.Errorf("expected no launch, got %d", len(r.launches))
+		}
+	})
+}
+
+func TestNewPlanID(t *testing.T) {
+	t.Run("uses the configured format", func(t *testing.T) {
+		setupLookupCache(t)
+		for format, pattern := range map[string]string{
+			"":          `^plan-add-oauth-[0-9a-f]{4}$`,
+			"slug":      `^plan-add-oauth-[0-9a-f]{4}$`,
+			"timestamp": `^PLAN-[0-9]+$`,
+		} {
+			cfg := &config.Config{Plan: config.PlanConfig{IDFormat: format}}
+			id, err := newPlanID(cfg, "Add OAuth")
+			if err != nil {
+				t.Fatalf("newPlanID(%q) error = %v", format, err)
+			}
+			if !regexp.MustCompile(pattern).MatchString(id) {
+				t.Errorf("newPlanID(%q) = %q, want it to match %s", format, id, pattern)
+			}
+		}
+	})
+
+	t.Run("avoids an ID already in the cache", func(t *testing.T) {
+		taken := fmt.Sprintf("PLAN-%d", time.Now().Unix())
+		setupLookupCache(t, plan.NewPlan(taken, "Existing", "tester"))
+
+		id, err := newPlanID(&config.Config{Plan: config.PlanConfig{IDFormat: "timestamp"}}, "Add OAuth")
+		if err != nil {
+			t.Fatalf("newPlanID() error = %v", err)
+		}
+		if id == taken || !strings.HasPrefix(id, "PLAN-") {
+			t.Errorf("newPlanID() = %q, want a PLAN- ID other than %q", id, taken)
+		}
+	})
+
+	t.Run("rejects an unknown format", func(t *testing.T) {
+		setupLookupCache(t)
+		if _, err := newPlanID(&config.Config{Plan: config.PlanConfig{IDFormat: "uuid"}}, "Add OAuth"); err == nil {
+			t.Error("expected an error for an unknown plan.id_format")
+		}
+	})
+}
+
+func TestOfflineMode(t *testing.T) {
+	// Any request to Linear means a tracker call was made while offline
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	// Set the keys explicitly, as earlier tests may have set them in viper
+	jigHome := setupOnboardingConfig(t, "", "test-key")
+	for key, value := range map[string]interface{}{
+		"default.tracker":             "linear",
+		"linear.api_url":              server.URL,
+		"linear.sync_plan_on_save":    true,
+		"linear.create_issue_on_save": true,
+	} {
+		if err := config.Set(key, value); err != nil {
+			t.Fatalf("config.Set(%s) error = %v", key, err)
+		}
+	}
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	linked := plan.NewPlan("PLAN-1", "Offline Plan", "tester")
+	linked.IssueID = "NUM-41"
+	if !shouldSyncPlan(config.Get(), linked) {
+		t.Fatal("expected a linked plan to sync when online")
+	}
+
+	t.Setenv("JIG_OFFLINE", "1")
+	t.Chdir(t.TempDir())
+
+	t.Run("save skips issue creation and sync", func(t *testing.T) {
+		if shouldCreateIssueForPlan(config.Get(), plan.NewPlan("PLAN-2", "Unlinked", "tester")) {
+			t.Error("expected no issue creation while offline")
+		}
+		if shouldSyncPlan(config.Get(), linked) {
+			t.Error("expected no sync while offline")
+		}
+
+		planFile := filepath.Join(jigHome, "plan.md")
+		os.WriteFile(planFile, []byte(`---
+id: PLAN-OFFLINE
+title: Offline Plan
+status: draft
+author: tester
+issue_id: NUM-41
+---
+
+# Offline Plan
+
+## Problem Statement
+
+Planes have no wifi.
+
+## Proposed Solution
+
+Save to the cache only.
+`), 0644)
+
+		out, err := captureStdout(t, func() error {
+			return runPlanSave(planSaveCmd, []string{planFile})
+		})
+		if err != nil {
+			t.Fatalf("runPlanSave() error = %v", err)
+		}
+		if !strings.Contains(out, "Plan saved: PLAN-OFFLINE") || strings.Contains(out, "⚠") {
+			t.Errorf("expected the plan saved without warnings, got:\n%s", out)
+		}
+		if p, err := state.DefaultCache.GetPlan("PLAN-OFFLINE"); err != nil || p == nil {
+			t.Errorf("expected the plan in the cache, got %v, %v", p, err)
+		}
+	})
+
+	t.Run("show skips fetching comments", func(t *testing.T) {
+		planShowComments = true
+		defer func() { planShowComments = false }()
+
+		out, err := captureStdout(t, func() error {
+			return runPlanShow(planShowCmd, []string{"PLAN-OFFLINE"})
+		})
+		if err != nil {
+			t.Fatalf("runPlanShow() error = %v", err)
+		}
+		if !strings.Contains(out, "Planes have no wifi.") || strings.Contains(out, "## Comments") {
+			t.Errorf("expected the cached plan without comments, got:\n%s", out)
+		}
+	})
+
+	t.Run("tracker clients aren't created", func(t *testing.T) {
+		if _, err := getTracker(config.Get()); !errors.Is(err, errOffline) {
+			t.Errorf("getTracker() error = %v, want errOffline", err)
+		}
+		if _, err := getPlanSyncer(config.Get()); !errors.Is(err, errOffline) {
+			t.Errorf("getPlanSyncer() error = %v, want errOffline", err)
+		}
+	})
+
+	if requests != 0 {
+		t.Errorf("expected no requests to Linear while offline, got %d", requests)
+	}
+}
+
+func TestSavePlanContent_SessionNoIssue(t *testing.T) {
+	// Any request to Linear means an issue was created or synced
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	// Set the keys explicitly, as earlier tests may have set them in viper
+	setupOnboardingConfig(t, "", "test-key")
+	for key, value := range map[string]interface{}{
+		"default.tracker":             "linear",
+		"linear.api_url":              server.URL,
+		"linear.create_issue_on_save": true,
+	} {
+		if err := config.Set(key, value); err != nil {
+			t.Fatalf("config.Set(%s) error = %v", key, err)
+		}
+	}
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	const sessionID = "no-issue-session"
+	if err := writeSessionMetadata(sessionID, state.SessionMetadata{NoIssue: true}); err != nil {
+		t.Fatalf("writeSessionMetadata() error = %v", err)
+	}
+	planSaveSessionID = sessionID
+	defer func() { planSaveSessionID = "" }()
+
+	content := []byte("---\nid: PLAN-THROWAWAY\ntitle: Throwaway Plan\nstatus: draft\nauthor: tester\n---\n\n" +
+		"# Throwaway Plan\n\n## Problem Statement\n\nTrying an idea.\n\n## Proposed Solution\n\nSpike it.\n")
+
+	t.Run("dry run would not create an issue", func(t *testing.T) {
+		planSaveDryRun = true
+		defer func() { planSaveDryRun = false }()
+
+		out, err := captureStdout(t, func() error { return savePlanContent(content) })
+		if err != nil {
+			t.Fatalf("savePlanContent() error = %v", err)
+		}
+		if strings.Contains(out, "Create a Linear issue") {
+			t.Errorf("expected no issue creation in the preview, got:\n%s", out)
+		}
+	})
+
+	t.Run("save skips issue creation", func(t *testing.T) {
+		out, err := captureStdout(t, func() error { return savePlanContent(content) })
+		if err != nil {
+			t.Fatalf("savePlanContent() error = %v", err)
+		}
+		if !strings.Contains(out, "Plan saved: PLAN-THROWAWAY") {
+			t.Errorf("expected the plan saved, got:\n%s", out)
+		}
+		p, err := state.DefaultCache.GetPlan("PLAN-THROWAWAY")
+		if err != nil || p == nil {
+			t.Fatalf("expected the plan in the cache, got %v, %v", p, err)
+		}
+		if p.HasLinkedIssue() {
+			t.Errorf("expected no issue linked, got %s", p.IssueID)
+		}
+	})
+
+	if requests != 0 {
+		t.Errorf("expected no requests to Linear, got %d", requests)
+	}
+}
+
+func TestPlanSaveAndSync_GitLab(t *testing.T) {
+	// A GitLab project with a single issue and its notes
+	var (
+		issue    map[string]interface{}
+		notes    []map[string]interface{}
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		if r.Header.Get("PRIVATE-TOKEN") != "gl-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4"); {
+		case path == "/user":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "name": "Jane"})
+		case path == "/projects/acme%2Fapi/issues" && r.Method == http.MethodPost:
+			issue = map[string]interface{}{"iid": 1, "project_id": 7, "title": body["title"], "state": "opened", "labels": []string{}}
+			json.NewEncoder(w).Encode(issue)
+		case path == "/projects/acme%2Fapi/issues/1" && issue != nil && r.Method == http.MethodPut:
+			for key, value := range body {
+				issue[key] = value
+			}
+			json.NewEncoder(w).Encode(issue)
+		case path == "/projects/acme%2Fapi/issues/1" && issue != nil:
+			json.NewEncoder(w).Encode(issue)
+		case path == "/projects/acme%2Fapi/issues/1/notes" && r.Method == http.MethodPost:
+			note := map[string]interface{}{"id": 100 + len(notes), "body": body["body"], "author": map[string]interface{}{"id": 1}}
+			notes = append(notes, note)
+			json.NewEncoder(w).Encode(note)
+		case path == "/projects/acme%2Fapi/issues/1/notes":
+			json.NewEncoder(w).Encode(notes)
+		case path == "/projects/acme%2Fapi/issues/1/notes/100" && r.Method == http.MethodPut:
+			notes[0]["body"] = body["body"]
+			json.NewEncoder(w).Encode(notes[0])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Set the keys explicitly, as earlier tests may have set them in viper
+	jigHome := setupOnboardingConfig(t, "", "")
+	store, err := config.NewStore()
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	if err := store.SetGitLabToken("gl-token"); err != nil {
+		t.Fatalf("failed to store token: %v", err)
+	}
+	for key, value := range map[string]interface{}{
+		"default.tracker": "gitlab",
+		"gitlab.url":      server.URL,
+		"gitlab.project":  "acme/api",
+	} {
+		if err := config.Set(key, value); err != nil {
+			t.Fatalf("config.Set(%s) error = %v", key, err)
+		}
+	}
+	if err := config.Init(""); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	t.Cleanup(func() {
+		config.Set("default.tracker", "linear")
+		config.Init("")
+	})
+	t.Chdir(t.TempDir())
+
+	planFile := filepath.Join(jigHome, "plan.md")
+	os.WriteFile(planFile, []byte(`---
+id: PLAN-GITLAB
+title: GitLab Plan
+status: draft
+author: tester
+---
+
+# GitLab Plan
+
+## Problem Statement
+
+Issues live in GitLab.
+
+## Proposed Solution
+
+Create and sync them there.
+`), 0644)
+
+	t.Run("save creates an issue and syncs the plan to it", func(t *testing.T) {
+		out, err := captureStdout(t, func() error {
+			return runPlanSave(planSaveCmd, []string{planFile})
+		})
+		if err != nil {
+			t.Fatalf("runPlanSave() error = %v", err)
+		}
+		if !strings.Contains(out, "Created issue GL-1") || !strings.Contains(out, "Plan synced to GitLab issue GL-1") {
+			t.Errorf("expected the issue created and synced, got:\n%s", out)
+		}
+		if len(notes) != 1 || !strings.Contains(notes[0]["body"].(string), "Issues live in GitLab.") {
+			t.Errorf("expected the plan synced as a note, got %v", notes)
+		}
+		p, err := state.DefaultCache.GetPlan("PLAN-GITLAB")
+		if err != nil || p == nil || p.IssueID != "GL-1" {
+			t.Fatalf("expected the plan linked to GL-1, got %+v, %v", p, err)
+		}
+	})
+
+	t.Run("sync updates the plan's issue", func(t *testing.T) {
+		if err := state.DefaultCache.MarkPlanUnsynced("PLAN-GITLAB"); err != nil {
+			t.Fatalf("MarkPlanUnsynced() error = %v", err)
+		}
+		requests = nil
+		planSyncCmd.SetContext(context.Background())
+		out, err := captureStdout(t, func() error {
+			return runPlanSync(planSyncCmd, []string{"PLAN-GITLAB"})
+		})
+		if err != nil {
+			t.Fatalf("runPlanSync() error = %v", err)
+		}
+		if !strings.Contains(out, "Plan synced to issue GL-1") {
+			t.Errorf("expected the plan synced, got:\n%s", out)
+		}
+		if !slices.Contains(requests, "PUT /api/v4/projects/acme%2Fapi/issues/1/notes/100") {
+			t.Errorf("expected the plan's note updated in place, got requests %v", requests)
+		}
+	})
+
+	t.Run("status and rename update the plan's issue", func(t *testing.T) {
+		out, err := captureStdout(t, func() error {
+			return runPlanStatus(planStatusCmd, []string{"PLAN-GITLAB", "in-progress"})
+		})
+		if err != nil {
+			t.Fatalf("runPlanStatus() error = %v", err)
+		}
+		if !strings.Contains(out, "Moved GL-1 to in_progress") || issue["add_labels"] != "status::in_progress" {
+			t.Errorf("expected the issue moved to in progress, got %v and:\n%s", issue, out)
+		}
+
+		out, err = captureStdout(t, func() error {
+			return runPlanRename(planRenameCmd, []string{"PLAN-GITLAB", "Renamed", "Plan"})
+		})
+		if err != nil {
+			t.Fatalf("runPlanRename() error = %v", err)
+		}
+		if !strings.Contains(out, "Renamed GL-1") || issue["title"] != "Renamed Plan" {
+			t.Errorf("expected the issue retitled, got %v and:\n%s", issue, out)
+		}
+	})
+}
+
+func TestPlanNewCmd_NoIssueFlag(t *testing.T) {
+	for _, cmd := range []*cobra.Command{planCmd, planNewCmd} {
+		flag := cmd.Flags().Lookup("no-issue")
+		if flag == nil {
+			t.Fatalf("expected --no-issue flag to be defined on %s", cmd.Name())
+		}
+		if flag.DefValue != "false" {
+			t.Errorf("expected --no-issue default value to be false, got %q", flag.DefValue)
+		}
+	}
+}
+
+// TestPlanUpdates_KeepSyncState checks that commands saving a cached plan keep
+// the hashes its next sync is compared against
+func TestPlanUpdates_KeepSyncState(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		update func(deps planPhaseDeps) error
+	}{
+		{"pull", func(deps planPhaseDeps) error {
+			_, err := pullPlanStatusWithDeps(ctx, "PLAN-1", planPullDeps{
+				getCachedPlan: deps.getCachedPlan,
+				savePlan:      deps.savePlan,
+				getIssue: func(ctx context.Context, id string) (*tracker.Issue, error) {
+					return &tracker.Issue{Identifier: id, Status: tracker.StatusInProgress}, nil
+				},
+			})
+			return err
+		}},
+		{"phase set", func(deps planPhaseDeps) error {
+			_, err := setPlanPhaseStatusWithDeps("PLAN-1", "p1", "done", deps)
+			return err
+		}},
+		{"status", func(deps planPhaseDeps) error {
+			_, err := setPlanStatusWithDeps(ctx, "PLAN-1", "reviewing", planStatusDeps{getCachedPlan: deps.getCachedPlan, savePlan: deps.savePlan})
+			return err
+		}},
+		{"rename", func(deps planPhaseDeps) error {
+			_, err := renamePlanWithDeps(ctx, "PLAN-1", "Renamed", planRenameDeps{getCachedPlan: deps.getCachedPlan, savePlan: deps.savePlan})
+			return err
+		}},
+		{"implement phase", func(deps planPhaseDeps) error {
+			cached, err := deps.getCachedPlan("PLAN-1")
+			if err != nil {
+				return err
+			}
+			_, _, err = implementPhaseWithDeps(cached.Plan, "p1", deps)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := plan.NewPlan("PLAN-1", "Sync State", "tester")
+			p.IssueID = "NUM-1"
+			p.Phases = []plan.Phase{{ID: "p1", Title: "One"}}
+			setupLookupCache(t, p)
+			if err := state.DefaultCache.MarkPlanSyncedWithHash("PLAN-1", "content-hash"); err != nil {
+				t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+			}
+			if err := state.DefaultCache.SetSyncedCommentHash("PLAN-1", "comment-hash"); err != nil {
+				t.Fatalf("SetSyncedCommentHash() error = %v", err)
+			}
+
+			deps := planPhaseDeps{
+				getCachedPlan: state.DefaultCache.GetCachedPlan,
+				savePlan:      state.DefaultCache.SavePlan,
+			}
+			if _, err := captureStdout(t, func() error { return tt.update(deps) }); err != nil {
+				t.Fatalf("update error = %v", err)
+			}
+
+			cached, err := state.DefaultCache.GetCachedPlan("PLAN-1")
+			if err != nil {
+				t.Fatalf("GetCachedPlan() error = %v", err)
+			}
+			if cached.SyncedAt == nil || cached.SyncedContentHash != "content-hash" || cached.SyncedCommentHash != "comment-hash" {
+				t.Errorf("expected the sync state kept, got synced at %v, content hash %q, comment hash %q",
+					cached.SyncedAt, cached.SyncedContentHash, cached.SyncedCommentHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+	"github.com/charleslr/jig/internal/tracker"
+	"github.com/charleslr/jig/internal/tracker/mock"
+)
+
+func TestUnsyncPlanWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newUnsyncDeps returns deps serving a cached plan linked to issueID,
+	// recording which plans were marked unsynced
+	newUnsyncDeps := func(issueID string, tr tracker.Tracker) (planUnsyncDeps, *[]string) {
+		p := &plan.Plan{ID: "PLAN-1", IssueID: issueID, Title: "Test Plan"}
+
+		var unsynced []string
+		deps := planUnsyncDeps{
+			getCachedPlan: func(id string) (*state.CachedPlan, error) {
+				if id != p.ID {
+					return nil, nil
+				}
+				return &state.CachedPlan{Plan: p}, nil
+			},
+			markUnsynced: func(id string) error {
+				unsynced = append(unsynced, id)
+				return nil
+			},
+			getTracker: func() (tracker.Tracker, error) { return tr, nil },
+		}
+		return deps, &unsynced
+	}
+
+	newIssue := func(t *testing.T, client *mock.Client, labels ...string) *tracker.Issue {
+		t.Helper()
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue", Labels: labels})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		return issue
+	}
+
+	t.Run("removes plan label and keeps other labels", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, "bug", "jig-plan", "backend")
+		deps, unsynced := newUnsyncDeps(issue.Identifier, client)
+
+		result, err := unsyncPlanWithDeps(ctx, "PLAN-1", "jig-plan", deps)
+		if err != nil {
+			t.Fatalf("unsyncPlanWithDeps() error = %v", err)
+		}
+
+		if !result.LabelRemoved {
+			t.Error("expected label to be removed")
+		}
+		got, _ := client.GetIssue(ctx, issue.ID)
+		if labels := strings.Join(got.Labels, ","); labels != "bug,backend" {
+			t.Errorf("expected labels bug,backend, got %s", labels)
+		}
+		if strings.Join(*unsynced, ",") != "PLAN-1" {
+			t.Errorf("expected PLAN-1 to be marked unsynced, got %v", *unsynced)
+		}
+	})
+
+	t.Run("issue without the label", func(t *testing.T) {
+		client := mock.NewClient()
+		issue := newIssue(t, client, "bug")
+		deps, unsynced := newUnsyncDeps(issue.Identifier, client)
+
+		result, err := unsyncPlanWithDeps(ctx, "PLAN-1", "jig-plan", deps)
+		if err != nil {
+			t.Fatalf("unsyncPlanWithDeps() error = %v", err)
+		}
+		if result.LabelRemoved {
+			t.Error("expected no label to be removed")
+		}
+		if len(*unsynced) != 1 {
+			t.Error("expected plan to be marked unsynced")
+		}
+	})
+
+	t.Run("without removing the label the tracker is not used", func(t *testing.T) {
+		deps, unsynced := newUnsyncDeps("NUM-1", nil)
+		deps.getTracker = func() (tracker.Tracker, error) {
+			t.Error("tracker should not be used")
+			return nil, nil
+		}
+
+		if _, err := unsyncPlanWithDeps(ctx, "PLAN-1", "", deps); err != nil {
+			t.Fatalf("unsyncPlanWithDeps() error = %v", err)
+		}
+		if len(*unsynced) != 1 {
+			t.Error("expected plan to be marked unsynced")
+		}
+	})
+
+	t.Run("failed label removal leaves sync state untouched", func(t *testing.T) {
+		// The mock tracker has no such issue
+		deps, unsynced := newUnsyncDeps("MOCK-404", mock.NewClient())
+
+		_, err := unsyncPlanWithDeps(ctx, "PLAN-1", "jig-plan", deps)
+		if err == nil || !strings.Contains(err.Error(), "issue not found") {
+			t.Errorf("expected issue not found error, got %v", err)
+		}
+		if len(*unsynced) != 0 {
+			t.Error("expected plan not to be marked unsynced")
+		}
+	})
+
+	t.Run("removing the label requires a linked issue", func(t *testing.T) {
+		deps, _ := newUnsyncDeps("", mock.NewClient())
+
+		_, err := unsyncPlanWithDeps(ctx, "PLAN-1", "jig-plan", deps)
+		if err == nil || !strings.Contains(err.Error(), "not linked to an issue") {
+			t.Errorf("expected not linked error, got %v", err)
+		}
+	})
+
+	t.Run("missing plan", func(t *testing.T) {
+		deps, _ := newUnsyncDeps("", nil)
+
+		_, err := unsyncPlanWithDeps(ctx, "PLAN-404", "", deps)
+		if err == nil || !strings.Contains(err.Error(), "plan not found: PLAN-404") {
+			t.Errorf("expected plan not found error, got %v", err)
+		}
+	})
+}
+
+func TestUnlinkPlanWithDeps(t *testing.T) {
+	ctx := context.Background()
+
+	// newUnlinkDeps returns deps backed by an isolated cache holding a synced
+	// plan linked to issueID
+	newUnlinkDeps := func(t *testing.T, issueID string, tr tracker.Tracker) planUnlinkDeps {
+		p := plan.NewPlan("PLAN-1", "Test Plan", "tester")
+		p.IssueID = issueID
+		p.RelatedIssues = []string{"NUM-2"}
+		setupLookupCache(t, p)
+		if err := state.DefaultCache.MarkPlanSyncedWithHash("PLAN-1", "hash-1"); err != nil {
+			t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+		}
+
+		return planUnlinkDeps{
+			planUnsyncDeps: planUnsyncDeps{
+				getCachedPlan: state.DefaultCache.GetCachedPlan,
+				markUnsynced:  state.DefaultCache.MarkPlanUnsynced,
+				getTracker:    func() (tracker.Tracker, error) { return tr, nil },
+			},
+			updatePlan: state.DefaultCache.UpdatePlan,
+		}
+	}
+
+	t.Run("clears the link and sync state", func(t *testing.T) {
+		deps := newUnlinkDeps(t, "NUM-1", nil)
+
+		result, err := unlinkPlanWithDeps(ctx, "PLAN-1", "", deps)
+		if err != nil {
+			t.Fatalf("unlinkPlanWithDeps() error = %v", err)
+		}
+		if result.IssueID != "NUM-1" || result.LabelRemoved {
+			t.Errorf("unexpected result %+v", result)
+		}
+
+		cached, err := state.DefaultCache.GetCachedPlan("PLAN-1")
+		if err != nil {
+			t.Fatalf("GetCachedPlan() error = %v", err)
+		}
+		if cached.Plan.IssueID != "" || cached.IssueID != "" || len(cached.Plan.RelatedIssues) != 0 {
+			t.Errorf("expected the link to be cleared, got plan issue %q, cached issue %q, related %v",
+				cached.Plan.IssueID, cached.IssueID, cached.Plan.RelatedIssues)
+		}
+		if cached.SyncedAt != nil || cached.SyncedContentHash != "" {
+			t.Errorf("expected sync state to be reset, got %v and %q", cached.SyncedAt, cached.SyncedContentHash)
+		}
+		if md, _ := state.DefaultCache.GetPlanMarkdown("PLAN-1"); strings.Contains(md, "NUM-1") {
+			t.Errorf("expected the markdown to drop the issue, got:\n%s", md)
+		}
+		if linked, _ := state.DefaultCache.GetPlansByIssueID("NUM-1"); len(linked) != 0 {
+			t.Errorf("expected no plans linked to NUM-1, got %d", len(linked))
+		}
+	})
+
+	t.Run("removes the plan label", func(t *testing.T) {
+		client := mock.NewClient()
+		issue, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Test Issue", Labels: []string{"bug", "jig-plan"}})
+		if err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		deps := newUnlinkDeps(t, issue.Identifier, client)
+
+		result, err := unlinkPlanWithDeps(ctx, "PLAN-1", "jig-plan", deps)
+		if err != nil {
+			t.Fatalf("unlinkPlanWithDeps() error = %v", err)
+		}
+		if !result.LabelRemoved {
+			t.Error("expected label to be removed")
+		}
+		got, _ := client.GetIssue(ctx, issue.ID)
+		if labels := strings.Join(got.Labels, ","); labels != "bug" {
+			t.Errorf("expected labels bug, got %s", labels)
+		}
+	})
+
+	t.Run("failed label removal leaves the plan linked", func(t *testing.T) {
+		deps := newUnlinkDeps(t, "MOCK-404", mock.NewClient())
+
+		if _, err := unlinkPlanWithDeps(ctx, "PLAN-1", "jig-plan", deps); err == nil {
+			t.Fatal("expected an error")
+		}
+		cached, _ := state.DefaultCache.GetCachedPlan("PLAN-1")
+		if cached.Plan.IssueID != "MOCK-404" || cached.SyncedAt == nil {
+			t.Errorf("expected the plan to stay linked and synced, got %+v", cached)
+		}
+	})
+
+	t.Run("plan without an issue", func(t *testing.T) {
+		deps := newUnlinkDeps(t, "", nil)
+
+		_, err := unlinkPlanWithDeps(ctx, "PLAN-1", "", deps)
+		if err == nil || !strings.Contains(err.Error(), "not linked to an issue") {
+			t.Errorf("expected not linked error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const validatePlanBody = `
+# Test Plan
+
+## Problem Statement
+
+This is the problem.
+
+## Proposed Solution
+
+This is the solution.
+`
+
+func TestValidatePlanContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantProblems []string
+		wantOutput   []string
+	}{
+		{
+			name: "valid plan",
+			content: `---
+title: Test Plan
+status: draft
+author: tester
+labels: [backend]
+phases:
+  - id: phase-1
+    title: Phase 1
+---
+` + validatePlanBody,
+			wantOutput: []string{
+				"Plan is valid",
+				"ID: none (generated on save)",
+				"Title: Test Plan",
+				"Status: draft",
+				"Phases: 1",
+				"Labels: backend",
+			},
+		},
+		{
+			name:    "missing frontmatter",
+			content: validatePlanBody,
+			wantProblems: []string{
+				"missing required frontmatter fields: title, status, author",
+			},
+		},
+		{
+			name: "bad status value",
+			content: `---
+title: Test Plan
+status: finished
+author: tester
+---
+` + validatePlanBody,
+			wantProblems: []string{
+				`line 3: status "finished" is not valid`,
+			},
+		},
+		{
+			name: "inconsistent fields",
+			content: `---
+title: Test Plan
+status: draft
+author: tester
+issue_id: not-an-issue
+phases:
+  - id: phase-1
+  - id: phase-1
+---
+` + validatePlanBody,
+			wantProblems: []string{
+				`issue_id: "not-an-issue" is not an issue identifier`,
+				`phases[1].id: "phase-1" is used by another phase`,
+			},
+		},
+		{
+			name: "missing section",
+			content: `---
+title: Test Plan
+status: draft
+author: tester
+---
+
+## Problem Statement
+
+This is the problem.
+`,
+			wantProblems: []string{
+				"missing required sections: Proposed Solution",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, problems := validatePlanContent([]byte(tt.content))
+
+			if len(problems) != len(tt.wantProblems) {
+				t.Fatalf("expected %d problems, got %d: %v", len(tt.wantProblems), len(problems), problems)
+			}
+			for i, want := range tt.wantProblems {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %d = %q, want it to contain %q", i, problems[i], want)
+				}
+			}
+			if len(problems) == 0 && p == nil {
+				t.Fatal("expected a parsed plan for a valid plan")
+			}
+
+			var buf bytes.Buffer
+			writePlanValidation(&buf, p, problems)
+			output := buf.String()
+			if len(problems) > 0 && !strings.HasPrefix(output, "Plan is invalid:") {
+				t.Errorf("expected invalid output, got:\n%s", output)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRunPlanValidate(t *testing.T) {
+	jigHome := createTestJigHome(t)
+	defer os.RemoveAll(jigHome)
+	t.Setenv("JIG_HOME", jigHome)
+
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.md")
+	os.WriteFile(validFile, []byte("---\ntitle: Test Plan\nstatus: draft\nauthor: tester\n---\n"+validatePlanBody), 0644)
+	invalidFile := filepath.Join(dir, "invalid.md")
+	os.WriteFile(invalidFile, []byte("---\ntitle: Test Plan\nstatus: finished\nauthor: tester\n---\n"+validatePlanBody), 0644)
+
+	if _, err := captureStdout(t, func() error { return runPlanValidate(nil, []string{validFile}) }); err != nil {
+		t.Errorf("runPlanValidate() on a valid plan error = %v", err)
+	}
+
+	output, err := captureStdout(t, func() error { return runPlanValidate(nil, []string{invalidFile}) })
+	if err == nil || !strings.Contains(err.Error(), "plan is invalid (1 problem(s))") {
+		t.Errorf("expected invalid plan error, got %v", err)
+	}
+	if !strings.Contains(output, `status "finished" is not valid`) {
+		t.Errorf("expected the problem to be printed, got:\n%s", output)
+	}
+
+	// Validating never touches the cache
+	if _, err := os.Stat(filepath.Join(jigHome, "cache")); !os.IsNotExist(err) {
+		t.Errorf("expected no cache directory to be created, got %v", err)
+	}
+}
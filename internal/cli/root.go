@@ -1,17 +1,23 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/logging"
+	"github.com/charleslr/jig/internal/runner"
 )
 
 var (
 	cfgFile string
+	offline bool
 	rootCmd = &cobra.Command{
 		Use:   "jig",
 		Short: "A workflow orchestrator for software engineering",
@@ -31,7 +37,7 @@ func Execute() error {
 	// Enable Cobra's built-in command suggestions
 	rootCmd.SuggestionsMinimumDistance = 2
 
-	err := rootCmd.Execute()
+	err := friendlyTrackerError(rootCmd.Execute())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 	}
@@ -43,9 +49,12 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is ~/.jig/config.toml)")
-	rootCmd.PersistentFlags().Bool("verbose", false, "enable verbose output")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "enable verbose output")
+	rootCmd.PersistentFlags().Bool("debug", false, "enable debug logging (API requests, cache and session marker operations)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "work from the local cache only, without contacting the tracker (or set JIG_OFFLINE=1)")
 
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
@@ -59,15 +68,88 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(issuesCmd)
+	rootCmd.AddCommand(issueCmd)
+	rootCmd.AddCommand(cacheCmd)
 	rootCmd.AddCommand(amendCmd)
 	rootCmd.AddCommand(hookCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
 func initConfig() {
+	if level, ok := logging.LevelFromFlags(viper.GetBool("verbose"), viper.GetBool("debug")); ok {
+		logging.Configure(os.Stderr, level)
+	}
+
 	if err := config.Init(cfgFile); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
 	}
+
+	// Register the runners declared in the config alongside the built-in ones
+	runner.DefaultRegistry.LoadConfig(config.Get().Runners)
+}
+
+// errOffline is returned instead of contacting the tracker while offline
+var errOffline = errors.New("jig is offline; unset --offline or JIG_OFFLINE to contact the tracker")
+
+// offlineNotice prints the offline notice once per run
+var offlineNotice sync.Once
+
+// isOffline reports whether jig should work from the local cache only, as
+// set with --offline or JIG_OFFLINE
+func isOffline() bool {
+	if offline {
+		return true
+	}
+	env, _ := strconv.ParseBool(os.Getenv("JIG_OFFLINE"))
+	return env
+}
+
+// trackerOffline reports whether a tracker call should be skipped because jig
+// is offline, printing a notice the first time one is
+func trackerOffline() bool {
+	if !isOffline() {
+		return false
+	}
+	offlineNotice.Do(func() {
+		fmt.Fprintln(os.Stderr, "→ Offline: working from the local cache only")
+	})
+	return true
+}
+
+// exitCodeError wraps an error with the process exit code it should produce
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode wraps err so that ExitCode reports the given code for it
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+// ExitCode returns the process exit code for an error returned by Execute.
+// Errors without a specific code map to 1; a nil error maps to 0.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.code
+	}
+	return 1
 }
 
 // Helper functions for CLI
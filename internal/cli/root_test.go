@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil error", err: nil, want: 0},
+		{name: "plain error", err: errors.New("boom"), want: 1},
+		{name: "coded error", err: withExitCode(3, errors.New("partial")), want: 3},
+		{name: "wrapped coded error", err: fmt.Errorf("outer: %w", withExitCode(4, errors.New("auth"))), want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithExitCode(t *testing.T) {
+	if withExitCode(3, nil) != nil {
+		t.Error("withExitCode(nil) should return nil")
+	}
+
+	inner := errors.New("inner")
+	err := withExitCode(3, inner)
+	if err.Error() != "inner" {
+		t.Errorf("expected message to be preserved, got %q", err.Error())
+	}
+	if !errors.Is(err, inner) {
+		t.Error("expected wrapped error to be unwrappable")
+	}
+}
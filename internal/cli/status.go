@@ -2,13 +2,20 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/charleslr/jig/internal/config"
 	"github.com/charleslr/jig/internal/git"
+	"github.com/charleslr/jig/internal/plan"
 	"github.com/charleslr/jig/internal/state"
 	"github.com/charleslr/jig/internal/ui"
 )
@@ -22,11 +29,36 @@ Shows:
 - Plan status
 - Linear issue status
 - PR status and unresolved comments
-- Worktree information`,
+- Worktree information
+
+Without ISSUE, the issue is detected from the current worktree or branch, or
+picked from the active worktrees. If there is none, or with --summary, shows
+a summary of the current repo and session instead:
+- The configured tracker
+- The plan for the current branch or planning session, and its sync state
+- Planning sessions and pending markers in the .jig directory
+
+The summary works outside a jig-initialized repo too, reporting what it can.
+
+Examples:
+  jig status
+  jig status NUM-123
+  jig status --summary
+  jig status --json`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStatus,
 }
 
+var (
+	statusSummaryOnly bool
+	statusJSON        bool
+)
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusSummaryOnly, "summary", false, "show the repo and session summary instead of an issue's status")
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "output the summary as JSON (implies --summary)")
+}
+
 func runStatus(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	cfg := config.Get()
@@ -39,6 +71,13 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize worktree state: %w", err)
 	}
 
+	if len(args) > 0 && (statusSummaryOnly || statusJSON) {
+		return fmt.Errorf("--summary and --json are only supported without ISSUE")
+	}
+	if statusSummaryOnly || statusJSON {
+		return runStatusSummary(cfg)
+	}
+
 	var issueID string
 	var worktreeInfo *state.WorktreeInfo
 
@@ -58,12 +97,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		// Try to detect from branch name
 		if issueID == "" {
 			branch, _ := git.GetCurrentBranch()
-			if branch != "" {
-				parts := strings.SplitN(branch, "-", 3)
-				if len(parts) >= 2 {
-					issueID = parts[0] + "-" + parts[1]
-				}
-			}
+			issueID = issueIDFromBranch(branch)
 		}
 	}
 
@@ -95,8 +129,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Without an issue to report on, summarize the repo and session
 	if issueID == "" {
-		return fmt.Errorf("could not detect issue - provide ISSUE argument or run from a worktree")
+		return runStatusSummary(cfg)
 	}
 
 	fmt.Printf("Issue: %s\n", issueID)
@@ -201,3 +236,270 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// statusSummary is the state of the current repo and session reported by
+// `jig status --summary`, or `jig status` when no issue is detected
+type statusSummary struct {
+	Tracker     string          `json:"tracker"`
+	Initialized bool            `json:"initialized"` // Whether the working directory has a .jig directory
+	Branch      string          `json:"branch,omitempty"`
+	IssueID     string          `json:"issue_id,omitempty"`
+	Plan        *statusPlan     `json:"plan,omitempty"`
+	Sessions    []statusSession `json:"sessions,omitempty"`
+	Markers     []string        `json:"markers,omitempty"` // Pending markers not scoped to a session
+}
+
+// statusPlan is the plan found for the current branch or session
+type statusPlan struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	IssueID string `json:"issue_id,omitempty"`
+	Sync    string `json:"sync"`
+	Source  string `json:"source"` // What the plan was found from: session, worktree or branch
+}
+
+// statusSession is a planning session recorded in the .jig directory
+type statusSession struct {
+	ID          string   `json:"id"`
+	IssueID     string   `json:"issue_id,omitempty"`
+	SavedPlanID string   `json:"saved_plan_id,omitempty"`
+	Markers     []string `json:"markers,omitempty"`
+}
+
+// statusDeps contains dependencies for collecting the status summary
+type statusDeps struct {
+	tracker          string
+	currentBranch    func() (string, error)
+	currentWorktree  func() *state.WorktreeInfo
+	getCachedPlan    func(id string) (*state.CachedPlan, error)
+	getPlanByIssueID func(issueID string) (*state.CachedPlan, error)
+}
+
+func runStatusSummary(cfg *config.Config) error {
+	summary := collectStatusWithDeps(statusDeps{
+		tracker:       cfg.Default.Tracker,
+		currentBranch: git.GetCurrentBranch,
+		currentWorktree: func() *state.WorktreeInfo {
+			cwd, err := git.GetWorktreeRoot()
+			if err != nil {
+				return nil
+			}
+			info, _ := state.DefaultWorktreeState.GetByPath(cwd)
+			return info
+		},
+		getCachedPlan:    state.DefaultCache.GetCachedPlan,
+		getPlanByIssueID: state.DefaultCache.GetPlanByIssueID,
+	})
+
+	if statusJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+
+	writeStatusSummaryText(os.Stdout, summary)
+	return nil
+}
+
+// collectStatusWithDeps inspects the working directory's .jig directory and
+// the cache. Anything that can't be read is left out of the summary rather
+// than failing, so this works outside a jig-initialized repo.
+func collectStatusWithDeps(deps statusDeps) *statusSummary {
+	summary := &statusSummary{Tracker: deps.tracker}
+
+	if info, err := os.Stat(".jig"); err == nil && info.IsDir() {
+		summary.Initialized = true
+		summary.Markers = listMarkers(".jig")
+		summary.Sessions = listStatusSessions()
+	}
+
+	if branch, err := deps.currentBranch(); err == nil {
+		summary.Branch = branch
+	}
+
+	worktree := deps.currentWorktree()
+	if worktree != nil {
+		summary.IssueID = worktree.IssueID
+	}
+	if summary.IssueID == "" {
+		summary.IssueID = issueIDFromBranch(summary.Branch)
+	}
+
+	// Prefer the plan saved by the most recent session, then the plan linked
+	// to the worktree, then the plan for the branch's issue
+	var cp *state.CachedPlan
+	source := ""
+	for _, session := range summary.Sessions {
+		if session.SavedPlanID == "" {
+			continue
+		}
+		if cp, _ = deps.getCachedPlan(session.SavedPlanID); cp != nil {
+			source = "session"
+		}
+		break
+	}
+	if cp == nil && worktree != nil && worktree.PlanID != "" {
+		if cp, _ = deps.getCachedPlan(worktree.PlanID); cp != nil {
+			source = "worktree"
+		}
+	}
+	if cp == nil && summary.IssueID != "" {
+		if cp, _ = deps.getPlanByIssueID(summary.IssueID); cp != nil {
+			source = "branch"
+		}
+	}
+	if cp != nil && cp.Plan != nil {
+		status := string(cp.Plan.Status)
+		if status == "" {
+			status = string(plan.StatusDraft)
+		}
+		summary.Plan = &statusPlan{
+			ID:      cp.Plan.ID,
+			Title:   cp.Plan.Title,
+			Status:  status,
+			IssueID: cp.Plan.IssueID,
+			Sync:    planSyncState(cp),
+			Source:  source,
+		}
+	}
+
+	return summary
+}
+
+// planSyncState describes whether a cached plan is in sync with its issue
+func planSyncState(cp *state.CachedPlan) string {
+	switch {
+	case cp.Plan.IssueID == "":
+		return "not linked"
+	case cp.SyncedAt == nil:
+		return "never synced"
+	case cp.NeedsSync():
+		return "updated since last sync"
+	default:
+		return "synced"
+	}
+}
+
+// issueIDFromBranch returns the issue ID a branch is named after, e.g. NUM-123
+// for NUM-123-fix-login, or "" if the branch doesn't start with one
+func issueIDFromBranch(branch string) string {
+	parts := strings.SplitN(branch, "-", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return parts[0] + "-" + parts[1]
+}
+
+// listStatusSessions returns the planning sessions in the .jig directory,
+// most recently active first
+func listStatusSessions() []statusSession {
+	sessionsDir := filepath.Join(".jig", "sessions")
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil {
+		return nil
+	}
+
+	type activeSession struct {
+		session statusSession
+		modTime time.Time
+	}
+	var active []activeSession
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		id := entry.Name()
+		active = append(active, activeSession{
+			session: statusSession{
+				ID:          id,
+				IssueID:     readSessionMetadata(id).IssueID,
+				SavedPlanID: readSavedPlanID(id),
+				Markers:     listMarkers(filepath.Join(sessionsDir, id)),
+			},
+			modTime: info.ModTime(),
+		})
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].modTime.After(active[j].modTime)
+	})
+
+	sessions := make([]statusSession, len(active))
+	for i, a := range active {
+		sessions[i] = a.session
+	}
+	return sessions
+}
+
+// listMarkers returns the names of the marker files in a directory, sorted
+func listMarkers(dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.marker"))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, match := range matches {
+		names = append(names, strings.TrimSuffix(filepath.Base(match), ".marker"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeStatusSummaryText writes the status summary in a human-readable form
+func writeStatusSummaryText(w io.Writer, summary *statusSummary) {
+	fmt.Fprintf(w, "Tracker: %s\n", summary.Tracker)
+	if summary.Branch != "" {
+		fmt.Fprintf(w, "Branch:  %s\n", summary.Branch)
+	}
+	if summary.IssueID != "" {
+		fmt.Fprintf(w, "Issue:   %s\n", summary.IssueID)
+	}
+
+	fmt.Fprintln(w)
+	if summary.Plan != nil {
+		fmt.Fprintf(w, "Plan:    %s (from %s)\n", summary.Plan.ID, summary.Plan.Source)
+		fmt.Fprintf(w, "  Title:  %s\n", summary.Plan.Title)
+		fmt.Fprintf(w, "  Status: %s\n", summary.Plan.Status)
+		if summary.Plan.IssueID != "" {
+			fmt.Fprintf(w, "  Issue:  %s\n", summary.Plan.IssueID)
+		}
+		fmt.Fprintf(w, "  Sync:   %s\n", summary.Plan.Sync)
+	} else {
+		fmt.Fprintln(w, "Plan:    none for this branch or session")
+	}
+
+	fmt.Fprintln(w)
+	if !summary.Initialized {
+		fmt.Fprintln(w, "No .jig directory here; run 'jig plan' to start a planning session")
+		return
+	}
+	if len(summary.Sessions) == 0 {
+		fmt.Fprintln(w, "Sessions: none")
+	} else {
+		fmt.Fprintf(w, "Sessions (%d):\n", len(summary.Sessions))
+		for _, session := range summary.Sessions {
+			var details []string
+			if session.IssueID != "" {
+				details = append(details, "issue "+session.IssueID)
+			}
+			if session.SavedPlanID != "" {
+				details = append(details, "saved "+session.SavedPlanID)
+			}
+			if len(session.Markers) > 0 {
+				details = append(details, "markers: "+strings.Join(session.Markers, ", "))
+			}
+			if len(details) == 0 {
+				fmt.Fprintf(w, "  %s\n", session.ID)
+			} else {
+				fmt.Fprintf(w, "  %s (%s)\n", session.ID, strings.Join(details, "; "))
+			}
+		}
+	}
+	if len(summary.Markers) > 0 {
+		fmt.Fprintf(w, "Markers: %s\n", strings.Join(summary.Markers, ", "))
+	}
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/state"
+)
+
+func TestCollectStatusWithDeps(t *testing.T) {
+	syncedAt := time.Now().Add(-time.Hour)
+	plans := map[string]*state.CachedPlan{
+		"PLAN-1": {
+			Plan:      &plan.Plan{ID: "PLAN-1", Title: "Session Plan", Status: plan.StatusReviewing, IssueID: "NUM-1"},
+			UpdatedAt: time.Now(),
+			SyncedAt:  &syncedAt,
+		},
+		"PLAN-2": {
+			Plan:     &plan.Plan{ID: "PLAN-2", Title: "Branch Plan", IssueID: "NUM-2"},
+			SyncedAt: &syncedAt,
+		},
+	}
+
+	newDeps := func(branch string) statusDeps {
+		return statusDeps{
+			tracker: "linear",
+			currentBranch: func() (string, error) {
+				if branch == "" {
+					return "", errors.New("not a git repository")
+				}
+				return branch, nil
+			},
+			currentWorktree: func() *state.WorktreeInfo { return nil },
+			getCachedPlan:   func(id string) (*state.CachedPlan, error) { return plans[id], nil },
+			getPlanByIssueID: func(issueID string) (*state.CachedPlan, error) {
+				for _, cp := range plans {
+					if cp.Plan.IssueID == issueID {
+						return cp, nil
+					}
+				}
+				return nil, nil
+			},
+		}
+	}
+
+	t.Run("reports sessions, markers and the session's plan", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		if err := writeSessionMetadata("session-old", state.SessionMetadata{IssueID: "NUM-9"}); err != nil {
+			t.Fatalf("writeSessionMetadata() error = %v", err)
+		}
+		old := time.Now().Add(-time.Hour)
+		os.Chtimes(filepath.Join(".jig", "sessions", "session-old"), old, old)
+		if err := writeSessionMetadata("session-new", state.SessionMetadata{IssueID: "NUM-1"}); err != nil {
+			t.Fatalf("writeSessionMetadata() error = %v", err)
+		}
+		writeSavedPlanID("session-new", "PLAN-1")
+		os.WriteFile(filepath.Join(".jig", "sessions", "session-new", "skip-save.marker"), []byte{}, 0644)
+		markPlanSaved()
+
+		summary := collectStatusWithDeps(newDeps("NUM-2-fix-login"))
+
+		if summary.Tracker != "linear" || !summary.Initialized {
+			t.Errorf("expected an initialized linear repo, got %+v", summary)
+		}
+		if summary.Branch != "NUM-2-fix-login" || summary.IssueID != "NUM-2" {
+			t.Errorf("expected branch NUM-2-fix-login and issue NUM-2, got %q and %q", summary.Branch, summary.IssueID)
+		}
+		if len(summary.Markers) != 1 || summary.Markers[0] != "plan-saved" {
+			t.Errorf("expected the plan-saved marker, got %v", summary.Markers)
+		}
+
+		if len(summary.Sessions) != 2 {
+			t.Fatalf("expected 2 sessions, got %+v", summary.Sessions)
+		}
+		latest := summary.Sessions[0]
+		if latest.ID != "session-new" || latest.IssueID != "NUM-1" || latest.SavedPlanID != "PLAN-1" {
+			t.Errorf("expected session-new first with its issue and saved plan, got %+v", latest)
+		}
+		if len(latest.Markers) != 1 || latest.Markers[0] != "skip-save" {
+			t.Errorf("expected the session's skip-save marker, got %v", latest.Markers)
+		}
+		if summary.Sessions[1].ID != "session-old" || summary.Sessions[1].IssueID != "NUM-9" {
+			t.Errorf("expected session-old second, got %+v", summary.Sessions[1])
+		}
+
+		// The session's saved plan takes precedence over the branch's
+		want := statusPlan{ID: "PLAN-1", Title: "Session Plan", Status: "reviewing", IssueID: "NUM-1", Sync: "updated since last sync", Source: "session"}
+		if summary.Plan == nil || *summary.Plan != want {
+			t.Errorf("plan = %+v, want %+v", summary.Plan, want)
+		}
+	})
+
+	t.Run("finds the plan for the branch outside a jig repo", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		summary := collectStatusWithDeps(newDeps("NUM-2-fix-login"))
+
+		if summary.Initialized || len(summary.Sessions) != 0 || len(summary.Markers) != 0 {
+			t.Errorf("expected no .jig state, got %+v", summary)
+		}
+		want := statusPlan{ID: "PLAN-2", Title: "Branch Plan", Status: "draft", IssueID: "NUM-2", Sync: "synced", Source: "branch"}
+		if summary.Plan == nil || *summary.Plan != want {
+			t.Errorf("plan = %+v, want %+v", summary.Plan, want)
+		}
+	})
+
+	t.Run("reports what it can outside a git repo", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+
+		summary := collectStatusWithDeps(newDeps(""))
+
+		if summary.Tracker != "linear" || summary.Branch != "" || summary.IssueID != "" || summary.Plan != nil {
+			t.Errorf("expected only the tracker, got %+v", summary)
+		}
+
+		var buf bytes.Buffer
+		writeStatusSummaryText(&buf, summary)
+		for _, want := range []string{"Tracker: linear", "Plan:    none for this branch or session", "No .jig directory here"} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+			}
+		}
+	})
+}
+
+func TestStatusSummaryJSON(t *testing.T) {
+	summary := &statusSummary{
+		Tracker:     "linear",
+		Initialized: true,
+		Plan:        &statusPlan{ID: "PLAN-1", Sync: "never synced", Source: "worktree"},
+		Sessions:    []statusSession{{ID: "session-1", Markers: []string{"plan-saved"}}},
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var fields map[string]interface{}
+	json.Unmarshal(data, &fields)
+	for _, key := range []string{"tracker", "initialized", "plan", "sessions"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("expected %q in JSON, got %s", key, data)
+		}
+	}
+	for _, key := range []string{"branch", "issue_id", "markers"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("expected empty %q to be omitted, got %s", key, data)
+		}
+	}
+}
+
+func TestIssueIDFromBranch(t *testing.T) {
+	tests := map[string]string{
+		"NUM-123-fix-login": "NUM-123",
+		"NUM-123":           "NUM-123",
+		"main":              "",
+		"":                  "",
+	}
+	for branch, want := range tests {
+		if got := issueIDFromBranch(branch); got != want {
+			t.Errorf("issueIDFromBranch(%q) = %q, want %q", branch, got, want)
+		}
+	}
+}
+
+func TestRunStatus_SummaryWithoutIssue(t *testing.T) {
+	t.Setenv("JIG_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	// No issue can be detected outside a git repo, so the summary is shown
+	out, err := captureStdout(t, func() error { return runStatus(statusCmd, nil) })
+	if err != nil {
+		t.Fatalf("runStatus() error = %v", err)
+	}
+	if !strings.Contains(out, "Tracker:") || !strings.Contains(out, "Plan:    none") {
+		t.Errorf("expected the summary, got:\n%s", out)
+	}
+
+	statusSummaryOnly = true
+	t.Cleanup(func() { statusSummaryOnly = false })
+	if err := runStatus(statusCmd, []string{"NUM-1"}); err == nil {
+		t.Error("expected an error for --summary with ISSUE")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -13,11 +14,13 @@ type Config struct {
 	Default DefaultConfig         `mapstructure:"default"`
 	Linear  LinearConfig          `mapstructure:"linear"`
 	GitHub  GitHubConfig          `mapstructure:"github"`
+	GitLab  GitLabConfig          `mapstructure:"gitlab"`
 	Claude  ClaudeConfig          `mapstructure:"claude"`
 	Runners map[string]RunnerSpec `mapstructure:"runners"`
 	Review  ReviewConfig          `mapstructure:"review"`
 	Git     GitConfig             `mapstructure:"git"`
 	Repos   map[string]RepoConfig `mapstructure:"repos"`
+	Plan    PlanConfig            `mapstructure:"plan"`
 }
 
 // DefaultConfig holds default settings
@@ -28,12 +31,21 @@ type DefaultConfig struct {
 
 // LinearConfig holds Linear API configuration
 type LinearConfig struct {
-	APIKey            string `mapstructure:"api_key"`
-	TeamID            string `mapstructure:"team_id"`
-	DefaultProject    string `mapstructure:"default_project"`
-	SyncPlanOnSave    *bool  `mapstructure:"sync_plan_on_save"`    // default: true
-	CreateIssueOnSave *bool  `mapstructure:"create_issue_on_save"` // default: true
-	PlanLabelName     string `mapstructure:"plan_label_name"`      // default: "jig-plan"
+	APIKey              string            `mapstructure:"api_key"`
+	TeamID              string            `mapstructure:"team_id"`
+	DefaultProject      string            `mapstructure:"default_project"`
+	SyncPlanOnSave      *bool             `mapstructure:"sync_plan_on_save"`     // default: true
+	CreateIssueOnSave   *bool             `mapstructure:"create_issue_on_save"`  // default: true
+	PlanLabelName       string            `mapstructure:"plan_label_name"`       // default: "jig-plan"
+	APIURL              string            `mapstructure:"api_url"`               // default: Linear's public GraphQL API
+	RequestTimeout      time.Duration     `mapstructure:"request_timeout"`       // per-request timeout, e.g. "15s" (default: none)
+	CreateMissingLabels bool              `mapstructure:"create_missing_labels"` // create plan labels missing from the team (default: false)
+	StatusMap           map[string]string `mapstructure:"status_map"`            // workflow state name -> jig status, e.g. "PR Open" = "in_review"
+	PlanOverflowMode    string            `mapstructure:"plan_overflow_mode"`    // where plans too long for one comment continue: "comment" or "description" (default: "comment")
+	SyncToDescription   bool              `mapstructure:"sync_to_description"`   // keep the plan's summary in the issue description (default: false)
+	IssueTemplate       string            `mapstructure:"issue_template"`        // ID or name of the issue template for issues created from plans (default: none)
+	PlanCommentTemplate string            `mapstructure:"plan_comment_template"` // layout of plan comments, with {title}, {problem}, {solution}, {sections} and {synced} placeholders (default: built-in)
+	SyncConcurrency     int               `mapstructure:"sync_concurrency"`      // plans synced at once by 'jig plan sync' (default: 3)
 }
 
 // GitHubConfig holds GitHub configuration (uses gh CLI auth)
@@ -41,16 +53,65 @@ type GitHubConfig struct {
 	// GitHub configuration is handled by gh CLI
 }
 
+// GitLabConfig holds GitLab configuration. The access token is kept in the
+// credential store (see Store.GetGitLabToken).
+type GitLabConfig struct {
+	URL               string `mapstructure:"url"`                  // instance URL (default: "https://gitlab.com")
+	Project           string `mapstructure:"project"`              // ID or path of the project issues are created in, e.g. "acme/api"
+	Group             string `mapstructure:"group"`                // ID or path of a group to search and list issues across (default: the project only)
+	IssueKey          string `mapstructure:"issue_key"`            // prefix of issue identifiers, e.g. "API" for API-42 (default: "GL")
+	Token             string `mapstructure:"token"`                // fallback when no token is stored
+	SyncPlanOnSave    *bool  `mapstructure:"sync_plan_on_save"`    // default: true
+	CreateIssueOnSave *bool  `mapstructure:"create_issue_on_save"` // default: true
+	PlanLabelName     string `mapstructure:"plan_label_name"`      // default: "jig-plan"
+}
+
+// GetIssueKey returns the prefix of GitLab issue identifiers
+func (c *GitLabConfig) GetIssueKey() string {
+	if c.IssueKey == "" {
+		return "GL" // default
+	}
+	return c.IssueKey
+}
+
+// ShouldSyncPlanOnSave returns whether plans should be synced to GitLab on save
+func (c *GitLabConfig) ShouldSyncPlanOnSave() bool {
+	if c.SyncPlanOnSave == nil {
+		return true // default
+	}
+	return *c.SyncPlanOnSave
+}
+
+// ShouldCreateIssueOnSave returns whether a GitLab issue should be auto-created when saving a plan without a linked issue
+func (c *GitLabConfig) ShouldCreateIssueOnSave() bool {
+	if c.CreateIssueOnSave == nil {
+		return true // default
+	}
+	return *c.CreateIssueOnSave
+}
+
+// GetPlanLabelName returns the label name to use for plans in GitLab
+func (c *GitLabConfig) GetPlanLabelName() string {
+	if c.PlanLabelName == "" {
+		return "jig-plan" // default
+	}
+	return c.PlanLabelName
+}
+
 // ClaudeConfig holds Claude Code configuration
 type ClaudeConfig struct {
 	SkillsLocation string `mapstructure:"skills_location"` // "global" or "project"
 }
 
-// RunnerSpec defines configuration for an external coding tool
+// RunnerSpec defines configuration for an external coding tool. Runners
+// other than the built-in ones are launched as plain commands built from
+// Args, PromptArg and PlanModeArgs.
 type RunnerSpec struct {
-	Command   string `mapstructure:"command"`
-	SkillDir  string `mapstructure:"skill_dir"`
-	PromptArg string `mapstructure:"prompt_arg"`
+	Command      string   `mapstructure:"command"`
+	SkillDir     string   `mapstructure:"skill_dir"`
+	PromptArg    string   `mapstructure:"prompt_arg"`     // flag before the prompt, e.g. "-p" (default: the prompt is the last argument)
+	Args         []string `mapstructure:"args"`           // arguments, with {prompt}, {system_prompt} and {worktree} replaced
+	PlanModeArgs []string `mapstructure:"plan_mode_args"` // added for planning sessions; planning isn't supported without them
 }
 
 // ReviewConfig holds review workflow configuration
@@ -65,6 +126,29 @@ type GitConfig struct {
 	WorktreeDir   string `mapstructure:"worktree_dir"`
 }
 
+// PlanConfig holds plan settings
+type PlanConfig struct {
+	IDFormat     string        `mapstructure:"id_format"`      // format of generated plan IDs: "slug" or "timestamp" (default: "slug")
+	MarkerMaxAge time.Duration `mapstructure:"marker_max_age"` // age after which a plan-saved marker is ignored, e.g. "30m" (default: 1h)
+}
+
+// GetIDFormat returns the format of generated plan IDs
+func (c *PlanConfig) GetIDFormat() string {
+	if c.IDFormat == "" {
+		return "slug" // default
+	}
+	return c.IDFormat
+}
+
+// GetMarkerMaxAge returns how long a plan-saved marker lets planning sessions
+// exit plan mode
+func (c *PlanConfig) GetMarkerMaxAge() time.Duration {
+	if c.MarkerMaxAge <= 0 {
+		return time.Hour // default
+	}
+	return c.MarkerMaxAge
+}
+
 // RepoConfig holds per-repository configuration
 type RepoConfig struct {
 	Path           string `mapstructure:"path"`
@@ -123,15 +207,71 @@ func Init(customCfgFile string) error {
 		// Config file not found is okay, we'll use defaults
 	}
 
+	// Layer the project config over the global one. The project values only
+	// live in the merged view, so 'jig config set' keeps writing the global file.
+	merged := viper.New()
+	if err := merged.MergeConfigMap(viper.AllSettings()); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := mergeProjectConfig(merged); err != nil {
+		return err
+	}
+
 	// Unmarshal into struct
 	cfg = &Config{}
-	if err := viper.Unmarshal(cfg); err != nil {
+	if err := merged.Unmarshal(cfg); err != nil {
 		return fmt.Errorf("failed to parse config: %w", err)
 	}
 
 	return nil
 }
 
+// ProjectConfigFile is the path of the optional per-project config, relative
+// to the working directory
+const ProjectConfigFile = ".jig/config.toml"
+
+// mergeProjectConfig merges the project config file, if there is one in the
+// working directory, over the settings in v. Secrets are never read from the
+// project file, since it is usually committed alongside the code.
+func mergeProjectConfig(v *viper.Viper) error {
+	path, err := filepath.Abs(ProjectConfigFile)
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	// Running from the home directory would find the global config again
+	if used, err := filepath.Abs(viper.ConfigFileUsed()); err == nil && used == path {
+		return nil
+	}
+
+	project := viper.New()
+	project.SetConfigFile(path)
+	if err := project.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read project config %s: %w", path, err)
+	}
+
+	settings := project.AllSettings()
+	if linear, ok := settings["linear"].(map[string]interface{}); ok {
+		if _, ok := linear["api_key"]; ok {
+			delete(linear, "api_key")
+			fmt.Fprintf(os.Stderr, "Warning: ignoring linear.api_key in %s; use 'jig config set linear.api_key' instead\n", path)
+		}
+	}
+	if gitlab, ok := settings["gitlab"].(map[string]interface{}); ok {
+		if _, ok := gitlab["token"]; ok {
+			delete(gitlab, "token")
+			fmt.Fprintf(os.Stderr, "Warning: ignoring gitlab.token in %s; use 'jig config set gitlab.token' instead\n", path)
+		}
+	}
+
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge project config %s: %w", path, err)
+	}
+	return nil
+}
+
 // setDefaults sets default configuration values
 func setDefaults() {
 	// Default settings
@@ -145,6 +285,7 @@ func setDefaults() {
 	viper.SetDefault("runners.codex.command", "codex")
 
 	viper.SetDefault("runners.opencode.command", "opencode")
+	viper.SetDefault("runners.opencode.prompt_arg", "--prompt")
 
 	// Default review settings
 	viper.SetDefault("review.default_reviewers", []string{"lead", "security"})
@@ -165,7 +306,9 @@ func setDefaults() {
 	viper.SetDefault("git.worktree_dir", filepath.Join(jigDir, "worktrees"))
 }
 
-// Get returns the current configuration
+// Get returns the current configuration: the global config from the jig
+// directory, overridden by the project config (ProjectConfigFile) if the
+// working directory has one
 func Get() *Config {
 	if cfg == nil {
 		// Return default config if not initialized
@@ -221,6 +364,15 @@ func PromptsDir() (string, error) {
 	return filepath.Join(jigDir, "prompts"), nil
 }
 
+// TemplatesDir returns the path to the user plan templates directory
+func TemplatesDir() (string, error) {
+	jigDir, err := JigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(jigDir, "templates"), nil
+}
+
 // CacheDir returns the path to the cache directory
 func CacheDir() (string, error) {
 	jigDir, err := JigDir()
@@ -230,6 +382,39 @@ func CacheDir() (string, error) {
 	return filepath.Join(jigDir, "cache"), nil
 }
 
+// ShouldSyncPlanOnSave returns whether plans should be synced to the
+// configured tracker on save. Trackers that can't sync plans never do.
+func (c *Config) ShouldSyncPlanOnSave() bool {
+	switch c.Default.Tracker {
+	case "linear":
+		return c.Linear.ShouldSyncPlanOnSave()
+	case "gitlab":
+		return c.GitLab.ShouldSyncPlanOnSave()
+	}
+	return false
+}
+
+// ShouldCreateIssueOnSave returns whether an issue should be auto-created in
+// the configured tracker when saving a plan without a linked issue
+func (c *Config) ShouldCreateIssueOnSave() bool {
+	switch c.Default.Tracker {
+	case "linear":
+		return c.Linear.ShouldCreateIssueOnSave()
+	case "gitlab":
+		return c.GitLab.ShouldCreateIssueOnSave()
+	}
+	return false
+}
+
+// GetPlanLabelName returns the label name to use for plans in the configured
+// tracker
+func (c *Config) GetPlanLabelName() string {
+	if c.Default.Tracker == "gitlab" {
+		return c.GitLab.GetPlanLabelName()
+	}
+	return c.Linear.GetPlanLabelName()
+}
+
 // ShouldSyncPlanOnSave returns whether plans should be synced to Linear on save
 func (c *LinearConfig) ShouldSyncPlanOnSave() bool {
 	if c.SyncPlanOnSave == nil {
@@ -246,6 +431,14 @@ func (c *LinearConfig) GetPlanLabelName() string {
 	return c.PlanLabelName
 }
 
+// GetSyncConcurrency returns how many plans to sync with Linear at once
+func (c *LinearConfig) GetSyncConcurrency() int {
+	if c.SyncConcurrency <= 0 {
+		return 3 // default
+	}
+	return c.SyncConcurrency
+}
+
 // ShouldCreateIssueOnSave returns whether a Linear issue should be auto-created when saving a plan without a linked issue
 func (c *LinearConfig) ShouldCreateIssueOnSave() bool {
 	if c.CreateIssueOnSave == nil {
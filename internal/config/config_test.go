@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 func TestLinearConfig_ShouldSyncPlanOnSave(t *testing.T) {
@@ -72,6 +75,44 @@ func TestLinearConfig_GetPlanLabelName(t *testing.T) {
 	}
 }
 
+func TestLinearConfig_GetSyncConcurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   LinearConfig
+		expected int
+	}{
+		{"unset defaults to 3", LinearConfig{}, 3},
+		{"negative defaults to 3", LinearConfig{SyncConcurrency: -1}, 3},
+		{"custom concurrency is returned", LinearConfig{SyncConcurrency: 8}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.GetSyncConcurrency(); got != tt.expected {
+				t.Errorf("GetSyncConcurrency() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanConfig_GetIDFormat(t *testing.T) {
+	if got := (&PlanConfig{}).GetIDFormat(); got != "slug" {
+		t.Errorf("GetIDFormat() = %q, want slug", got)
+	}
+	if got := (&PlanConfig{IDFormat: "timestamp"}).GetIDFormat(); got != "timestamp" {
+		t.Errorf("GetIDFormat() = %q, want timestamp", got)
+	}
+}
+
+func TestPlanConfig_GetMarkerMaxAge(t *testing.T) {
+	if got := (&PlanConfig{}).GetMarkerMaxAge(); got != time.Hour {
+		t.Errorf("GetMarkerMaxAge() = %v, want 1h", got)
+	}
+	if got := (&PlanConfig{MarkerMaxAge: 10 * time.Minute}).GetMarkerMaxAge(); got != 10*time.Minute {
+		t.Errorf("GetMarkerMaxAge() = %v, want 10m", got)
+	}
+}
+
 func TestLinearConfig_ShouldCreateIssueOnSave(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -105,6 +146,52 @@ func TestLinearConfig_ShouldCreateIssueOnSave(t *testing.T) {
 	}
 }
 
+func TestConfig_TrackerPlanSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    Config
+		sync      bool
+		create    bool
+		labelName string
+	}{
+		{
+			name:      "linear uses the linear settings",
+			config:    Config{Default: DefaultConfig{Tracker: "linear"}, Linear: LinearConfig{CreateIssueOnSave: boolPtr(false), PlanLabelName: "plan"}},
+			sync:      true,
+			create:    false,
+			labelName: "plan",
+		},
+		{
+			name:      "gitlab uses the gitlab settings",
+			config:    Config{Default: DefaultConfig{Tracker: "gitlab"}, Linear: LinearConfig{SyncPlanOnSave: boolPtr(true)}, GitLab: GitLabConfig{SyncPlanOnSave: boolPtr(false)}},
+			sync:      false,
+			create:    true,
+			labelName: "jig-plan",
+		},
+		{
+			name:      "no tracker never syncs or creates",
+			config:    Config{Default: DefaultConfig{Tracker: "none"}},
+			sync:      false,
+			create:    false,
+			labelName: "jig-plan",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ShouldSyncPlanOnSave(); got != tt.sync {
+				t.Errorf("ShouldSyncPlanOnSave() = %v, want %v", got, tt.sync)
+			}
+			if got := tt.config.ShouldCreateIssueOnSave(); got != tt.create {
+				t.Errorf("ShouldCreateIssueOnSave() = %v, want %v", got, tt.create)
+			}
+			if got := tt.config.GetPlanLabelName(); got != tt.labelName {
+				t.Errorf("GetPlanLabelName() = %q, want %q", got, tt.labelName)
+			}
+		})
+	}
+}
+
 // boolPtr returns a pointer to a bool value
 func boolPtr(b bool) *bool {
 	return &b
@@ -161,3 +248,110 @@ func TestJigDir(t *testing.T) {
 		}
 	})
 }
+
+func TestInit_ProjectConfigOverridesGlobal(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() {
+		viper.Reset()
+		cfg = nil
+	})
+
+	jigHome := t.TempDir()
+	t.Setenv("JIG_HOME", jigHome)
+	globalConfig := `[default]
+tracker = "linear"
+runner = "claude"
+
+[linear]
+api_key = "lin_api_global"
+team_id = "GLOBAL"
+default_project = "global-project"
+plan_label_name = "global-label"
+
+[git]
+branch_pattern = "{issue_id}-{slug}"
+worktree_dir = "/tmp/global-worktrees"
+`
+	if err := os.WriteFile(filepath.Join(jigHome, "config.toml"), []byte(globalConfig), 0644); err != nil {
+		t.Fatalf("failed to write global config: %v", err)
+	}
+
+	projectDir := t.TempDir()
+	projectConfig := `[default]
+runner = "codex"
+
+[linear]
+api_key = "lin_api_project"
+team_id = "PROJECT"
+request_timeout = "15s"
+
+[git]
+branch_pattern = "{slug}"
+`
+	if err := os.MkdirAll(filepath.Join(projectDir, ".jig"), 0755); err != nil {
+		t.Fatalf("failed to create project .jig dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, ProjectConfigFile), []byte(projectConfig), 0644); err != nil {
+		t.Fatalf("failed to write project config: %v", err)
+	}
+	t.Chdir(projectDir)
+
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	got := Get()
+
+	tests := []struct {
+		field string
+		got   interface{}
+		want  interface{}
+	}{
+		// Project values win
+		{"default.runner", got.Default.Runner, "codex"},
+		{"linear.team_id", got.Linear.TeamID, "PROJECT"},
+		{"linear.request_timeout", got.Linear.RequestTimeout, 15 * time.Second},
+		{"git.branch_pattern", got.Git.BranchPattern, "{slug}"},
+		// Values the project doesn't set come from the global config
+		{"default.tracker", got.Default.Tracker, "linear"},
+		{"linear.default_project", got.Linear.DefaultProject, "global-project"},
+		{"linear.plan_label_name", got.Linear.PlanLabelName, "global-label"},
+		{"git.worktree_dir", got.Git.WorktreeDir, "/tmp/global-worktrees"},
+		// Secrets are never taken from the project config
+		{"linear.api_key", got.Linear.APIKey, "lin_api_global"},
+		// Defaults still apply underneath both files
+		{"claude.skills_location", got.Claude.SkillsLocation, "global"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.field, tt.got, tt.want)
+		}
+	}
+
+	// The project overrides must not leak into the global config that
+	// 'jig config set' writes back
+	if runner := viper.GetString("default.runner"); runner != "claude" {
+		t.Errorf("global default.runner = %q, want %q", runner, "claude")
+	}
+}
+
+func TestInit_WithoutProjectConfig(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() {
+		viper.Reset()
+		cfg = nil
+	})
+
+	jigHome := t.TempDir()
+	t.Setenv("JIG_HOME", jigHome)
+	if err := os.WriteFile(filepath.Join(jigHome, "config.toml"), []byte("[linear]\nteam_id = \"GLOBAL\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write global config: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	if err := Init(""); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if got := Get().Linear.TeamID; got != "GLOBAL" {
+		t.Errorf("linear.team_id = %q, want %q", got, "GLOBAL")
+	}
+}
@@ -17,6 +17,7 @@ type Store struct {
 // Credentials holds sensitive API keys and tokens
 type Credentials struct {
 	LinearAPIKey string `json:"linear_api_key,omitempty"`
+	GitLabToken  string `json:"gitlab_token,omitempty"`
 }
 
 // NewStore creates a new credential store
@@ -93,6 +94,25 @@ func (s *Store) GetLinearAPIKey() (string, error) {
 	return creds.LinearAPIKey, nil
 }
 
+// SetGitLabToken stores the GitLab access token
+func (s *Store) SetGitLabToken(token string) error {
+	creds, err := s.Load()
+	if err != nil {
+		creds = &Credentials{}
+	}
+	creds.GitLabToken = token
+	return s.Save(creds)
+}
+
+// GetGitLabToken retrieves the GitLab access token
+func (s *Store) GetGitLabToken() (string, error) {
+	creds, err := s.Load()
+	if err != nil {
+		return "", err
+	}
+	return creds.GitLabToken, nil
+}
+
 // Clear removes all stored credentials
 func (s *Store) Clear() error {
 	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
@@ -0,0 +1,23 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// GetConfig returns the value of a git config key, or an empty string if the
+// key is not set. Returns an error if git could not be run.
+func GetConfig(key string) (string, error) {
+	cmd := exec.Command("git", "config", "--get", key)
+	output, err := cmd.Output()
+	if err != nil {
+		// git config exits with 1 when the key is not set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
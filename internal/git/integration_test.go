@@ -342,3 +342,27 @@ func TestConcurrentWorktreeOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestGetConfig(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.InDir(func() {
+		name, err := GetConfig("user.name")
+		if err != nil {
+			t.Fatalf("GetConfig failed: %v", err)
+		}
+		if name != "Jig Test" {
+			t.Errorf("expected user.name %q, got %q", "Jig Test", name)
+		}
+
+		// Unset keys are not an error
+		value, err := GetConfig("jig.nonexistent")
+		if err != nil {
+			t.Fatalf("GetConfig failed for unset key: %v", err)
+		}
+		if value != "" {
+			t.Errorf("expected empty value for unset key, got %q", value)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// logger is the diagnostic logger. It discards everything until Configure is
+// called, which the root command does for --verbose and --debug.
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	Disable()
+}
+
+// sensitiveKeys lists attribute keys whose values are never logged
+var sensitiveKeys = map[string]bool{
+	"api_key":       true,
+	"apikey":        true,
+	"authorization": true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+// linearAPIKeyPattern matches Linear personal API keys embedded in strings
+var linearAPIKeyPattern = regexp.MustCompile(`lin_api_[A-Za-z0-9]+`)
+
+const redacted = "[REDACTED]"
+
+// Configure enables logging of messages at level and above to w
+func Configure(w io.Writer, level slog.Level) {
+	logger.Store(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	})))
+}
+
+// Disable turns logging off
+func Disable() {
+	logger.Store(slog.New(slog.DiscardHandler))
+}
+
+// LevelFromFlags returns the level selected by the --verbose and --debug
+// flags, and false if neither is set
+func LevelFromFlags(verbose, debug bool) (slog.Level, bool) {
+	switch {
+	case debug:
+		return slog.LevelDebug, true
+	case verbose:
+		return slog.LevelInfo, true
+	default:
+		return 0, false
+	}
+}
+
+// Debug logs a message at debug level
+func Debug(msg string, args ...any) {
+	logger.Load().Debug(msg, args...)
+}
+
+// Info logs a message at info level
+func Info(msg string, args ...any) {
+	logger.Load().Info(msg, args...)
+}
+
+// redact hides the values of sensitive attributes and any Linear API key that
+// appears in a string value
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+	if a.Value.Kind() == slog.KindString {
+		if s := a.Value.String(); linearAPIKeyPattern.MatchString(s) {
+			return slog.String(a.Key, linearAPIKeyPattern.ReplaceAllString(s, redacted))
+		}
+	}
+	return a
+}
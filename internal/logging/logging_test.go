@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestConfigure(t *testing.T) {
+	var buf bytes.Buffer
+	Configure(&buf, slog.LevelInfo)
+	defer Disable()
+
+	Debug("hidden debug message")
+	Info("shown info message", "plan", "PLAN-1")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden debug message") {
+		t.Errorf("expected debug message to be filtered at info level, got:\n%s", out)
+	}
+	if !strings.Contains(out, "shown info message") || !strings.Contains(out, "plan=PLAN-1") {
+		t.Errorf("expected info message with attributes, got:\n%s", out)
+	}
+}
+
+func TestDisable(t *testing.T) {
+	var buf bytes.Buffer
+	Configure(&buf, slog.LevelDebug)
+	Disable()
+
+	Info("should not be written")
+	if buf.Len() != 0 {
+		t.Errorf("expected no output after Disable, got:\n%s", buf.String())
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	Configure(&buf, slog.LevelDebug)
+	defer Disable()
+
+	Debug("request",
+		"api_key", "plain-secret",
+		"Authorization", "Bearer abc",
+		"error", "unauthorized: lin_api_abc123 is invalid",
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"plain-secret", "Bearer abc", "lin_api_abc123"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted, got:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "is invalid") {
+		t.Errorf("expected the rest of the message to be kept, got:\n%s", out)
+	}
+}
+
+func TestLevelFromFlags(t *testing.T) {
+	if _, ok := LevelFromFlags(false, false); ok {
+		t.Error("expected logging to stay off without flags")
+	}
+	if level, ok := LevelFromFlags(true, false); !ok || level != slog.LevelInfo {
+		t.Errorf("--verbose: got %v, %v", level, ok)
+	}
+	if level, ok := LevelFromFlags(true, true); !ok || level != slog.LevelDebug {
+		t.Errorf("--debug: got %v, %v", level, ok)
+	}
+}
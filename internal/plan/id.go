@@ -0,0 +1,87 @@
+package plan
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Formats for generated plan IDs, set with plan.id_format
+const (
+	// IDFormatSlug is plan-<title slug>-<random suffix>, e.g. plan-add-oauth-a1b2
+	IDFormatSlug = "slug"
+	// IDFormatTimestamp is PLAN-<unix time>, e.g. PLAN-1700000000
+	IDFormatTimestamp = "timestamp"
+)
+
+// maxIDSlugLength bounds the title part of a slug plan ID
+const maxIDSlugLength = 40
+
+// maxIDAttempts bounds how many IDs GenerateID tries before giving up
+const maxIDAttempts = 100
+
+// GenerateID returns an ID for a new plan titled title, in the given format.
+// exists reports whether a plan already has an ID; GenerateID keeps trying
+// until it finds one that is free.
+func GenerateID(format, title string, exists func(id string) bool) (string, error) {
+	var next func(attempt int) string
+	switch format {
+	case IDFormatSlug, "":
+		prefix := "plan-"
+		if slug := IDSlug(title); slug != "" {
+			prefix += slug + "-"
+		}
+		next = func(int) string { return prefix + randomIDSuffix() }
+	case IDFormatTimestamp:
+		base := fmt.Sprintf("PLAN-%d", time.Now().Unix())
+		next = func(attempt int) string {
+			if attempt == 0 {
+				return base
+			}
+			return fmt.Sprintf("%s-%d", base, attempt+1)
+		}
+	default:
+		return "", fmt.Errorf("unknown plan ID format %q (must be %s or %s)", format, IDFormatSlug, IDFormatTimestamp)
+	}
+
+	for attempt := 0; attempt < maxIDAttempts; attempt++ {
+		if id := next(attempt); !exists(id) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate a unique plan ID")
+}
+
+// IDSlug converts a plan title to the slug used in plan IDs: lowercase
+// letters and digits separated by single hyphens, cut at a word boundary to
+// at most 40 characters
+func IDSlug(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+
+	slug := ""
+	for _, word := range words {
+		next := word
+		if slug != "" {
+			next = slug + "-" + word
+		}
+		if len(next) > maxIDSlugLength {
+			if slug == "" {
+				slug = word[:maxIDSlugLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+// randomIDSuffix returns four random hex characters
+func randomIDSuffix() string {
+	b := make([]byte, 2)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
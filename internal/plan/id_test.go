@@ -0,0 +1,96 @@
+package plan
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestIDSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Add OAuth", "add-oauth"},
+		{"  Fix: login_page (v2)!  ", "fix-login-page-v2"},
+		{"Café déjà vu", "caf-d-j-vu"},
+		{"", ""},
+		{"!!!", ""},
+		{"Migrate the billing service to the new payments provider", "migrate-the-billing-service-to-the-new"},
+		{strings.Repeat("a", 50), strings.Repeat("a", 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := IDSlug(tt.title); got != tt.want {
+				t.Errorf("IDSlug(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateID(t *testing.T) {
+	none := func(string) bool { return false }
+
+	t.Run("slug format", func(t *testing.T) {
+		for _, format := range []string{IDFormatSlug, ""} {
+			id, err := GenerateID(format, "Add OAuth", none)
+			if err != nil {
+				t.Fatalf("GenerateID(%q) error = %v", format, err)
+			}
+			if !regexp.MustCompile(`^plan-add-oauth-[0-9a-f]{4}$`).MatchString(id) {
+				t.Errorf("GenerateID(%q) = %q, want plan-add-oauth-<suffix>", format, id)
+			}
+		}
+	})
+
+	t.Run("slug format without a title", func(t *testing.T) {
+		id, err := GenerateID(IDFormatSlug, "", none)
+		if err != nil {
+			t.Fatalf("GenerateID() error = %v", err)
+		}
+		if !regexp.MustCompile(`^plan-[0-9a-f]{4}$`).MatchString(id) {
+			t.Errorf("GenerateID() = %q, want plan-<suffix>", id)
+		}
+	})
+
+	t.Run("timestamp format", func(t *testing.T) {
+		id, err := GenerateID(IDFormatTimestamp, "Add OAuth", none)
+		if err != nil {
+			t.Fatalf("GenerateID() error = %v", err)
+		}
+		if !regexp.MustCompile(`^PLAN-[0-9]+$`).MatchString(id) {
+			t.Errorf("GenerateID() = %q, want PLAN-<unix time>", id)
+		}
+	})
+
+	t.Run("avoids existing IDs", func(t *testing.T) {
+		for _, format := range []string{IDFormatSlug, IDFormatTimestamp} {
+			var tried []string
+			exists := func(id string) bool {
+				tried = append(tried, id)
+				return len(tried) == 1 // Only the first ID is taken
+			}
+
+			id, err := GenerateID(format, "Add OAuth", exists)
+			if err != nil {
+				t.Fatalf("GenerateID(%q) error = %v", format, err)
+			}
+			if len(tried) != 2 || id != tried[1] {
+				t.Errorf("GenerateID(%q) = %q after trying %v, want the second ID", format, id, tried)
+			}
+		}
+	})
+
+	t.Run("gives up when every ID is taken", func(t *testing.T) {
+		if _, err := GenerateID(IDFormatTimestamp, "Add OAuth", func(string) bool { return true }); err == nil {
+			t.Error("expected an error when no ID is free")
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if _, err := GenerateID("uuid", "Add OAuth", none); err == nil || !strings.Contains(err.Error(), "unknown plan ID format") {
+			t.Errorf("GenerateID() error = %v, want an unknown format error", err)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JSONPlan is a structured plan payload, for tools that emit JSON rather
+// than markdown with frontmatter. Problem and Solution become the plan's
+// required sections; Sections are added after them, in order.
+type JSONPlan struct {
+	ID            string        `json:"id"`
+	Title         string        `json:"title"`
+	Status        string        `json:"status"` // Defaults to draft
+	Author        string        `json:"author"`
+	IssueID       string        `json:"issue_id"`
+	Problem       string        `json:"problem"`
+	Solution      string        `json:"solution"`
+	Sections      []JSONSection `json:"sections"`
+	Phases        []Phase       `json:"phases"`
+	Estimate      *int          `json:"estimate"`
+	Labels        []string      `json:"labels"`
+	RelatedIssues []string      `json:"related_issues"`
+	Links         []string      `json:"links"`
+	Blocks        []string      `json:"blocks"`
+	BlockedBy     []string      `json:"blocked_by"`
+}
+
+// JSONSection is a markdown section of a JSONPlan, e.g. "Acceptance Criteria"
+type JSONSection struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// Plan converts the payload into a plan. The plan's RawContent is the
+// canonical markdown for it, as written by Serialize, followed by the extra
+// sections, so it can be saved like any other plan. Returns an error listing
+// every missing required field, or for an unknown plan or phase status.
+func (jp JSONPlan) Plan() (*Plan, error) {
+	var missing []string
+	if strings.TrimSpace(jp.Title) == "" {
+		missing = append(missing, "title")
+	}
+	if strings.TrimSpace(jp.Problem) == "" {
+		missing = append(missing, "problem")
+	}
+	if strings.TrimSpace(jp.Solution) == "" {
+		missing = append(missing, "solution")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+
+	status := StatusDraft
+	if jp.Status != "" {
+		var err error
+		if status, err = ParseStatus(jp.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	var phases []Phase
+	for i, phase := range jp.Phases {
+		if phase.ID == "" {
+			return nil, fmt.Errorf("phase %d has no id", i+1)
+		}
+		if phase.Status == "" {
+			phase.Status = PhasePending
+		} else if _, err := ParsePhaseStatus(string(phase.Status)); err != nil {
+			return nil, fmt.Errorf("phase %s: %w", phase.ID, err)
+		}
+		phases = append(phases, phase)
+	}
+	if jp.Estimate != nil && *jp.Estimate < 0 {
+		return nil, fmt.Errorf("invalid estimate %d: must not be negative", *jp.Estimate)
+	}
+
+	p := &Plan{
+		ID:               jp.ID,
+		IssueID:          jp.IssueID,
+		Title:            strings.TrimSpace(jp.Title),
+		Status:           status,
+		Created:          time.Now(),
+		Author:           jp.Author,
+		Phases:           phases,
+		Estimate:         jp.Estimate,
+		Labels:           jp.Labels,
+		RelatedIssues:    jp.RelatedIssues,
+		Links:            jp.Links,
+		Blocks:           jp.Blocks,
+		BlockedBy:        jp.BlockedBy,
+		ProblemStatement: strings.TrimSpace(jp.Problem),
+		ProposedSolution: strings.TrimSpace(jp.Solution),
+		QuestionsAnswers: make(map[string]string),
+		ReviewNotes:      make(map[ReviewerType]string),
+	}
+
+	content, err := Serialize(p)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Write(content)
+	for _, section := range jp.Sections {
+		title := strings.TrimSpace(section.Title)
+		if title == "" {
+			return nil, fmt.Errorf("section has no title")
+		}
+		buf.WriteString(fmt.Sprintf("## %s\n\n%s\n\n", title, strings.TrimSpace(section.Content)))
+	}
+	p.RawContent = buf.String()
+	return p, nil
+}
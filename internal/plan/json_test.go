@@ -0,0 +1,80 @@
+package plan
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// planFromJSON converts a JSON plan payload into a plan, failing the test if
+// the payload isn't valid JSON
+func planFromJSON(t *testing.T, data string) (*Plan, error) {
+	t.Helper()
+	var jp JSONPlan
+	if err := json.Unmarshal([]byte(data), &jp); err != nil {
+		t.Fatalf("invalid plan JSON: %v", err)
+	}
+	return jp.Plan()
+}
+
+func TestJSONPlan_Plan(t *testing.T) {
+	data := `{
+		"id": "PLAN-1",
+		"title": "Add retries",
+		"author": "alice",
+		"problem": "Requests fail under load.",
+		"solution": "Retry them with backoff.",
+		"sections": [{"title": "Acceptance Criteria", "content": "- [ ] Retries are capped"}],
+		"phases": [{"id": "p1", "title": "Client"}, {"id": "p2", "title": "Server", "status": "done"}],
+		"labels": ["backend"]
+	}`
+
+	p, err := planFromJSON(t, data)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if p.ID != "PLAN-1" || p.Title != "Add retries" || p.Status != StatusDraft {
+		t.Errorf("unexpected plan fields: %+v", p)
+	}
+	if len(p.Phases) != 2 || p.Phases[0].Status != PhasePending || p.Phases[1].Status != PhaseDone {
+		t.Errorf("expected phase statuses to default to pending, got %+v", p.Phases)
+	}
+	if !strings.Contains(p.RawContent, "## Acceptance Criteria\n\n- [ ] Retries are capped") {
+		t.Errorf("expected the extra section in the markdown, got:\n%s", p.RawContent)
+	}
+
+	parsed, err := Parse([]byte(p.RawContent))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Title != p.Title || parsed.ProblemStatement != p.ProblemStatement || parsed.ProposedSolution != p.ProposedSolution {
+		t.Errorf("markdown didn't round-trip: got %+v", parsed)
+	}
+	if err := ValidateStructure([]byte(p.RawContent)); err != nil {
+		t.Errorf("ValidateStructure() error = %v", err)
+	}
+}
+
+func TestJSONPlan_Plan_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		errContains string
+	}{
+		{"missing fields", `{"title": "Only a title"}`, "missing required fields: problem, solution"},
+		{"unknown status", `{"title": "T", "problem": "P", "solution": "S", "status": "someday"}`, "someday"},
+		{"phase without id", `{"title": "T", "problem": "P", "solution": "S", "phases": [{"title": "One"}]}`, "phase 1 has no id"},
+		{"unknown phase status", `{"title": "T", "problem": "P", "solution": "S", "phases": [{"id": "p1", "status": "stuck"}]}`, "phase p1"},
+		{"negative estimate", `{"title": "T", "problem": "P", "solution": "S", "estimate": -1}`, "invalid estimate"},
+		{"section without title", `{"title": "T", "problem": "P", "solution": "S", "sections": [{"content": "x"}]}`, "section has no title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := planFromJSON(t, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Plan() error = %v, want it to contain %q", err, tt.errContains)
+			}
+		})
+	}
+}
@@ -5,21 +5,35 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/adrg/frontmatter"
 	"gopkg.in/yaml.v3"
 )
 
-// Frontmatter represents the YAML frontmatter of a plan document
+// Frontmatter represents the YAML frontmatter of a plan document. Fields are
+// serialized in declaration order, so keep the identifying fields first and
+// append new fields rather than reordering existing ones.
 type Frontmatter struct {
-	ID        string    `yaml:"id"`
-	IssueID   string    `yaml:"issue_id,omitempty"`
-	Title     string    `yaml:"title"`
-	Status    Status    `yaml:"status"`
-	Created   string    `yaml:"created"`
-	Author    string    `yaml:"author"`
-	Reviewers Reviewers `yaml:"reviewers"`
+	ID            string    `yaml:"id"`
+	Title         string    `yaml:"title"`
+	Status        Status    `yaml:"status"`
+	Author        string    `yaml:"author"`
+	IssueID       string    `yaml:"issue_id,omitempty"`
+	Labels        []string  `yaml:"labels,omitempty"`
+	Phases        []Phase   `yaml:"phases,omitempty"`
+	Estimate      *int      `yaml:"estimate,omitempty"`
+	Created       string    `yaml:"created"`
+	Reviewers     Reviewers `yaml:"reviewers"`
+	RelatedIssues []string  `yaml:"related_issues,omitempty"`
+	Links         []string  `yaml:"links,omitempty"`
+	Blocks        []string  `yaml:"blocks,omitempty"`
+	BlockedBy     []string  `yaml:"blocked_by,omitempty"`
+
+	// Extra holds the keys not modelled above, such as a team's own metadata.
+	// They are written after the known fields, sorted by key.
+	Extra map[string]any `yaml:",inline"`
 }
 
 // ParseFile reads and parses a plan from a file
@@ -52,6 +66,14 @@ func Parse(data []byte) (*Plan, error) {
 		Status:           fm.Status,
 		Author:           fm.Author,
 		Reviewers:        fm.Reviewers,
+		Phases:           fm.Phases,
+		Estimate:         fm.Estimate,
+		Labels:           fm.Labels,
+		RelatedIssues:    fm.RelatedIssues,
+		Links:            fm.Links,
+		Blocks:           fm.Blocks,
+		BlockedBy:        fm.BlockedBy,
+		Extra:            normalizeFrontmatterExtra(fm.Extra),
 		RawContent:       string(data),
 		QuestionsAnswers: make(map[string]string),
 		ReviewNotes:      make(map[ReviewerType]string),
@@ -70,6 +92,41 @@ func Parse(data []byte) (*Plan, error) {
 	return plan, nil
 }
 
+// normalizeFrontmatterExtra returns unmodelled frontmatter values with their
+// nested mappings keyed by strings, as the YAML decoder keys them by any
+// type. Returns nil if there are none.
+func normalizeFrontmatterExtra(extra map[string]any) map[string]any {
+	if len(extra) == 0 {
+		return nil
+	}
+	normalized := make(map[string]any, len(extra))
+	for key, value := range extra {
+		normalized[key] = normalizeFrontmatterValue(value)
+	}
+	return normalized
+}
+
+// normalizeFrontmatterValue converts the mappings within a decoded YAML
+// value to map[string]any
+func normalizeFrontmatterValue(value any) any {
+	switch v := value.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalizeFrontmatterValue(item)
+		}
+		return m
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = normalizeFrontmatterValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
 // parseMarkdownBody extracts structured content from the markdown
 func parseMarkdownBody(plan *Plan, body string) {
 	sections := splitSections(body)
@@ -131,6 +188,38 @@ func splitSections(body string) []Section {
 	return sections
 }
 
+// taskItemRegex matches a markdown task-list item, capturing its checkbox
+var taskItemRegex = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]`)
+
+// isAcceptanceCriteriaSection returns true if a section header names the
+// plan's acceptance criteria
+func isAcceptanceCriteriaSection(header string) bool {
+	return strings.Contains(strings.ToLower(header), "acceptance criteria")
+}
+
+// countTaskItems counts the checked and total task-list items in markdown,
+// skipping fenced code blocks
+func countTaskItems(content string) (done, total int) {
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := taskItemRegex.FindStringSubmatch(line); m != nil {
+			total++
+			if m[1] != " " {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
 // parseQA extracts Q&A pairs from a section
 func parseQA(plan *Plan, content string) {
 	// Look for Q: ... A: ... patterns
@@ -228,13 +317,21 @@ func Serialize(plan *Plan) ([]byte, error) {
 
 	// Write frontmatter with current field values
 	fm := Frontmatter{
-		ID:        plan.ID,
-		IssueID:   plan.IssueID,
-		Title:     plan.Title,
-		Status:    plan.Status,
-		Created:   plan.Created.Format("2006-01-02T15:04:05Z"),
-		Author:    plan.Author,
-		Reviewers: plan.Reviewers,
+		ID:            plan.ID,
+		Title:         plan.Title,
+		Status:        plan.Status,
+		Author:        plan.Author,
+		IssueID:       plan.IssueID,
+		Labels:        plan.Labels,
+		Phases:        plan.Phases,
+		Estimate:      plan.Estimate,
+		Created:       plan.Created.Format("2006-01-02T15:04:05Z"),
+		Reviewers:     plan.Reviewers,
+		RelatedIssues: plan.RelatedIssues,
+		Links:         plan.Links,
+		Blocks:        plan.Blocks,
+		BlockedBy:     plan.BlockedBy,
+		Extra:         plan.Extra,
 	}
 
 	buf.WriteString("---\n")
@@ -273,17 +370,28 @@ func Serialize(plan *Plan) ([]byte, error) {
 
 	if len(plan.QuestionsAnswers) > 0 {
 		buf.WriteString("## Clarifying Questions & Answers\n\n")
-		for q, a := range plan.QuestionsAnswers {
+		// Map iteration order is random; sort so output is byte-identical
+		questions := make([]string, 0, len(plan.QuestionsAnswers))
+		for q := range plan.QuestionsAnswers {
+			questions = append(questions, q)
+		}
+		sort.Strings(questions)
+		for _, q := range questions {
 			buf.WriteString(fmt.Sprintf("**Q: %s**\n", q))
-			buf.WriteString(fmt.Sprintf("A: %s\n\n", a))
+			buf.WriteString(fmt.Sprintf("A: %s\n\n", plan.QuestionsAnswers[q]))
 		}
 	}
 
 	if len(plan.ReviewNotes) > 0 {
 		buf.WriteString("## Review Notes\n\n")
-		for reviewer, notes := range plan.ReviewNotes {
+		reviewers := make([]ReviewerType, 0, len(plan.ReviewNotes))
+		for reviewer := range plan.ReviewNotes {
+			reviewers = append(reviewers, reviewer)
+		}
+		sort.Slice(reviewers, func(i, j int) bool { return reviewers[i] < reviewers[j] })
+		for _, reviewer := range reviewers {
 			buf.WriteString(fmt.Sprintf("### %s Review\n\n", strings.Title(string(reviewer))))
-			buf.WriteString(notes)
+			buf.WriteString(plan.ReviewNotes[reviewer])
 			buf.WriteString("\n\n")
 		}
 	}
@@ -1,6 +1,7 @@
 package plan
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -674,3 +675,403 @@ title: Test
 		})
 	}
 }
+
+func TestParsePhases(t *testing.T) {
+	content := `---
+id: test-plan
+title: Phased Plan
+status: in-progress
+author: testuser
+phases:
+  - id: phase-1
+    title: Add schema
+    status: done
+  - id: phase-2
+    title: Wire up API
+    status: in-progress
+  - id: phase-3
+    title: Write docs
+    status: pending
+---
+
+# Phased Plan
+
+## Problem Statement
+
+Problem.
+
+## Proposed Solution
+
+Solution.
+`
+
+	plan, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []Phase{
+		{ID: "phase-1", Title: "Add schema", Status: PhaseDone},
+		{ID: "phase-2", Title: "Wire up API", Status: PhaseInProgress},
+		{ID: "phase-3", Title: "Write docs", Status: PhasePending},
+	}
+	if len(plan.Phases) != len(want) {
+		t.Fatalf("expected %d phases, got %d", len(want), len(plan.Phases))
+	}
+	for i, phase := range plan.Phases {
+		if phase != want[i] {
+			t.Errorf("phase %d = %+v, want %+v", i, phase, want[i])
+		}
+	}
+}
+
+func TestSerializePreservesPhases(t *testing.T) {
+	original := &Plan{
+		ID:     "test-plan",
+		Title:  "Phased Plan",
+		Status: StatusDraft,
+		Author: "testuser",
+		Phases: []Phase{
+			{ID: "phase-1", Title: "Add schema", Status: PhaseDone},
+		},
+	}
+
+	data, err := Serialize(original)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(parsed.Phases) != 1 || parsed.Phases[0] != original.Phases[0] {
+		t.Errorf("expected phases to round-trip, got %+v", parsed.Phases)
+	}
+}
+
+func TestSerializeIsDeterministic(t *testing.T) {
+	p := &Plan{
+		ID:      "test-plan",
+		IssueID: "NUM-41",
+		Title:   "Deterministic Plan",
+		Status:  StatusDraft,
+		Author:  "testuser",
+		Labels:  []string{"backend"},
+		Phases: []Phase{
+			{ID: "phase-1", Title: "Add schema"},
+		},
+		ProblemStatement: "Problem.",
+		QuestionsAnswers: map[string]string{
+			"Which database?": "Postgres",
+			"Any migration?":  "Yes",
+			"Who owns it?":    "Platform",
+		},
+		ReviewNotes: map[ReviewerType]string{
+			ReviewerSecurity: "Looks safe",
+			ReviewerLead:     "Approved",
+		},
+	}
+
+	first, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := Serialize(p)
+		if err != nil {
+			t.Fatalf("Serialize() error = %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("expected byte-identical output, got:\n%s\nthen:\n%s", first, again)
+		}
+	}
+
+	// Frontmatter keys come in a fixed order
+	output := string(first)
+	keys := []string{"id:", "title:", "status:", "author:", "issue_id:", "labels:", "phases:", "created:", "reviewers:"}
+	last := -1
+	for _, key := range keys {
+		idx := strings.Index(output, "\n"+key)
+		if idx == -1 {
+			t.Fatalf("expected frontmatter key %q, got:\n%s", key, output)
+		}
+		if idx < last {
+			t.Errorf("expected %q to come after the previous key, got:\n%s", key, output)
+		}
+		last = idx
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	content := `---
+id: test-plan
+title: Estimated Plan
+status: draft
+author: testuser
+estimate: 5
+phases:
+  - id: phase-1
+    title: Phase 1
+    status: pending
+    estimate: 3
+---
+
+# Estimated Plan
+`
+
+	p, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if p.Estimate == nil || *p.Estimate != 5 {
+		t.Errorf("expected estimate 5, got %v", p.Estimate)
+	}
+	if len(p.Phases) != 1 || p.Phases[0].Estimate == nil || *p.Phases[0].Estimate != 3 {
+		t.Fatalf("expected phase estimate 3, got %+v", p.Phases)
+	}
+
+	data, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Estimate == nil || *parsed.Estimate != 5 {
+		t.Errorf("expected estimate to round-trip, got %v", parsed.Estimate)
+	}
+	if parsed.Phases[0].Estimate == nil || *parsed.Phases[0].Estimate != 3 {
+		t.Errorf("expected phase estimate to round-trip, got %v", parsed.Phases[0].Estimate)
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	content := `---
+id: test-plan
+title: Labeled Plan
+status: draft
+author: testuser
+labels:
+  - backend
+  - security
+---
+
+# Labeled Plan
+`
+
+	p, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := strings.Join(p.Labels, ","); got != "backend,security" {
+		t.Errorf("expected labels backend,security, got %v", p.Labels)
+	}
+
+	data, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := strings.Join(parsed.Labels, ","); got != "backend,security" {
+		t.Errorf("expected labels to round-trip, got %v", parsed.Labels)
+	}
+}
+
+func TestParseRelatedIssues(t *testing.T) {
+	content := `---
+id: test-plan
+issue_id: NUM-1
+related_issues:
+  - NUM-2
+  - NUM-3
+title: Epic Plan
+status: draft
+author: testuser
+---
+
+# Epic Plan
+`
+
+	p, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if p.IssueID != "NUM-1" {
+		t.Errorf("expected primary issue NUM-1, got %q", p.IssueID)
+	}
+	if got := strings.Join(p.RelatedIssues, ","); got != "NUM-2,NUM-3" {
+		t.Errorf("expected related issues NUM-2,NUM-3, got %v", p.RelatedIssues)
+	}
+
+	data, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := strings.Join(parsed.RelatedIssues, ","); got != "NUM-2,NUM-3" {
+		t.Errorf("expected related issues to round-trip, got %v", parsed.RelatedIssues)
+	}
+
+	// Plans without related issues don't write the field
+	p.RelatedIssues = nil
+	data, err = Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	if strings.Contains(string(data), "related_issues") {
+		t.Errorf("expected no related_issues field, got:\n%s", data)
+	}
+}
+
+func TestParseBlockingRelations(t *testing.T) {
+	content := `---
+id: test-plan
+issue_id: NUM-1
+blocks:
+  - NUM-2
+blocked_by: [NUM-3, NUM-4]
+title: Blocked Plan
+status: draft
+author: testuser
+---
+
+# Blocked Plan
+`
+
+	p, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if strings.Join(p.Blocks, ",") != "NUM-2" || strings.Join(p.BlockedBy, ",") != "NUM-3,NUM-4" {
+		t.Errorf("expected blocks NUM-2 and blocked_by NUM-3,NUM-4, got %v and %v", p.Blocks, p.BlockedBy)
+	}
+
+	data, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if strings.Join(parsed.Blocks, ",") != "NUM-2" || strings.Join(parsed.BlockedBy, ",") != "NUM-3,NUM-4" {
+		t.Errorf("expected relations to round-trip, got %v and %v", parsed.Blocks, parsed.BlockedBy)
+	}
+}
+
+func TestParseExtraFrontmatter(t *testing.T) {
+	content := `---
+id: test-plan
+title: Custom Plan
+status: draft
+author: testuser
+sprint: 42
+jira_id: PROJ-7
+reviewed: true
+owners:
+  - alice
+  - bob
+budget:
+  hours: 16
+  approved_by: carol
+---
+
+# Custom Plan
+`
+
+	p, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	want := map[string]any{
+		"sprint":   42,
+		"jira_id":  "PROJ-7",
+		"reviewed": true,
+		"owners":   []any{"alice", "bob"},
+		"budget":   map[string]any{"hours": 16, "approved_by": "carol"},
+	}
+	if !reflect.DeepEqual(p.Extra, want) {
+		t.Errorf("Extra = %#v, want %#v", p.Extra, want)
+	}
+
+	// Changing a known field keeps the custom keys unchanged
+	p.Status = StatusApproved
+	data, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	for _, line := range []string{"sprint: 42", "jira_id: PROJ-7", "reviewed: true", "  - alice", "  hours: 16", "  approved_by: carol"} {
+		if !strings.Contains(string(data), line+"\n") {
+			t.Errorf("expected serialized frontmatter to contain %q, got:\n%s", line, data)
+		}
+	}
+
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(parsed.Extra, want) || parsed.Status != StatusApproved {
+		t.Errorf("expected custom keys to round-trip, got %#v", parsed.Extra)
+	}
+
+	// Plans without custom keys have none
+	parsed, err = Parse([]byte("---\nid: plain\ntitle: Plain\nstatus: draft\nauthor: testuser\n---\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Extra != nil {
+		t.Errorf("expected no extra keys, got %v", parsed.Extra)
+	}
+}
+
+func TestParseLinks(t *testing.T) {
+	content := `---
+id: test-plan
+title: Linked Plan
+status: draft
+author: testuser
+links:
+  - https://example.com/design-doc
+  - https://example.com/spec
+---
+
+# Linked Plan
+`
+
+	p, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := strings.Join(p.Links, ","); got != "https://example.com/design-doc,https://example.com/spec" {
+		t.Errorf("unexpected links: %v", p.Links)
+	}
+
+	data, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := strings.Join(parsed.Links, ","); got != "https://example.com/design-doc,https://example.com/spec" {
+		t.Errorf("expected links to round-trip, got %v", parsed.Links)
+	}
+
+	p.Links = nil
+	data, err = Serialize(p)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	if strings.Contains(string(data), "links:") {
+		t.Errorf("expected no links field, got:\n%s", data)
+	}
+}
@@ -2,6 +2,8 @@ package plan
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -34,17 +36,65 @@ type Reviewers struct {
 	OptedOut []ReviewerType `yaml:"opted_out,omitempty"`
 }
 
+// PhaseStatus represents the progress of a single plan phase
+type PhaseStatus string
+
+const (
+	PhasePending    PhaseStatus = "pending"
+	PhaseInProgress PhaseStatus = "in-progress"
+	PhaseDone       PhaseStatus = "done"
+)
+
+// ParseStatus validates and converts a string to a plan Status
+func ParseStatus(s string) (Status, error) {
+	status := Status(s)
+	if !isValidStatus(status) {
+		return "", fmt.Errorf("invalid plan status %q (must be one of: %s)", s, joinStatuses(validStatuses))
+	}
+	return status, nil
+}
+
+// ParsePhaseStatus validates and converts a string to a PhaseStatus
+func ParsePhaseStatus(s string) (PhaseStatus, error) {
+	switch status := PhaseStatus(s); status {
+	case PhasePending, PhaseInProgress, PhaseDone:
+		return status, nil
+	default:
+		return "", fmt.Errorf("invalid phase status %q (must be one of: %s, %s, %s)", s, PhasePending, PhaseInProgress, PhaseDone)
+	}
+}
+
+// Phase is a unit of work within a plan, declared in the frontmatter
+type Phase struct {
+	ID       string      `yaml:"id" json:"id"`
+	Title    string      `yaml:"title" json:"title"`
+	Status   PhaseStatus `yaml:"status" json:"status"`
+	Estimate *int        `yaml:"estimate,omitempty" json:"estimate,omitempty"` // Story points for this phase
+	IssueID  string      `yaml:"issue_id,omitempty" json:"issue_id,omitempty"` // Sub-issue created for this phase by jig plan breakdown
+}
+
 // Plan represents a complete work plan
 type Plan struct {
 	// Frontmatter fields
-	ID        string    `yaml:"id"`                      // Internal plan ID (e.g., PLAN-1234567890)
-	IssueID   string    `yaml:"issue_id,omitempty"`      // Optional linked issue ID (e.g., NUM-41)
-	Title     string    `yaml:"title"`
-	Status    Status    `yaml:"status"`
-	Created   time.Time `yaml:"created"`
-	Updated   time.Time `yaml:"updated,omitempty"`
-	Author    string    `yaml:"author"`
-	Reviewers Reviewers `yaml:"reviewers"`
+	ID            string    `yaml:"id"`                 // Internal plan ID (e.g., PLAN-1234567890)
+	IssueID       string    `yaml:"issue_id,omitempty"` // Optional linked issue ID (e.g., NUM-41)
+	Title         string    `yaml:"title"`
+	Status        Status    `yaml:"status"`
+	Created       time.Time `yaml:"created"`
+	Updated       time.Time `yaml:"updated,omitempty"`
+	Author        string    `yaml:"author"`
+	Reviewers     Reviewers `yaml:"reviewers"`
+	Phases        []Phase   `yaml:"phases,omitempty"`
+	Estimate      *int      `yaml:"estimate,omitempty"`       // Story points; defaults to the sum of phase estimates
+	Labels        []string  `yaml:"labels,omitempty"`         // Tracker labels to apply alongside the plan label
+	RelatedIssues []string  `yaml:"related_issues,omitempty"` // Other issues the plan covers, e.g. sub-issues of IssueID
+	Links         []string  `yaml:"links,omitempty"`          // URLs of design docs, specs etc., attached to the issue
+	Blocks        []string  `yaml:"blocks,omitempty"`         // Issues IssueID blocks, related in the tracker on sync
+	BlockedBy     []string  `yaml:"blocked_by,omitempty"`     // Issues blocking IssueID, related in the tracker on sync
+
+	// Frontmatter keys jig doesn't model, such as a team's own metadata, kept
+	// through a round trip
+	Extra map[string]any `yaml:",inline"`
 
 	// Parsed from markdown body
 	ProblemStatement string                  `yaml:"-"`
@@ -75,6 +125,51 @@ func NewPlan(id, title, author string) *Plan {
 	}
 }
 
+// PlaceholderTitle is the title 'jig plan new' gives a plan until the
+// planning session writes a real one
+const PlaceholderTitle = "New Plan"
+
+// maxDerivedTitleLength is the longest title, in characters, DeriveTitle
+// returns before its ellipsis
+const maxDerivedTitleLength = 80
+
+// derivedTitleMarkup removes emphasis and code markers from derived titles
+var derivedTitleMarkup = strings.NewReplacer("**", "", "__", "", "`", "")
+
+// DeriveTitle derives a title from the first line of the plan's problem
+// statement, without markdown markers and shortened at a word boundary.
+// Returns "" if there is no problem statement, or only the TODO that 'jig
+// plan new' starts plans with.
+func (p *Plan) DeriveTitle() string {
+	var line string
+	for _, l := range strings.Split(p.ProblemStatement, "\n") {
+		l = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(l), "#>"))
+		for _, marker := range []string{"- ", "* ", "+ "} {
+			l = strings.TrimPrefix(l, marker)
+		}
+		if l = strings.TrimSpace(derivedTitleMarkup.Replace(l)); l != "" {
+			line = l
+			break
+		}
+	}
+	if line == "" || strings.HasPrefix(line, "TODO") {
+		return ""
+	}
+
+	line = strings.TrimRight(line, ".:")
+	runes := []rune(line)
+	if len(runes) <= maxDerivedTitleLength {
+		return line
+	}
+	// Cut on a character, not a byte, so multi-byte text stays valid
+	head := string(runes[:maxDerivedTitleLength])
+	cut := strings.LastIndex(head, " ")
+	if cut <= 0 {
+		cut = len(head)
+	}
+	return strings.TrimRight(head[:cut], " ,;:.") + "..."
+}
+
 // IsReadyForReview returns true if the plan can be reviewed
 func (p *Plan) IsReadyForReview() bool {
 	return p.Status == StatusDraft
@@ -85,18 +180,101 @@ func (p *Plan) CanBeImplemented() bool {
 	return p.Status == StatusApproved || p.Status == StatusInProgress
 }
 
-// Validate checks if the plan is well-formed
-func (p *Plan) Validate() error {
-	if p.ID == "" {
-		return fmt.Errorf("plan ID is required")
+// issueKeyPattern matches a keyed issue identifier: a team key of letters and
+// digits starting with a letter, possibly hyphenated ("WEB-API"), then a
+// hyphen and the issue number
+const issueKeyPattern = `[A-Za-z][A-Za-z0-9]*(?:-[A-Za-z0-9]+)*-[0-9]+`
+
+// issueKeyRegexp matches keyed issue identifiers such as "ENG-123"
+var issueKeyRegexp = regexp.MustCompile(`^` + issueKeyPattern + `$`)
+
+// issueIDPattern matches tracker issue identifiers: keyed identifiers such as
+// "ENG-123", and the full references ("acme/web#45") and project-qualified
+// IDs ("123:45") GitLab uses for issues outside its configured project
+var issueIDPattern = regexp.MustCompile(`^(?:` + issueKeyPattern + `|[\w.-]+(?:/[\w.-]+)+#[0-9]+|[0-9]+:[0-9]+)$`)
+
+// IsIssueKey reports whether s is a keyed issue identifier such as "ENG-123"
+// or "WEB-API-42": a team key of letters and digits starting with a letter,
+// possibly hyphenated, then a hyphen and the issue number
+func IsIssueKey(s string) bool {
+	return issueKeyRegexp.MatchString(s)
+}
+
+// FieldError is a problem with one field of a plan
+type FieldError struct {
+	Field   string // Frontmatter name of the field, e.g. "phases[1].id"
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidateID checks that id is usable as a plan ID. Plan IDs name files, so
+// they must not be blank, contain slashes or whitespace, or start with a dot.
+// Returns a *FieldError for the "id" field.
+func ValidateID(id string) error {
+	switch {
+	case strings.TrimSpace(id) == "":
+		return &FieldError{Field: "id", Message: "is required"}
+	case strings.ContainsAny(id, "/\\ \t\n") || strings.HasPrefix(id, "."):
+		return &FieldError{Field: "id", Message: fmt.Sprintf("%q must not contain slashes or whitespace, or start with a dot", id)}
+	}
+	return nil
+}
+
+// Validate checks that the plan is well-formed: it has an ID usable as a
+// file name, a title, a known status and an author, its phases have unique
+// IDs and known or empty statuses, estimates are non-negative, and IssueID, if set,
+// looks like an issue identifier. Returns a *FieldError for every problem
+// found, or nil if there are none.
+func (p *Plan) Validate() []error {
+	var errs []error
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
 	}
-	if p.Title == "" {
-		return fmt.Errorf("plan title is required")
+
+	if err := ValidateID(p.ID); err != nil {
+		errs = append(errs, err)
 	}
-	if p.Author == "" {
-		return fmt.Errorf("plan author is required")
+	if strings.TrimSpace(p.Title) == "" {
+		add("title", "is required")
 	}
-	return nil
+	if !isValidStatus(p.Status) {
+		add("status", "%q is not valid (must be one of: %s)", p.Status, joinStatuses(validStatuses))
+	}
+	if strings.TrimSpace(p.Author) == "" {
+		add("author", "is required")
+	}
+	if p.IssueID != "" && !issueIDPattern.MatchString(p.IssueID) {
+		add("issue_id", "%q is not an issue identifier (e.g. ENG-123)", p.IssueID)
+	}
+	if p.Estimate != nil && *p.Estimate < 0 {
+		add("estimate", "must not be negative, got %d", *p.Estimate)
+	}
+
+	seen := make(map[string]bool)
+	for i, phase := range p.Phases {
+		field := fmt.Sprintf("phases[%d]", i)
+		switch {
+		case strings.TrimSpace(phase.ID) == "":
+			add(field+".id", "is required")
+		case seen[phase.ID]:
+			add(field+".id", "%q is used by another phase", phase.ID)
+		}
+		seen[phase.ID] = true
+		// A phase without a status is pending
+		if phase.Status != "" && !isValidPhaseStatus(phase.Status) {
+			add(field+".status", "%q is not valid (must be one of: %s)", phase.Status, joinStatuses(validPhaseStatuses))
+		}
+		if phase.Estimate != nil && *phase.Estimate < 0 {
+			add(field+".estimate", "must not be negative, got %d", *phase.Estimate)
+		}
+		if phase.IssueID != "" && !issueIDPattern.MatchString(phase.IssueID) {
+			add(field+".issue_id", "%q is not an issue identifier (e.g. ENG-123)", phase.IssueID)
+		}
+	}
+	return errs
 }
 
 // HasLinkedIssue returns true if the plan is linked to an external issue
@@ -104,6 +282,205 @@ func (p *Plan) HasLinkedIssue() bool {
 	return p.IssueID != ""
 }
 
+// PhaseProgress returns the number of completed phases and the total number of phases
+func (p *Plan) PhaseProgress() (done, total int) {
+	for _, phase := range p.Phases {
+		if phase.Status == PhaseDone {
+			done++
+		}
+	}
+	return done, len(p.Phases)
+}
+
+// Progress returns the number of checked and total task-list items ("- [x]"
+// and "- [ ]") in the plan's Acceptance Criteria section, including its
+// subsections. Checkboxes elsewhere in the plan, or in code blocks, are not
+// counted.
+func (p *Plan) Progress() (done, total int) {
+	if p.RawContent == "" {
+		return 0, 0
+	}
+	body, err := extractBodyFromRawContent(p.RawContent)
+	if err != nil {
+		return 0, 0
+	}
+
+	sections := splitSections(body)
+	for i := 0; i < len(sections); i++ {
+		section := sections[i]
+		if !isAcceptanceCriteriaSection(section.Header) {
+			continue
+		}
+		d, t := countTaskItems(section.Content)
+		done += d
+		total += t
+		// Count the subsections here and skip past them, so one named like
+		// the section isn't counted again
+		for i+1 < len(sections) && sections[i+1].Level > section.Level {
+			i++
+			d, t := countTaskItems(sections[i].Content)
+			done += d
+			total += t
+		}
+	}
+	return done, total
+}
+
+// TotalEstimate returns the plan's estimate in story points: the top-level
+// estimate if set, otherwise the sum of the phase estimates. Returns false if
+// neither the plan nor any of its phases has an estimate.
+func (p *Plan) TotalEstimate() (int, bool) {
+	if p.Estimate != nil {
+		return *p.Estimate, true
+	}
+
+	var total int
+	var found bool
+	for _, phase := range p.Phases {
+		if phase.Estimate != nil {
+			total += *phase.Estimate
+			found = true
+		}
+	}
+	return total, found
+}
+
+// SetPhaseStatus updates the status of the phase with the given ID
+func (p *Plan) SetPhaseStatus(phaseID string, status PhaseStatus) error {
+	for i := range p.Phases {
+		if p.Phases[i].ID == phaseID {
+			p.Phases[i].Status = status
+			p.Updated = time.Now()
+			return nil
+		}
+	}
+	return fmt.Errorf("phase not found: %s", phaseID)
+}
+
+// SetPhaseIssue records the sub-issue created for the phase with the given ID
+func (p *Plan) SetPhaseIssue(phaseID, issueID string) error {
+	for i := range p.Phases {
+		if p.Phases[i].ID == phaseID {
+			p.Phases[i].IssueID = issueID
+			p.Updated = time.Now()
+			return nil
+		}
+	}
+	return fmt.Errorf("phase not found: %s", phaseID)
+}
+
+// Body returns the plan's markdown without its frontmatter, or "" if the
+// plan has no raw content
+func (p *Plan) Body() string {
+	body, err := extractBodyFromRawContent(p.RawContent)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(body)
+}
+
+// PhaseBody returns the content of the section of the plan's markdown that
+// describes a phase: the first heading naming the phase's title (or its ID if
+// it has no title), up to the next heading at the same level or above. The
+// name must be the whole heading or one of its parts, so "Phase 2: API"
+// matches "Phase 2" and "API" but neither "Phase 20" nor "API changes".
+// Returns "" if no heading matches.
+func (p *Plan) PhaseBody(phase Phase) string {
+	if p.RawContent == "" {
+		return ""
+	}
+	body, err := extractBodyFromRawContent(p.RawContent)
+	if err != nil {
+		return ""
+	}
+
+	name := strings.ToLower(phase.Title)
+	if name == "" {
+		name = strings.ToLower(phase.ID)
+	}
+	if name == "" {
+		return ""
+	}
+
+	sections := splitSections(body)
+	for i, section := range sections {
+		if !headingNames(section.Header, name) {
+			continue
+		}
+
+		// Include subsections, re-adding their headings
+		parts := []string{section.Content}
+		for _, sub := range sections[i+1:] {
+			if sub.Level <= section.Level {
+				break
+			}
+			parts = append(parts, strings.Repeat("#", sub.Level)+" "+sub.Header+"\n\n"+sub.Content)
+		}
+		return strings.TrimSpace(strings.Join(parts, "\n\n"))
+	}
+	return ""
+}
+
+// headingSeparators split a heading into parts, e.g. "Phase 1: Schema"
+var headingSeparators = strings.NewReplacer(" - ", ":", " – ", ":", " — ", ":")
+
+// headingNames reports whether a lowercased name is the whole heading or one
+// of its parts, ignoring case and surrounding space
+func headingNames(heading, name string) bool {
+	name = strings.TrimSpace(name)
+	heading = strings.ToLower(heading)
+	if strings.TrimSpace(heading) == name {
+		return true
+	}
+	for _, part := range strings.Split(headingSeparators.Replace(heading), ":") {
+		if strings.TrimSpace(part) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeToPhase returns a copy of the plan covering only the phase with the
+// given ID. The copy keeps the problem statement and proposed solution for
+// context, followed by the phase's own section of the plan.
+func (p *Plan) ScopeToPhase(phaseID string) (*Plan, error) {
+	var phase *Phase
+	for i := range p.Phases {
+		if p.Phases[i].ID == phaseID {
+			phase = &p.Phases[i]
+			break
+		}
+	}
+	if phase == nil {
+		return nil, fmt.Errorf("phase not found: %s", phaseID)
+	}
+
+	title := phase.Title
+	if title == "" {
+		title = phase.ID
+	}
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("\n# %s\n\n", p.Title))
+	if p.ProblemStatement != "" {
+		body.WriteString("## Problem Statement\n\n" + p.ProblemStatement + "\n\n")
+	}
+	if p.ProposedSolution != "" {
+		body.WriteString("## Proposed Solution\n\n" + p.ProposedSolution + "\n\n")
+	}
+	body.WriteString(fmt.Sprintf("## Phase: %s\n\n", title))
+	body.WriteString(fmt.Sprintf("Implement only this phase (%s) of the plan.\n\n", phase.ID))
+	if section := p.PhaseBody(*phase); section != "" {
+		body.WriteString(section + "\n")
+	}
+
+	scoped := *p
+	scoped.Phases = []Phase{*phase}
+	// Serialize replaces the frontmatter, so only the body matters here
+	scoped.RawContent = "---\n---\n" + body.String()
+	return &scoped, nil
+}
+
 // TransitionTo changes the plan status with validation
 func (p *Plan) TransitionTo(status Status) error {
 	validTransitions := map[Status][]Status{
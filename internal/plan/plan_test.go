@@ -1,6 +1,8 @@
 package plan
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -22,54 +24,77 @@ func TestNewPlan(t *testing.T) {
 }
 
 func TestPlanValidate(t *testing.T) {
+	validPlan := func() *Plan {
+		return &Plan{
+			ID:      "PLAN-123",
+			IssueID: "ENG-123",
+			Title:   "Test",
+			Status:  StatusDraft,
+			Author:  "user",
+			Phases: []Phase{
+				{ID: "p1", Title: "One", Status: PhaseDone},
+				{ID: "p2", Title: "Two", Status: PhasePending, IssueID: "ENG-124"},
+			},
+		}
+	}
+	negative := -1
+
+	if errs := validPlan().Validate(); len(errs) != 0 {
+		t.Errorf("Validate() on a valid plan = %v, want no errors", errs)
+	}
+
 	tests := []struct {
-		name    string
-		plan    *Plan
-		wantErr bool
+		name   string
+		modify func(p *Plan)
+		field  string
 	}{
-		{
-			name: "valid plan",
-			plan: &Plan{
-				ID:     "ENG-123",
-				Title:  "Test",
-				Author: "user",
-			},
-			wantErr: false,
-		},
-		{
-			name: "missing ID",
-			plan: &Plan{
-				Title:  "Test",
-				Author: "user",
-			},
-			wantErr: true,
-		},
-		{
-			name: "missing title",
-			plan: &Plan{
-				ID:     "ENG-123",
-				Author: "user",
-			},
-			wantErr: true,
-		},
-		{
-			name: "missing author",
-			plan: &Plan{
-				ID:    "ENG-123",
-				Title: "Test",
-			},
-			wantErr: true,
-		},
+		{"missing ID", func(p *Plan) { p.ID = "" }, "id"},
+		{"ID with a slash", func(p *Plan) { p.ID = "../PLAN-1" }, "id"},
+		{"missing title", func(p *Plan) { p.Title = " " }, "title"},
+		{"missing status", func(p *Plan) { p.Status = "" }, "status"},
+		{"unknown status", func(p *Plan) { p.Status = "someday" }, "status"},
+		{"missing author", func(p *Plan) { p.Author = "" }, "author"},
+		{"malformed issue ID", func(p *Plan) { p.IssueID = "not an issue" }, "issue_id"},
+		{"negative estimate", func(p *Plan) { p.Estimate = &negative }, "estimate"},
+		{"phase without ID", func(p *Plan) { p.Phases[1].ID = "" }, "phases[1].id"},
+		{"duplicate phase ID", func(p *Plan) { p.Phases[1].ID = "p1" }, "phases[1].id"},
+		{"unknown phase status", func(p *Plan) { p.Phases[0].Status = "stuck" }, "phases[0].status"},
+		{"negative phase estimate", func(p *Plan) { p.Phases[0].Estimate = &negative }, "phases[0].estimate"},
+		{"malformed phase issue ID", func(p *Plan) { p.Phases[1].IssueID = "124" }, "phases[1].issue_id"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.plan.Validate()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			p := validPlan()
+			tt.modify(p)
+
+			errs := p.Validate()
+			if len(errs) != 1 {
+				t.Fatalf("Validate() = %v, want exactly one error", errs)
+			}
+			var fieldErr *FieldError
+			if !errors.As(errs[0], &fieldErr) || fieldErr.Field != tt.field {
+				t.Errorf("Validate() = %v, want an error for field %q", errs[0], tt.field)
 			}
 		})
 	}
+
+	t.Run("accepts hyphenated team keys and GitLab references", func(t *testing.T) {
+		for _, issueID := range []string{"WEB-API-42", "acme/web#45", "acme/platform/web#45", "123:45"} {
+			p := validPlan()
+			p.IssueID = issueID
+			p.Phases[1].IssueID = issueID
+			if errs := p.Validate(); len(errs) != 0 {
+				t.Errorf("Validate() with issue %q = %v, want no errors", issueID, errs)
+			}
+		}
+	})
+
+	t.Run("reports every problem", func(t *testing.T) {
+		if errs := (&Plan{}).Validate(); len(errs) != 4 {
+			t.Errorf("Validate() on an empty plan = %v, want 4 errors", errs)
+		}
+	})
 }
 
 func TestPlanStatusTransition(t *testing.T) {
@@ -127,6 +152,31 @@ func TestPlanStatusTransitionInProgressToComplete(t *testing.T) {
 	}
 }
 
+func TestDeriveTitle(t *testing.T) {
+	long := "Requests to the search API time out when the index is rebuilding during peak traffic hours in every region"
+	tests := []struct {
+		name    string
+		problem string
+		want    string
+	}{
+		{"first line", "Requests fail under load.\n\nThey time out after 30s.", "Requests fail under load"},
+		{"skips blank lines and markers", "\n\n- **Checkout** is slow\n", "Checkout is slow"},
+		{"shortened at a word boundary", long, "Requests to the search API time out when the index is rebuilding during peak..."},
+		{"shortened on a character boundary", strings.Repeat("検索", 50), strings.Repeat("検索", 40) + "..."},
+		{"no problem statement", "  \n", ""},
+		{"placeholder problem statement", "TODO: Define the problem being solved", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Plan{ProblemStatement: tt.problem}
+			if got := p.DeriveTitle(); got != tt.want {
+				t.Errorf("DeriveTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsReadyForReview(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -194,3 +244,363 @@ func TestHasLinkedIssue(t *testing.T) {
 	}
 }
 
+
+func TestPhaseProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		phases    []Phase
+		wantDone  int
+		wantTotal int
+	}{
+		{name: "no phases", phases: nil, wantDone: 0, wantTotal: 0},
+		{
+			name: "mixed statuses",
+			phases: []Phase{
+				{ID: "1", Status: PhaseDone},
+				{ID: "2", Status: PhaseDone},
+				{ID: "3", Status: PhaseInProgress},
+				{ID: "4", Status: PhasePending},
+				{ID: "5", Status: PhasePending},
+			},
+			wantDone:  2,
+			wantTotal: 5,
+		},
+		{
+			name:      "all done",
+			phases:    []Phase{{ID: "1", Status: PhaseDone}},
+			wantDone:  1,
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Plan{Phases: tt.phases}
+			done, total := p.PhaseProgress()
+			if done != tt.wantDone || total != tt.wantTotal {
+				t.Errorf("PhaseProgress() = %d/%d, want %d/%d", done, total, tt.wantDone, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestProgress(t *testing.T) {
+	const frontmatter = "---\nid: PLAN-1\ntitle: Test\n---\n\n# Test\n\n"
+
+	tests := []struct {
+		name      string
+		body      string
+		wantDone  int
+		wantTotal int
+	}{
+		{
+			name:      "counts checked and unchecked items",
+			body:      "## Acceptance Criteria\n\n- [x] One\n- [X] Two\n- [ ] Three\n* [ ] Four\n  - [x] Nested\n",
+			wantDone:  3,
+			wantTotal: 5,
+		},
+		{
+			name:      "all checked",
+			body:      "## Acceptance Criteria\n\n- [x] One\n- [x] Two\n",
+			wantDone:  2,
+			wantTotal: 2,
+		},
+		{
+			name:      "ignores checkboxes outside the section",
+			body:      "## Implementation Steps\n\n- [x] Step one\n- [ ] Step two\n\n## Acceptance Criteria\n\n- [ ] Works\n\n## Notes\n\n- [x] Not a criterion\n",
+			wantDone:  0,
+			wantTotal: 1,
+		},
+		{
+			name:      "includes subsections",
+			body:      "## Acceptance Criteria\n\n- [x] One\n\n### Performance\n\n- [ ] Fast\n\n## Notes\n\n- [ ] Other\n",
+			wantDone:  1,
+			wantTotal: 2,
+		},
+		{
+			name:      "counts a subsection named like the section once",
+			body:      "## Acceptance Criteria\n\n- [x] One\n\n### Acceptance criteria\n\n- [ ] Two\n- [x] Three\n",
+			wantDone:  2,
+			wantTotal: 3,
+		},
+		{
+			name:      "ignores checkboxes in code blocks",
+			body:      "## Acceptance Criteria\n\n- [ ] Real\n\n```markdown\n- [x] Example\n```\n",
+			wantDone:  0,
+			wantTotal: 1,
+		},
+		{
+			name:      "section without checkboxes",
+			body:      "## Acceptance Criteria\n\n- Works\n- Is fast\n",
+			wantDone:  0,
+			wantTotal: 0,
+		},
+		{
+			name:      "no acceptance criteria section",
+			body:      "## Problem Statement\n\n- [x] Not counted\n",
+			wantDone:  0,
+			wantTotal: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse([]byte(frontmatter + tt.body))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			done, total := p.Progress()
+			if done != tt.wantDone || total != tt.wantTotal {
+				t.Errorf("Progress() = %d/%d, want %d/%d", done, total, tt.wantDone, tt.wantTotal)
+			}
+		})
+	}
+
+	if done, total := (&Plan{}).Progress(); done != 0 || total != 0 {
+		t.Errorf("Progress() without content = %d/%d, want 0/0", done, total)
+	}
+}
+
+func TestTotalEstimate(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	tests := []struct {
+		name   string
+		plan   *Plan
+		want   int
+		wantOK bool
+	}{
+		{name: "no estimate", plan: &Plan{Phases: []Phase{{ID: "1"}}}},
+		{
+			name:   "explicit estimate wins over phases",
+			plan:   &Plan{Estimate: intPtr(8), Phases: []Phase{{ID: "1", Estimate: intPtr(2)}}},
+			want:   8,
+			wantOK: true,
+		},
+		{
+			name:   "explicit zero estimate",
+			plan:   &Plan{Estimate: intPtr(0)},
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name: "phase estimates are summed",
+			plan: &Plan{Phases: []Phase{
+				{ID: "1", Estimate: intPtr(2)},
+				{ID: "2"},
+				{ID: "3", Estimate: intPtr(3)},
+			}},
+			want:   5,
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.plan.TotalEstimate()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("TotalEstimate() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for _, s := range []string{"draft", "reviewing", "approved", "in-progress", "in-review", "complete"} {
+		if _, err := ParseStatus(s); err != nil {
+			t.Errorf("ParseStatus(%q) unexpected error: %v", s, err)
+		}
+	}
+
+	for _, s := range []string{"", "done", "in_progress", "Draft"} {
+		if _, err := ParseStatus(s); err == nil {
+			t.Errorf("ParseStatus(%q) expected error", s)
+		}
+	}
+}
+
+func TestParsePhaseStatus(t *testing.T) {
+	for _, s := range []string{"pending", "in-progress", "done"} {
+		if _, err := ParsePhaseStatus(s); err != nil {
+			t.Errorf("ParsePhaseStatus(%q) unexpected error: %v", s, err)
+		}
+	}
+
+	for _, s := range []string{"", "complete", "in_progress", "Done"} {
+		if _, err := ParsePhaseStatus(s); err == nil {
+			t.Errorf("ParsePhaseStatus(%q) expected error", s)
+		}
+	}
+}
+
+func TestSetPhaseStatus(t *testing.T) {
+	p := &Plan{
+		Phases: []Phase{
+			{ID: "phase-1", Status: PhasePending},
+			{ID: "phase-2", Status: PhasePending},
+		},
+	}
+
+	if err := p.SetPhaseStatus("phase-2", PhaseDone); err != nil {
+		t.Fatalf("SetPhaseStatus() error = %v", err)
+	}
+	if p.Phases[1].Status != PhaseDone {
+		t.Errorf("expected phase-2 to be done, got %s", p.Phases[1].Status)
+	}
+	if p.Phases[0].Status != PhasePending {
+		t.Errorf("expected phase-1 to be unchanged, got %s", p.Phases[0].Status)
+	}
+	if p.Updated.IsZero() {
+		t.Error("expected Updated to be bumped")
+	}
+
+	if err := p.SetPhaseStatus("phase-9", PhaseDone); err == nil {
+		t.Error("expected error for unknown phase")
+	}
+}
+
+func TestSetPhaseIssue(t *testing.T) {
+	p := &Plan{
+		Phases: []Phase{
+			{ID: "phase-1"},
+			{ID: "phase-2"},
+		},
+	}
+
+	if err := p.SetPhaseIssue("phase-2", "NUM-42"); err != nil {
+		t.Fatalf("SetPhaseIssue() error = %v", err)
+	}
+	if p.Phases[1].IssueID != "NUM-42" || p.Phases[0].IssueID != "" {
+		t.Errorf("expected only phase-2 to be linked, got %+v", p.Phases)
+	}
+	if p.Updated.IsZero() {
+		t.Error("expected Updated to be bumped")
+	}
+
+	if err := p.SetPhaseIssue("phase-9", "NUM-43"); err == nil {
+		t.Error("expected error for unknown phase")
+	}
+}
+
+func TestPhaseBody(t *testing.T) {
+	p := &Plan{
+		RawContent: `---
+title: Test Plan
+---
+
+# Test Plan
+
+## Implementation
+
+### Phase 1: Database schema
+
+Add the tables.
+
+#### Migrations
+
+Write a migration.
+
+### Phase 2: API
+
+Add the endpoints.
+
+### Phase 10 - API endpoints
+
+Document them.
+
+## Risks
+
+None.
+`,
+	}
+
+	tests := []struct {
+		name  string
+		phase Phase
+		want  string
+	}{
+		{
+			name:  "includes subsections",
+			phase: Phase{ID: "phase-1", Title: "Database Schema"},
+			want:  "Add the tables.\n\n#### Migrations\n\nWrite a migration.",
+		},
+		{
+			name:  "stops at the next heading at the same level",
+			phase: Phase{ID: "phase-2", Title: "API"},
+			want:  "Add the endpoints.",
+		},
+		{
+			name:  "matches the ID without a title",
+			phase: Phase{ID: "Phase 2"},
+			want:  "Add the endpoints.",
+		},
+		{
+			name:  "doesn't match part of a heading's words",
+			phase: Phase{ID: "phase-4", Title: "Endpoints"},
+			want:  "",
+		},
+		{
+			name:  "no matching heading",
+			phase: Phase{ID: "phase-3", Title: "Rollout"},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.PhaseBody(tt.phase); got != tt.want {
+				t.Errorf("PhaseBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("doesn't match a longer phase number", func(t *testing.T) {
+		p := &Plan{RawContent: "---\ntitle: Test Plan\n---\n\n## Phase 10: Cleanup\n\nRemove the flag.\n\n## Phase 1: Schema\n\nAdd the tables.\n"}
+		if got, want := p.PhaseBody(Phase{ID: "Phase 1"}), "Add the tables."; got != want {
+			t.Errorf("PhaseBody() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestScopeToPhase(t *testing.T) {
+	p := &Plan{
+		ID:               "PLAN-1",
+		Title:            "Test Plan",
+		ProblemStatement: "The problem.",
+		ProposedSolution: "The solution.",
+		Phases: []Phase{
+			{ID: "phase-1", Title: "Database schema"},
+			{ID: "phase-2", Title: "API"},
+		},
+		RawContent: "---\ntitle: Test Plan\n---\n\n# Test Plan\n\n## Database schema\n\nAdd the tables.\n\n## API\n\nAdd the endpoints.\n",
+	}
+
+	scoped, err := p.ScopeToPhase("phase-2")
+	if err != nil {
+		t.Fatalf("ScopeToPhase() error = %v", err)
+	}
+	if len(scoped.Phases) != 1 || scoped.Phases[0].ID != "phase-2" {
+		t.Errorf("expected only phase-2, got %+v", scoped.Phases)
+	}
+	if len(p.Phases) != 2 {
+		t.Errorf("expected the original plan to keep its phases, got %+v", p.Phases)
+	}
+
+	data, err := Serialize(scoped)
+	if err != nil {
+		t.Fatalf("Serialize() error = %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"The problem.", "The solution.", "## Phase: API", "Add the endpoints."} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected scoped plan to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Add the tables.") {
+		t.Errorf("expected other phases to be left out, got:\n%s", content)
+	}
+
+	if _, err := p.ScopeToPhase("phase-9"); err == nil || !strings.Contains(err.Error(), "phase not found: phase-9") {
+		t.Errorf("expected phase not found error, got %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package plan
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validStatuses lists every plan status accepted in frontmatter
+var validStatuses = []Status{
+	StatusDraft,
+	StatusReviewing,
+	StatusApproved,
+	StatusInProgress,
+	StatusInReview,
+	StatusComplete,
+}
+
+// validPhaseStatuses lists every phase status accepted in frontmatter
+var validPhaseStatuses = []PhaseStatus{PhasePending, PhaseInProgress, PhaseDone}
+
+// SchemaError lists every problem found in a plan's frontmatter
+type SchemaError struct {
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	return "invalid frontmatter:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// ValidateFrontmatter checks the YAML frontmatter of a plan document against
+// the plan schema: id, title and author must be non-empty strings (id may be
+// omitted, as jig plan save generates one), status and phase statuses must be
+// known values, estimates must be non-negative whole numbers, labels,
+// related_issues, blocks and blocked_by must be lists of strings, links must
+// be a list of http(s) URLs, and every phase needs an id. All problems are reported at once as a
+// *SchemaError, each with the line it was found on.
+//
+// Documents without YAML frontmatter are left to ValidateStructure.
+func ValidateFrontmatter(data []byte) error {
+	raw, ok := extractFrontmatter(data)
+	if !ok {
+		return nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	v := &schemaValidator{}
+	if len(doc.Content) == 0 {
+		v.addf(1, "frontmatter is empty")
+		return v.err()
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.addf(root.Line, "frontmatter must be a mapping of fields")
+		return v.err()
+	}
+
+	fields := mappingFields(root)
+	if id, ok := fields["id"]; ok {
+		v.requireString(id, "id")
+	}
+	v.requireField(root, fields, "title", "title")
+	v.requireField(root, fields, "author", "author")
+	if status, ok := v.requireField(root, fields, "status", "status"); ok {
+		if !isValidStatus(Status(status.Value)) {
+			v.addf(status.Line, "status %q is not valid (must be one of: %s)", status.Value, joinStatuses(validStatuses))
+		}
+	}
+
+	if estimate, ok := fields["estimate"]; ok {
+		v.requireEstimate(estimate, "estimate")
+	}
+	for _, key := range []string{"labels", "related_issues", "blocks", "blocked_by"} {
+		if list, ok := fields[key]; ok {
+			v.validateStringList(list, key)
+		}
+	}
+	if links, ok := fields["links"]; ok {
+		v.validateLinks(links)
+	}
+	if phases, ok := fields["phases"]; ok {
+		v.validatePhases(phases)
+	}
+
+	return v.err()
+}
+
+// schemaValidator collects frontmatter problems with their line numbers
+type schemaValidator struct {
+	problems []string
+}
+
+// addf records a problem on the given frontmatter line
+func (v *schemaValidator) addf(line int, format string, args ...interface{}) {
+	// Frontmatter starts on the line after the opening "---"
+	v.problems = append(v.problems, fmt.Sprintf("line %d: %s", line+1, fmt.Sprintf(format, args...)))
+}
+
+// err returns the collected problems as a *SchemaError, or nil if there are none
+func (v *schemaValidator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &SchemaError{Problems: v.problems}
+}
+
+// requireField checks that a mapping has a non-empty string field under key,
+// reporting problems under name
+func (v *schemaValidator) requireField(mapping *yaml.Node, fields map[string]*yaml.Node, key, name string) (*yaml.Node, bool) {
+	node, ok := fields[key]
+	if !ok {
+		v.addf(mapping.Line, "missing required field %q", name)
+		return nil, false
+	}
+	return node, v.requireString(node, name)
+}
+
+// requireString checks that a field value is a non-empty string
+func (v *schemaValidator) requireString(node *yaml.Node, name string) bool {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+		v.addf(node.Line, "%s must be a string, got %s", name, describeNode(node))
+		return false
+	}
+	if strings.TrimSpace(node.Value) == "" {
+		v.addf(node.Line, "%s must not be empty", name)
+		return false
+	}
+	return true
+}
+
+// validatePhases checks the phases list and each phase in it
+func (v *schemaValidator) validatePhases(phases *yaml.Node) {
+	if phases.Kind != yaml.SequenceNode {
+		v.addf(phases.Line, "phases must be a list, got %s", describeNode(phases))
+		return
+	}
+
+	for i, phase := range phases.Content {
+		name := fmt.Sprintf("phases[%d]", i)
+		if phase.Kind != yaml.MappingNode {
+			v.addf(phase.Line, "%s must be a mapping with id, title and status, got %s", name, describeNode(phase))
+			continue
+		}
+
+		fields := mappingFields(phase)
+		v.requireField(phase, fields, "id", name+".id")
+		if status, ok := fields["status"]; ok && v.requireString(status, name+".status") {
+			if !isValidPhaseStatus(PhaseStatus(status.Value)) {
+				v.addf(status.Line, "%s.status %q is not valid (must be one of: %s)", name, status.Value, joinStatuses(validPhaseStatuses))
+			}
+		}
+		if estimate, ok := fields["estimate"]; ok {
+			v.requireEstimate(estimate, name+".estimate")
+		}
+		if issueID, ok := fields["issue_id"]; ok {
+			v.requireString(issueID, name+".issue_id")
+		}
+	}
+}
+
+// validateStringList checks that a field is a list of non-empty strings
+func (v *schemaValidator) validateStringList(list *yaml.Node, name string) {
+	if list.Kind != yaml.SequenceNode {
+		v.addf(list.Line, "%s must be a list, got %s", name, describeNode(list))
+		return
+	}
+	for i, item := range list.Content {
+		v.requireString(item, fmt.Sprintf("%s[%d]", name, i))
+	}
+}
+
+// validateLinks checks that links is a list of absolute http(s) URLs
+func (v *schemaValidator) validateLinks(list *yaml.Node) {
+	if list.Kind != yaml.SequenceNode {
+		v.addf(list.Line, "links must be a list, got %s", describeNode(list))
+		return
+	}
+	for i, item := range list.Content {
+		name := fmt.Sprintf("links[%d]", i)
+		if !v.requireString(item, name) {
+			continue
+		}
+		if u, err := url.Parse(item.Value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.addf(item.Line, "%s must be an http(s) URL, got %q", name, item.Value)
+		}
+	}
+}
+
+// requireEstimate checks that an estimate is a non-negative whole number of points
+func (v *schemaValidator) requireEstimate(node *yaml.Node, name string) {
+	var estimate int
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!int" || node.Decode(&estimate) != nil || estimate < 0 {
+		v.addf(node.Line, "%s must be a non-negative whole number, got %s", name, describeNode(node))
+	}
+}
+
+// extractFrontmatter returns the YAML between the opening and closing "---"
+// lines of a document
+func extractFrontmatter(data []byte) ([]byte, bool) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, false
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return []byte(strings.Join(lines[1:i], "\n")), true
+		}
+	}
+	return nil, false
+}
+
+// mappingFields indexes a mapping node's values by key
+func mappingFields(mapping *yaml.Node) map[string]*yaml.Node {
+	fields := make(map[string]*yaml.Node, len(mapping.Content)/2)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		fields[mapping.Content[i].Value] = mapping.Content[i+1]
+	}
+	return fields
+}
+
+// describeNode names the YAML type of a node for error messages
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a mapping"
+	}
+	switch node.Tag {
+	case "!!int", "!!float":
+		return fmt.Sprintf("number %s", node.Value)
+	case "!!bool":
+		return fmt.Sprintf("boolean %s", node.Value)
+	case "!!null":
+		return "null"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
+
+func isValidStatus(status Status) bool {
+	for _, s := range validStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidPhaseStatus(status PhaseStatus) bool {
+	for _, s := range validPhaseStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// joinStatuses formats a list of statuses for error messages
+func joinStatuses[S ~string](statuses []S) string {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,258 @@
+package plan
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateFrontmatter(t *testing.T) {
+	const body = `
+# Test Plan
+
+## Problem Statement
+
+This is the problem.
+
+## Proposed Solution
+
+This is the solution.
+`
+
+	tests := []struct {
+		name        string
+		frontmatter string
+		wantErrs    []string
+	}{
+		{
+			name: "valid plan",
+			frontmatter: `id: test-plan
+title: Test Plan
+status: in-progress
+author: testuser
+phases:
+  - id: phase-1
+    title: Phase 1
+    status: done
+  - id: phase-2
+    title: Phase 2`,
+		},
+		{
+			name: "id may be omitted",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser`,
+		},
+		{
+			name: "invalid status",
+			frontmatter: `id: test-plan
+title: Test Plan
+status: inprogress
+author: testuser`,
+			wantErrs: []string{`line 4: status "inprogress" is not valid (must be one of: draft, reviewing, approved, in-progress, in-review, complete)`},
+		},
+		{
+			name: "empty id",
+			frontmatter: `id: ""
+title: Test Plan
+status: draft
+author: testuser`,
+			wantErrs: []string{"line 2: id must not be empty"},
+		},
+		{
+			name: "missing title",
+			frontmatter: `id: test-plan
+status: draft
+author: testuser`,
+			wantErrs: []string{`line 2: missing required field "title"`},
+		},
+		{
+			name: "non-string author",
+			frontmatter: `id: test-plan
+title: Test Plan
+status: draft
+author: 42`,
+			wantErrs: []string{"line 5: author must be a string, got number 42"},
+		},
+		{
+			name: "list title",
+			frontmatter: `id: test-plan
+title: [a, b]
+status: draft
+author: testuser`,
+			wantErrs: []string{"line 3: title must be a string, got a list"},
+		},
+		{
+			name: "invalid phase status and missing phase id",
+			frontmatter: `id: test-plan
+title: Test Plan
+status: draft
+author: testuser
+phases:
+  - id: phase-1
+    status: finished
+  - title: Phase 2`,
+			wantErrs: []string{
+				`line 8: phases[0].status "finished" is not valid (must be one of: pending, in-progress, done)`,
+				`line 9: missing required field "phases[1].id"`,
+			},
+		},
+		{
+			name: "phase issue ids",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+phases:
+  - id: phase-1
+    issue_id: NUM-42
+  - id: phase-2
+    issue_id: 42`,
+			wantErrs: []string{"line 9: phases[1].issue_id must be a string, got number 42"},
+		},
+		{
+			name: "phases not a list",
+			frontmatter: `id: test-plan
+title: Test Plan
+status: draft
+author: testuser
+phases: phase-1`,
+			wantErrs: []string{`line 6: phases must be a list, got "phase-1"`},
+		},
+		{
+			name: "valid estimates",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+estimate: 0
+phases:
+  - id: phase-1
+    estimate: 3`,
+		},
+		{
+			name: "negative estimate",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+estimate: -2`,
+			wantErrs: []string{"line 5: estimate must be a non-negative whole number, got number -2"},
+		},
+		{
+			name: "non-numeric and fractional estimates",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+estimate: large
+phases:
+  - id: phase-1
+    estimate: 1.5`,
+			wantErrs: []string{
+				`line 5: estimate must be a non-negative whole number, got "large"`,
+				"line 8: phases[0].estimate must be a non-negative whole number, got number 1.5",
+			},
+		},
+		{
+			name: "valid labels",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+labels: [backend, security]`,
+		},
+		{
+			name: "invalid labels",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+labels:
+  - backend
+  - 42
+  - ""`,
+			wantErrs: []string{
+				"line 7: labels[1] must be a string, got number 42",
+				"line 8: labels[2] must not be empty",
+			},
+		},
+		{
+			name: "labels not a list",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+labels: backend`,
+			wantErrs: []string{`line 5: labels must be a list, got "backend"`},
+		},
+		{
+			name: "related issues",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+issue_id: NUM-1
+related_issues: [NUM-2, 3]`,
+			wantErrs: []string{"line 6: related_issues[1] must be a string, got number 3"},
+		},
+		{
+			name: "valid links",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+links: ["https://example.com/design", "http://wiki.internal/spec"]`,
+		},
+		{
+			name: "invalid links",
+			frontmatter: `title: Test Plan
+status: draft
+author: testuser
+links:
+  - docs/design.md
+  - 42
+  - mailto:team@example.com`,
+			wantErrs: []string{
+				`line 6: links[0] must be an http(s) URL, got "docs/design.md"`,
+				"line 7: links[1] must be a string, got number 42",
+				`line 8: links[2] must be an http(s) URL, got "mailto:team@example.com"`,
+			},
+		},
+		{
+			name: "every problem is reported",
+			frontmatter: `id: test-plan
+status: inprogress
+author: true`,
+			wantErrs: []string{
+				`missing required field "title"`,
+				"author must be a string, got boolean true",
+				`status "inprogress" is not valid`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFrontmatter([]byte("---\n" + tt.frontmatter + "\n---\n" + body))
+
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("ValidateFrontmatter() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("ValidateFrontmatter() error = %v, want *SchemaError", err)
+			}
+			if len(schemaErr.Problems) != len(tt.wantErrs) {
+				t.Errorf("expected %d problems, got %d: %v", len(tt.wantErrs), len(schemaErr.Problems), schemaErr.Problems)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateFrontmatter() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFrontmatter_NoFrontmatter(t *testing.T) {
+	// Missing frontmatter is reported by ValidateStructure
+	if err := ValidateFrontmatter([]byte("# Just markdown\n")); err != nil {
+		t.Errorf("ValidateFrontmatter() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// templateExt is the file extension of plan templates
+const templateExt = ".md"
+
+// TemplateVars holds the values substituted into a plan template
+type TemplateVars struct {
+	Title   string // Replaces {{title}}
+	IssueID string // Replaces {{issue_id}}
+}
+
+// ListTemplates returns the names of the plan templates in dir, sorted by
+// name. A missing directory has no templates.
+func ListTemplates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != templateExt {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), templateExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadTemplate reads the plan template with the given name from dir. The name
+// may be given with or without the .md extension.
+func LoadTemplate(dir, name string) (string, error) {
+	name = strings.TrimSuffix(name, templateExt)
+	if name == "" || filepath.Base(name) != name || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid template name: %q", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name+templateExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("template not found: %s (run 'jig plan template list' to see available templates)", name)
+		}
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return string(data), nil
+}
+
+// RenderTemplate substitutes {{title}} and {{issue_id}} in a plan template.
+// Any other text, including other {{...}} placeholders, is left as is.
+func RenderTemplate(content string, vars TemplateVars) string {
+	return strings.NewReplacer(
+		"{{title}}", vars.Title,
+		"{{issue_id}}", vars.IssueID,
+	).Replace(content)
+}
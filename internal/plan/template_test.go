@@ -0,0 +1,96 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListTemplates(t *testing.T) {
+	t.Run("lists markdown templates by name", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"feature.md", "bugfix.md", "notes.txt"} {
+			os.WriteFile(filepath.Join(dir, name), []byte("# Template"), 0644)
+		}
+		os.Mkdir(filepath.Join(dir, "nested.md"), 0755)
+
+		names, err := ListTemplates(dir)
+		if err != nil {
+			t.Fatalf("ListTemplates() error = %v", err)
+		}
+		if got := strings.Join(names, ","); got != "bugfix,feature" {
+			t.Errorf("ListTemplates() = %v, want [bugfix feature]", names)
+		}
+	})
+
+	t.Run("missing directory has no templates", func(t *testing.T) {
+		names, err := ListTemplates(filepath.Join(t.TempDir(), "templates"))
+		if err != nil {
+			t.Fatalf("ListTemplates() error = %v", err)
+		}
+		if len(names) != 0 {
+			t.Errorf("expected no templates, got %v", names)
+		}
+	})
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "feature.md"), []byte("# {{title}}\n"), 0644)
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		want    string
+		wantErr string
+	}{
+		{name: "by name", tmpl: "feature", want: "# {{title}}\n"},
+		{name: "with extension", tmpl: "feature.md", want: "# {{title}}\n"},
+		{name: "missing template", tmpl: "bugfix", wantErr: "template not found: bugfix"},
+		{name: "path outside templates directory", tmpl: "../feature", wantErr: "invalid template name"},
+		{name: "empty name", tmpl: "", wantErr: "invalid template name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadTemplate(dir, tt.tmpl)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadTemplate() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("LoadTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	content := `---
+title: {{title}}
+---
+
+# {{title}}
+
+Tracks {{issue_id}}. Leave {{unknown}} alone.
+`
+	got := RenderTemplate(content, TemplateVars{Title: "Add caching", IssueID: "NUM-42"})
+
+	want := `---
+title: Add caching
+---
+
+# Add caching
+
+Tracks NUM-42. Leave {{unknown}} alone.
+`
+	if got != want {
+		t.Errorf("RenderTemplate() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -46,6 +46,24 @@ func (r *ClaudeRunner) Available() bool {
 
 // Prepare sets up the context for Claude Code
 func (r *ClaudeRunner) Prepare(ctx context.Context, opts *PrepareOpts) error {
+	if err := prepareJigContext(opts); err != nil {
+		return err
+	}
+
+	// Copy .claude/commands/ from the main repo to the worktree
+	// so that Claude Code can find the /jig:implement skill
+	if err := r.copyClaudeCommands(opts.WorktreeDir); err != nil {
+		// Non-fatal - log but continue
+		fmt.Fprintf(os.Stderr, "Warning: could not copy Claude commands: %v\n", err)
+	}
+
+	return nil
+}
+
+// prepareJigContext writes the context every runner gets to the worktree's
+// .jig directory: the planning context for planning sessions, and the plan
+// and its issue metadata if there is a plan
+func prepareJigContext(opts *PrepareOpts) error {
 	if opts.WorktreeDir == "" {
 		return fmt.Errorf("worktree directory is required")
 	}
@@ -58,7 +76,7 @@ func (r *ClaudeRunner) Prepare(ctx context.Context, opts *PrepareOpts) error {
 
 	// Handle planning-specific context files
 	if opts.PromptType == PromptTypePlan {
-		if err := r.writePlanningContext(jigDir, opts); err != nil {
+		if err := WritePlanningContext(opts.WorktreeDir, opts); err != nil {
 			return err
 		}
 	}
@@ -93,19 +111,15 @@ func (r *ClaudeRunner) Prepare(ctx context.Context, opts *PrepareOpts) error {
 		}
 	}
 
-	// Copy .claude/commands/ from the main repo to the worktree
-	// so that Claude Code can find the /jig:implement skill
-	if err := r.copyClaudeCommands(opts.WorktreeDir); err != nil {
-		// Non-fatal - log but continue
-		fmt.Fprintf(os.Stderr, "Warning: could not copy Claude commands: %v\n", err)
-	}
-
 	return nil
 }
 
-// writePlanningContext writes the planning-specific context files to .jig/sessions/<session-id>/
-// The session ID is passed directly to the skill invocation to avoid race conditions
-func (r *ClaudeRunner) writePlanningContext(jigDir string, opts *PrepareOpts) error {
+// WritePlanningContext writes the planning-specific context files to
+// <worktreeDir>/.jig/sessions/<session-id>/. The session ID is passed directly
+// to the skill invocation to avoid race conditions.
+func WritePlanningContext(worktreeDir string, opts *PrepareOpts) error {
+	jigDir := filepath.Join(worktreeDir, ".jig")
+
 	// Use session ID if provided, otherwise fall back to "default"
 	sessionID := opts.SessionID
 	if sessionID == "" {
@@ -135,6 +149,14 @@ func (r *ClaudeRunner) writePlanningContext(jigDir string, opts *PrepareOpts) er
 		}
 	}
 
+	// Write the plan template if the plan was started from one
+	if opts.PlanTemplate != "" {
+		templatePath := filepath.Join(sessionDir, "plan-template.md")
+		if err := os.WriteFile(templatePath, []byte(opts.PlanTemplate), 0644); err != nil {
+			return fmt.Errorf("failed to write plan template: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -197,8 +219,6 @@ func copyDir(src, dst string) error {
 // Launch starts Claude Code as a subprocess with TTY passthrough.
 // It blocks until Claude Code exits and returns information about the session.
 func (r *ClaudeRunner) Launch(ctx context.Context, opts *LaunchOpts) (*LaunchResult, error) {
-	startTime := time.Now()
-
 	// Build arguments
 	var args []string
 
@@ -229,12 +249,21 @@ func (r *ClaudeRunner) Launch(ctx context.Context, opts *LaunchOpts) (*LaunchRes
 	// Add any extra arguments
 	args = append(args, opts.Args...)
 
+	return runCommand(ctx, r.command, args, opts.WorktreeDir)
+}
+
+// runCommand runs a runner's command in dir with TTY passthrough and waits for
+// it to exit. A non-zero exit is reported in the result rather than as an
+// error, since the user may simply have quit.
+func runCommand(ctx context.Context, command string, args []string, dir string) (*LaunchResult, error) {
+	startTime := time.Now()
+
 	// Create the command
-	cmd := exec.CommandContext(ctx, r.command, args...)
+	cmd := exec.CommandContext(ctx, command, args...)
 
 	// Set working directory if specified
-	if opts.WorktreeDir != "" {
-		cmd.Dir = opts.WorktreeDir
+	if dir != "" {
+		cmd.Dir = dir
 	}
 
 	// Pass through stdin for interactive TTY support
@@ -258,7 +287,7 @@ func (r *ClaudeRunner) Launch(ctx context.Context, opts *LaunchOpts) (*LaunchRes
 			// Non-zero exit is not necessarily an error (user may have quit)
 			return result, nil
 		}
-		return result, fmt.Errorf("failed to run claude: %w", err)
+		return result, fmt.Errorf("failed to run %s: %w", command, err)
 	}
 
 	return result, nil
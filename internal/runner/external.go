@@ -0,0 +1,106 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charleslr/jig/internal/config"
+)
+
+// ExternalRunner implements the Runner interface for a tool declared in the
+// config, launched as a plain command
+type ExternalRunner struct {
+	name string
+	spec config.RunnerSpec
+}
+
+// NewExternalRunner creates a runner for the tool described by spec
+func NewExternalRunner(name string, spec config.RunnerSpec) *ExternalRunner {
+	if spec.Command == "" {
+		spec.Command = name
+	}
+	return &ExternalRunner{name: name, spec: spec}
+}
+
+// Name returns the runner identifier
+func (r *ExternalRunner) Name() string {
+	return r.name
+}
+
+// Available checks if the runner's command is on the PATH
+func (r *ExternalRunner) Available() bool {
+	_, err := exec.LookPath(r.spec.Command)
+	return err == nil
+}
+
+// SupportsPlanMode reports whether the runner can be launched for planning
+func (r *ExternalRunner) SupportsPlanMode() bool {
+	return len(r.spec.PlanModeArgs) > 0
+}
+
+// Prepare writes the plan and planning context to the worktree's .jig directory
+func (r *ExternalRunner) Prepare(ctx context.Context, opts *PrepareOpts) error {
+	return prepareJigContext(opts)
+}
+
+// Launch starts the runner's command with TTY passthrough and blocks until it exits
+func (r *ExternalRunner) Launch(ctx context.Context, opts *LaunchOpts) (*LaunchResult, error) {
+	if opts.PlanMode && !r.SupportsPlanMode() {
+		return nil, fmt.Errorf("runner %s does not support plan mode (set runners.%s.plan_mode_args)", r.name, r.name)
+	}
+	return runCommand(ctx, r.spec.Command, r.args(opts), opts.WorktreeDir)
+}
+
+// args builds the command's arguments. The prompt is substituted for
+// {prompt} in the configured args; if none of them use it, it is added at
+// the end, after PromptArg if that is set. Arguments that are empty after
+// substitution are dropped.
+func (r *ExternalRunner) args(opts *LaunchOpts) []string {
+	prompt := opts.Prompt
+	if opts.Interactive {
+		prompt = opts.InitialPrompt
+	}
+	replacer := strings.NewReplacer(
+		"{prompt}", prompt,
+		"{system_prompt}", opts.SystemPrompt,
+		"{worktree}", opts.WorktreeDir,
+	)
+
+	var args []string
+	usesPrompt := false
+	for _, arg := range r.spec.Args {
+		if strings.Contains(arg, "{prompt}") {
+			usesPrompt = true
+		}
+		expanded := replacer.Replace(arg)
+		if expanded == "" && arg != "" {
+			continue
+		}
+		args = append(args, expanded)
+	}
+	if opts.PlanMode {
+		args = append(args, r.spec.PlanModeArgs...)
+	}
+	args = append(args, opts.Args...)
+
+	if prompt != "" && !usesPrompt {
+		if r.spec.PromptArg != "" {
+			args = append(args, r.spec.PromptArg)
+		}
+		args = append(args, prompt)
+	}
+	return args
+}
+
+// LoadConfig registers an ExternalRunner for each runner declared in the
+// config. Runners already registered, such as the built-in ones, are kept.
+func (r *Registry) LoadConfig(specs map[string]config.RunnerSpec) {
+	for name, spec := range specs {
+		if _, ok := r.runners[name]; ok {
+			continue
+		}
+		r.Register(NewExternalRunner(name, spec))
+	}
+}
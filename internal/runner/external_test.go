@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/config"
+)
+
+// writeFakeCommand puts an executable named name on a fresh PATH. It writes
+// each argument it is run with on its own line to the returned file.
+func writeFakeCommand(t *testing.T, name string) string {
+	t.Helper()
+	dir := t.TempDir()
+	out := filepath.Join(t.TempDir(), "args.txt")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > '" + out + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake command: %v", err)
+	}
+	t.Setenv("PATH", dir)
+	return out
+}
+
+func TestRegistryLoadConfig(t *testing.T) {
+	builtin := NewClaudeRunner("", "")
+	r := NewRegistry()
+	r.Register(builtin)
+
+	r.LoadConfig(map[string]config.RunnerSpec{
+		"claude": {Command: "not-claude"},
+		"aider":  {Command: "aider", Args: []string{"--message", "{prompt}"}},
+	})
+
+	if got, err := r.Get("claude"); err != nil || got != builtin {
+		t.Errorf("expected the built-in claude runner to be kept, got %v, %v", got, err)
+	}
+	got, err := r.Get("aider")
+	if err != nil {
+		t.Fatalf("Get(aider) error = %v", err)
+	}
+	if _, ok := got.(*ExternalRunner); !ok || got.Name() != "aider" {
+		t.Errorf("expected an external runner named aider, got %T %q", got, got.Name())
+	}
+}
+
+func TestExternalRunnerAvailable(t *testing.T) {
+	writeFakeCommand(t, "aider")
+
+	if !NewExternalRunner("aider", config.RunnerSpec{}).Available() {
+		t.Error("expected a runner whose command is on the PATH to be available")
+	}
+	if NewExternalRunner("other", config.RunnerSpec{Command: "missing-tool"}).Available() {
+		t.Error("expected a runner whose command isn't on the PATH to be unavailable")
+	}
+}
+
+func TestExternalRunnerLaunch(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		spec config.RunnerSpec
+		opts LaunchOpts
+		want []string
+	}{
+		{
+			name: "templated prompt",
+			spec: config.RunnerSpec{Args: []string{"--yes", "--message={prompt}"}},
+			opts: LaunchOpts{Prompt: "do the thing"},
+			want: []string{"--yes", "--message=do the thing"},
+		},
+		{
+			name: "prompt after prompt_arg",
+			spec: config.RunnerSpec{PromptArg: "-p", Args: []string{"--yes"}},
+			opts: LaunchOpts{Prompt: "do the thing", Args: []string{"--verbose"}},
+			want: []string{"--yes", "--verbose", "-p", "do the thing"},
+		},
+		{
+			name: "interactive with plan mode",
+			spec: config.RunnerSpec{Args: []string{"{prompt}", "--system={system_prompt}"}, PlanModeArgs: []string{"--read-only"}},
+			opts: LaunchOpts{InitialPrompt: "/jig:plan abc", Interactive: true, PlanMode: true},
+			want: []string{"/jig:plan abc", "--system=", "--read-only"},
+		},
+		{
+			name: "empty placeholders are dropped",
+			spec: config.RunnerSpec{Args: []string{"{system_prompt}", "--go"}},
+			opts: LaunchOpts{},
+			want: []string{"--go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := writeFakeCommand(t, "aider")
+			r := NewExternalRunner("aider", tt.spec)
+
+			result, err := r.Launch(ctx, &tt.opts)
+			if err != nil {
+				t.Fatalf("Launch() error = %v", err)
+			}
+			if result.ExitCode != 0 {
+				t.Errorf("ExitCode = %d, want 0", result.ExitCode)
+			}
+
+			data, err := os.ReadFile(out)
+			if err != nil {
+				t.Fatalf("command was not run: %v", err)
+			}
+			got := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("command args = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("plan mode requires plan_mode_args", func(t *testing.T) {
+		writeFakeCommand(t, "aider")
+		r := NewExternalRunner("aider", config.RunnerSpec{})
+
+		_, err := r.Launch(ctx, &LaunchOpts{PlanMode: true})
+		if err == nil || !strings.Contains(err.Error(), "does not support plan mode") {
+			t.Errorf("expected a plan mode error, got %v", err)
+		}
+	})
+}
+
+func TestExternalRunnerPrepare(t *testing.T) {
+	dir := t.TempDir()
+	r := NewExternalRunner("aider", config.RunnerSpec{})
+
+	err := r.Prepare(context.Background(), &PrepareOpts{
+		WorktreeDir: dir,
+		PromptType:  PromptTypePlan,
+		PlanGoal:    "Add retries",
+		SessionID:   "abc",
+	})
+	if err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".jig", "sessions", "abc", "planning-context.md")); err != nil {
+		t.Errorf("expected the planning context to be written: %v", err)
+	}
+}
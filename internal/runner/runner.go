@@ -28,6 +28,7 @@ type PrepareOpts struct {
 	PlanGoal     string            // User's description of what they want to plan
 	IssueContext string            // Context from linked issue (Linear, etc.)
 	SessionID    string            // Unique session ID for parallel planning sessions
+	PlanTemplate string            // Initial plan content from a plan template
 	ExtraVars    map[string]string
 }
 
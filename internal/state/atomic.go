@@ -0,0 +1,69 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+)
+
+var (
+	// writeTempFile writes the new contents of a file to its temporary file.
+	// Tests swap it to simulate partial writes.
+	writeTempFile = func(f *os.File, data []byte) error {
+		_, err := f.Write(data)
+		return err
+	}
+	// renameFile replaces a file with its newly written version. Tests swap it
+	// to simulate failed writes.
+	renameFile = os.Rename
+)
+
+// writeFileAtomic writes data to path via a temporary file in the same
+// directory, so readers never see a partially written file and a crash or full
+// disk mid-write leaves the previous version intact
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := writeTempFile(tmp, data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	// Flush to disk before the rename, so a crash can't leave an empty file
+	// in place of the old one
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := renameFile(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+// syncDir flushes a directory entry change (such as a rename) to disk. It's
+// best effort: some platforms, notably Windows, can't sync directories.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
@@ -0,0 +1,93 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+// failHalfway makes writes to temporary files stop halfway through with an
+// error, like a full disk, until the test ends
+func failHalfway(t *testing.T) {
+	t.Helper()
+	original := writeTempFile
+	writeTempFile = func(f *os.File, data []byte) error {
+		f.Write(data[:len(data)/2])
+		return errors.New("no space left on device")
+	}
+	t.Cleanup(func() { writeTempFile = original })
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+
+	if err := writeFileAtomic(path, []byte(`{"version": 1}`), 0644); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	t.Run("partial write keeps the previous file", func(t *testing.T) {
+		failHalfway(t)
+
+		if err := writeFileAtomic(path, []byte(`{"version": 2}`), 0644); err == nil {
+			t.Fatal("expected write error")
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+		if string(got) != `{"version": 1}` {
+			t.Errorf("expected previous contents, got %q", got)
+		}
+
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Errorf("expected the temporary file to be removed, found %d entries", len(entries))
+		}
+	})
+
+	t.Run("replaces the file", func(t *testing.T) {
+		if err := writeFileAtomic(path, []byte(`{"version": 3}`), 0600); err != nil {
+			t.Fatalf("writeFileAtomic() error = %v", err)
+		}
+		got, _ := os.ReadFile(path)
+		if string(got) != `{"version": 3}` {
+			t.Errorf("expected new contents, got %q", got)
+		}
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+	})
+}
+
+func TestSavePlan_PartialWriteKeepsPreviousPlan(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	failHalfway(t)
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusApproved)); err == nil {
+		t.Fatal("expected SavePlan to fail")
+	}
+
+	cached, err := cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.Plan.Status != plan.StatusDraft {
+		t.Errorf("expected previous plan to remain, got status %q", cached.Plan.Status)
+	}
+
+	md, err := cache.GetPlanMarkdown("PLAN-1")
+	if err != nil || md == "" {
+		t.Errorf("expected previous markdown to remain, got %q (err %v)", md, err)
+	}
+}
@@ -2,15 +2,22 @@ package state
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/charleslr/jig/internal/config"
+	"github.com/charleslr/jig/internal/logging"
 	"github.com/charleslr/jig/internal/plan"
 )
 
+// ErrPlanNotFound is returned when a plan that must exist is not in the cache
+var ErrPlanNotFound = errors.New("plan not found")
+
 // Cache provides local caching for plans and metadata
 type Cache struct {
 	dir string
@@ -24,6 +31,8 @@ type CachedPlan struct {
 	UpdatedAt        time.Time  `json:"updated_at"`
 	SyncedAt         *time.Time `json:"synced_at,omitempty"`
 	SyncedContentHash string    `json:"synced_content_hash,omitempty"`
+	SyncedCommentHash string    `json:"synced_comment_hash,omitempty"`
+	Archived          bool      `json:"archived,omitempty"`
 }
 
 // NeedsSync returns true if the plan should be synced to the tracker.
@@ -58,7 +67,20 @@ func NewCache() (*Cache, error) {
 	return &Cache{dir: cacheDir}, nil
 }
 
-// SavePlan caches a plan locally
+// PlanExists reports whether a plan with the given ID is cached, archived or
+// not. Unlike NewCache, it doesn't create the cache if there is none.
+func PlanExists(id string) bool {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(cacheDir, "plans", id+".json"))
+	return err == nil
+}
+
+// SavePlan caches a plan locally. Archive and sync state of an already
+// cached plan are kept, the latter only while it stays linked to the same
+// issue.
 func (c *Cache) SavePlan(p *plan.Plan) error {
 	if p.ID == "" {
 		return fmt.Errorf("plan ID is required for caching")
@@ -71,14 +93,28 @@ func (c *Cache) SavePlan(p *plan.Plan) error {
 		UpdatedAt: p.Updated,
 	}
 
-	data, err := json.MarshalIndent(cached, "", "  ")
+	path := filepath.Join(c.dir, "plans", p.ID+".json")
+	unlock, err := lockFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to serialize plan: %w", err)
+		return err
+	}
+	defer unlock()
+
+	// Saving a new version of an archived plan keeps it archived, and one of
+	// a synced plan keeps its sync state, so the next sync can tell whether
+	// the plan or its comment changed. A plan linked to another issue hasn't
+	// been synced to it.
+	if existing, err := c.GetCachedPlan(p.ID); err == nil && existing != nil {
+		cached.Archived = existing.Archived
+		if existing.IssueID == p.IssueID {
+			cached.SyncedAt = existing.SyncedAt
+			cached.SyncedContentHash = existing.SyncedContentHash
+			cached.SyncedCommentHash = existing.SyncedCommentHash
+		}
 	}
 
-	path := filepath.Join(c.dir, "plans", p.ID+".json")
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("failed to write plan cache: %w", err)
+	if err := c.writeCachedPlan(p.ID, cached); err != nil {
+		return err
 	}
 
 	// Serialize the plan to markdown, updating frontmatter with current field values
@@ -88,11 +124,75 @@ func (c *Cache) SavePlan(p *plan.Plan) error {
 		return fmt.Errorf("failed to serialize plan markdown: %w", err)
 	}
 
-	mdPath := filepath.Join(c.dir, "plans", p.ID+".md")
-	if err := os.WriteFile(mdPath, mdContent, 0644); err != nil {
-		return fmt.Errorf("failed to write plan markdown: %w", err)
+	return c.writePlanMarkdown(p.ID, mdContent)
+}
+
+// UpdatePlan loads a cached plan, applies fn to it and saves the result while
+// holding the plan's lock, so concurrent jig processes don't lose each other's
+// changes. Archive state is kept, and so is sync state unless fn links the
+// plan to another issue, which it hasn't been synced to. Nothing is written if
+// fn returns an error, and a failed write leaves the previous version in
+// place. Returns an error wrapping ErrPlanNotFound if the plan isn't cached.
+func (c *Cache) UpdatePlan(id string, fn func(*plan.Plan) error) error {
+	unlock, err := lockFile(filepath.Join(c.dir, "plans", id+".json"))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cached, err := c.GetCachedPlan(id)
+	if err != nil {
+		return fmt.Errorf("failed to get cached plan: %w", err)
+	}
+	if cached == nil || cached.Plan == nil {
+		return fmt.Errorf("%w: %s", ErrPlanNotFound, id)
 	}
 
+	previous, err := json.MarshalIndent(cached, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize plan: %w", err)
+	}
+
+	issueID := cached.Plan.IssueID
+	if err := fn(cached.Plan); err != nil {
+		return err
+	}
+	if cached.Plan.ID != id {
+		return fmt.Errorf("plan ID cannot be changed by an update (%s to %s)", id, cached.Plan.ID)
+	}
+
+	mdContent, err := plan.Serialize(cached.Plan)
+	if err != nil {
+		return fmt.Errorf("failed to serialize plan markdown: %w", err)
+	}
+
+	if cached.Plan.IssueID != issueID {
+		cached.SyncedAt = nil
+		cached.SyncedContentHash = ""
+		cached.SyncedCommentHash = ""
+	}
+	cached.IssueID = cached.Plan.IssueID
+	cached.CachedAt = time.Now()
+	cached.UpdatedAt = cached.Plan.Updated
+	if err := c.writeCachedPlan(id, cached); err != nil {
+		return err
+	}
+	if err := c.writePlanMarkdown(id, mdContent); err != nil {
+		// Put the previous metadata back so it matches the markdown on disk
+		if restoreErr := writeFileAtomic(filepath.Join(c.dir, "plans", id+".json"), previous, 0644); restoreErr != nil {
+			return fmt.Errorf("%w (restoring the previous version also failed: %v)", err, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// writePlanMarkdown writes a plan's markdown. Callers must hold the plan's lock.
+func (c *Cache) writePlanMarkdown(id string, content []byte) error {
+	mdPath := filepath.Join(c.dir, "plans", id+".md")
+	if err := writeFileAtomic(mdPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write plan markdown: %w", err)
+	}
 	return nil
 }
 
@@ -118,8 +218,9 @@ func (c *Cache) GetPlan(id string) (*plan.Plan, error) {
 // PlanLookupResult contains the results of looking up a plan by ID.
 // A lookup may find a plan by plan ID, issue ID, or both.
 type PlanLookupResult struct {
-	ByPlanID  *plan.Plan // Plan found by exact plan ID match
-	ByIssueID *plan.Plan // Plan found by issue ID match
+	ByPlanID     *plan.Plan   // Plan found by exact plan ID match
+	ByIssueID    *plan.Plan   // First plan found by issue ID match
+	IssueMatches []*plan.Plan // All plans linked to the issue ID
 }
 
 // HasConflict returns true if both lookups found different plans
@@ -130,9 +231,15 @@ func (r *PlanLookupResult) HasConflict() bool {
 	return r.ByPlanID.ID != r.ByIssueID.ID
 }
 
+// IsAmbiguous returns true if no plan matched by plan ID and several
+// plans are linked to the same issue ID
+func (r *PlanLookupResult) IsAmbiguous() bool {
+	return r.ByPlanID == nil && len(r.IssueMatches) > 1
+}
+
 // Plan returns the single plan if there's no conflict, or nil if there is
 func (r *PlanLookupResult) Plan() *plan.Plan {
-	if r.HasConflict() {
+	if r.HasConflict() || r.IsAmbiguous() {
 		return nil
 	}
 	if r.ByPlanID != nil {
@@ -153,33 +260,54 @@ func (c *Cache) LookupPlan(id string) (*PlanLookupResult, error) {
 	}
 	result.ByPlanID = byPlanID
 
-	// Scan for issue ID match
-	planDir := filepath.Join(c.dir, "plans")
-	entries, err := os.ReadDir(planDir)
+	// Scan for issue ID matches
+	byIssueID, err := c.GetPlansByIssueID(id)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return result, nil
-		}
-		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+		return nil, err
+	}
+	for _, cached := range byIssueID {
+		result.IssueMatches = append(result.IssueMatches, cached.Plan)
+	}
+	if len(result.IssueMatches) > 0 {
+		result.ByIssueID = result.IssueMatches[0]
 	}
 
-	for _, entry := range entries {
-		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
-			continue
-		}
+	return result, nil
+}
 
-		planID := entry.Name()[:len(entry.Name())-5] // Remove .json
-		p, err := c.GetPlan(planID)
-		if err != nil {
-			continue
-		}
-		if p != nil && p.IssueID == id {
-			result.ByIssueID = p
-			break
-		}
+// GetPlanByIssueID returns the cached plan linked to the given issue ID, or nil
+// if there is none. If several plans are linked to the issue, the first one in
+// cache order (sorted by plan ID) is returned; use GetPlansByIssueID to get all.
+func (c *Cache) GetPlanByIssueID(issueID string) (*CachedPlan, error) {
+	matches, err := c.GetPlansByIssueID(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return matches[0], nil
+}
+
+// GetPlansByIssueID returns all cached plans linked to the given issue ID,
+// sorted by plan ID
+func (c *Cache) GetPlansByIssueID(issueID string) ([]*CachedPlan, error) {
+	if issueID == "" {
+		return nil, nil
 	}
 
-	return result, nil
+	cachedPlans, err := c.ListAllCachedPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*CachedPlan
+	for _, cached := range cachedPlans {
+		if cached.Plan != nil && cached.Plan.IssueID == issueID {
+			matches = append(matches, cached)
+		}
+	}
+	return matches, nil
 }
 
 // GetPlanMarkdown retrieves the raw markdown for a cached plan
@@ -195,18 +323,32 @@ func (c *Cache) GetPlanMarkdown(id string) (string, error) {
 	return string(data), nil
 }
 
-// DeletePlan removes a cached plan
+// DeletePlan removes a cached plan's JSON and markdown entries.
+// Returns an error if neither entry exists.
 func (c *Cache) DeletePlan(id string) error {
 	jsonPath := filepath.Join(c.dir, "plans", id+".json")
 	mdPath := filepath.Join(c.dir, "plans", id+".md")
 
-	os.Remove(jsonPath)
-	os.Remove(mdPath)
+	found := false
+	for _, path := range []string{jsonPath, mdPath} {
+		err := os.Remove(path)
+		if err == nil {
+			found = true
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete plan cache: %w", err)
+		}
+	}
 
+	if !found {
+		return fmt.Errorf("plan not found: %s", id)
+	}
+	logging.Debug("cache delete", "plan", id)
 	return nil
 }
 
-// ListPlans returns all cached plans
+// ListPlans returns all cached plans that are not archived
 func (c *Cache) ListPlans() ([]*plan.Plan, error) {
 	planDir := filepath.Join(c.dir, "plans")
 	entries, err := os.ReadDir(planDir)
@@ -224,12 +366,12 @@ func (c *Cache) ListPlans() ([]*plan.Plan, error) {
 		}
 
 		id := entry.Name()[:len(entry.Name())-5] // Remove .json
-		p, err := c.GetPlan(id)
+		cached, err := c.GetCachedPlan(id)
 		if err != nil {
 			continue
 		}
-		if p != nil {
-			plans = append(plans, p)
+		if cached != nil && cached.Plan != nil && !cached.Archived {
+			plans = append(plans, cached.Plan)
 		}
 	}
 
@@ -242,10 +384,12 @@ func (c *Cache) GetCachedPlan(id string) (*CachedPlan, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
+			logging.Debug("cache miss", "plan", id)
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to read plan cache: %w", err)
 	}
+	logging.Debug("cache read", "plan", id, "bytes", len(data))
 
 	var cached CachedPlan
 	if err := json.Unmarshal(data, &cached); err != nil {
@@ -255,8 +399,24 @@ func (c *Cache) GetCachedPlan(id string) (*CachedPlan, error) {
 	return &cached, nil
 }
 
-// ListCachedPlans returns all cached plans with full metadata
+// ListCachedPlans returns all cached plans that are not archived, with full metadata
 func (c *Cache) ListCachedPlans() ([]*CachedPlan, error) {
+	cachedPlans, err := c.ListAllCachedPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []*CachedPlan
+	for _, cp := range cachedPlans {
+		if !cp.Archived {
+			plans = append(plans, cp)
+		}
+	}
+	return plans, nil
+}
+
+// ListAllCachedPlans returns all cached plans, including archived ones, with full metadata
+func (c *Cache) ListAllCachedPlans() ([]*CachedPlan, error) {
 	planDir := filepath.Join(c.dir, "plans")
 	entries, err := os.ReadDir(planDir)
 	if err != nil {
@@ -285,6 +445,205 @@ func (c *Cache) ListCachedPlans() ([]*CachedPlan, error) {
 	return plans, nil
 }
 
+// SearchPlans returns cached plans whose title, problem statement, proposed
+// solution, or raw content contains the query (case-insensitive)
+func (c *Cache) SearchPlans(query string) ([]*CachedPlan, error) {
+	cachedPlans, err := c.ListCachedPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(query)
+	var matches []*CachedPlan
+	for _, cp := range cachedPlans {
+		if cp.Plan == nil {
+			continue
+		}
+		for _, field := range []string{cp.Plan.Title, cp.Plan.ProblemStatement, cp.Plan.ProposedSolution, cp.Plan.RawContent} {
+			if strings.Contains(strings.ToLower(field), needle) {
+				matches = append(matches, cp)
+				break
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// CacheStats summarizes the contents of the cache
+type CacheStats struct {
+	TotalPlans     int        `json:"total_plans"`
+	SyncedPlans    int        `json:"synced_plans"`
+	UnsyncedPlans  int        `json:"unsynced_plans"`
+	LinkedPlans    int        `json:"linked_plans"`
+	UnlinkedPlans  int        `json:"unlinked_plans"`
+	TotalBytes     int64      `json:"total_bytes"`
+	OldestCachedAt *time.Time `json:"oldest_cached_at,omitempty"`
+	NewestCachedAt *time.Time `json:"newest_cached_at,omitempty"`
+}
+
+// Stats returns counts of the cached plans, including archived ones, and the
+// total size of the cache on disk. A plan counts as synced once it has been
+// synced at least once, and as linked if it has an issue ID.
+func (c *Cache) Stats() (*CacheStats, error) {
+	cachedPlans, err := c.ListAllCachedPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CacheStats{TotalPlans: len(cachedPlans)}
+	for _, cp := range cachedPlans {
+		if cp.SyncedAt != nil {
+			stats.SyncedPlans++
+		} else {
+			stats.UnsyncedPlans++
+		}
+
+		if cp.Plan != nil && cp.Plan.IssueID != "" {
+			stats.LinkedPlans++
+		} else {
+			stats.UnlinkedPlans++
+		}
+
+		cachedAt := cp.CachedAt
+		if stats.OldestCachedAt == nil || cachedAt.Before(*stats.OldestCachedAt) {
+			stats.OldestCachedAt = &cachedAt
+		}
+		if stats.NewestCachedAt == nil || cachedAt.After(*stats.NewestCachedAt) {
+			stats.NewestCachedAt = &cachedAt
+		}
+	}
+
+	err = filepath.WalkDir(c.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		stats.TotalBytes += info.Size()
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to measure cache size: %w", err)
+	}
+
+	return stats, nil
+}
+
+// CleanOptions controls which entries Clean removes
+type CleanOptions struct {
+	// OlderThan removes plans last cached longer ago than this. Zero keeps
+	// plans regardless of age.
+	OlderThan time.Duration
+	// DryRun reports what would be removed without removing anything
+	DryRun bool
+}
+
+// CleanedEntry is a file removed (or, in a dry run, to be removed) by Clean
+type CleanedEntry struct {
+	File   string // File name within the plans directory
+	Reason string
+}
+
+// Clean prunes the plans directory. It removes .json entries that fail to
+// parse, .md files with no matching .json, and, if opts.OlderThan is set,
+// plans last cached longer ago than that. The lock and temporary files of a
+// removed plan go with it. Entries are returned sorted by file name.
+func (c *Cache) Clean(opts CleanOptions) ([]CleanedEntry, error) {
+	planDir := filepath.Join(c.dir, "plans")
+	entries, err := os.ReadDir(planDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+	}
+
+	var removed []CleanedEntry
+	// reasons maps the ID of each plan whose .json is removed to why, so its
+	// .md is removed for the same reason
+	reasons := make(map[string]string)
+	kept := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		id := strings.TrimSuffix(entry.Name(), ".json")
+		cached, err := c.GetCachedPlan(id)
+		switch {
+		case err != nil:
+			reasons[id] = "invalid JSON"
+		case opts.OlderThan > 0 && time.Since(cached.CachedAt) > opts.OlderThan:
+			reasons[id] = fmt.Sprintf("older than %s", opts.OlderThan)
+		default:
+			kept[id] = true
+			continue
+		}
+		removed = append(removed, CleanedEntry{File: entry.Name(), Reason: reasons[id]})
+	}
+
+	// Markdown, and the lock and temporary files of writes, are only
+	// meaningful alongside their .json entry
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		id, ok := companionPlanID(entry.Name())
+		if !ok || kept[id] {
+			continue
+		}
+		reason, ok := reasons[id]
+		if !ok {
+			reason = "no matching .json"
+		}
+		removed = append(removed, CleanedEntry{File: entry.Name(), Reason: reason})
+	}
+
+	sort.Slice(removed, func(i, j int) bool { return removed[i].File < removed[j].File })
+
+	if opts.DryRun {
+		return removed, nil
+	}
+	for _, entry := range removed {
+		if err := os.Remove(filepath.Join(planDir, entry.File)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove %s: %w", entry.File, err)
+		}
+	}
+	return removed, nil
+}
+
+// companionPlanID returns the ID of the plan a file in the plans directory
+// accompanies: its markdown ("ID.md"), its lock ("ID.json.lock") or a
+// temporary file left by writing either ("ID.json.123.tmp")
+func companionPlanID(name string) (string, bool) {
+	switch {
+	case strings.HasSuffix(name, ".json.lock"):
+		return strings.TrimSuffix(name, ".json.lock"), true
+	case strings.HasSuffix(name, ".tmp"):
+		// writeFileAtomic names temporary files after their target
+		target := strings.TrimSuffix(name, ".tmp")
+		if i := strings.LastIndex(target, "."); i >= 0 {
+			target = target[:i]
+		}
+		for _, ext := range []string{".json", ".md"} {
+			if strings.HasSuffix(target, ext) {
+				return strings.TrimSuffix(target, ext), true
+			}
+		}
+		return "", false
+	case strings.HasSuffix(name, ".md"):
+		return strings.TrimSuffix(name, ".md"), true
+	}
+	return "", false
+}
+
 // MarkPlanSynced updates the SyncedAt timestamp for a cached plan
 func (c *Cache) MarkPlanSynced(id string) error {
 	return c.MarkPlanSyncedWithHash(id, "")
@@ -292,29 +651,74 @@ func (c *Cache) MarkPlanSynced(id string) error {
 
 // MarkPlanSyncedWithHash updates the SyncedAt timestamp and content hash for a cached plan
 func (c *Cache) MarkPlanSyncedWithHash(id, contentHash string) error {
+	return c.updateCachedPlan(id, func(cached *CachedPlan) {
+		now := time.Now()
+		cached.SyncedAt = &now
+		if contentHash != "" {
+			cached.SyncedContentHash = contentHash
+		}
+	})
+}
+
+// MarkPlanUnsynced clears a cached plan's sync state, so it is treated as
+// never having been synced
+func (c *Cache) MarkPlanUnsynced(id string) error {
+	return c.updateCachedPlan(id, func(cached *CachedPlan) {
+		cached.SyncedAt = nil
+		cached.SyncedContentHash = ""
+		cached.SyncedCommentHash = ""
+	})
+}
+
+// SetSyncedCommentHash records the hash of the plan comment body as it was last
+// written to the tracker, so later syncs can detect edits made outside jig
+func (c *Cache) SetSyncedCommentHash(id, commentHash string) error {
+	return c.updateCachedPlan(id, func(cached *CachedPlan) {
+		cached.SyncedCommentHash = commentHash
+	})
+}
+
+// SetArchived archives or unarchives a cached plan. Archived plans are hidden
+// from ListPlans and ListCachedPlans but can still be looked up by ID.
+func (c *Cache) SetArchived(id string, archived bool) error {
+	return c.updateCachedPlan(id, func(cached *CachedPlan) {
+		cached.Archived = archived
+	})
+}
+
+// updateCachedPlan applies update to a cached plan's metadata while holding
+// the plan's lock, so concurrent jig processes don't lose each other's updates
+func (c *Cache) updateCachedPlan(id string, update func(*CachedPlan)) error {
+	unlock, err := lockFile(filepath.Join(c.dir, "plans", id+".json"))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	cached, err := c.GetCachedPlan(id)
 	if err != nil {
 		return fmt.Errorf("failed to get cached plan: %w", err)
 	}
 	if cached == nil {
-		return fmt.Errorf("plan not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrPlanNotFound, id)
 	}
 
-	now := time.Now()
-	cached.SyncedAt = &now
-	if contentHash != "" {
-		cached.SyncedContentHash = contentHash
-	}
+	update(cached)
+	return c.writeCachedPlan(id, cached)
+}
 
+// writeCachedPlan writes a cached plan's metadata. Callers must hold the plan's lock.
+func (c *Cache) writeCachedPlan(id string, cached *CachedPlan) error {
 	data, err := json.MarshalIndent(cached, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to serialize plan: %w", err)
 	}
 
 	path := filepath.Join(c.dir, "plans", id+".json")
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write plan cache: %w", err)
 	}
+	logging.Debug("cache write", "plan", id, "bytes", len(data))
 
 	return nil
 }
@@ -344,7 +748,7 @@ func (c *Cache) SaveIssueMetadata(meta *IssueMetadata) error {
 	}
 
 	path := filepath.Join(c.dir, "issues", meta.IssueID+".json")
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write metadata: %w", err)
 	}
 
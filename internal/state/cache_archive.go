@@ -0,0 +1,248 @@
+package state
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+// maxArchiveEntrySize bounds the size of a single file read from a cache
+// archive, so a corrupt or hostile archive can't exhaust memory
+const maxArchiveEntrySize = 10 << 20
+
+// ImportResult reports what ImportAll did with each plan in an archive
+type ImportResult struct {
+	Imported []string          `json:"imported"`
+	Skipped  []string          `json:"skipped"` // Already cached and not overwritten
+	Invalid  map[string]string `json:"invalid"` // Plan ID to why it was rejected
+}
+
+// ExportAll writes every cached plan, including archived ones, to w as a
+// gzipped tar archive of the plans' JSON and markdown files. Sync and archive
+// state are kept, so an import restores the cache as it was. Returns the
+// number of plans exported.
+func (c *Cache) ExportAll(w io.Writer) (int, error) {
+	cachedPlans, err := c.ListAllCachedPlans()
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for _, cp := range cachedPlans {
+		if cp.Plan != nil && cp.Plan.ID != "" {
+			ids = append(ids, cp.Plan.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	planDir := filepath.Join(c.dir, "plans")
+	count := 0
+	for _, id := range ids {
+		for _, ext := range []string{".json", ".md"} {
+			name := id + ext
+			data, err := os.ReadFile(filepath.Join(planDir, name))
+			if err != nil {
+				if os.IsNotExist(err) && ext == ".md" {
+					continue
+				}
+				return count, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			if err := writeArchiveFile(tw, "plans/"+name, data); err != nil {
+				return count, err
+			}
+		}
+		count++
+	}
+
+	if err := tw.Close(); err != nil {
+		return count, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return count, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return count, nil
+}
+
+// writeArchiveFile adds a file to a tar archive
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// ImportAll restores plans from an archive written by ExportAll. Each plan is
+// validated before it is written: its JSON must parse and match its file
+// name, and its markdown, if any, must parse. Plans already in the cache are
+// skipped unless overwrite is set. Returns an error only if the archive
+// itself can't be read; a rejected plan is reported in the result.
+func (c *Cache) ImportAll(r io.Reader, overwrite bool) (*ImportResult, error) {
+	files, err := readArchivePlans(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Invalid: make(map[string]string)}
+	ids := make([]string, 0, len(files))
+	for id := range files {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		entry := files[id]
+		cached, markdown, err := validateArchivePlan(id, entry.json, entry.markdown)
+		if err != nil {
+			result.Invalid[id] = err.Error()
+			continue
+		}
+
+		imported, err := c.importPlan(cached, markdown, overwrite)
+		if err != nil {
+			result.Invalid[id] = err.Error()
+			continue
+		}
+		if imported {
+			result.Imported = append(result.Imported, id)
+		} else {
+			result.Skipped = append(result.Skipped, id)
+		}
+	}
+	return result, nil
+}
+
+// archivePlan holds the files of one plan read from an archive
+type archivePlan struct {
+	json     []byte
+	markdown []byte
+}
+
+// readArchivePlans reads the plan files from a gzipped tar archive, keyed by
+// plan ID. Entries outside plans/ or with unexpected names are ignored.
+func readArchivePlans(r io.Reader) (map[string]*archivePlan, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string]*archivePlan)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dir, name := path.Split(path.Clean(header.Name))
+		ext := path.Ext(name)
+		id := strings.TrimSuffix(name, ext)
+		if dir != "plans/" || id == "" || strings.HasPrefix(id, ".") || (ext != ".json" && ext != ".md") {
+			continue
+		}
+		if header.Size > maxArchiveEntrySize {
+			return nil, fmt.Errorf("archive entry %s is too large (%d bytes)", header.Name, header.Size)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxArchiveEntrySize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+
+		entry := files[id]
+		if entry == nil {
+			entry = &archivePlan{}
+			files[id] = entry
+		}
+		if ext == ".json" {
+			entry.json = data
+		} else {
+			entry.markdown = data
+		}
+	}
+	return files, nil
+}
+
+// validateArchivePlan checks a plan read from an archive and returns its
+// metadata and markdown. Plans exported without markdown have it generated.
+func validateArchivePlan(id string, jsonData, markdown []byte) (*CachedPlan, []byte, error) {
+	if jsonData == nil {
+		return nil, nil, fmt.Errorf("no %s.json in archive", id)
+	}
+
+	var cached CachedPlan
+	if err := json.Unmarshal(jsonData, &cached); err != nil {
+		return nil, nil, fmt.Errorf("invalid plan JSON: %w", err)
+	}
+	if cached.Plan == nil {
+		return nil, nil, fmt.Errorf("plan JSON has no plan")
+	}
+	if cached.Plan.ID != id {
+		return nil, nil, fmt.Errorf("plan ID %q does not match file name %s.json", cached.Plan.ID, id)
+	}
+
+	if markdown == nil {
+		generated, err := plan.Serialize(cached.Plan)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to serialize plan markdown: %w", err)
+		}
+		return &cached, generated, nil
+	}
+	if _, err := plan.Parse(markdown); err != nil {
+		return nil, nil, fmt.Errorf("invalid plan markdown: %w", err)
+	}
+	return &cached, markdown, nil
+}
+
+// importPlan writes an imported plan to the cache while holding its lock.
+// Returns false without writing if the plan is already cached and overwrite
+// isn't set.
+func (c *Cache) importPlan(cached *CachedPlan, markdown []byte, overwrite bool) (bool, error) {
+	id := cached.Plan.ID
+	unlock, err := lockFile(filepath.Join(c.dir, "plans", id+".json"))
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	if !overwrite {
+		if _, err := os.Stat(filepath.Join(c.dir, "plans", id+".json")); err == nil {
+			return false, nil
+		}
+	}
+
+	if err := c.writeCachedPlan(id, cached); err != nil {
+		return false, err
+	}
+	if err := c.writePlanMarkdown(id, markdown); err != nil {
+		return false, err
+	}
+	return true, nil
+}
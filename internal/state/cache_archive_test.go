@@ -0,0 +1,167 @@
+package state
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+func TestExportImportAll_RoundTrip(t *testing.T) {
+	src, cleanupSrc := createTestCache(t)
+	defer cleanupSrc()
+
+	plans := []*plan.Plan{
+		{ID: "PLAN-1", Title: "First", Status: plan.StatusDraft, IssueID: "NUM-1", RawContent: "---\ntitle: First\n---\n\n# First\n\n## Problem Statement\n\nSomething.\n"},
+		{ID: "PLAN-2", Title: "Second", Status: plan.StatusApproved},
+		{ID: "PLAN-3", Title: "Archived", Status: plan.StatusComplete},
+	}
+	for _, p := range plans {
+		if err := src.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", p.ID, err)
+		}
+	}
+	if err := src.MarkPlanSyncedWithHash("PLAN-1", "hash-1"); err != nil {
+		t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+	}
+	if err := src.SetArchived("PLAN-3", true); err != nil {
+		t.Fatalf("SetArchived() error = %v", err)
+	}
+
+	var archive bytes.Buffer
+	count, err := src.ExportAll(&archive)
+	if err != nil {
+		t.Fatalf("ExportAll() error = %v", err)
+	}
+	if count != len(plans) {
+		t.Errorf("ExportAll() exported %d plans, want %d", count, len(plans))
+	}
+
+	dst, cleanupDst := createTestCache(t)
+	defer cleanupDst()
+
+	result, err := dst.ImportAll(&archive, false)
+	if err != nil {
+		t.Fatalf("ImportAll() error = %v", err)
+	}
+	if len(result.Imported) != len(plans) || len(result.Skipped) != 0 || len(result.Invalid) != 0 {
+		t.Fatalf("expected every plan imported, got %+v", result)
+	}
+
+	all, err := dst.ListAllCachedPlans()
+	if err != nil {
+		t.Fatalf("ListAllCachedPlans() error = %v", err)
+	}
+	if len(all) != len(plans) {
+		t.Errorf("expected %d plans after import, got %d", len(plans), len(all))
+	}
+	for _, p := range plans {
+		wantMD, _ := src.GetPlanMarkdown(p.ID)
+		gotMD, err := dst.GetPlanMarkdown(p.ID)
+		if err != nil || gotMD != wantMD {
+			t.Errorf("markdown for %s = %q, %v; want %q", p.ID, gotMD, err, wantMD)
+		}
+	}
+
+	synced, _ := dst.GetCachedPlan("PLAN-1")
+	if synced.SyncedAt == nil || synced.SyncedContentHash != "hash-1" || synced.Plan.IssueID != "NUM-1" {
+		t.Errorf("expected sync state to be kept, got %+v", synced)
+	}
+	archived, _ := dst.GetCachedPlan("PLAN-3")
+	if !archived.Archived {
+		t.Error("expected PLAN-3 to stay archived")
+	}
+}
+
+func TestImportAll_OverwriteAndSkip(t *testing.T) {
+	src, cleanupSrc := createTestCache(t)
+	defer cleanupSrc()
+	if err := src.SavePlan(&plan.Plan{ID: "PLAN-1", Title: "From Archive", Status: plan.StatusDraft}); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	var archive bytes.Buffer
+	if _, err := src.ExportAll(&archive); err != nil {
+		t.Fatalf("ExportAll() error = %v", err)
+	}
+
+	for _, overwrite := range []bool{false, true} {
+		dst, cleanup := createTestCache(t)
+		defer cleanup()
+		if err := dst.SavePlan(&plan.Plan{ID: "PLAN-1", Title: "Local", Status: plan.StatusDraft}); err != nil {
+			t.Fatalf("SavePlan() error = %v", err)
+		}
+
+		result, err := dst.ImportAll(bytes.NewReader(archive.Bytes()), overwrite)
+		if err != nil {
+			t.Fatalf("ImportAll(overwrite=%v) error = %v", overwrite, err)
+		}
+
+		got, _ := dst.GetPlan("PLAN-1")
+		if overwrite {
+			if len(result.Imported) != 1 || got.Title != "From Archive" {
+				t.Errorf("expected the plan to be overwritten, got %+v and title %q", result, got.Title)
+			}
+		} else {
+			if len(result.Skipped) != 1 || result.Skipped[0] != "PLAN-1" || got.Title != "Local" {
+				t.Errorf("expected the plan to be skipped, got %+v and title %q", result, got.Title)
+			}
+		}
+	}
+}
+
+func TestImportAll_RejectsInvalidPlans(t *testing.T) {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	files := map[string]string{
+		"plans/PLAN-OK.json":       `{"plan":{"id":"PLAN-OK","title":"OK","status":"draft"}}`,
+		"plans/PLAN-BAD.json":      `{not json`,
+		"plans/PLAN-MISMATCH.json": `{"plan":{"id":"PLAN-OTHER","title":"Mismatch"}}`,
+		"plans/PLAN-NOJSON.md":     "---\ntitle: No JSON\n---\n",
+		"../escape/PLAN-EVIL.json": `{"plan":{"id":"PLAN-EVIL"}}`,
+		"plans/nested/PLAN-X.json": `{"plan":{"id":"PLAN-X"}}`,
+		"plans/PLAN-OK.unexpected": "ignored",
+	}
+	for name, content := range files {
+		if err := writeArchiveFile(tw, name, []byte(content)); err != nil {
+			t.Fatalf("writeArchiveFile() error = %v", err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	result, err := cache.ImportAll(&archive, false)
+	if err != nil {
+		t.Fatalf("ImportAll() error = %v", err)
+	}
+	if len(result.Imported) != 1 || result.Imported[0] != "PLAN-OK" {
+		t.Errorf("expected only PLAN-OK imported, got %v", result.Imported)
+	}
+	for id, reason := range map[string]string{
+		"PLAN-BAD":      "invalid plan JSON",
+		"PLAN-MISMATCH": "does not match file name",
+		"PLAN-NOJSON":   "no PLAN-NOJSON.json in archive",
+	} {
+		if !strings.Contains(result.Invalid[id], reason) {
+			t.Errorf("expected %s rejected with %q, got %q", id, reason, result.Invalid[id])
+		}
+	}
+	if len(result.Invalid) != 3 {
+		t.Errorf("expected entries outside plans/ to be ignored, got %v", result.Invalid)
+	}
+
+	// The imported plan gets markdown generated from its JSON
+	if md, err := cache.GetPlanMarkdown("PLAN-OK"); err != nil || !strings.Contains(md, "title: OK") {
+		t.Errorf("expected generated markdown for PLAN-OK, got %q, %v", md, err)
+	}
+
+	if _, err := cache.ImportAll(strings.NewReader("not an archive"), false); err == nil {
+		t.Error("expected an error for a file that isn't an archive")
+	}
+}
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -1255,3 +1257,821 @@ func TestLookupPlan_SamePlanByBothMethods(t *testing.T) {
 		t.Error("Plan() should return the found plan")
 	}
 }
+
+func TestDeletePlan(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	if err := cache.DeletePlan("PLAN-1"); err != nil {
+		t.Fatalf("DeletePlan() error = %v", err)
+	}
+
+	for _, ext := range []string{".json", ".md"} {
+		path := filepath.Join(cache.dir, "plans", "PLAN-1"+ext)
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", path)
+		}
+	}
+
+	p, err := cache.GetPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetPlan() error = %v", err)
+	}
+	if p != nil {
+		t.Error("expected plan to be gone after delete")
+	}
+}
+
+func TestDeletePlan_NotFound(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	err := cache.DeletePlan("PLAN-missing")
+	if err == nil {
+		t.Fatal("expected error when deleting a missing plan")
+	}
+	if !strings.Contains(err.Error(), "plan not found") {
+		t.Errorf("expected 'plan not found' error, got: %v", err)
+	}
+}
+
+func TestDeletePlan_LeavesOtherPlans(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	for _, id := range []string{"PLAN-1", "PLAN-2"} {
+		if err := cache.SavePlan(createTestPlan(id, plan.StatusDraft)); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", id, err)
+		}
+	}
+
+	if err := cache.DeletePlan("PLAN-1"); err != nil {
+		t.Fatalf("DeletePlan() error = %v", err)
+	}
+
+	plans, err := cache.ListPlans()
+	if err != nil {
+		t.Fatalf("ListPlans() error = %v", err)
+	}
+	if len(plans) != 1 || plans[0].ID != "PLAN-2" {
+		t.Errorf("expected only PLAN-2 to remain, got %v", plans)
+	}
+
+	md, err := cache.GetPlanMarkdown("PLAN-2")
+	if err != nil {
+		t.Fatalf("GetPlanMarkdown() error = %v", err)
+	}
+	if md == "" {
+		t.Error("expected PLAN-2 markdown to remain")
+	}
+}
+
+func TestSearchPlans(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	plans := []*plan.Plan{
+		{ID: "PLAN-title", Title: "Add Rate Limiting", Status: plan.StatusDraft},
+		{ID: "PLAN-problem", Title: "Other", Status: plan.StatusDraft, ProblemStatement: "Requests are RATE limited by Linear"},
+		{ID: "PLAN-solution", Title: "Other", Status: plan.StatusDraft, ProposedSolution: "Back off when rate limited"},
+		{ID: "PLAN-raw", Title: "Other", Status: plan.StatusDraft, RawContent: "---\nid: PLAN-raw\n---\n## Notes\nmentions rate limits\n"},
+		{ID: "PLAN-unrelated", Title: "Dark mode", Status: plan.StatusDraft, ProblemStatement: "Too bright"},
+	}
+	for _, p := range plans {
+		if err := cache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", p.ID, err)
+		}
+	}
+
+	matches, err := cache.SearchPlans("rate limit")
+	if err != nil {
+		t.Fatalf("SearchPlans() error = %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, cp := range matches {
+		got[cp.Plan.ID] = true
+	}
+
+	for _, id := range []string{"PLAN-title", "PLAN-problem", "PLAN-solution", "PLAN-raw"} {
+		if !got[id] {
+			t.Errorf("expected %s to match", id)
+		}
+	}
+	if got["PLAN-unrelated"] {
+		t.Error("expected PLAN-unrelated not to match")
+	}
+	if len(matches) != 4 {
+		t.Errorf("expected 4 matches, got %d", len(matches))
+	}
+}
+
+func TestSearchPlans_NoMatches(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	matches, err := cache.SearchPlans("nonexistent-term")
+	if err != nil {
+		t.Fatalf("SearchPlans() error = %v", err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("expected no matches, got %d", len(matches))
+	}
+}
+
+func TestLookupPlan_MultipleIssueMatches(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	for _, id := range []string{"PLAN-A", "PLAN-B"} {
+		p := createTestPlan(id, plan.StatusDraft)
+		p.IssueID = "NUM-41"
+		if err := cache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", id, err)
+		}
+	}
+
+	result, err := cache.LookupPlan("NUM-41")
+	if err != nil {
+		t.Fatalf("LookupPlan() error = %v", err)
+	}
+
+	if len(result.IssueMatches) != 2 {
+		t.Fatalf("expected 2 issue matches, got %d", len(result.IssueMatches))
+	}
+	if !result.IsAmbiguous() {
+		t.Error("expected lookup to be ambiguous")
+	}
+	if result.Plan() != nil {
+		t.Error("Plan() should return nil when ambiguous")
+	}
+}
+
+func TestLookupPlan_SingleIssueMatchNotAmbiguous(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	p := createTestPlan("PLAN-A", plan.StatusDraft)
+	p.IssueID = "NUM-41"
+	if err := cache.SavePlan(p); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	result, err := cache.LookupPlan("NUM-41")
+	if err != nil {
+		t.Fatalf("LookupPlan() error = %v", err)
+	}
+	if result.IsAmbiguous() {
+		t.Error("single issue match should not be ambiguous")
+	}
+	if result.Plan() == nil || result.Plan().ID != "PLAN-A" {
+		t.Error("Plan() should return the single issue match")
+	}
+}
+
+func TestSetSyncedCommentHash(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	if err := cache.MarkPlanSyncedWithHash("PLAN-1", "content-hash"); err != nil {
+		t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+	}
+
+	if err := cache.SetSyncedCommentHash("PLAN-1", "comment-hash"); err != nil {
+		t.Fatalf("SetSyncedCommentHash() error = %v", err)
+	}
+
+	cached, err := cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.SyncedCommentHash != "comment-hash" {
+		t.Errorf("expected comment hash 'comment-hash', got %q", cached.SyncedCommentHash)
+	}
+	if cached.SyncedContentHash != "content-hash" {
+		t.Errorf("content hash should be preserved, got %q", cached.SyncedContentHash)
+	}
+	if cached.SyncedAt == nil {
+		t.Error("SyncedAt should be preserved")
+	}
+}
+
+func TestMarkPlanUnsynced(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	p := createTestPlan("PLAN-1", plan.StatusDraft)
+	p.IssueID = "NUM-1"
+	if err := cache.SavePlan(p); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	if err := cache.MarkPlanSyncedWithHash("PLAN-1", "content-hash"); err != nil {
+		t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+	}
+	if err := cache.SetSyncedCommentHash("PLAN-1", "comment-hash"); err != nil {
+		t.Fatalf("SetSyncedCommentHash() error = %v", err)
+	}
+
+	if err := cache.MarkPlanUnsynced("PLAN-1"); err != nil {
+		t.Fatalf("MarkPlanUnsynced() error = %v", err)
+	}
+
+	cached, err := cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.SyncedAt != nil || cached.SyncedContentHash != "" || cached.SyncedCommentHash != "" {
+		t.Errorf("expected sync state to be cleared, got %+v", cached)
+	}
+	if cached.Plan.IssueID != "NUM-1" {
+		t.Errorf("expected plan to stay linked, got issue %q", cached.Plan.IssueID)
+	}
+	if !cached.NeedsSync() {
+		t.Error("expected unsynced plan to need sync")
+	}
+
+	if err := cache.MarkPlanUnsynced("PLAN-404"); err == nil {
+		t.Error("expected error for missing plan")
+	}
+}
+
+func TestSetSyncedCommentHash_NotFound(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	err := cache.SetSyncedCommentHash("nonexistent", "comment-hash")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected 'not found' error, got: %v", err)
+	}
+}
+
+func TestSavePlan_KeepsSyncState(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	p := createTestPlan("PLAN-1", plan.StatusDraft)
+	p.IssueID = "NUM-1"
+	if err := cache.SavePlan(p); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	if err := cache.MarkPlanSyncedWithHash("PLAN-1", "content-hash"); err != nil {
+		t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+	}
+	if err := cache.SetSyncedCommentHash("PLAN-1", "comment-hash"); err != nil {
+		t.Fatalf("SetSyncedCommentHash() error = %v", err)
+	}
+
+	// Saving the plan again, as jig plan save does before syncing, keeps
+	// what the next sync compares against
+	p.ProblemStatement = "Edited problem"
+	if err := cache.SavePlan(p); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	cached, err := cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.SyncedAt == nil || cached.SyncedContentHash != "content-hash" || cached.SyncedCommentHash != "comment-hash" {
+		t.Errorf("expected the sync state kept, got %+v", cached)
+	}
+
+	// A plan linked to another issue hasn't been synced to it
+	p.IssueID = "NUM-2"
+	if err := cache.SavePlan(p); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	cached, err = cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.SyncedAt != nil || cached.SyncedContentHash != "" || cached.SyncedCommentHash != "" {
+		t.Errorf("expected the sync state cleared for a new issue, got %+v", cached)
+	}
+}
+
+func TestGetPlanByIssueID(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	linked := createTestPlan("PLAN-LINKED", plan.StatusDraft)
+	linked.IssueID = "NUM-41"
+	for _, p := range []*plan.Plan{linked, createTestPlan("PLAN-OTHER", plan.StatusDraft)} {
+		if err := cache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", p.ID, err)
+		}
+	}
+
+	t.Run("single match", func(t *testing.T) {
+		cached, err := cache.GetPlanByIssueID("NUM-41")
+		if err != nil {
+			t.Fatalf("GetPlanByIssueID() error = %v", err)
+		}
+		if cached == nil || cached.Plan.ID != "PLAN-LINKED" {
+			t.Errorf("expected PLAN-LINKED, got %+v", cached)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		cached, err := cache.GetPlanByIssueID("NUM-999")
+		if err != nil {
+			t.Fatalf("GetPlanByIssueID() error = %v", err)
+		}
+		if cached != nil {
+			t.Errorf("expected nil, got %s", cached.Plan.ID)
+		}
+	})
+
+	t.Run("empty issue ID matches nothing", func(t *testing.T) {
+		cached, err := cache.GetPlanByIssueID("")
+		if err != nil {
+			t.Fatalf("GetPlanByIssueID() error = %v", err)
+		}
+		if cached != nil {
+			t.Errorf("expected unlinked plans not to match, got %s", cached.Plan.ID)
+		}
+	})
+}
+
+func TestGetPlansByIssueID_MultipleMatches(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	for _, id := range []string{"PLAN-B", "PLAN-A"} {
+		p := createTestPlan(id, plan.StatusDraft)
+		p.IssueID = "NUM-41"
+		if err := cache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", id, err)
+		}
+	}
+
+	matches, err := cache.GetPlansByIssueID("NUM-41")
+	if err != nil {
+		t.Fatalf("GetPlansByIssueID() error = %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Plan.ID != "PLAN-A" || matches[1].Plan.ID != "PLAN-B" {
+		t.Errorf("expected matches sorted by plan ID, got %s, %s", matches[0].Plan.ID, matches[1].Plan.ID)
+	}
+
+	first, err := cache.GetPlanByIssueID("NUM-41")
+	if err != nil {
+		t.Fatalf("GetPlanByIssueID() error = %v", err)
+	}
+	if first.Plan.ID != "PLAN-A" {
+		t.Errorf("expected GetPlanByIssueID to return the first match, got %s", first.Plan.ID)
+	}
+}
+
+func TestSetArchived(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	archived := createTestPlan("PLAN-ARCHIVED", plan.StatusComplete)
+	archived.IssueID = "NUM-41"
+	for _, p := range []*plan.Plan{archived, createTestPlan("PLAN-ACTIVE", plan.StatusDraft)} {
+		if err := cache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan() error = %v", err)
+		}
+	}
+
+	if err := cache.SetArchived("PLAN-ARCHIVED", true); err != nil {
+		t.Fatalf("SetArchived() error = %v", err)
+	}
+
+	cachedPlans, err := cache.ListCachedPlans()
+	if err != nil {
+		t.Fatalf("ListCachedPlans() error = %v", err)
+	}
+	if len(cachedPlans) != 1 || cachedPlans[0].Plan.ID != "PLAN-ACTIVE" {
+		t.Errorf("expected only PLAN-ACTIVE from ListCachedPlans, got %d plans", len(cachedPlans))
+	}
+
+	plans, err := cache.ListPlans()
+	if err != nil {
+		t.Fatalf("ListPlans() error = %v", err)
+	}
+	if len(plans) != 1 || plans[0].ID != "PLAN-ACTIVE" {
+		t.Errorf("expected only PLAN-ACTIVE from ListPlans, got %d plans", len(plans))
+	}
+
+	allPlans, err := cache.ListAllCachedPlans()
+	if err != nil {
+		t.Fatalf("ListAllCachedPlans() error = %v", err)
+	}
+	if len(allPlans) != 2 {
+		t.Errorf("expected 2 plans from ListAllCachedPlans, got %d", len(allPlans))
+	}
+
+	// Archived plans can still be found by issue ID
+	byIssue, err := cache.GetPlanByIssueID("NUM-41")
+	if err != nil {
+		t.Fatalf("GetPlanByIssueID() error = %v", err)
+	}
+	if byIssue == nil || byIssue.Plan.ID != "PLAN-ARCHIVED" {
+		t.Error("expected archived plan to be found by issue ID")
+	}
+
+	// Saving a new version keeps the plan archived
+	archived.Title = "Updated"
+	if err := cache.SavePlan(archived); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	cached, err := cache.GetCachedPlan("PLAN-ARCHIVED")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if !cached.Archived {
+		t.Error("expected plan to stay archived after SavePlan")
+	}
+
+	if err := cache.SetArchived("PLAN-ARCHIVED", false); err != nil {
+		t.Fatalf("SetArchived(false) error = %v", err)
+	}
+	cachedPlans, err = cache.ListCachedPlans()
+	if err != nil {
+		t.Fatalf("ListCachedPlans() error = %v", err)
+	}
+	if len(cachedPlans) != 2 {
+		t.Errorf("expected 2 plans after unarchiving, got %d", len(cachedPlans))
+	}
+}
+
+func TestSetArchived_NotFound(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	err := cache.SetArchived("nonexistent", true)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected 'not found' error, got: %v", err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	plans := []*plan.Plan{
+		{ID: "PLAN-synced", Title: "Synced", Status: plan.StatusDraft, IssueID: "NUM-1"},
+		{ID: "PLAN-linked", Title: "Linked", Status: plan.StatusDraft, IssueID: "NUM-2"},
+		{ID: "PLAN-local", Title: "Local", Status: plan.StatusDraft},
+		{ID: "PLAN-archived", Title: "Archived", Status: plan.StatusDraft},
+	}
+	for _, p := range plans {
+		if err := cache.SavePlan(p); err != nil {
+			t.Fatalf("SavePlan(%s) error = %v", p.ID, err)
+		}
+	}
+	if err := cache.MarkPlanSynced("PLAN-synced"); err != nil {
+		t.Fatalf("MarkPlanSynced() error = %v", err)
+	}
+	if err := cache.SetArchived("PLAN-archived", true); err != nil {
+		t.Fatalf("SetArchived() error = %v", err)
+	}
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+
+	if stats.TotalPlans != 4 {
+		t.Errorf("TotalPlans = %d, want 4", stats.TotalPlans)
+	}
+	if stats.SyncedPlans != 1 || stats.UnsyncedPlans != 3 {
+		t.Errorf("synced/unsynced = %d/%d, want 1/3", stats.SyncedPlans, stats.UnsyncedPlans)
+	}
+	if stats.LinkedPlans != 2 || stats.UnlinkedPlans != 2 {
+		t.Errorf("linked/unlinked = %d/%d, want 2/2", stats.LinkedPlans, stats.UnlinkedPlans)
+	}
+	if stats.TotalBytes <= 0 {
+		t.Errorf("TotalBytes = %d, want > 0", stats.TotalBytes)
+	}
+	if stats.OldestCachedAt == nil || stats.NewestCachedAt == nil {
+		t.Fatal("expected oldest and newest CachedAt to be set")
+	}
+	if stats.NewestCachedAt.Before(*stats.OldestCachedAt) {
+		t.Errorf("newest %v is before oldest %v", stats.NewestCachedAt, stats.OldestCachedAt)
+	}
+}
+
+func TestStats_Empty(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.TotalPlans != 0 || stats.TotalBytes != 0 {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+	if stats.OldestCachedAt != nil || stats.NewestCachedAt != nil {
+		t.Errorf("expected no CachedAt range for an empty cache, got %+v", stats)
+	}
+}
+
+func TestClean(t *testing.T) {
+	// setup seeds a cache with a valid plan, an old plan, an invalid entry
+	// with its markdown, and an orphaned markdown file
+	setup := func(t *testing.T) (*Cache, string) {
+		t.Helper()
+		cache, cleanup := createTestCache(t)
+		t.Cleanup(cleanup)
+
+		for _, id := range []string{"PLAN-fresh", "PLAN-old"} {
+			if err := cache.SavePlan(createTestPlan(id, plan.StatusDraft)); err != nil {
+				t.Fatalf("SavePlan(%s) error = %v", id, err)
+			}
+		}
+
+		// Age one plan by rewriting its CachedAt
+		cached, err := cache.GetCachedPlan("PLAN-old")
+		if err != nil {
+			t.Fatalf("GetCachedPlan() error = %v", err)
+		}
+		cached.CachedAt = time.Now().Add(-48 * time.Hour)
+		data, err := json.Marshal(cached)
+		if err != nil {
+			t.Fatalf("failed to serialize cached plan: %v", err)
+		}
+		planDir := filepath.Join(cache.dir, "plans")
+		if err := os.WriteFile(filepath.Join(planDir, "PLAN-old.json"), data, 0644); err != nil {
+			t.Fatalf("failed to age cached plan: %v", err)
+		}
+
+		for name, content := range map[string]string{
+			"PLAN-broken.json": "{not json",
+			"PLAN-broken.md":   "# Broken",
+			"PLAN-orphan.md":   "# Orphan",
+		} {
+			if err := os.WriteFile(filepath.Join(planDir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+		return cache, planDir
+	}
+
+	files := func(entries []CleanedEntry) string {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.File
+		}
+		return strings.Join(names, ",")
+	}
+
+	exists := func(t *testing.T, planDir, name string) bool {
+		t.Helper()
+		_, err := os.Stat(filepath.Join(planDir, name))
+		return err == nil
+	}
+
+	t.Run("removes invalid and orphaned entries", func(t *testing.T) {
+		cache, planDir := setup(t)
+
+		removed, err := cache.Clean(CleanOptions{})
+		if err != nil {
+			t.Fatalf("Clean() error = %v", err)
+		}
+		if got := files(removed); got != "PLAN-broken.json,PLAN-broken.md,PLAN-orphan.md" {
+			t.Errorf("Clean() removed %q", got)
+		}
+		if removed[0].Reason != "invalid JSON" || removed[1].Reason != "invalid JSON" {
+			t.Errorf("expected broken entry to be removed as invalid JSON, got %+v", removed[:2])
+		}
+
+		for _, name := range []string{"PLAN-broken.json", "PLAN-broken.md", "PLAN-orphan.md"} {
+			if exists(t, planDir, name) {
+				t.Errorf("expected %s to be removed", name)
+			}
+		}
+		for _, name := range []string{"PLAN-fresh.json", "PLAN-fresh.md", "PLAN-old.json", "PLAN-old.md"} {
+			if !exists(t, planDir, name) {
+				t.Errorf("expected %s to be kept", name)
+			}
+		}
+	})
+
+	t.Run("removes plans older than the cutoff", func(t *testing.T) {
+		cache, planDir := setup(t)
+
+		removed, err := cache.Clean(CleanOptions{OlderThan: 24 * time.Hour})
+		if err != nil {
+			t.Fatalf("Clean() error = %v", err)
+		}
+		if got := files(removed); got != "PLAN-broken.json,PLAN-broken.md,PLAN-old.json,PLAN-old.md,PLAN-orphan.md" {
+			t.Errorf("Clean() removed %q", got)
+		}
+		if exists(t, planDir, "PLAN-old.json") || exists(t, planDir, "PLAN-old.md") {
+			t.Error("expected old plan to be removed")
+		}
+		if !exists(t, planDir, "PLAN-fresh.json") {
+			t.Error("expected fresh plan to be kept")
+		}
+	})
+
+	t.Run("removes the lock and temporary files of removed plans", func(t *testing.T) {
+		cache, planDir := setup(t)
+		sidecars := []string{"PLAN-old.json.lock", "PLAN-old.json.123.tmp", "PLAN-old.md.456.tmp", "PLAN-fresh.json.lock", "PLAN-fresh.json.789.tmp"}
+		for _, name := range sidecars {
+			if err := os.WriteFile(filepath.Join(planDir, name), nil, 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+
+		removed, err := cache.Clean(CleanOptions{OlderThan: 24 * time.Hour})
+		if err != nil {
+			t.Fatalf("Clean() error = %v", err)
+		}
+		want := "PLAN-broken.json,PLAN-broken.md,PLAN-old.json,PLAN-old.json.123.tmp,PLAN-old.json.lock,PLAN-old.md,PLAN-old.md.456.tmp,PLAN-orphan.md"
+		if got := files(removed); got != want {
+			t.Errorf("Clean() removed %q, want %q", got, want)
+		}
+		for _, name := range sidecars[:3] {
+			if exists(t, planDir, name) {
+				t.Errorf("expected %s to be removed", name)
+			}
+		}
+		for _, name := range sidecars[3:] {
+			if !exists(t, planDir, name) {
+				t.Errorf("expected %s to be kept", name)
+			}
+		}
+	})
+
+	t.Run("dry run removes nothing", func(t *testing.T) {
+		cache, planDir := setup(t)
+
+		removed, err := cache.Clean(CleanOptions{OlderThan: 24 * time.Hour, DryRun: true})
+		if err != nil {
+			t.Fatalf("Clean() error = %v", err)
+		}
+		if len(removed) != 5 {
+			t.Errorf("expected 5 entries to be reported, got %d", len(removed))
+		}
+		for _, entry := range removed {
+			if !exists(t, planDir, entry.File) {
+				t.Errorf("dry run removed %s", entry.File)
+			}
+		}
+	})
+}
+
+func TestUpdatePlan(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	if err := cache.MarkPlanSyncedWithHash("PLAN-1", "content-hash"); err != nil {
+		t.Fatalf("MarkPlanSyncedWithHash() error = %v", err)
+	}
+
+	err := cache.UpdatePlan("PLAN-1", func(p *plan.Plan) error {
+		p.Title = "Renamed Plan"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdatePlan() error = %v", err)
+	}
+
+	cached, err := cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.Plan.Title != "Renamed Plan" {
+		t.Errorf("expected update to be saved, got title %q", cached.Plan.Title)
+	}
+	if cached.SyncedContentHash != "content-hash" || cached.SyncedAt == nil {
+		t.Errorf("expected sync state to be kept, got %+v", cached)
+	}
+
+	// Linking the plan to another issue clears the sync state, so the next
+	// sync posts the plan to the new issue
+	if err := cache.SetSyncedCommentHash("PLAN-1", "comment-hash"); err != nil {
+		t.Fatalf("SetSyncedCommentHash() error = %v", err)
+	}
+	err = cache.UpdatePlan("PLAN-1", func(p *plan.Plan) error {
+		p.IssueID = "NUM-1"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdatePlan() error = %v", err)
+	}
+	cached, err = cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetCachedPlan() error = %v", err)
+	}
+	if cached.IssueID != "NUM-1" {
+		t.Errorf("expected issue NUM-1, got %q", cached.IssueID)
+	}
+	if cached.SyncedAt != nil || cached.SyncedContentHash != "" || cached.SyncedCommentHash != "" {
+		t.Errorf("expected sync state cleared for a new issue, got %+v", cached)
+	}
+
+	md, err := cache.GetPlanMarkdown("PLAN-1")
+	if err != nil {
+		t.Fatalf("GetPlanMarkdown() error = %v", err)
+	}
+	if !strings.Contains(md, "issue_id: NUM-1") || !strings.Contains(md, "# Test") {
+		t.Errorf("expected markdown to be updated with the body kept, got:\n%s", md)
+	}
+}
+
+func TestUpdatePlan_NotFound(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	called := false
+	err := cache.UpdatePlan("PLAN-404", func(p *plan.Plan) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrPlanNotFound) {
+		t.Errorf("expected ErrPlanNotFound, got %v", err)
+	}
+	if called {
+		t.Error("callback should not run for a missing plan")
+	}
+}
+
+func TestUpdatePlan_CallbackError(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	wantErr := errors.New("refused")
+	err := cache.UpdatePlan("PLAN-1", func(p *plan.Plan) error {
+		p.Title = "Half-made change"
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected callback error, got %v", err)
+	}
+
+	cached, _ := cache.GetCachedPlan("PLAN-1")
+	if cached.Plan.Title != "Test Plan" {
+		t.Errorf("expected plan to be unchanged, got title %q", cached.Plan.Title)
+	}
+}
+
+func TestUpdatePlan_WriteFailure(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SavePlan(createTestPlan("PLAN-1", plan.StatusDraft)); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+	plansDir := filepath.Join(cache.dir, "plans")
+	jsonBefore, _ := os.ReadFile(filepath.Join(plansDir, "PLAN-1.json"))
+	mdBefore, _ := os.ReadFile(filepath.Join(plansDir, "PLAN-1.md"))
+
+	for _, failing := range []string{".json", ".md"} {
+		t.Run("failing "+failing, func(t *testing.T) {
+			original := renameFile
+			renameFile = func(oldPath, newPath string) error {
+				if strings.HasSuffix(newPath, failing) {
+					return errors.New("disk full")
+				}
+				return original(oldPath, newPath)
+			}
+			defer func() { renameFile = original }()
+
+			err := cache.UpdatePlan("PLAN-1", func(p *plan.Plan) error {
+				p.Title = "Never Saved"
+				return nil
+			})
+			if err == nil || !strings.Contains(err.Error(), "disk full") {
+				t.Fatalf("expected write error, got %v", err)
+			}
+
+			if got, _ := os.ReadFile(filepath.Join(plansDir, "PLAN-1.json")); string(got) != string(jsonBefore) {
+				t.Errorf("expected plan JSON to be unchanged, got:\n%s", got)
+			}
+			if got, _ := os.ReadFile(filepath.Join(plansDir, "PLAN-1.md")); string(got) != string(mdBefore) {
+				t.Errorf("expected plan markdown to be unchanged, got:\n%s", got)
+			}
+			entries, _ := os.ReadDir(plansDir)
+			for _, entry := range entries {
+				if strings.HasSuffix(entry.Name(), ".tmp") || strings.HasSuffix(entry.Name(), ".lock") {
+					t.Errorf("expected no leftover file, found %s", entry.Name())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// IssueTTL is how long a fetched issue is served from the cache before it is
+// fetched from the tracker again
+var IssueTTL = 15 * time.Minute
+
+// CachedIssue stores an issue fetched from the tracker
+type CachedIssue struct {
+	Issue     *tracker.Issue `json:"issue"`
+	FetchedAt time.Time      `json:"fetched_at"`
+}
+
+// Fresh returns true if the issue was fetched less than IssueTTL ago
+func (ci *CachedIssue) Fresh() bool {
+	return time.Since(ci.FetchedAt) < IssueTTL
+}
+
+// remoteIssueDir returns the directory fetched issues are stored in. It sits
+// inside the issues directory, apart from the issue metadata files.
+func (c *Cache) remoteIssueDir() string {
+	return filepath.Join(c.dir, "issues", "remote")
+}
+
+// remoteIssuePath returns the path a fetched issue is stored at. Issues are
+// keyed by their human-readable identifier, falling back to their ID.
+func (c *Cache) remoteIssuePath(id string) string {
+	return filepath.Join(c.remoteIssueDir(), strings.ToUpper(id)+".json")
+}
+
+// SaveIssue caches an issue fetched from the tracker
+func (c *Cache) SaveIssue(issue *tracker.Issue) error {
+	key := issue.Identifier
+	if key == "" {
+		key = issue.ID
+	}
+	if key == "" {
+		return fmt.Errorf("issue ID is required for caching")
+	}
+
+	data, err := json.MarshalIndent(&CachedIssue{Issue: issue, FetchedAt: time.Now()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize issue: %w", err)
+	}
+
+	if err := os.MkdirAll(c.remoteIssueDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create issue cache directory: %w", err)
+	}
+	if err := writeFileAtomic(c.remoteIssuePath(key), data, 0644); err != nil {
+		return fmt.Errorf("failed to write issue cache: %w", err)
+	}
+	return nil
+}
+
+// GetIssue retrieves a cached issue by identifier. Returns nil if the issue
+// isn't cached or was fetched more than IssueTTL ago.
+func (c *Cache) GetIssue(id string) (*tracker.Issue, error) {
+	cached, err := c.getCachedIssue(c.remoteIssuePath(id))
+	if err != nil || cached == nil {
+		return nil, err
+	}
+	if !cached.Fresh() {
+		return nil, nil
+	}
+	return cached.Issue, nil
+}
+
+// getCachedIssue reads the cached issue at path, or nil if there is none
+func (c *Cache) getCachedIssue(path string) (*CachedIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read issue cache: %w", err)
+	}
+
+	var cached CachedIssue
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to parse issue cache: %w", err)
+	}
+	if cached.Issue == nil {
+		return nil, nil
+	}
+	return &cached, nil
+}
+
+// ListCachedIssues returns all cached issues, including expired ones, most
+// recently fetched first
+func (c *Cache) ListCachedIssues() ([]*CachedIssue, error) {
+	entries, err := os.ReadDir(c.remoteIssueDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read issue cache directory: %w", err)
+	}
+
+	var issues []*CachedIssue
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		cached, err := c.getCachedIssue(filepath.Join(c.remoteIssueDir(), entry.Name()))
+		if err != nil || cached == nil {
+			continue
+		}
+		issues = append(issues, cached)
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].FetchedAt.After(issues[j].FetchedAt)
+	})
+	return issues, nil
+}
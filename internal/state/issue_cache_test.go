@@ -0,0 +1,118 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+func TestSaveIssue_RoundTrip(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	issue := &tracker.Issue{
+		ID:         "uuid-1",
+		Identifier: "NUM-41",
+		Title:      "Fix login",
+		Status:     tracker.StatusInProgress,
+		Labels:     []string{"backend"},
+		URL:        "https://linear.app/team/issue/NUM-41",
+	}
+	if err := cache.SaveIssue(issue); err != nil {
+		t.Fatalf("SaveIssue() error = %v", err)
+	}
+
+	// Identifiers are matched regardless of case
+	for _, id := range []string{"NUM-41", "num-41"} {
+		got, err := cache.GetIssue(id)
+		if err != nil {
+			t.Fatalf("GetIssue(%q) error = %v", id, err)
+		}
+		if got == nil {
+			t.Fatalf("GetIssue(%q) returned nil", id)
+		}
+		if got.ID != issue.ID || got.Title != issue.Title || got.Status != issue.Status || got.URL != issue.URL {
+			t.Errorf("GetIssue(%q) = %+v, want %+v", id, got, issue)
+		}
+		if len(got.Labels) != 1 || got.Labels[0] != "backend" {
+			t.Errorf("expected labels to round-trip, got %v", got.Labels)
+		}
+	}
+
+	got, err := cache.GetIssue("NUM-404")
+	if err != nil || got != nil {
+		t.Errorf("GetIssue() for uncached issue = %v, %v; want nil, nil", got, err)
+	}
+
+	// Issue metadata in the same directory is unaffected
+	metadata, err := cache.ListIssueMetadata()
+	if err != nil {
+		t.Fatalf("ListIssueMetadata() error = %v", err)
+	}
+	if len(metadata) != 0 {
+		t.Errorf("expected no issue metadata, got %d entries", len(metadata))
+	}
+}
+
+func TestGetIssue_Expired(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	if err := cache.SaveIssue(&tracker.Issue{ID: "uuid-1", Identifier: "NUM-41"}); err != nil {
+		t.Fatalf("SaveIssue() error = %v", err)
+	}
+
+	oldTTL := IssueTTL
+	IssueTTL = time.Nanosecond
+	defer func() { IssueTTL = oldTTL }()
+	time.Sleep(time.Millisecond)
+
+	got, err := cache.GetIssue("NUM-41")
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected expired issue to be a cache miss, got %+v", got)
+	}
+
+	// Expired issues are still listed
+	issues, err := cache.ListCachedIssues()
+	if err != nil {
+		t.Fatalf("ListCachedIssues() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].Fresh() {
+		t.Errorf("expected one stale issue, got %+v", issues)
+	}
+}
+
+func TestListCachedIssues(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	issues, err := cache.ListCachedIssues()
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("ListCachedIssues() on empty cache = %v, %v", issues, err)
+	}
+
+	for _, id := range []string{"NUM-1", "NUM-2"} {
+		if err := cache.SaveIssue(&tracker.Issue{Identifier: id}); err != nil {
+			t.Fatalf("SaveIssue() error = %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := cache.SaveIssue(&tracker.Issue{}); err == nil {
+		t.Error("expected SaveIssue() to reject an issue without an ID")
+	}
+
+	issues, err = cache.ListCachedIssues()
+	if err != nil {
+		t.Fatalf("ListCachedIssues() error = %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Issue.Identifier != "NUM-2" || issues[1].Issue.Identifier != "NUM-1" {
+		t.Errorf("expected most recently fetched first, got %s, %s", issues[0].Issue.Identifier, issues[1].Issue.Identifier)
+	}
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+var (
+	// lockTimeout is how long to wait for another process to release a lock
+	lockTimeout = 10 * time.Second
+	// lockRetryInterval is how often a held lock is retried
+	lockRetryInterval = 10 * time.Millisecond
+	// staleLockAge is the age after which a lock is assumed to belong to a
+	// process that died without releasing it
+	staleLockAge = time.Minute
+)
+
+// lockFile acquires an advisory lock on path by creating a path+".lock"
+// sidecar file exclusively, waiting up to lockTimeout for another holder to
+// release it. The returned function releases the lock.
+func lockFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			// The PID is informational, to help identify the holder of a stuck lock
+			f.WriteString(strconv.Itoa(os.Getpid()))
+			owned, statErr := f.Stat()
+			return func() {
+				// Leave the lock alone if it was broken as stale and is
+				// someone else's now. The file is held open until then so
+				// that its inode can't be reused for theirs.
+				if info, err := os.Stat(lockPath); err == nil && (statErr != nil || os.SameFile(info, owned)) {
+					os.Remove(lockPath)
+				}
+				f.Close()
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			breakStaleLock(lockPath, info)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock on %s (remove %s if no other jig process is running)", filepath.Base(path), lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// breakStaleLock removes the stale lock file that stale describes. Removing it
+// by path could remove a lock another process took after breaking the same
+// stale lock, letting both hold it, so the lock is first renamed aside: only
+// one process can move a given file, and if the file moved turns out not to be
+// the stale one, it is put back.
+func breakStaleLock(lockPath string, stale os.FileInfo) {
+	aside, err := os.CreateTemp(filepath.Dir(lockPath), filepath.Base(lockPath)+".*.stale")
+	if err != nil {
+		return
+	}
+	aside.Close()
+	defer os.Remove(aside.Name())
+
+	if err := os.Rename(lockPath, aside.Name()); err != nil {
+		return // Another process broke the lock first
+	}
+	info, err := os.Stat(aside.Name())
+	if err != nil || !os.SameFile(info, stale) || !info.ModTime().Equal(stale.ModTime()) {
+		// This is a lock taken since, so restore it unless the path was
+		// locked again in the meantime
+		os.Link(aside.Name(), lockPath)
+	}
+}
@@ -0,0 +1,239 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+func TestLockFile_SerializesReadModifyWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+	if err := os.WriteFile(path, []byte("0"), 0644); err != nil {
+		t.Fatalf("failed to write counter: %v", err)
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := lockFile(path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer unlock()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			n, _ := strconv.Atoi(string(data))
+			errs <- writeFileAtomic(path, []byte(strconv.Itoa(n+1)), 0644)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("worker error: %v", err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != strconv.Itoa(workers) {
+		t.Errorf("counter = %s, want %d (updates were lost)", data, workers)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Error("expected lock file to be removed after release")
+	}
+}
+
+func TestLockFile_Timeout(t *testing.T) {
+	oldTimeout := lockTimeout
+	lockTimeout = 50 * time.Millisecond
+	defer func() { lockTimeout = oldTimeout }()
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatalf("lockFile() error = %v", err)
+	}
+	defer unlock()
+
+	if _, err := lockFile(path); err == nil || !strings.Contains(err.Error(), "timed out waiting for lock") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
+
+func TestLockFile_BreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	lockPath := path + ".lock"
+	if err := os.WriteFile(lockPath, []byte("12345"), 0644); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("failed to age lock file: %v", err)
+	}
+
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatalf("expected stale lock to be broken, got %v", err)
+	}
+	unlock()
+}
+
+func TestLockFile_StaleLockBrokenOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+	if err := os.WriteFile(path, []byte("0"), 0644); err != nil {
+		t.Fatalf("failed to write counter: %v", err)
+	}
+	lockPath := path + ".lock"
+	if err := os.WriteFile(lockPath, []byte("12345"), 0644); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("failed to age lock file: %v", err)
+	}
+
+	// Every worker finds the same stale lock, but only one may take over
+	// from it at a time
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := lockFile(path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer unlock()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			n, _ := strconv.Atoi(string(data))
+			time.Sleep(time.Millisecond)
+			errs <- writeFileAtomic(path, []byte(strconv.Itoa(n+1)), 0644)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("worker error: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != strconv.Itoa(workers) {
+		t.Errorf("counter = %s, want %d (updates were lost)", data, workers)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, entry := range entries {
+		if entry.Name() != "counter" {
+			t.Errorf("unexpected file left behind: %s", entry.Name())
+		}
+	}
+}
+
+func TestLockFile_ReleaseKeepsAnotherHoldersLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatalf("lockFile() error = %v", err)
+	}
+
+	// Another process breaks the lock as stale and takes it
+	lockPath := path + ".lock"
+	if err := os.Remove(lockPath); err != nil {
+		t.Fatalf("failed to remove lock file: %v", err)
+	}
+	if err := os.WriteFile(lockPath, []byte("12345"), 0644); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+
+	unlock()
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Errorf("expected the other holder's lock to be kept, got %v", err)
+	}
+}
+
+func TestCache_ConcurrentUpdates(t *testing.T) {
+	cache, cleanup := createTestCache(t)
+	defer cleanup()
+
+	p := createTestPlan("PLAN-1", plan.StatusApproved)
+	p.IssueID = "NUM-1"
+	if err := cache.SavePlan(p); err != nil {
+		t.Fatalf("SavePlan() error = %v", err)
+	}
+
+	// Each kind of update only touches its own fields, so any update that
+	// overwrites another with stale data shows up as a missing field
+	const rounds = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, rounds*3)
+	for i := 0; i < rounds; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			errs <- cache.MarkPlanSyncedWithHash("PLAN-1", "content-hash")
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- cache.SetSyncedCommentHash("PLAN-1", "comment-hash")
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- cache.SetArchived("PLAN-1", true)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent update error: %v", err)
+		}
+	}
+
+	cached, err := cache.GetCachedPlan("PLAN-1")
+	if err != nil {
+		t.Fatalf("cached plan is corrupted: %v", err)
+	}
+	if cached.SyncedAt == nil || cached.SyncedContentHash != "content-hash" {
+		t.Errorf("lost sync update: SyncedAt=%v, SyncedContentHash=%q", cached.SyncedAt, cached.SyncedContentHash)
+	}
+	if cached.SyncedCommentHash != "comment-hash" {
+		t.Errorf("lost comment hash update: %q", cached.SyncedCommentHash)
+	}
+	if !cached.Archived {
+		t.Error("lost archive update")
+	}
+
+	// No lock or temporary files are left behind
+	entries, _ := os.ReadDir(filepath.Join(cache.dir, "plans"))
+	for _, entry := range entries {
+		if name := entry.Name(); name != "PLAN-1.json" && name != "PLAN-1.md" {
+			t.Errorf("unexpected file left in cache: %s", name)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// sessionMetadataFile is the name of a planning session's metadata file
+	sessionMetadataFile = "metadata.json"
+	// legacySavedPlanIDFile is where sessions used to record the plan they saved,
+	// before it moved into the metadata file
+	legacySavedPlanIDFile = "saved-plan-id"
+)
+
+// SessionStatus is the stage a planning session has reached
+type SessionStatus string
+
+const (
+	SessionStatusPlanning SessionStatus = "planning" // The runner is writing the plan
+	SessionStatusSaved    SessionStatus = "saved"    // The plan was saved with jig plan save
+)
+
+// SessionMetadata describes a planning session. It's written when the session
+// starts and updated when its plan is saved.
+type SessionMetadata struct {
+	IssueID   string        `json:"issue_id,omitempty"`
+	PlanID    string        `json:"plan_id,omitempty"` // Plan saved during the session
+	Goal      string        `json:"goal,omitempty"`
+	Runner    string        `json:"runner,omitempty"`
+	Status    SessionStatus `json:"status,omitempty"`
+	Team      string        `json:"team,omitempty"`     // Team override for the plan's new issue
+	Project   string        `json:"project,omitempty"`  // Project override for the plan's new issue
+	NoIssue   bool          `json:"no_issue,omitempty"` // Never create an issue for the plan (--no-issue)
+	Title     string        `json:"title,omitempty"`    // Title given up front, overriding the generated one
+	Labels    []string      `json:"labels,omitempty"`   // Labels given up front, added to the plan's own
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// ReadSessionMetadata reads the metadata of the session in dir. A session
+// without metadata has empty metadata. The plan ID of a session that recorded
+// it in the legacy saved-plan-id file is read from there.
+func ReadSessionMetadata(dir string) (*SessionMetadata, error) {
+	m := &SessionMetadata{}
+	data, err := os.ReadFile(filepath.Join(dir, sessionMetadataFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read session metadata: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("failed to parse session metadata: %w", err)
+		}
+	}
+
+	if m.PlanID == "" {
+		if data, err := os.ReadFile(filepath.Join(dir, legacySavedPlanIDFile)); err == nil {
+			m.PlanID = strings.TrimSpace(string(data))
+		}
+	}
+	return m, nil
+}
+
+// WriteSessionMetadata replaces the metadata of the session in dir, setting
+// its update time and, if unset, its creation time. The file is written
+// atomically under a lock, so concurrent writers never leave it corrupt.
+func WriteSessionMetadata(dir string, m *SessionMetadata) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	path := filepath.Join(dir, sessionMetadataFile)
+	unlock, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return writeSessionMetadata(path, m)
+}
+
+// UpdateSessionMetadata applies update to the metadata of the session in dir
+// and writes it back, holding the lock throughout so concurrent updates
+// aren't lost
+func UpdateSessionMetadata(dir string, update func(m *SessionMetadata)) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	path := filepath.Join(dir, sessionMetadataFile)
+	unlock, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	m, err := ReadSessionMetadata(dir)
+	if err != nil {
+		return err
+	}
+	update(m)
+	return writeSessionMetadata(path, m)
+}
+
+// writeSessionMetadata writes session metadata to path. Callers must hold
+// the lock on path.
+func writeSessionMetadata(path string, m *SessionMetadata) error {
+	now := time.Now()
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = now
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize session metadata: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionMetadata_RoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sessions", "12345")
+
+	m := &SessionMetadata{
+		IssueID: "NUM-1",
+		Goal:    "Support SSO logins",
+		Runner:  "claude",
+		Status:  SessionStatusPlanning,
+		Team:    "OPS",
+		NoIssue: true,
+		Labels:  []string{"auth"},
+	}
+	if err := WriteSessionMetadata(dir, m); err != nil {
+		t.Fatalf("WriteSessionMetadata() error = %v", err)
+	}
+	if m.CreatedAt.IsZero() || !m.UpdatedAt.Equal(m.CreatedAt) {
+		t.Errorf("expected the creation and update times to be set, got %v and %v", m.CreatedAt, m.UpdatedAt)
+	}
+
+	got, err := ReadSessionMetadata(dir)
+	if err != nil {
+		t.Fatalf("ReadSessionMetadata() error = %v", err)
+	}
+	if !got.CreatedAt.Equal(m.CreatedAt) || !got.UpdatedAt.Equal(m.UpdatedAt) {
+		t.Errorf("expected the times to round-trip, got %v and %v", got.CreatedAt, got.UpdatedAt)
+	}
+	got.CreatedAt, got.UpdatedAt = m.CreatedAt, m.UpdatedAt
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("ReadSessionMetadata() = %+v, want %+v", got, m)
+	}
+
+	// Saving the plan keeps the rest of the metadata and its creation time
+	if err := UpdateSessionMetadata(dir, func(m *SessionMetadata) {
+		m.PlanID = "PLAN-1"
+		m.Status = SessionStatusSaved
+	}); err != nil {
+		t.Fatalf("UpdateSessionMetadata() error = %v", err)
+	}
+	got, _ = ReadSessionMetadata(dir)
+	if got.PlanID != "PLAN-1" || got.Status != SessionStatusSaved || got.IssueID != "NUM-1" || got.Goal != "Support SSO logins" {
+		t.Errorf("expected the plan recorded alongside the session's fields, got %+v", got)
+	}
+	if !got.CreatedAt.Equal(m.CreatedAt) || got.UpdatedAt.Before(m.UpdatedAt) {
+		t.Errorf("expected the creation time kept and the update time moved on, got %v and %v", got.CreatedAt, got.UpdatedAt)
+	}
+
+	// A session without metadata has none
+	got, err = ReadSessionMetadata(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || !reflect.DeepEqual(got, &SessionMetadata{}) {
+		t.Errorf("ReadSessionMetadata() for a missing session = %+v, %v, want empty", got, err)
+	}
+}
+
+func TestReadSessionMetadata_Legacy(t *testing.T) {
+	dir := t.TempDir()
+
+	// Older sessions wrote a free-form metadata file and the saved plan's ID
+	// to a file of its own
+	legacy := `{
+  "issue_id": "NUM-22",
+  "created_at": "2025-01-02T15:04:05Z",
+  "command": "jig plan NUM-22",
+  "team": "OPS",
+  "labels": ["backend"]
+}`
+	if err := os.WriteFile(filepath.Join(dir, sessionMetadataFile), []byte(legacy), 0644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, legacySavedPlanIDFile), []byte("PLAN-22\n  "), 0644); err != nil {
+		t.Fatalf("failed to write plan ID: %v", err)
+	}
+
+	got, err := ReadSessionMetadata(dir)
+	if err != nil {
+		t.Fatalf("ReadSessionMetadata() error = %v", err)
+	}
+	want := &SessionMetadata{
+		IssueID:   "NUM-22",
+		PlanID:    "PLAN-22",
+		Team:      "OPS",
+		Labels:    []string{"backend"},
+		CreatedAt: time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadSessionMetadata() = %+v, want %+v", got, want)
+	}
+
+	// A corrupt file is an error rather than empty metadata
+	if err := os.WriteFile(filepath.Join(dir, sessionMetadataFile), []byte("{"), 0644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	if _, err := ReadSessionMetadata(dir); err == nil {
+		t.Error("expected an error for corrupt metadata")
+	}
+}
+
+func TestSessionMetadata_ConcurrentWrites(t *testing.T) {
+	dir := t.TempDir()
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- WriteSessionMetadata(dir, &SessionMetadata{IssueID: fmt.Sprintf("NUM-%d", i), Goal: fmt.Sprintf("Goal %d", i)})
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- UpdateSessionMetadata(dir, func(m *SessionMetadata) {
+				m.Labels = append(m.Labels, fmt.Sprintf("label-%02d", i))
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("writer error: %v", err)
+		}
+	}
+
+	// The file is always one writer's complete metadata
+	data, err := os.ReadFile(filepath.Join(dir, sessionMetadataFile))
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	var m SessionMetadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("metadata is corrupt: %v\n%s", err, data)
+	}
+	if m.IssueID != "" {
+		var n int
+		if _, err := fmt.Sscanf(m.IssueID, "NUM-%d", &n); err != nil || m.Goal != fmt.Sprintf("Goal %d", n) {
+			t.Errorf("expected the issue and goal from the same write, got %q and %q", m.IssueID, m.Goal)
+		}
+	}
+
+	// Updates alone are never lost
+	if err := WriteSessionMetadata(dir, &SessionMetadata{}); err != nil {
+		t.Fatalf("WriteSessionMetadata() error = %v", err)
+	}
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			UpdateSessionMetadata(dir, func(m *SessionMetadata) {
+				m.Labels = append(m.Labels, fmt.Sprintf("label-%02d", i))
+			})
+		}()
+	}
+	wg.Wait()
+
+	got, err := ReadSessionMetadata(dir)
+	if err != nil {
+		t.Fatalf("ReadSessionMetadata() error = %v", err)
+	}
+	if len(got.Labels) != writers {
+		t.Errorf("expected %d labels, got %d (updates were lost): %v", writers, len(got.Labels), got.Labels)
+	}
+	if _, err := os.Stat(filepath.Join(dir, sessionMetadataFile+".lock")); !os.IsNotExist(err) {
+		t.Error("expected lock file to be removed after release")
+	}
+}
@@ -75,7 +75,7 @@ func (ws *WorktreeState) Track(info *WorktreeInfo) error {
 	}
 
 	path := filepath.Join(ws.dir, info.IssueID+".json")
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write worktree info: %w", err)
 	}
 
@@ -0,0 +1,361 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charleslr/jig/internal/logging"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+const (
+	gitlabURL = "https://gitlab.com"
+
+	// pageSize is the number of items fetched per request of a paginated list
+	pageSize = 100
+
+	// defaultIssueKey prefixes issue identifiers when IssueKey is unset
+	defaultIssueKey = "GL"
+)
+
+// ErrNoProject is returned when an issue needs a project and the client has
+// none configured
+var ErrNoProject = errors.New("no GitLab project configured: set gitlab.project")
+
+// Client is a GitLab Issues API client. Issues are created in one project,
+// while searches and the current user's issues can span a group.
+//
+// GitLab issues are numbered per project, so an issue's ID is its project's
+// numeric ID and its IID ("123:45"). Its identifier is its IID prefixed by
+// IssueKey ("GL-45") in the configured project, and its full reference
+// ("acme/web#45") in the group's other projects, so every identifier
+// resolves back to the same issue.
+type Client struct {
+	token      string
+	apiURL     string
+	httpClient *http.Client
+	project    string
+
+	// Group is the ID or path of a group whose issues searches and the
+	// current user's issue list cover. Empty covers the project only.
+	Group string
+
+	// IssueKey prefixes issue identifiers, e.g. "API" for API-42. Empty
+	// uses "GL".
+	IssueKey string
+
+	// viewer caches the user the token belongs to (see GetCurrentUser)
+	viewer *viewerCache
+}
+
+// viewerCache holds the user the token belongs to once it is fetched
+type viewerCache struct {
+	mu   sync.Mutex
+	user *tracker.User
+}
+
+// NewClient creates a new GitLab client for the instance at baseURL, e.g.
+// "https://gitlab.example.com", creating issues in project (an ID or a path
+// such as "acme/api"). An empty baseURL uses gitlab.com. Returns an error if
+// baseURL is not an absolute http(s) URL.
+func NewClient(token, baseURL, project string) (*Client, error) {
+	if baseURL == "" {
+		baseURL = gitlabURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitLab URL %q: %w", baseURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid GitLab URL %q: scheme must be http or https", baseURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid GitLab URL %q: missing host", baseURL)
+	}
+
+	return &Client{
+		token:  token,
+		apiURL: strings.TrimSuffix(baseURL, "/") + "/api/v4",
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		project: project,
+		viewer:  &viewerCache{},
+	}, nil
+}
+
+// APIError is an error reported by the GitLab API
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Message is the error message from the response body, or the body
+	// itself if it held none
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GitLab API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// IsAuthError reports whether err is a GitLab API error caused by a missing,
+// invalid or insufficiently privileged token
+func IsAuthError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
+
+// parseErrorResponse builds an APIError from a failed response. GitLab
+// reports errors as {"message": ...} or {"error": ...}, where the message
+// may itself be an object of field errors.
+func parseErrorResponse(status int, body []byte) *APIError {
+	var resp struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}
+	apiErr := &APIError{StatusCode: status, Message: strings.TrimSpace(string(body))}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return apiErr
+	}
+
+	var message string
+	switch {
+	case json.Unmarshal(resp.Message, &message) == nil && message != "":
+		apiErr.Message = message
+	case len(resp.Message) > 0 && string(resp.Message) != "null":
+		apiErr.Message = string(resp.Message)
+	case resp.Error != "":
+		apiErr.Message = resp.Error
+	}
+	return apiErr
+}
+
+// do sends a request to the API and decodes the JSON response into out, if
+// not nil. path is relative to the API URL and must be escaped already.
+// Returns the response headers, which hold the pagination state of lists.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) (http.Header, error) {
+	endpoint := c.apiURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logging.Debug("gitlab request", "method", method, "path", path)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logging.Debug("gitlab request failed", "method", method, "path", path, "error", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	logging.Debug("gitlab response", "method", method, "path", path, "status", resp.StatusCode, "bytes", len(respBody), "duration", time.Since(start).Round(time.Millisecond))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, parseErrorResponse(resp.StatusCode, respBody)
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// getAll fetches every page of a list, following the X-Next-Page header
+func getAll[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(pageSize))
+
+	var all []T
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+		var items []T
+		header, err := c.do(ctx, http.MethodGet, path, query, nil, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		// Stop on a page that doesn't advance rather than looping forever
+		next := header.Get("X-Next-Page")
+		if next == page {
+			break
+		}
+		page = next
+	}
+	return all, nil
+}
+
+// projectPath returns the API path of a project, given its ID or path
+func projectPath(project string) string {
+	return "/projects/" + url.PathEscape(project)
+}
+
+// groupPath returns the API path of a group, given its ID or path
+func groupPath(group string) string {
+	return "/groups/" + url.PathEscape(group)
+}
+
+// issuePath returns the API path of an issue
+func issuePath(ref issueRef) string {
+	return projectPath(ref.project) + "/issues/" + strconv.Itoa(ref.iid)
+}
+
+// issueRef locates an issue: its project and its number in the project
+type issueRef struct {
+	project string
+	iid     int
+}
+
+// resolveIssue parses an issue ID ("123:45"), full reference ("acme/web#45")
+// or identifier ("GL-45", "#45" or "45"). Identifiers without a project are
+// resolved in the configured project.
+func (c *Client) resolveIssue(id string) (issueRef, error) {
+	id = strings.TrimSpace(id)
+	if project, iid, ok := strings.Cut(id, ":"); ok {
+		n, err := strconv.Atoi(iid)
+		if err != nil || project == "" || n <= 0 {
+			return issueRef{}, fmt.Errorf("invalid GitLab issue ID %q", id)
+		}
+		return issueRef{project: project, iid: n}, nil
+	}
+	if i := strings.LastIndex(id, "#"); i > 0 {
+		n, err := strconv.Atoi(id[i+1:])
+		if err != nil || n <= 0 {
+			return issueRef{}, fmt.Errorf("invalid GitLab issue reference %q", id)
+		}
+		return issueRef{project: id[:i], iid: n}, nil
+	}
+
+	number := strings.TrimPrefix(id, "#")
+	if i := strings.LastIndex(number, "-"); i >= 0 {
+		if !strings.EqualFold(number[:i], c.issueKey()) {
+			return issueRef{}, fmt.Errorf("invalid GitLab issue %q: identifiers start with %s-", id, c.issueKey())
+		}
+		number = number[i+1:]
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return issueRef{}, fmt.Errorf("invalid GitLab issue %q", id)
+	}
+	if c.project == "" {
+		return issueRef{}, ErrNoProject
+	}
+	return issueRef{project: c.project, iid: n}, nil
+}
+
+// issueKey returns the prefix of issue identifiers
+func (c *Client) issueKey() string {
+	if c.IssueKey == "" {
+		return defaultIssueKey
+	}
+	return c.IssueKey
+}
+
+// scopePath returns the API path issue searches and lists run against: the
+// group if one is configured, otherwise the project
+func (c *Client) scopePath() (string, error) {
+	if c.Group != "" {
+		return groupPath(c.Group), nil
+	}
+	if c.project == "" {
+		return "", ErrNoProject
+	}
+	return projectPath(c.project), nil
+}
+
+// GitLabIssue represents an issue from the GitLab API
+type GitLabIssue struct {
+	ID          int       `json:"id"`
+	IID         int       `json:"iid"`
+	ProjectID   int       `json:"project_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"` // "opened" or "closed"
+	Labels      []string  `json:"labels"`
+	WebURL      string    `json:"web_url"`
+	Weight      *int      `json:"weight"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Assignee    *struct {
+		Username string `json:"username"`
+	} `json:"assignee"`
+	Milestone *struct {
+		ID int `json:"id"`
+	} `json:"milestone"`
+	References struct {
+		Full string `json:"full"` // e.g. "acme/api#45"
+	} `json:"references"`
+}
+
+// GitLabNote represents a note (comment) on an issue from the GitLab API
+type GitLabNote struct {
+	ID        int       `json:"id"`
+	Body      string    `json:"body"`
+	System    bool      `json:"system"` // Notes GitLab writes itself, e.g. "changed the description"
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Author    struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"author"`
+}
+
+// GitLabUser represents a user from the GitLab API
+type GitLabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+}
+
+// GitLabProject represents a project from the GitLab API
+type GitLabProject struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+// GitLabMilestone represents a milestone from the GitLab API
+type GitLabMilestone struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
@@ -0,0 +1,588 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// searchLimit is the number of issues a search returns
+const searchLimit = 50
+
+// statusLabelPrefix starts the scoped labels that hold an open issue's
+// status, e.g. "status::in_review". GitLab issues are only open or closed,
+// so the finer statuses are kept in labels.
+const statusLabelPrefix = "status::"
+
+// allStatuses are the statuses every GitLab issue can move to
+var allStatuses = []tracker.Status{
+	tracker.StatusBacklog,
+	tracker.StatusTodo,
+	tracker.StatusInProgress,
+	tracker.StatusInReview,
+	tracker.StatusDone,
+	tracker.StatusCanceled,
+}
+
+// CreateIssue creates a new issue in the configured project. The issue's
+// ProjectID, if set, is the ID of the milestone to add it to.
+func (c *Client) CreateIssue(ctx context.Context, issue *tracker.Issue) (*tracker.Issue, error) {
+	if c.project == "" {
+		return nil, ErrNoProject
+	}
+
+	body := map[string]interface{}{
+		"title":       issue.Title,
+		"description": issue.Description,
+	}
+	labels := issue.Labels
+	if label := statusLabel(issue.Status); label != "" {
+		labels = append(labels[:len(labels):len(labels)], label)
+	}
+	if len(labels) > 0 {
+		body["labels"] = strings.Join(labels, ",")
+	}
+	if issue.ProjectID != "" {
+		milestone, err := strconv.Atoi(issue.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitLab milestone ID %q", issue.ProjectID)
+		}
+		body["milestone_id"] = milestone
+	}
+	if issue.Estimate != nil {
+		body["weight"] = *issue.Estimate
+	}
+
+	var created GitLabIssue
+	if _, err := c.do(ctx, http.MethodPost, projectPath(c.project)+"/issues", nil, body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	// Issues are created open, so closing ones are closed afterwards
+	if issue.Status == tracker.StatusDone || issue.Status == tracker.StatusCanceled {
+		result := c.toTrackerIssue(&created)
+		if err := c.TransitionIssue(ctx, result.ID, issue.Status); err != nil {
+			return nil, err
+		}
+		result.Status = issue.Status
+		return result, nil
+	}
+	return c.toTrackerIssue(&created), nil
+}
+
+// UpdateIssue updates an issue. GitLab has no issue priorities, so Priority
+// is ignored, as are Assignee and ParentID.
+func (c *Client) UpdateIssue(ctx context.Context, id string, updates *tracker.IssueUpdate) error {
+	ref, err := c.resolveIssue(id)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{}
+	if updates.Title != nil {
+		body["title"] = *updates.Title
+	}
+	if updates.Description != nil {
+		body["description"] = *updates.Description
+	}
+	if updates.Labels != nil {
+		body["labels"] = strings.Join(updates.Labels, ",")
+	}
+	if len(body) > 0 {
+		if _, err := c.do(ctx, http.MethodPut, issuePath(ref), nil, body, nil); err != nil {
+			return fmt.Errorf("failed to update issue: %w", err)
+		}
+	}
+
+	if updates.Status != nil {
+		return c.TransitionIssue(ctx, id, *updates.Status)
+	}
+	return nil
+}
+
+// GetIssue retrieves an issue by ID ("123:45"), identifier ("GL-45") or
+// full reference ("acme/web#45")
+func (c *Client) GetIssue(ctx context.Context, id string) (*tracker.Issue, error) {
+	ref, err := c.resolveIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := c.getIssue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return c.toTrackerIssue(issue), nil
+}
+
+// getIssue fetches an issue as the API returns it
+func (c *Client) getIssue(ctx context.Context, ref issueRef) (*GitLabIssue, error) {
+	var issue GitLabIssue
+	if _, err := c.do(ctx, http.MethodGet, issuePath(ref), nil, nil, &issue); err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	return &issue, nil
+}
+
+// SearchIssues searches the project's issues, or the group's if one is
+// configured, for a query in their titles and descriptions
+func (c *Client) SearchIssues(ctx context.Context, query string) ([]*tracker.Issue, error) {
+	path, err := c.scopePath()
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(searchLimit))
+	params.Set("order_by", "updated_at")
+	if query != "" {
+		params.Set("search", query)
+	}
+
+	var issues []GitLabIssue
+	if _, err := c.do(ctx, http.MethodGet, path+"/issues", params, nil, &issues); err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	return c.toTrackerIssues(issues), nil
+}
+
+// ListAssignedToMe returns the open issues assigned to the token's user in
+// the project, or the group if one is configured
+func (c *Client) ListAssignedToMe(ctx context.Context) ([]*tracker.Issue, error) {
+	path, err := c.scopePath()
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("scope", "assigned_to_me")
+	params.Set("state", "opened")
+	issues, err := getAll[GitLabIssue](ctx, c, path+"/issues", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assigned issues: %w", err)
+	}
+	return c.toTrackerIssues(issues), nil
+}
+
+// GetCurrentUser returns the user the token belongs to. The user is fetched
+// once and cached for the lifetime of the client.
+func (c *Client) GetCurrentUser(ctx context.Context) (*tracker.User, error) {
+	c.viewer.mu.Lock()
+	defer c.viewer.mu.Unlock()
+	if c.viewer.user != nil {
+		return c.viewer.user, nil
+	}
+
+	var user GitLabUser
+	if _, err := c.do(ctx, http.MethodGet, "/user", nil, nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	c.viewer.user = &tracker.User{
+		ID:    strconv.Itoa(user.ID),
+		Name:  user.Name,
+		Email: user.Email,
+	}
+	return c.viewer.user, nil
+}
+
+// CreateSubIssue creates an issue linked to its parent. GitLab only has
+// child issues in some tiers, so sub-issues are related issues.
+func (c *Client) CreateSubIssue(ctx context.Context, parentID string, issue *tracker.Issue) (*tracker.Issue, error) {
+	parent, err := c.resolveIssue(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := c.CreateIssue(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+	child, err := c.resolveIssue(created.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"target_project_id": child.project,
+		"target_issue_iid":  child.iid,
+		"link_type":         "relates_to",
+	}
+	if _, err := c.do(ctx, http.MethodPost, issuePath(parent)+"/links", nil, body, nil); err != nil {
+		return nil, fmt.Errorf("failed to link %s to its parent: %w", created.Identifier, err)
+	}
+	created.ParentID = parentID
+	return created, nil
+}
+
+// GetSubIssues returns the issues linked to an issue (see CreateSubIssue)
+func (c *Client) GetSubIssues(ctx context.Context, parentID string) ([]*tracker.Issue, error) {
+	parent, err := c.resolveIssue(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var linked []GitLabIssue
+	if _, err := c.do(ctx, http.MethodGet, issuePath(parent)+"/links", nil, nil, &linked); err != nil {
+		return nil, fmt.Errorf("failed to get linked issues: %w", err)
+	}
+	issues := c.toTrackerIssues(linked)
+	for _, issue := range issues {
+		issue.ParentID = parentID
+	}
+	return issues, nil
+}
+
+// AddComment adds a note to an issue
+func (c *Client) AddComment(ctx context.Context, issueID string, body string) (*tracker.Comment, error) {
+	ref, err := c.resolveIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var note GitLabNote
+	if _, err := c.do(ctx, http.MethodPost, issuePath(ref)+"/notes", nil, map[string]string{"body": body}, &note); err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+	return c.toTrackerComment(ctx, &note), nil
+}
+
+// UpdateComment replaces the body of a note on an issue
+func (c *Client) UpdateComment(ctx context.Context, issueID, commentID, body string) (*tracker.Comment, error) {
+	ref, err := c.resolveIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var note GitLabNote
+	path := issuePath(ref) + "/notes/" + url.PathEscape(commentID)
+	if _, err := c.do(ctx, http.MethodPut, path, nil, map[string]string{"body": body}, &note); err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+	return c.toTrackerComment(ctx, &note), nil
+}
+
+// GetComments retrieves the notes on an issue, oldest first. Notes GitLab
+// writes itself about changes to the issue are left out.
+func (c *Client) GetComments(ctx context.Context, issueID string) ([]*tracker.Comment, error) {
+	ref, err := c.resolveIssue(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("sort", "asc")
+	params.Set("order_by", "created_at")
+	notes, err := getAll[GitLabNote](ctx, c, issuePath(ref)+"/notes", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	var comments []*tracker.Comment
+	for i := range notes {
+		if !notes[i].System {
+			comments = append(comments, c.toTrackerComment(ctx, &notes[i]))
+		}
+	}
+	return comments, nil
+}
+
+// TransitionIssue moves an issue to a status. Done and canceled issues are
+// closed, others are open, and every status but done is kept in a
+// "status::" label that replaces the issue's previous one.
+func (c *Client) TransitionIssue(ctx context.Context, id string, status tracker.Status) error {
+	if !isKnownStatus(status) {
+		return fmt.Errorf("unknown status %q", status)
+	}
+	ref, err := c.resolveIssue(id)
+	if err != nil {
+		return err
+	}
+	issue, err := c.getIssue(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	target := statusLabel(status)
+	var remove []string
+	for _, label := range issue.Labels {
+		if isStatusLabel(label) && label != target {
+			remove = append(remove, label)
+		}
+	}
+
+	body := map[string]interface{}{}
+	if target != "" {
+		body["add_labels"] = target
+	}
+	if len(remove) > 0 {
+		body["remove_labels"] = strings.Join(remove, ",")
+	}
+	closed := status == tracker.StatusDone || status == tracker.StatusCanceled
+	switch {
+	case closed && issue.State != "closed":
+		body["state_event"] = "close"
+	case !closed && issue.State == "closed":
+		body["state_event"] = "reopen"
+	}
+
+	if _, err := c.do(ctx, http.MethodPut, issuePath(ref), nil, body, nil); err != nil {
+		return fmt.Errorf("failed to move issue to %s: %w", status, err)
+	}
+	return nil
+}
+
+// GetAvailableStatuses returns the statuses an issue can move to. Statuses
+// are labels in GitLab, so every status is available.
+func (c *Client) GetAvailableStatuses(ctx context.Context, id string) ([]tracker.Status, error) {
+	return append([]tracker.Status(nil), allStatuses...), nil
+}
+
+// RemoveLabel removes a label from an issue, keeping its other labels.
+// GitLab labels are identified by name.
+func (c *Client) RemoveLabel(ctx context.Context, issueID, labelID string) error {
+	ref, err := c.resolveIssue(issueID)
+	if err != nil {
+		return err
+	}
+	if _, err := c.do(ctx, http.MethodPut, issuePath(ref), nil, map[string]string{"remove_labels": labelID}, nil); err != nil {
+		return fmt.Errorf("failed to remove label: %w", err)
+	}
+	return nil
+}
+
+// UpdateIssueLabels replaces an issue's labels with exactly the named
+// labels. Labels the project doesn't have yet are created.
+func (c *Client) UpdateIssueLabels(ctx context.Context, issueID string, labelIDs []string) error {
+	ref, err := c.resolveIssue(issueID)
+	if err != nil {
+		return err
+	}
+	if _, err := c.do(ctx, http.MethodPut, issuePath(ref), nil, map[string]string{"labels": strings.Join(labelIDs, ",")}, nil); err != nil {
+		return fmt.Errorf("failed to update labels: %w", err)
+	}
+	return nil
+}
+
+// FindIssueLabel returns the name of the label on an issue matching name
+// case-insensitively, or "" if the issue doesn't have it
+func (c *Client) FindIssueLabel(ctx context.Context, issueID, name string) (string, error) {
+	issue, err := c.GetIssue(ctx, issueID)
+	if err != nil {
+		return "", err
+	}
+	for _, label := range issue.Labels {
+		if strings.EqualFold(label, name) {
+			return label, nil
+		}
+	}
+	return "", nil
+}
+
+// GetTeams returns the projects of the configured group, or the configured
+// project alone. Projects play the part of teams: issues belong to them.
+func (c *Client) GetTeams(ctx context.Context) ([]tracker.Team, error) {
+	var projects []GitLabProject
+	if c.Group != "" {
+		params := url.Values{}
+		params.Set("include_subgroups", "true")
+		params.Set("archived", "false")
+		var err error
+		if projects, err = getAll[GitLabProject](ctx, c, groupPath(c.Group)+"/projects", params); err != nil {
+			return nil, fmt.Errorf("failed to get projects: %w", err)
+		}
+	} else {
+		if c.project == "" {
+			return nil, ErrNoProject
+		}
+		var project GitLabProject
+		if _, err := c.do(ctx, http.MethodGet, projectPath(c.project), nil, nil, &project); err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+		projects = []GitLabProject{project}
+	}
+
+	teams := make([]tracker.Team, len(projects))
+	for i, p := range projects {
+		teams[i] = tracker.Team{
+			ID:   strconv.Itoa(p.ID),
+			Name: p.Name,
+			Key:  p.PathWithNamespace,
+		}
+	}
+	return teams, nil
+}
+
+// GetProjects returns the active milestones of a GitLab project (a team;
+// see GetTeams), which group issues the way projects do. An empty teamID
+// uses the configured project.
+func (c *Client) GetProjects(ctx context.Context, teamID string) ([]tracker.Project, error) {
+	if teamID == "" {
+		if c.project == "" {
+			return nil, ErrNoProject
+		}
+		teamID = c.project
+	}
+
+	params := url.Values{}
+	params.Set("state", "active")
+	milestones, err := getAll[GitLabMilestone](ctx, c, projectPath(teamID)+"/milestones", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestones: %w", err)
+	}
+
+	projects := make([]tracker.Project, len(milestones))
+	for i, m := range milestones {
+		projects[i] = tracker.Project{
+			ID:     strconv.Itoa(m.ID),
+			Name:   m.Title,
+			TeamID: teamID,
+		}
+	}
+	return projects, nil
+}
+
+// Helper functions
+
+// toTrackerIssue converts an issue from the API
+func (c *Client) toTrackerIssue(gi *GitLabIssue) *tracker.Issue {
+	issue := &tracker.Issue{
+		ID:          fmt.Sprintf("%d:%d", gi.ProjectID, gi.IID),
+		Identifier:  c.identifier(gi),
+		Title:       gi.Title,
+		Description: gi.Description,
+		Status:      issueStatus(gi.State, gi.Labels),
+		TeamID:      strconv.Itoa(gi.ProjectID),
+		Estimate:    gi.Weight,
+		CreatedAt:   gi.CreatedAt,
+		UpdatedAt:   gi.UpdatedAt,
+		URL:         gi.WebURL,
+	}
+	for _, label := range gi.Labels {
+		if !isStatusLabel(label) {
+			issue.Labels = append(issue.Labels, label)
+		}
+	}
+	if gi.Assignee != nil {
+		issue.Assignee = gi.Assignee.Username
+	}
+	if gi.Milestone != nil {
+		issue.ProjectID = strconv.Itoa(gi.Milestone.ID)
+	}
+	return issue
+}
+
+// identifier returns an issue's identifier: its IID prefixed by IssueKey in
+// the configured project, otherwise its full reference, or its ID if the API
+// didn't return one
+func (c *Client) identifier(gi *GitLabIssue) string {
+	if c.inProject(gi) {
+		return fmt.Sprintf("%s-%d", c.issueKey(), gi.IID)
+	}
+	if gi.References.Full != "" {
+		return gi.References.Full
+	}
+	return fmt.Sprintf("%d:%d", gi.ProjectID, gi.IID)
+}
+
+// inProject reports whether an issue belongs to the configured project. An
+// issue without a reference does unless a group widens the client's scope.
+func (c *Client) inProject(gi *GitLabIssue) bool {
+	if c.project == "" {
+		return false
+	}
+	if c.project == strconv.Itoa(gi.ProjectID) {
+		return true
+	}
+	if path, _, ok := strings.Cut(gi.References.Full, "#"); ok {
+		return strings.EqualFold(path, c.project)
+	}
+	return c.Group == ""
+}
+
+// toTrackerIssues converts issues from the API
+func (c *Client) toTrackerIssues(issues []GitLabIssue) []*tracker.Issue {
+	result := make([]*tracker.Issue, len(issues))
+	for i := range issues {
+		result[i] = c.toTrackerIssue(&issues[i])
+	}
+	return result
+}
+
+// toTrackerComment converts a note from the API. Notes are by the viewer if
+// the token's user, when it can be fetched, wrote them.
+func (c *Client) toTrackerComment(ctx context.Context, note *GitLabNote) *tracker.Comment {
+	comment := &tracker.Comment{
+		ID:        strconv.Itoa(note.ID),
+		Body:      note.Body,
+		Author:    note.Author.Name,
+		AuthorID:  strconv.Itoa(note.Author.ID),
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+	if comment.Author == "" {
+		comment.Author = note.Author.Username
+	}
+	if viewer, err := c.GetCurrentUser(ctx); err == nil {
+		comment.ByViewer = viewer.ID == comment.AuthorID
+	}
+	return comment
+}
+
+// issueStatus returns the status of an issue from its state and labels.
+// Closed issues are done unless labeled canceled; open issues without a
+// status label are todo.
+func issueStatus(state string, labels []string) tracker.Status {
+	var labeled tracker.Status
+	for _, label := range labels {
+		if isStatusLabel(label) {
+			labeled = normalizeStatus(label[len(statusLabelPrefix):])
+		}
+	}
+
+	if state == "closed" {
+		if labeled == tracker.StatusCanceled {
+			return tracker.StatusCanceled
+		}
+		return tracker.StatusDone
+	}
+	switch labeled {
+	case tracker.StatusBacklog, tracker.StatusInProgress, tracker.StatusInReview:
+		return labeled
+	}
+	return tracker.StatusTodo
+}
+
+// statusLabel returns the label holding a status, or "" for done, which
+// closing the issue expresses
+func statusLabel(status tracker.Status) string {
+	if status == "" || status == tracker.StatusDone {
+		return ""
+	}
+	return statusLabelPrefix + string(status)
+}
+
+// isStatusLabel reports whether a label holds an issue's status
+func isStatusLabel(label string) bool {
+	return strings.HasPrefix(strings.ToLower(label), statusLabelPrefix)
+}
+
+// normalizeStatus turns a status label's value, e.g. "In Review", into a
+// status
+func normalizeStatus(value string) tracker.Status {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return tracker.Status(strings.NewReplacer(" ", "_", "-", "_").Replace(value))
+}
+
+// isKnownStatus reports whether status is one of jig's statuses
+func isKnownStatus(status tracker.Status) bool {
+	for _, s := range allStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
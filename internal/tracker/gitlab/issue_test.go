@@ -0,0 +1,383 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// fakeGitLab is an in-memory GitLab serving the issue and note endpoints
+// of project 7 ("acme/api") for the client's tests
+type fakeGitLab struct {
+	mu       sync.Mutex
+	issues   map[int]*GitLabIssue
+	notes    map[int][]*GitLabNote
+	nextNote int
+	updates  []map[string]interface{}
+}
+
+func newFakeGitLab(t *testing.T) (*fakeGitLab, *Client) {
+	t.Helper()
+	f := &fakeGitLab{issues: map[int]*GitLabIssue{}, notes: map[int][]*GitLabNote{}, nextNote: 100}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient("test-token", server.URL, "acme/api")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return f, client
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("PRIVATE-TOKEN") != "test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"401 Unauthorized"}`))
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4")
+	if path == "/user" {
+		writeJSON(w, GitLabUser{ID: 1, Username: "jane", Name: "Jane Doe"})
+		return
+	}
+	// The project is addressed by path or by ID
+	path = strings.Replace(path, "/projects/7/", "/projects/acme%2Fapi/", 1)
+	rest, ok := strings.CutPrefix(path, "/projects/acme%2Fapi/issues")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if rest == "" && r.Method == http.MethodPost {
+		iid := len(f.issues) + 1
+		issue := &GitLabIssue{IID: iid, ID: 1000 + iid, ProjectID: 7, State: "opened", Title: body["title"].(string), WebURL: fmt.Sprintf("https://gitlab.example.com/acme/api/-/issues/%d", iid)}
+		issue.Description, _ = body["description"].(string)
+		if labels, _ := body["labels"].(string); labels != "" {
+			issue.Labels = strings.Split(labels, ",")
+		}
+		f.issues[iid] = issue
+		writeJSON(w, issue)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(rest, "/"), "/")
+	iid, _ := strconv.Atoi(parts[0])
+	issue := f.issues[iid]
+	if issue == nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"404 Not found"}`))
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, issue)
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		f.updates = append(f.updates, body)
+		applyIssueUpdate(issue, body)
+		writeJSON(w, issue)
+	case len(parts) == 2 && parts[1] == "notes" && r.Method == http.MethodGet:
+		writeJSON(w, f.notes[iid])
+	case len(parts) == 2 && parts[1] == "notes" && r.Method == http.MethodPost:
+		f.nextNote++
+		note := &GitLabNote{ID: f.nextNote, Body: body["body"].(string), CreatedAt: time.Now()}
+		note.Author.ID, note.Author.Name = 1, "Jane Doe"
+		f.notes[iid] = append(f.notes[iid], note)
+		writeJSON(w, note)
+	case len(parts) == 3 && parts[1] == "notes" && r.Method == http.MethodPut:
+		noteID, _ := strconv.Atoi(parts[2])
+		for _, note := range f.notes[iid] {
+			if note.ID == noteID {
+				note.Body = body["body"].(string)
+				writeJSON(w, note)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// applyIssueUpdate applies the fields of an issue update request the way
+// GitLab does
+func applyIssueUpdate(issue *GitLabIssue, body map[string]interface{}) {
+	if title, ok := body["title"].(string); ok {
+		issue.Title = title
+	}
+	if labels, ok := body["labels"].(string); ok {
+		issue.Labels = nil
+		if labels != "" {
+			issue.Labels = strings.Split(labels, ",")
+		}
+	}
+	if remove, ok := body["remove_labels"].(string); ok {
+		var kept []string
+		for _, label := range issue.Labels {
+			if !hasLabel(strings.Split(remove, ","), label) {
+				kept = append(kept, label)
+			}
+		}
+		issue.Labels = kept
+	}
+	if add, ok := body["add_labels"].(string); ok {
+		issue.Labels = append(issue.Labels, strings.Split(add, ",")...)
+	}
+	switch body["state_event"] {
+	case "close":
+		issue.State = "closed"
+	case "reopen":
+		issue.State = "opened"
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient("token", "", "acme/api")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.apiURL != "https://gitlab.com/api/v4" {
+		t.Errorf("expected gitlab.com by default, got %q", client.apiURL)
+	}
+
+	client, err = NewClient("token", "https://gitlab.example.com/", "acme/api")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.apiURL != "https://gitlab.example.com/api/v4" {
+		t.Errorf("expected the instance's API URL, got %q", client.apiURL)
+	}
+
+	for _, baseURL := range []string{"gitlab.example.com", "ftp://gitlab.example.com", "https://"} {
+		if _, err := NewClient("token", baseURL, "acme/api"); err == nil {
+			t.Errorf("NewClient(%q) expected an error", baseURL)
+		}
+	}
+}
+
+func TestCreateAndGetIssue(t *testing.T) {
+	f, client := newFakeGitLab(t)
+	ctx := context.Background()
+
+	created, err := client.CreateIssue(ctx, &tracker.Issue{
+		Title:       "Support SSO",
+		Description: "Let users sign in with SSO",
+		Labels:      []string{"auth"},
+		Status:      tracker.StatusInProgress,
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+	if created.ID != "7:1" || created.Identifier != "GL-1" {
+		t.Errorf("expected ID 7:1 and identifier GL-1, got %q and %q", created.ID, created.Identifier)
+	}
+	if created.Status != tracker.StatusInProgress {
+		t.Errorf("expected the status held in a label, got %q", created.Status)
+	}
+	if len(created.Labels) != 1 || created.Labels[0] != "auth" {
+		t.Errorf("expected the status label hidden from the labels, got %v", created.Labels)
+	}
+	if got := f.issues[1].Labels; len(got) != 2 || got[1] != "status::in_progress" {
+		t.Errorf("expected the issue created with a status label, got %v", got)
+	}
+
+	// An issue can be fetched by ID or by its number in the project
+	client.IssueKey = "API"
+	for _, id := range []string{"7:1", "API-1", "api-1", "#1", "1", "acme/api#1"} {
+		issue, err := client.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("GetIssue(%q) error = %v", id, err)
+		}
+		if issue.Identifier != "API-1" || issue.Title != "Support SSO" || issue.URL == "" {
+			t.Errorf("GetIssue(%q) = %+v", id, issue)
+		}
+	}
+
+	if _, err := client.GetIssue(ctx, "NUM-1"); err == nil {
+		t.Error("expected an error for another tracker's identifier")
+	}
+	if _, err := client.GetIssue(ctx, "API-9"); err == nil {
+		t.Error("expected an error for a missing issue")
+	}
+}
+
+func TestGroupIssueIdentifiers(t *testing.T) {
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4")
+		fetched = append(fetched, path)
+		switch path {
+		case "/groups/acme/issues":
+			issues := []GitLabIssue{{IID: 1, ProjectID: 7, Title: "In the project"}, {IID: 3, ProjectID: 8, Title: "Elsewhere"}}
+			issues[0].References.Full = "acme/api#1"
+			issues[1].References.Full = "acme/web#3"
+			writeJSON(w, issues)
+		case "/projects/acme%2Fweb/issues/3":
+			issue := GitLabIssue{IID: 3, ProjectID: 8, Title: "Elsewhere"}
+			issue.References.Full = "acme/web#3"
+			writeJSON(w, issue)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient("test-token", server.URL, "acme/api")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.Group = "acme"
+	ctx := context.Background()
+
+	issues, err := client.SearchIssues(ctx, "")
+	if err != nil {
+		t.Fatalf("SearchIssues() error = %v", err)
+	}
+	if len(issues) != 2 || issues[0].Identifier != "GL-1" || issues[1].Identifier != "acme/web#3" {
+		t.Fatalf("expected GL-1 and acme/web#3, got %+v", issues)
+	}
+
+	// An issue from another project resolves in its own project
+	issue, err := client.GetIssue(ctx, issues[1].Identifier)
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if issue.Title != "Elsewhere" || fetched[len(fetched)-1] != "/projects/acme%2Fweb/issues/3" {
+		t.Errorf("expected acme/web#3 fetched from its project, got %+v from %v", issue, fetched)
+	}
+
+	if _, err := client.GetIssue(ctx, "acme/web#x"); err == nil {
+		t.Error("expected an error for an invalid reference")
+	}
+}
+
+func TestComments(t *testing.T) {
+	f, client := newFakeGitLab(t)
+	ctx := context.Background()
+	if _, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Support SSO"}); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+
+	comment, err := client.AddComment(ctx, "GL-1", "First note")
+	if err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+	if !comment.ByViewer || comment.Author != "Jane Doe" {
+		t.Errorf("expected the note by the token's user, got %+v", comment)
+	}
+
+	if _, err := client.UpdateComment(ctx, "GL-1", comment.ID, "Edited note"); err != nil {
+		t.Fatalf("UpdateComment() error = %v", err)
+	}
+
+	// Notes GitLab writes itself are left out
+	system := &GitLabNote{ID: 1, Body: "changed the description", System: true}
+	f.notes[1] = append(f.notes[1], system)
+
+	comments, err := client.GetComments(ctx, "GL-1")
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "Edited note" {
+		t.Errorf("expected the edited note alone, got %+v", comments)
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	f, client := newFakeGitLab(t)
+	ctx := context.Background()
+	if _, err := client.CreateIssue(ctx, &tracker.Issue{Title: "Support SSO", Labels: []string{"auth"}}); err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+
+	tests := []struct {
+		status tracker.Status
+		state  string
+		labels []string
+	}{
+		{tracker.StatusInProgress, "opened", []string{"auth", "status::in_progress"}},
+		{tracker.StatusInReview, "opened", []string{"auth", "status::in_review"}},
+		{tracker.StatusDone, "closed", []string{"auth"}},
+		{tracker.StatusTodo, "opened", []string{"auth", "status::todo"}},
+		{tracker.StatusCanceled, "closed", []string{"auth", "status::canceled"}},
+	}
+	for _, tt := range tests {
+		if err := client.TransitionIssue(ctx, "GL-1", tt.status); err != nil {
+			t.Fatalf("TransitionIssue(%s) error = %v", tt.status, err)
+		}
+		issue := f.issues[1]
+		if issue.State != tt.state || strings.Join(issue.Labels, ",") != strings.Join(tt.labels, ",") {
+			t.Errorf("after moving to %s: state %q, labels %v; want %q, %v", tt.status, issue.State, issue.Labels, tt.state, tt.labels)
+		}
+
+		got, err := client.GetIssue(ctx, "GL-1")
+		if err != nil {
+			t.Fatalf("GetIssue() error = %v", err)
+		}
+		if got.Status != tt.status {
+			t.Errorf("expected status %s read back, got %s", tt.status, got.Status)
+		}
+	}
+
+	if err := client.TransitionIssue(ctx, "GL-1", "blocked"); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
+
+func TestIssueStatus(t *testing.T) {
+	tests := []struct {
+		state  string
+		labels []string
+		want   tracker.Status
+	}{
+		{"opened", nil, tracker.StatusTodo},
+		{"opened", []string{"Status::In Review"}, tracker.StatusInReview},
+		{"opened", []string{"status::in-progress"}, tracker.StatusInProgress},
+		{"opened", []string{"status::backlog"}, tracker.StatusBacklog},
+		{"opened", []string{"status::unknown"}, tracker.StatusTodo},
+		{"closed", nil, tracker.StatusDone},
+		{"closed", []string{"status::in_progress"}, tracker.StatusDone},
+		{"closed", []string{"status::canceled"}, tracker.StatusCanceled},
+	}
+	for _, tt := range tests {
+		if got := issueStatus(tt.state, tt.labels); got != tt.want {
+			t.Errorf("issueStatus(%q, %v) = %s, want %s", tt.state, tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	_, client := newFakeGitLab(t)
+	client.token = "wrong"
+
+	_, err := client.GetIssue(context.Background(), "GL-1")
+	if !IsAuthError(err) {
+		t.Fatalf("expected an auth error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "401 Unauthorized") {
+		t.Errorf("expected the API's message in the error, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// Ensure Client implements tracker.Tracker
+var _ tracker.Tracker = (*Client)(nil)
+
+// Ensure Client implements tracker.PlanSyncer
+var _ tracker.PlanSyncer = (*Client)(nil)
+
+// Ensure Client implements tracker.LabelFinder
+var _ tracker.LabelFinder = (*Client)(nil)
+
+const (
+	// planNoteHeader starts the note jig syncs a plan to
+	planNoteHeader = "## 📋 Implementation Plan"
+
+	// planNoteFooter ends the note jig syncs a plan to, identifying it on
+	// later syncs
+	planNoteFooter = "*This plan was synced by [jig](https://github.com/charleslr/jig)*"
+)
+
+// CreateIssueFromPlan creates a new issue in the configured project from a
+// plan that has no linked issue, described by the plan's problem statement
+// and proposed solution and labeled with the plan's labels. Returns the
+// created issue with its identifier (e.g., "GL-12").
+func (c *Client) CreateIssueFromPlan(ctx context.Context, p *plan.Plan) (*tracker.Issue, error) {
+	title := strings.TrimSpace(p.Title)
+	if title == "" {
+		return nil, fmt.Errorf("plan title is required")
+	}
+
+	return c.CreateIssue(ctx, &tracker.Issue{
+		Title:       title,
+		Description: planDescription(p),
+		Labels:      p.Labels,
+	})
+}
+
+// SyncPlanStatus moves a plan's linked issue to the status matching the
+// plan's status
+func (c *Client) SyncPlanStatus(ctx context.Context, p *plan.Plan) error {
+	if p.IssueID == "" {
+		return fmt.Errorf("plan has no linked issue")
+	}
+	return c.TransitionIssue(ctx, p.IssueID, tracker.StatusForPlan(p.Status))
+}
+
+// SyncPlanToIssue syncs a plan to its linked issue as a note, updating the
+// note of a previous sync in place, and adds labelName and the plan's labels
+// to the issue. Labels already on the issue are kept.
+func (c *Client) SyncPlanToIssue(ctx context.Context, p *plan.Plan, labelName string) error {
+	if p.IssueID == "" {
+		return fmt.Errorf("plan has no linked issue")
+	}
+
+	ref, err := c.resolveIssue(p.IssueID)
+	if err != nil {
+		return err
+	}
+	issue, err := c.getIssue(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", p.IssueID, err)
+	}
+
+	existing, err := c.GetPlanComment(ctx, p.IssueID)
+	if err != nil {
+		return err
+	}
+	body := formatPlanNote(p)
+	if existing != nil {
+		if _, err := c.UpdateComment(ctx, p.IssueID, existing.ID, body); err != nil {
+			return err
+		}
+	} else if _, err := c.AddComment(ctx, p.IssueID, body); err != nil {
+		return err
+	}
+
+	// Add the labels the issue is missing
+	var missing []string
+	for _, name := range append([]string{labelName}, p.Labels...) {
+		if name != "" && !hasLabel(issue.Labels, name) && !hasLabel(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if _, err := c.do(ctx, http.MethodPut, issuePath(ref), nil, map[string]string{"add_labels": strings.Join(missing, ",")}, nil); err != nil {
+		return fmt.Errorf("failed to add labels to issue: %w", err)
+	}
+	return nil
+}
+
+// GetPlanComment returns the note a plan was synced to on an issue, or nil
+// if the issue has none
+func (c *Client) GetPlanComment(ctx context.Context, issueID string) (*tracker.Comment, error) {
+	comments, err := c.GetComments(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, planNoteFooter) {
+			return comment, nil
+		}
+	}
+	return nil, nil
+}
+
+// planDescription returns the description of an issue created from a plan
+func planDescription(p *plan.Plan) string {
+	var sb strings.Builder
+	if p.ProblemStatement != "" {
+		sb.WriteString("## Problem Statement\n\n")
+		sb.WriteString(p.ProblemStatement)
+		sb.WriteString("\n\n")
+	}
+	if p.ProposedSolution != "" {
+		sb.WriteString("## Proposed Solution\n\n")
+		sb.WriteString(p.ProposedSolution)
+		sb.WriteString("\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// formatPlanNote formats a plan as the markdown note synced to its issue:
+// the plan's body without its title heading, which the issue already shows
+func formatPlanNote(p *plan.Plan) string {
+	body := p.Body()
+	if strings.HasPrefix(body, "# ") {
+		_, body, _ = strings.Cut(body, "\n")
+		body = strings.TrimSpace(body)
+	}
+	if body == "" {
+		body = planDescription(p)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(planNoteHeader + "\n\n")
+	fmt.Fprintf(&sb, "**Synced:** %s\n\n", time.Now().UTC().Format("2006-01-02 15:04 UTC"))
+	sb.WriteString("---\n\n")
+	sb.WriteString(body)
+	sb.WriteString("\n\n---\n\n")
+	sb.WriteString(planNoteFooter + "\n")
+	return sb.String()
+}
+
+// hasLabel reports whether labels has name, ignoring case as GitLab does
+func hasLabel(labels []string, name string) bool {
+	for _, label := range labels {
+		if strings.EqualFold(label, name) {
+			return true
+		}
+	}
+	return false
+}
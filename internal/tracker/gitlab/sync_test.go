@@ -0,0 +1,115 @@
+package gitlab
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+func TestCreateIssueFromPlan(t *testing.T) {
+	f, client := newFakeGitLab(t)
+	ctx := context.Background()
+
+	p := &plan.Plan{
+		Title:            "  Support SSO  ",
+		ProblemStatement: "Users can't sign in with SSO.",
+		ProposedSolution: "Add a SAML provider.",
+		Labels:           []string{"auth"},
+	}
+	issue, err := client.CreateIssueFromPlan(ctx, p)
+	if err != nil {
+		t.Fatalf("CreateIssueFromPlan() error = %v", err)
+	}
+	if issue.Identifier != "GL-1" || issue.Title != "Support SSO" {
+		t.Errorf("expected GL-1 titled from the plan, got %q and %q", issue.Identifier, issue.Title)
+	}
+
+	created := f.issues[1]
+	if !strings.Contains(created.Description, "Users can't sign in with SSO.") || !strings.Contains(created.Description, "Add a SAML provider.") {
+		t.Errorf("expected the problem and solution in the description, got %q", created.Description)
+	}
+	if len(created.Labels) != 1 || created.Labels[0] != "auth" {
+		t.Errorf("expected the plan's labels, got %v", created.Labels)
+	}
+
+	if _, err := client.CreateIssueFromPlan(ctx, &plan.Plan{Title: "  "}); err == nil {
+		t.Error("expected an error for a blank title")
+	}
+}
+
+func TestSyncPlanToIssue(t *testing.T) {
+	f, client := newFakeGitLab(t)
+	ctx := context.Background()
+	if _, err := client.CreateIssueFromPlan(ctx, &plan.Plan{Title: "Support SSO", Labels: []string{"auth"}}); err != nil {
+		t.Fatalf("CreateIssueFromPlan() error = %v", err)
+	}
+
+	p := &plan.Plan{
+		ID:         "PLAN-1",
+		Title:      "Support SSO",
+		IssueID:    "GL-1",
+		Labels:     []string{"auth", "backend"},
+		RawContent: "---\nid: PLAN-1\n---\n\n# Support SSO\n\n## Problem Statement\n\nUsers can't sign in with SSO.\n",
+	}
+	if err := client.SyncPlanToIssue(ctx, p, "jig-plan"); err != nil {
+		t.Fatalf("SyncPlanToIssue() error = %v", err)
+	}
+
+	notes := f.notes[1]
+	if len(notes) != 1 {
+		t.Fatalf("expected one note, got %d", len(notes))
+	}
+	body := notes[0].Body
+	if !strings.HasPrefix(body, planNoteHeader) || !strings.Contains(body, "## Problem Statement") || !strings.Contains(body, planNoteFooter) {
+		t.Errorf("expected the plan's body in the note, got:\n%s", body)
+	}
+	if strings.Contains(body, "# Support SSO") || strings.Contains(body, "id: PLAN-1") {
+		t.Errorf("expected the title and frontmatter left out of the note, got:\n%s", body)
+	}
+	if got := strings.Join(f.issues[1].Labels, ","); got != "auth,jig-plan,backend" {
+		t.Errorf("expected the missing labels added, got %q", got)
+	}
+
+	// Syncing again updates the note in place and leaves the labels alone
+	p.RawContent = strings.Replace(p.RawContent, "Users can't", "Nobody can", 1)
+	updates := len(f.updates)
+	if err := client.SyncPlanToIssue(ctx, p, "jig-plan"); err != nil {
+		t.Fatalf("SyncPlanToIssue() error = %v", err)
+	}
+	if len(f.notes[1]) != 1 || !strings.Contains(f.notes[1][0].Body, "Nobody can") {
+		t.Errorf("expected the note updated in place, got %+v", f.notes[1])
+	}
+	if len(f.updates) != updates {
+		t.Errorf("expected no label update, got %v", f.updates[updates:])
+	}
+
+	comment, err := client.GetPlanComment(ctx, "GL-1")
+	if err != nil || comment == nil || comment.ID != "101" {
+		t.Errorf("GetPlanComment() = %+v, %v, want note 101", comment, err)
+	}
+
+	if err := client.SyncPlanToIssue(ctx, &plan.Plan{Title: "Unlinked"}, "jig-plan"); err == nil {
+		t.Error("expected an error for a plan without an issue")
+	}
+}
+
+func TestSyncPlanStatus(t *testing.T) {
+	f, client := newFakeGitLab(t)
+	ctx := context.Background()
+	if _, err := client.CreateIssueFromPlan(ctx, &plan.Plan{Title: "Support SSO"}); err != nil {
+		t.Fatalf("CreateIssueFromPlan() error = %v", err)
+	}
+
+	if err := client.SyncPlanStatus(ctx, &plan.Plan{IssueID: "GL-1", Status: plan.StatusInProgress}); err != nil {
+		t.Fatalf("SyncPlanStatus() error = %v", err)
+	}
+	if got := strings.Join(f.issues[1].Labels, ","); got != "status::in_progress" {
+		t.Errorf("expected the in-progress status label, got %q", got)
+	}
+
+	if err := client.SyncPlanStatus(ctx, &plan.Plan{Status: plan.StatusDraft}); err == nil {
+		t.Error("expected an error for a plan without an issue")
+	}
+}
@@ -0,0 +1,67 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// LinearAttachment represents an issue attachment from the Linear API
+type LinearAttachment struct {
+	ID    string `json:"id"`
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// CreateAttachment attaches a link to an issue. An empty title uses the URL.
+// Linear keeps one attachment per URL and issue, so attaching the same URL
+// again updates the existing attachment instead of duplicating it.
+func (c *Client) CreateAttachment(ctx context.Context, issueID, url, title string) (*LinearAttachment, error) {
+	if title == "" {
+		title = url
+	}
+
+	query := `
+		mutation CreateAttachment($input: AttachmentCreateInput!) {
+			attachmentCreate(input: $input) {
+				success
+				attachment {
+					id
+					url
+					title
+				}
+			}
+		}
+	`
+
+	resp, err := c.execute(ctx, &GraphQLRequest{
+		Query: query,
+		Variables: map[string]interface{}{
+			"input": map[string]interface{}{
+				"issueId": issueID,
+				"url":     url,
+				"title":   title,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		AttachmentCreate struct {
+			Success    bool             `json:"success"`
+			Attachment LinearAttachment `json:"attachment"`
+		} `json:"attachmentCreate"`
+	}
+
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !result.AttachmentCreate.Success {
+		return nil, fmt.Errorf("failed to create attachment")
+	}
+
+	return &result.AttachmentCreate.Attachment, nil
+}
@@ -0,0 +1,86 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateAttachment(t *testing.T) {
+	t.Run("sends attachmentCreate with the issue, URL and title", func(t *testing.T) {
+		var requests []GraphQLRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			requests = append(requests, req)
+
+			input := req.Variables["input"].(map[string]interface{})
+			json.NewEncoder(w).Encode(GraphQLResponse{
+				Data: json.RawMessage(`{"attachmentCreate": {"success": true, "attachment": {"id": "attachment-1", "url": "` + input["url"].(string) + `", "title": "` + input["title"].(string) + `"}}}`),
+			})
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		links := []string{"https://example.com/design", "https://example.com/spec"}
+		for _, link := range links {
+			attachment, err := client.CreateAttachment(context.Background(), "issue-uuid", link, "")
+			if err != nil {
+				t.Fatalf("CreateAttachment failed: %v", err)
+			}
+			if attachment.URL != link || attachment.Title != link {
+				t.Errorf("unexpected attachment: %+v", attachment)
+			}
+		}
+
+		if len(requests) != len(links) {
+			t.Fatalf("expected one request per link, got %d", len(requests))
+		}
+		for i, req := range requests {
+			if !strings.Contains(req.Query, "attachmentCreate(input: $input)") {
+				t.Errorf("expected attachmentCreate mutation, got: %s", req.Query)
+			}
+			input := req.Variables["input"].(map[string]interface{})
+			if input["issueId"] != "issue-uuid" || input["url"] != links[i] || input["title"] != links[i] {
+				t.Errorf("unexpected input: %v", input)
+			}
+		}
+	})
+
+	t.Run("uses the given title", func(t *testing.T) {
+		var captured GraphQLRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&captured)
+			json.NewEncoder(w).Encode(GraphQLResponse{
+				Data: json.RawMessage(`{"attachmentCreate": {"success": true, "attachment": {"id": "attachment-1"}}}`),
+			})
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if _, err := client.CreateAttachment(context.Background(), "issue-uuid", "https://example.com/design", "Design doc"); err != nil {
+			t.Fatalf("CreateAttachment failed: %v", err)
+		}
+		input := captured.Variables["input"].(map[string]interface{})
+		if input["title"] != "Design doc" {
+			t.Errorf("expected title 'Design doc', got %v", input["title"])
+		}
+	})
+
+	t.Run("returns error when unsuccessful", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(GraphQLResponse{
+				Data: json.RawMessage(`{"attachmentCreate": {"success": false}}`),
+			})
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if _, err := client.CreateAttachment(context.Background(), "issue-uuid", "https://example.com/design", ""); err == nil {
+			t.Error("expected error for unsuccessful attachmentCreate")
+		}
+	})
+}
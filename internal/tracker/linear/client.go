@@ -6,32 +6,134 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/charleslr/jig/internal/logging"
+	"github.com/charleslr/jig/internal/tracker"
 )
 
 const (
 	linearAPIURL = "https://api.linear.app/graphql"
+
+	// defaultMaxRetries is the number of retries for rate-limited or failed requests
+	defaultMaxRetries = 3
+	// defaultRetryBaseDelay is the initial backoff delay, doubled on each attempt
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the delay between two attempts
+	maxRetryDelay = 30 * time.Second
 )
 
 // Client is a Linear API client
 type Client struct {
 	apiKey     string
+	apiURL     string
 	httpClient *http.Client
 	teamID     string
 	projectID  string
+
+	// MaxRetries is the number of times a request is retried when Linear
+	// responds with HTTP 429 or a 5xx status. Zero disables retries.
+	MaxRetries int
+
+	// RequestTimeout bounds each individual API request. Zero means requests
+	// are only bounded by the caller's context and the HTTP client timeout.
+	RequestTimeout time.Duration
+
+	// CreateMissingLabels makes plan sync create labels listed in a plan that
+	// don't exist in the team yet. When false, such labels are skipped.
+	CreateMissingLabels bool
+
+	// StatusMap maps workflow state names (lowercased) to jig statuses for
+	// teams whose state names don't follow the conventions statusMatches
+	// assumes. Mapped states are matched by name only; see ParseStatusMap.
+	StatusMap map[string]tracker.Status
+
+	// PlanOverflowMode controls where a plan too long for one comment
+	// continues: in further comments (the default) or the issue description
+	PlanOverflowMode PlanOverflowMode
+
+	// SyncToDescription makes plan sync also keep a summary of the plan in
+	// a jig-delimited region of the issue description
+	SyncToDescription bool
+
+	// IssueTemplate is the ID or name of a Linear issue template whose
+	// fields issues created from plans start from
+	IssueTemplate string
+
+	// PlanCommentTemplate frames plan comments instead of the built-in
+	// layout; see ValidatePlanCommentTemplate for its placeholders
+	PlanCommentTemplate string
+
+	retryBaseDelay time.Duration
+
+	// viewer caches the user the API key belongs to (see GetCurrentUser).
+	// It is shared with copies made by WithTarget.
+	viewer *viewerCache
+}
+
+// viewerCache holds the user the API key belongs to once it is fetched
+type viewerCache struct {
+	mu   sync.Mutex
+	user *tracker.User
 }
 
-// NewClient creates a new Linear client
+// NewClient creates a new Linear client for the public Linear API
 func NewClient(apiKey, teamID, projectID string) *Client {
+	// The default URL is always valid, so this cannot fail
+	client, _ := NewClientWithURL(apiKey, teamID, projectID, linearAPIURL)
+	return client
+}
+
+// NewClientWithURL creates a new Linear client that sends requests to apiURL,
+// e.g. a corporate proxy or GraphQL gateway. An empty apiURL uses the public
+// Linear API. Returns an error if apiURL is not an absolute http(s) URL.
+func NewClientWithURL(apiKey, teamID, projectID, apiURL string) (*Client, error) {
+	if apiURL == "" {
+		apiURL = linearAPIURL
+	}
+	if err := validateAPIURL(apiURL); err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		apiKey: apiKey,
+		apiURL: apiURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		teamID:    teamID,
-		projectID: projectID,
+		teamID:         teamID,
+		projectID:      projectID,
+		MaxRetries:     defaultMaxRetries,
+		retryBaseDelay: defaultRetryBaseDelay,
+		viewer:         &viewerCache{},
+	}, nil
+}
+
+// HasTeam returns true if the client is configured with a team to create
+// issues in
+func (c *Client) HasTeam() bool {
+	return c.teamID != ""
+}
+
+// validateAPIURL checks that an API URL is an absolute http or https URL
+func validateAPIURL(apiURL string) error {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return fmt.Errorf("invalid Linear API URL %q: %w", apiURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid Linear API URL %q: scheme must be http or https", apiURL)
 	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid Linear API URL %q: missing host", apiURL)
+	}
+	return nil
 }
 
 // GraphQLRequest represents a GraphQL request
@@ -60,16 +162,88 @@ type GraphQLErrorLocation struct {
 	Column int `json:"column"`
 }
 
-// execute sends a GraphQL request to Linear
+// execute sends a GraphQL request to Linear.
+// Requests that are rate limited (429) or hit a server error (5xx) are retried
+// up to MaxRetries times with exponential backoff, honoring Retry-After.
 func (c *Client) execute(ctx context.Context, req *GraphQLRequest) (*GraphQLResponse, error) {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", linearAPIURL, bytes.NewReader(body))
+	op := operationName(req.Query)
+
+	var respBody []byte
+	for attempt := 0; ; attempt++ {
+		logging.Debug("linear request", "operation", op, "attempt", attempt+1, "bytes", len(body))
+		start := time.Now()
+
+		var resp *http.Response
+		resp, respBody, err = c.doRequest(ctx, body)
+		if err != nil {
+			logging.Debug("linear request failed", "operation", op, "error", err)
+			return nil, err
+		}
+
+		logging.Debug("linear response", "operation", op, "status", resp.StatusCode, "bytes", len(respBody), "duration", time.Since(start).Round(time.Millisecond))
+
+		if resp.StatusCode == http.StatusOK {
+			break
+		}
+
+		if !isRetryableStatus(resp.StatusCode) || attempt >= c.MaxRetries {
+			return nil, parseErrorResponse(resp.StatusCode, respBody)
+		}
+
+		delay := c.retryDelay(attempt, resp.Header.Get("Retry-After"))
+		logging.Info("retrying linear request", "operation", op, "status", resp.StatusCode, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("request canceled while waiting to retry: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	var gqlResp GraphQLResponse
+	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		apiErr := newAPIError(http.StatusOK, gqlResp.Errors)
+		logging.Debug("linear graphql errors", "operation", op, "count", apiErr.Count, "code", apiErr.Code, "first", apiErr.Message)
+		return nil, apiErr
+	}
+
+	return &gqlResp, nil
+}
+
+// operationPattern matches the name of a GraphQL query or mutation
+var operationPattern = regexp.MustCompile(`\b(?:query|mutation)\s+(\w+)`)
+
+// operationName returns the name of the operation in a GraphQL document, for
+// logging. Anonymous operations are reported as "anonymous".
+func operationName(query string) string {
+	if m := operationPattern.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	return "anonymous"
+}
+
+// doRequest sends a single API request and reads the response body,
+// applying RequestTimeout if set
+func (c *Client) doRequest(ctx context.Context, body []byte) (*http.Response, []byte, error) {
+	if c.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RequestTimeout)
+		defer cancel()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.apiURL, bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -77,29 +251,58 @@ func (c *Client) execute(ctx context.Context, req *GraphQLRequest) (*GraphQLResp
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	return resp, respBody, nil
+}
+
+// isRetryableStatus returns true for rate limiting and server errors
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryDelay returns how long to wait before the next attempt.
+// A valid Retry-After header takes precedence over exponential backoff.
+func (c *Client) retryDelay(attempt int, retryAfter string) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter); ok {
+		return min(d, maxRetryDelay)
 	}
 
-	var gqlResp GraphQLResponse
-	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	backoff := c.retryBaseDelay << attempt
+	if backoff <= 0 || backoff > maxRetryDelay {
+		backoff = maxRetryDelay
 	}
 
-	if len(gqlResp.Errors) > 0 {
-		return nil, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+	// Add up to 50% jitter so concurrent clients don't retry in lockstep
+	if half := int64(backoff / 2); half > 0 {
+		backoff += time.Duration(rand.Int64N(half))
 	}
+	return min(backoff, maxRetryDelay)
+}
 
-	return &gqlResp, nil
+// parseRetryAfter parses a Retry-After header in either delay-seconds or HTTP-date form
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
 }
 
 // LinearIssue represents an issue from the Linear API
@@ -150,9 +353,16 @@ type LinearComment struct {
 	User      struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
+		IsMe bool   `json:"isMe"`
 	} `json:"user"`
 }
 
+// PageInfo is the pagination state of a Linear connection
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
 // LinearTeam represents a team from the Linear API
 type LinearTeam struct {
 	ID   string `json:"id"`
@@ -0,0 +1,440 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/logging"
+)
+
+func TestExecute_Retry(t *testing.T) {
+	t.Run("retries on 429 then succeeds", func(t *testing.T) {
+		callCount := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			if callCount <= 2 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error": "rate limited"}`))
+				return
+			}
+			response := GraphQLResponse{
+				Data: json.RawMessage(`{"teams": {"nodes": [{"id": "team-1", "name": "Engineering", "key": "ENG"}]}}`),
+			}
+			json.NewEncoder(w).Encode(response)
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.retryBaseDelay = time.Millisecond
+
+		teams, err := client.GetTeams(context.Background())
+		if err != nil {
+			t.Fatalf("GetTeams failed: %v", err)
+		}
+		if len(teams) != 1 || teams[0].Key != "ENG" {
+			t.Errorf("unexpected teams: %+v", teams)
+		}
+		if callCount != 3 {
+			t.Errorf("expected 3 API calls, got %d", callCount)
+		}
+	})
+
+	t.Run("retries on 5xx", func(t *testing.T) {
+		callCount := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			if callCount == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(`{"teams": {"nodes": []}}`)})
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.retryBaseDelay = time.Millisecond
+
+		if _, err := client.GetTeams(context.Background()); err != nil {
+			t.Fatalf("GetTeams failed: %v", err)
+		}
+		if callCount != 2 {
+			t.Errorf("expected 2 API calls, got %d", callCount)
+		}
+	})
+
+	t.Run("fails fast on non-retryable 4xx", func(t *testing.T) {
+		callCount := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`bad request`))
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.retryBaseDelay = time.Millisecond
+
+		_, err := client.GetTeams(context.Background())
+		if err == nil {
+			t.Fatal("expected error for 400 response")
+		}
+		if !strings.Contains(err.Error(), "status 400") {
+			t.Errorf("expected status 400 error, got: %v", err)
+		}
+		if callCount != 1 {
+			t.Errorf("expected 1 API call, got %d", callCount)
+		}
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		callCount := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			callCount++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.retryBaseDelay = time.Millisecond
+		client.MaxRetries = 2
+
+		_, err := client.GetTeams(context.Background())
+		if err == nil {
+			t.Fatal("expected error after exhausting retries")
+		}
+		if !strings.Contains(err.Error(), "status 429") {
+			t.Errorf("expected status 429 error, got: %v", err)
+		}
+		if callCount != 3 {
+			t.Errorf("expected 3 API calls (1 + 2 retries), got %d", callCount)
+		}
+	})
+
+	t.Run("stops waiting when context is canceled", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := client.GetTeams(ctx)
+		if err == nil {
+			t.Fatal("expected error when context is canceled")
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("expected retry wait to be interrupted, took %v", elapsed)
+		}
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	client := NewClient("test-key", "", "")
+
+	t.Run("honors Retry-After seconds", func(t *testing.T) {
+		if got := client.retryDelay(0, "2"); got != 2*time.Second {
+			t.Errorf("expected 2s, got %v", got)
+		}
+	})
+
+	t.Run("caps Retry-After at max delay", func(t *testing.T) {
+		if got := client.retryDelay(0, "3600"); got != maxRetryDelay {
+			t.Errorf("expected %v, got %v", maxRetryDelay, got)
+		}
+	})
+
+	t.Run("backs off exponentially with jitter", func(t *testing.T) {
+		for attempt := 0; attempt < 3; attempt++ {
+			base := defaultRetryBaseDelay << attempt
+			got := client.retryDelay(attempt, "")
+			if got < base || got >= base+base/2 {
+				t.Errorf("attempt %d: expected delay in [%v, %v), got %v", attempt, base, base+base/2, got)
+			}
+		}
+	})
+
+	t.Run("ignores invalid Retry-After", func(t *testing.T) {
+		got := client.retryDelay(0, "soon")
+		if got < defaultRetryBaseDelay {
+			t.Errorf("expected backoff delay, got %v", got)
+		}
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		wantOK bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "5", wantOK: true},
+		{name: "negative seconds", value: "-1", wantOK: false},
+		{name: "http date", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), wantOK: true},
+		{name: "garbage", value: "later", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := parseRetryAfter(tt.value)
+			if ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewClientWithURL(t *testing.T) {
+	t.Run("uses custom URL for requests", func(t *testing.T) {
+		var gotPath string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(`{"teams": {"nodes": []}}`)})
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithURL("test-key", "", "", server.URL+"/gateway/graphql")
+		if err != nil {
+			t.Fatalf("NewClientWithURL failed: %v", err)
+		}
+
+		if _, err := client.GetTeams(context.Background()); err != nil {
+			t.Fatalf("GetTeams failed: %v", err)
+		}
+		if gotPath != "/gateway/graphql" {
+			t.Errorf("expected request to /gateway/graphql, got %q", gotPath)
+		}
+	})
+
+	t.Run("empty URL uses default endpoint", func(t *testing.T) {
+		client, err := NewClientWithURL("test-key", "", "", "")
+		if err != nil {
+			t.Fatalf("NewClientWithURL failed: %v", err)
+		}
+		if client.apiURL != linearAPIURL {
+			t.Errorf("expected default URL, got %q", client.apiURL)
+		}
+	})
+
+	t.Run("NewClient uses default endpoint", func(t *testing.T) {
+		if client := NewClient("test-key", "", ""); client.apiURL != linearAPIURL {
+			t.Errorf("expected default URL, got %q", client.apiURL)
+		}
+	})
+
+	t.Run("rejects malformed URLs", func(t *testing.T) {
+		for _, apiURL := range []string{"api.example.com/graphql", "ftp://example.com/graphql", "https://", "http://[::1"} {
+			if _, err := NewClientWithURL("test-key", "", "", apiURL); err == nil {
+				t.Errorf("expected error for %q", apiURL)
+			} else if !strings.Contains(err.Error(), "invalid Linear API URL") {
+				t.Errorf("expected 'invalid Linear API URL' error for %q, got: %v", apiURL, err)
+			}
+		}
+	})
+}
+
+func TestExecute_RequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(`{"teams": {"nodes": []}}`)})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient(server.URL)
+	client.MaxRetries = 0
+	client.RequestTimeout = 20 * time.Millisecond
+
+	start := time.Now()
+	_, err := client.GetTeams(context.Background())
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected request to time out quickly, took %v", elapsed)
+	}
+}
+
+func TestExecute_DebugLogging(t *testing.T) {
+	const apiKey = "lin_api_s3cr3tKey"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != apiKey {
+			t.Errorf("expected API key to be sent, got %q", r.Header.Get("Authorization"))
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(`{"teams": {"nodes": []}}`)})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logging.Configure(&buf, slog.LevelDebug)
+	defer logging.Disable()
+
+	client := NewClient(apiKey, "", "")
+	client.httpClient = &http.Client{Transport: &testTransport{baseURL: server.URL}}
+
+	if _, err := client.GetTeams(context.Background()); err != nil {
+		t.Fatalf("GetTeams failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"linear request", "operation=GetTeams", "linear response", "status=200"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected debug log to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, apiKey) {
+		t.Errorf("debug log leaked the API key:\n%s", out)
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"query GetTeams { teams { nodes { id } } }", "GetTeams"},
+		{"\n\t\tmutation UpdateIssueState($id: String!) { issueUpdate(id: $id) { success } }", "UpdateIssueState"},
+		{"{ viewer { id } }", "anonymous"},
+	}
+	for _, tt := range tests {
+		if got := operationName(tt.query); got != tt.want {
+			t.Errorf("operationName(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestExecute_GraphQLErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		body           string
+		wantCode       string
+		wantMessage    string
+		wantAuth       bool
+		wantValidation bool
+		wantNotFound   bool
+	}{
+		{
+			name:        "authentication error",
+			status:      http.StatusOK,
+			body:        `{"errors": [{"message": "Authentication required, not authenticated", "extensions": {"code": "AUTHENTICATION_ERROR"}}]}`,
+			wantCode:    "AUTHENTICATION_ERROR",
+			wantMessage: "Authentication required, not authenticated",
+			wantAuth:    true,
+		},
+		{
+			name:           "validation error prefers the user presentable message",
+			status:         http.StatusOK,
+			body:           `{"errors": [{"message": "Argument Validation Error", "extensions": {"code": "INVALID_INPUT", "userPresentableMessage": "teamId must be a UUID"}}, {"message": "second"}]}`,
+			wantCode:       "INVALID_INPUT",
+			wantMessage:    "teamId must be a UUID",
+			wantValidation: true,
+		},
+		{
+			name:         "entity not found",
+			status:       http.StatusOK,
+			body:         `{"errors": [{"message": "Entity not found: Issue", "extensions": {"code": "ENTITY_NOT_FOUND"}}]}`,
+			wantCode:     "ENTITY_NOT_FOUND",
+			wantMessage:  "Entity not found: Issue",
+			wantNotFound: true,
+		},
+		{
+			name:        "graphql errors in a non-200 response",
+			status:      http.StatusBadRequest,
+			body:        `{"errors": [{"message": "Authentication failed", "extensions": {"code": "AUTHENTICATION_ERROR"}}]}`,
+			wantCode:    "AUTHENTICATION_ERROR",
+			wantMessage: "Authentication failed",
+			wantAuth:    true,
+		},
+		{
+			name:        "unauthorized status without graphql errors",
+			status:      http.StatusUnauthorized,
+			body:        `unauthorized`,
+			wantMessage: "unauthorized",
+			wantAuth:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := newTestClient(server.URL)
+			client.MaxRetries = 0
+
+			_, err := client.GetTeams(context.Background())
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", apiErr.Code, tt.wantCode)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if tt.wantCode != "" && !strings.Contains(err.Error(), tt.wantCode) {
+				t.Errorf("expected error %q to contain the code", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("expected error %q to contain the message", err)
+			}
+			if got := IsAuthError(err); got != tt.wantAuth {
+				t.Errorf("IsAuthError() = %v, want %v", got, tt.wantAuth)
+			}
+			if got := IsValidationError(err); got != tt.wantValidation {
+				t.Errorf("IsValidationError() = %v, want %v", got, tt.wantValidation)
+			}
+			if got := IsNotFoundError(err); got != tt.wantNotFound {
+				t.Errorf("IsNotFoundError() = %v, want %v", got, tt.wantNotFound)
+			}
+		})
+	}
+
+	t.Run("helpers see through wrapping", func(t *testing.T) {
+		err := fmt.Errorf("failed to create issue: %w", &APIError{Code: "AUTHENTICATION_ERROR", Message: "bad key"})
+		if !IsAuthError(err) {
+			t.Error("expected wrapped auth error to be detected")
+		}
+		if IsAuthError(errors.New("AUTHENTICATION_ERROR")) {
+			t.Error("expected plain errors not to be detected")
+		}
+	})
+
+	t.Run("counts additional errors", func(t *testing.T) {
+		err := &APIError{StatusCode: http.StatusOK, Code: "INVALID_INPUT", Message: "bad", Count: 3}
+		if want := "Linear API error (INVALID_INPUT): bad (and 2 more)"; err.Error() != want {
+			t.Errorf("Error() = %q, want %q", err.Error(), want)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package linear
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+// planCommentPlaceholders are the placeholders a plan comment template may
+// use, in the order they are documented
+var planCommentPlaceholders = []string{"title", "problem", "solution", "sections", "synced"}
+
+// placeholderPattern matches a template placeholder, e.g. "{title}"
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_]+)\}`)
+
+// ValidatePlanCommentTemplate checks that a plan comment template only uses
+// known placeholders: {title}, {problem}, {solution}, {sections} and
+// {synced}. An empty template is valid and selects the built-in format.
+//
+// Templates may put the problem and solution under headers of their own, but
+// a plan fetched back from the comment only recovers them from headers naming
+// "Problem Statement" and "Proposed Solution"; under other headers they come
+// back as ordinary sections.
+func ValidatePlanCommentTemplate(tmpl string) error {
+	var unknown []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(tmpl, -1) {
+		name := match[1]
+		if !slices.Contains(planCommentPlaceholders, name) && !slices.Contains(unknown, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown placeholder(s) {%s} (available: {%s})",
+			strings.Join(unknown, "}, {"), strings.Join(planCommentPlaceholders, "}, {"))
+	}
+	return nil
+}
+
+// renderPlanCommentTemplate formats a plan as a comment from a template:
+// {title}, {problem} and {solution} are the plan's fields, {sections} the
+// rest of its sections in order, and {synced} the sync timestamp line. The
+// comment always starts with the plan header, which jig finds its comment by.
+func renderPlanCommentTemplate(tmpl string, p *plan.Plan) string {
+	synced := fmt.Sprintf("%s %s", syncedLinePrefix, time.Now().UTC().Format("2006-01-02 15:04 UTC"))
+	replacer := strings.NewReplacer(
+		"{title}", p.Title,
+		"{problem}", p.ProblemStatement,
+		"{solution}", p.ProposedSolution,
+		"{sections}", strings.TrimRight(formatAdditionalSections(p), "\n"),
+		"{synced}", synced,
+	)
+	comment := strings.TrimSpace(replacer.Replace(tmpl))
+	if !strings.HasPrefix(comment, planCommentPrefix) {
+		comment = planCommentPrefix + "\n\n" + comment
+	}
+	return comment
+}
+
+// formatAdditionalSections formats the sections of a plan other than its
+// problem statement and proposed solution, as they appear in its raw content
+func formatAdditionalSections(p *plan.Plan) string {
+	var sb strings.Builder
+	for _, section := range splitPlanSections(p.RawContent) {
+		if isProblemSection(section.name) || isSolutionSection(section.name) {
+			continue
+		}
+		sb.WriteString(section.header)
+		sb.WriteString("\n\n")
+		if section.content != "" {
+			sb.WriteString(section.content)
+			sb.WriteString("\n\n")
+		}
+	}
+	return sb.String()
+}
+
+// planComments formats a plan as its comments and description overflow,
+// from PlanCommentTemplate if set. A templated plan too long for one comment
+// falls back to the built-in layout, which can be split.
+func (c *Client) planComments(p *plan.Plan) (comments []string, overflow string) {
+	if comment, ok := c.templatedPlanComment(p); ok {
+		return []string{comment}, ""
+	}
+	return planCommentParts(p, c.PlanOverflowMode, maxPlanCommentLength)
+}
+
+// templatedPlanComment formats a plan as a single comment from
+// PlanCommentTemplate. Returns false if no template is set or the plan is too
+// long for one comment.
+func (c *Client) templatedPlanComment(p *plan.Plan) (string, bool) {
+	if c.PlanCommentTemplate == "" {
+		return "", false
+	}
+	comment := renderPlanCommentTemplate(c.PlanCommentTemplate, p)
+	return comment, len(comment) <= maxPlanCommentLength
+}
+
+// PlanBodyContent is PlanBodyContent for the comment this client would sync:
+// laid out by PlanCommentTemplate when the plan fits in one templated comment
+func (c *Client) PlanBodyContent(p *plan.Plan) string {
+	if comment, ok := c.templatedPlanComment(p); ok {
+		return ConvertCommentToBodyContent(comment)
+	}
+	return PlanBodyContent(p)
+}
@@ -0,0 +1,150 @@
+package linear
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+func TestValidatePlanCommentTemplate(t *testing.T) {
+	valid := []string{
+		"",
+		"Plain text without placeholders",
+		"# {title}\n\n{problem}\n\n{solution}\n\n{sections}\n\n{synced}",
+	}
+	for _, tmpl := range valid {
+		if err := ValidatePlanCommentTemplate(tmpl); err != nil {
+			t.Errorf("ValidatePlanCommentTemplate(%q) error = %v", tmpl, err)
+		}
+	}
+
+	err := ValidatePlanCommentTemplate("{title} by {author} on {date} for {author}")
+	if err == nil {
+		t.Fatal("expected an error for unknown placeholders")
+	}
+	if !strings.Contains(err.Error(), "unknown placeholder(s) {author}, {date}") {
+		t.Errorf("expected the unknown placeholders listed once each, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "{title}, {problem}, {solution}, {sections}, {synced}") {
+		t.Errorf("expected the available placeholders listed, got %v", err)
+	}
+}
+
+func TestPlanComments_Template(t *testing.T) {
+	p := &plan.Plan{
+		ID:               "PLAN-1",
+		Title:            "Add retries",
+		ProblemStatement: "Requests fail.",
+		ProposedSolution: "Retry them.",
+		RawContent: "---\ntitle: Add retries\n---\n\n# Add retries\n\n## Problem Statement\n\nRequests fail.\n\n" +
+			"## Proposed Solution\n\nRetry them.\n\n## Acceptance Criteria\n\n- [ ] Retries happen\n",
+	}
+
+	t.Run("renders a custom template", func(t *testing.T) {
+		client := NewClient("test-api-key", "", "")
+		client.PlanCommentTemplate = "> Reviewed by the platform team before work starts.\n\n" +
+			"**{title}**\n\n### Why\n\n{problem}\n\n### How\n\n{solution}\n\n{sections}\n\n{synced}\n\n_Posted by the planning bot_"
+
+		comments, overflow := client.planComments(p)
+		if len(comments) != 1 || overflow != "" {
+			t.Fatalf("expected a single comment, got %d comments and overflow %q", len(comments), overflow)
+		}
+		got := comments[0]
+		if !strings.HasPrefix(got, planCommentPrefix+"\n\n> Reviewed by the platform team") {
+			t.Errorf("expected the plan header before the template, got:\n%s", got)
+		}
+		for _, want := range []string{
+			"**Add retries**",
+			"### Why\n\nRequests fail.",
+			"### How\n\nRetry them.",
+			"## Acceptance Criteria\n\n- [ ] Retries happen",
+			syncedLinePrefix + " ",
+			"_Posted by the planning bot_",
+		} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected the comment to contain %q, got:\n%s", want, got)
+			}
+		}
+		if strings.Contains(got, "## Problem Statement") || strings.Contains(got, planCommentFooter) {
+			t.Errorf("expected only the template's layout, got:\n%s", got)
+		}
+		// The sync timestamp can still be told apart from the content
+		if strings.Contains(StripSyncTimestamp(got), syncedLinePrefix) {
+			t.Errorf("expected the sync timestamp to be strippable, got:\n%s", StripSyncTimestamp(got))
+		}
+	})
+
+	t.Run("keeps a template's own plan header", func(t *testing.T) {
+		client := NewClient("test-api-key", "", "")
+		client.PlanCommentTemplate = planCommentPrefix + ": {title}\n\n{problem}"
+
+		comments, _ := client.planComments(p)
+		if want := planCommentPrefix + ": Add retries\n\nRequests fail."; comments[0] != want {
+			t.Errorf("planComments() = %q, want %q", comments[0], want)
+		}
+	})
+
+	t.Run("uses the built-in format when unset", func(t *testing.T) {
+		client := NewClient("test-api-key", "", "")
+
+		comments, overflow := client.planComments(p)
+		if len(comments) != 1 || overflow != "" {
+			t.Fatalf("expected a single comment, got %d comments and overflow %q", len(comments), overflow)
+		}
+		if StripSyncTimestamp(comments[0]) != StripSyncTimestamp(formatPlanComment(p)) {
+			t.Errorf("expected the built-in comment, got:\n%s", comments[0])
+		}
+	})
+
+	t.Run("falls back to the built-in format for plans too long for one comment", func(t *testing.T) {
+		client := NewClient("test-api-key", "", "")
+		client.PlanCommentTemplate = "{title}\n\n{sections}"
+
+		comments, _ := client.planComments(newLongPlan(200))
+		if len(comments) < 2 || !isPlanContinuation(comments[1]) {
+			t.Fatalf("expected the plan split over several comments, got %d", len(comments))
+		}
+	})
+}
+
+func TestPlanContentHash_Template(t *testing.T) {
+	p := &plan.Plan{ID: "PLAN-1", Title: "Add retries", ProblemStatement: "Requests fail."}
+	client := NewClient("test-api-key", "", "")
+
+	if got := client.PlanContentHash(p); got != ComputePlanContentHash(p) {
+		t.Errorf("expected the plain content hash without a template, got %q", got)
+	}
+
+	client.PlanCommentTemplate = "{title}\n\n{problem}"
+	withTemplate := client.PlanContentHash(p)
+	if withTemplate == ComputePlanContentHash(p) {
+		t.Error("expected the template to change the hash")
+	}
+
+	client.PlanCommentTemplate = "### Why\n\n{problem}"
+	if client.PlanContentHash(p) == withTemplate {
+		t.Error("expected a different template to change the hash")
+	}
+}
+
+func TestClientPlanBodyContent(t *testing.T) {
+	p := &plan.Plan{
+		ID:               "PLAN-1",
+		Title:            "Add retries",
+		ProblemStatement: "Requests fail.",
+		ProposedSolution: "Retry them.",
+	}
+	client := NewClient("test-api-key", "", "")
+
+	if got := client.PlanBodyContent(p); got != PlanBodyContent(p) {
+		t.Errorf("expected the built-in body content without a template, got:\n%s", got)
+	}
+
+	client.PlanCommentTemplate = "### Why\n\n{problem}\n\n### How\n\n{solution}\n\n{synced}"
+	comments, _ := client.planComments(p)
+	want := ConvertCommentToBodyContent(comments[0])
+	if got := client.PlanBodyContent(p); got != want {
+		t.Errorf("PlanBodyContent() = %q, want the synced comment's %q", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package linear
+
+import (
+	"strings"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+// planSummaryStart and planSummaryEnd delimit the part of an issue
+// description jig keeps in sync with a plan's summary. Everything outside
+// them is left as written. They are visible lines rather than HTML comments
+// so they survive Linear's editor.
+const (
+	planSummaryStart = "## 📋 Plan Summary"
+	planSummaryEnd   = "*This summary is kept in sync with the plan by [jig](https://github.com/charleslr/jig); edit the plan to change it.*"
+)
+
+// planDescriptionSummary returns the delimited plan summary for an issue
+// description: the plan's problem statement and proposed solution. Returns ""
+// if the plan has neither.
+func planDescriptionSummary(p *plan.Plan) string {
+	summary := strings.TrimRight(buildPlanDescription(p), "\n")
+	if summary == "" {
+		return ""
+	}
+	return planSummaryStart + "\n\n" + summary + "\n\n" + planSummaryEnd
+}
+
+// withDescriptionSummary returns an issue description with its plan summary
+// region replaced by summary, or removed if summary is empty. A description
+// without a region gets one added at the end, before any plan overflow
+// section. Text outside the region is never changed.
+func withDescriptionSummary(description, summary string) string {
+	start := strings.Index(description, planSummaryStart)
+	if start != -1 {
+		if end := strings.Index(description[start:], planSummaryEnd); end != -1 {
+			before := strings.TrimRight(description[:start], "\n")
+			after := strings.TrimLeft(description[start+end+len(planSummaryEnd):], "\n")
+			return joinDescriptionParts(before, summary, after)
+		}
+	}
+
+	if summary == "" {
+		return description
+	}
+	overflow := descriptionOverflow(description)
+	before := strings.TrimRight(strings.TrimSuffix(description, overflow), "\n")
+	return joinDescriptionParts(before, summary, overflow)
+}
+
+// joinDescriptionParts joins the non-empty parts of a description with blank lines
+func joinDescriptionParts(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "\n\n")
+}
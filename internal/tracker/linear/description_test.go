@@ -0,0 +1,144 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+func TestWithDescriptionSummary(t *testing.T) {
+	summary := planSummaryStart + "\n\n## Problem Statement\n\nNew.\n\n" + planSummaryEnd
+	oldRegion := planSummaryStart + "\n\n## Problem Statement\n\nOld.\n\n" + planSummaryEnd
+	overflow := planContinuationPrefix + ")\n\nMore."
+
+	tests := []struct {
+		name        string
+		description string
+		summary     string
+		want        string
+	}{
+		{"adds a region to an empty description", "", summary, summary},
+		{"adds a region after human text", "Written by a human.", summary, "Written by a human.\n\n" + summary},
+		{"adds a region before the plan overflow", "Intro.\n\n" + overflow, summary, "Intro.\n\n" + summary + "\n\n" + overflow},
+		{"replaces the region in place", "Before.\n\n" + oldRegion + "\n\nAfter.", summary, "Before.\n\n" + summary + "\n\nAfter."},
+		{"replaces a region edited by hand", "Before.\n\n" + planSummaryStart + "\n\nHand edit.\n\n" + planSummaryEnd, summary, "Before.\n\n" + summary},
+		{"removes the region", "Before.\n\n" + oldRegion + "\n\nAfter.", "", "Before.\n\nAfter."},
+		{"leaves a description without a region alone", "Just text.", "", "Just text."},
+		{"adds a region when the existing one has no end", "Text.\n\n" + planSummaryStart + "\n\nTruncated", summary, "Text.\n\n" + planSummaryStart + "\n\nTruncated\n\n" + summary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withDescriptionSummary(tt.description, tt.summary); got != tt.want {
+				t.Errorf("withDescriptionSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncPlanToIssue_SyncToDescription(t *testing.T) {
+	const humanText = "Reported by support.\n\nSteps to reproduce: log in twice."
+
+	// newDescriptionServer serves an issue with description and records the
+	// descriptions passed to issueUpdate
+	newDescriptionServer := func(t *testing.T, description string) (*httptest.Server, *[]string) {
+		var updates []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			var data string
+			switch {
+			case strings.Contains(req.Query, "GetIssueByIdentifier"):
+				issue, _ := json.Marshal(map[string]interface{}{
+					"id": "issue-1", "identifier": "NUM-41", "title": "Test", "description": description,
+					"team": map[string]string{"id": "team-1"}, "state": map[string]string{"type": "unstarted"},
+				})
+				data = `{"issues": {"nodes": [` + string(issue) + `]}}`
+			case strings.Contains(req.Query, "GetComments"):
+				data = `{"issue": {"comments": {"nodes": []}}}`
+			case strings.Contains(req.Query, "commentCreate"):
+				data = `{"commentCreate": {"success": true, "comment": {"id": "comment-1"}}}`
+			case strings.Contains(req.Query, "GetTeamLabels"):
+				data = `{"team": {"labels": {"nodes": [{"id": "label-plan", "name": "jig-plan"}]}}}`
+			case strings.Contains(req.Query, "GetIssueLabels"):
+				data = `{"issue": {"labels": {"nodes": [{"id": "label-plan"}]}}}`
+			case strings.Contains(req.Query, "issueUpdate"):
+				if d, ok := req.Variables["input"].(map[string]interface{})["description"].(string); ok {
+					updates = append(updates, d)
+				}
+				data = `{"issueUpdate": {"success": true}}`
+			default:
+				t.Fatalf("unexpected query: %s", req.Query)
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+		}))
+		return server, &updates
+	}
+
+	p := &plan.Plan{
+		ID:               "PLAN-123",
+		IssueID:          "NUM-41",
+		Title:            "Test Plan",
+		ProblemStatement: "Logins fail.",
+		ProposedSolution: "Retry them.",
+	}
+
+	t.Run("adds the summary and keeps the human text", func(t *testing.T) {
+		server, updates := newDescriptionServer(t, humanText)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.SyncToDescription = true
+		if err := client.SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if len(*updates) != 1 {
+			t.Fatalf("expected 1 description update, got %d", len(*updates))
+		}
+		got := (*updates)[0]
+		if !strings.HasPrefix(got, humanText+"\n\n"+planSummaryStart) || !strings.HasSuffix(got, planSummaryEnd) {
+			t.Errorf("expected the human text followed by the summary, got:\n%s", got)
+		}
+		for _, want := range []string{"Logins fail.", "Retry them."} {
+			if !strings.Contains(got, want) {
+				t.Errorf("expected the description to contain %q, got:\n%s", want, got)
+			}
+		}
+	})
+
+	t.Run("doesn't update a summary that is current", func(t *testing.T) {
+		server, updates := newDescriptionServer(t, humanText+"\n\n"+planDescriptionSummary(p))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.SyncToDescription = true
+		if err := client.SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if len(*updates) != 0 {
+			t.Errorf("expected no description update, got %q", *updates)
+		}
+	})
+
+	t.Run("leaves the description untouched when disabled", func(t *testing.T) {
+		server, updates := newDescriptionServer(t, humanText)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if len(*updates) != 0 {
+			t.Errorf("expected no description update, got %q", *updates)
+		}
+	})
+}
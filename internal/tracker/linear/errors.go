@@ -0,0 +1,122 @@
+package linear
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error codes Linear reports in a GraphQL error's extensions.code
+const (
+	codeAuthentication   = "AUTHENTICATION_ERROR"
+	codeForbidden        = "FORBIDDEN"
+	codeInvalidInput     = "INVALID_INPUT"
+	codeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	codeBadUserInput     = "BAD_USER_INPUT"
+	codeEntityNotFound   = "ENTITY_NOT_FOUND"
+	codeNotFound         = "NOT_FOUND"
+)
+
+// ErrNoTeam is returned when creating an issue with no team to create it in:
+// neither the issue nor the client's configuration names one
+var ErrNoTeam = errors.New("no Linear team to create the issue in: set linear.team_id or pass --team")
+
+// APIError is an error reported by the Linear API, either as GraphQL errors
+// in the response body or as a non-200 HTTP status
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Code is the extensions.code of the first GraphQL error, if any
+	Code string
+	// Message is the message of the first GraphQL error, or the response
+	// body if it held no GraphQL errors
+	Message string
+	// Count is the number of GraphQL errors in the response
+	Count int
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if e.Count > 1 {
+		msg = fmt.Sprintf("%s (and %d more)", msg, e.Count-1)
+	}
+	switch {
+	case e.Code != "":
+		return fmt.Sprintf("Linear API error (%s): %s", e.Code, msg)
+	case e.StatusCode != http.StatusOK:
+		return fmt.Sprintf("Linear API error (status %d): %s", e.StatusCode, msg)
+	default:
+		return "Linear API error: " + msg
+	}
+}
+
+// newAPIError builds an APIError from the GraphQL errors of a response
+func newAPIError(status int, errs []GraphQLError) *APIError {
+	first := errs[0]
+	apiErr := &APIError{
+		StatusCode: status,
+		Message:    first.Message,
+		Count:      len(errs),
+	}
+	if code, ok := first.Extensions["code"].(string); ok {
+		apiErr.Code = code
+	}
+	// Linear attaches a clearer message for end users to many errors
+	if msg, ok := first.Extensions["userPresentableMessage"].(string); ok && msg != "" {
+		apiErr.Message = msg
+	}
+	return apiErr
+}
+
+// parseErrorResponse builds an APIError for a non-200 response, using the
+// GraphQL errors in the body when it has any
+func parseErrorResponse(status int, body []byte) *APIError {
+	var gqlResp GraphQLResponse
+	if err := json.Unmarshal(body, &gqlResp); err == nil && len(gqlResp.Errors) > 0 {
+		return newAPIError(status, gqlResp.Errors)
+	}
+	return &APIError{StatusCode: status, Message: string(body)}
+}
+
+// IsAuthError reports whether err is a Linear API error caused by a missing,
+// invalid or insufficiently privileged API key
+func IsAuthError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case codeAuthentication, codeForbidden:
+		return true
+	case "":
+		return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// IsValidationError reports whether err is a Linear API error caused by an
+// invalid request, e.g. an unknown team ID
+func IsValidationError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case codeInvalidInput, codeValidationFailed, codeBadUserInput:
+		return true
+	case "":
+		return apiErr.StatusCode == http.StatusBadRequest
+	}
+	return false
+}
+
+// IsNotFoundError reports whether err is a Linear API error for an entity
+// that doesn't exist or isn't visible to the API key
+func IsNotFoundError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == codeEntityNotFound || apiErr.Code == codeNotFound
+}
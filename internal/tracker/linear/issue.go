@@ -4,44 +4,39 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/charleslr/jig/internal/plan"
 	"github.com/charleslr/jig/internal/tracker"
 )
 
 // Ensure Client implements tracker.Tracker
 var _ tracker.Tracker = (*Client)(nil)
 
+// Ensure Client implements tracker.IssueURLGetter
+var _ tracker.IssueURLGetter = (*Client)(nil)
+
+// Ensure Client implements tracker.IssueSearcher
+var _ tracker.IssueSearcher = (*Client)(nil)
+
+// Ensure Client implements tracker.IssueRelator
+var _ tracker.IssueRelator = (*Client)(nil)
+
 // CreateIssue creates a new issue in Linear
 func (c *Client) CreateIssue(ctx context.Context, issue *tracker.Issue) (*tracker.Issue, error) {
-	query := `
-		mutation CreateIssue($input: IssueCreateInput!) {
-			issueCreate(input: $input) {
-				success
-				issue {
-					id
-					identifier
-					title
-					description
-					priority
-					url
-					createdAt
-					updatedAt
-					state {
-						id
-						name
-						type
-					}
-					team {
-						id
-						key
-					}
-				}
-			}
-		}
-	`
+	input, err := c.issueCreateInput(issue)
+	if err != nil {
+		return nil, err
+	}
+	return c.createIssueWithInput(ctx, input)
+}
 
+// issueCreateInput builds the IssueCreateInput for an issue, in the client's
+// team and project unless the issue has its own team
+func (c *Client) issueCreateInput(issue *tracker.Issue) (map[string]interface{}, error) {
 	input := map[string]interface{}{
 		"title": issue.Title,
 	}
@@ -50,13 +45,16 @@ func (c *Client) CreateIssue(ctx context.Context, issue *tracker.Issue) (*tracke
 		input["description"] = issue.Description
 	}
 
+	// Linear requires a team; catch a missing one before it rejects the
+	// mutation with a less helpful error
 	teamID := issue.TeamID
 	if teamID == "" {
 		teamID = c.teamID
 	}
-	if teamID != "" {
-		input["teamId"] = teamID
+	if teamID == "" {
+		return nil, ErrNoTeam
 	}
+	input["teamId"] = teamID
 
 	if c.projectID != "" {
 		input["projectId"] = c.projectID
@@ -70,6 +68,42 @@ func (c *Client) CreateIssue(ctx context.Context, issue *tracker.Issue) (*tracke
 		input["parentId"] = issue.ParentID
 	}
 
+	if issue.Estimate != nil {
+		input["estimate"] = *issue.Estimate
+	}
+
+	return input, nil
+}
+
+// createIssueWithInput runs the issueCreate mutation with the given input
+func (c *Client) createIssueWithInput(ctx context.Context, input map[string]interface{}) (*tracker.Issue, error) {
+	query := `
+		mutation CreateIssue($input: IssueCreateInput!) {
+			issueCreate(input: $input) {
+				success
+				issue {
+					id
+					identifier
+					title
+					description
+					priority
+					url
+					createdAt
+					updatedAt
+					state {
+						id
+						name
+						type
+					}
+					team {
+						id
+						key
+					}
+				}
+			}
+		}
+	`
+
 	resp, err := c.execute(ctx, &GraphQLRequest{
 		Query: query,
 		Variables: map[string]interface{}{
@@ -154,6 +188,38 @@ func (c *Client) UpdateIssue(ctx context.Context, id string, updates *tracker.Is
 	return nil
 }
 
+// ParseIssueURL extracts the issue identifier from a Linear issue URL, such as
+// https://linear.app/acme/issue/ENG-123/fix-login. The title slug, query and
+// fragment are optional.
+func ParseIssueURL(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid issue URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("invalid issue URL %q: expected an http(s) URL", rawURL)
+	}
+	if host := strings.ToLower(u.Hostname()); host != "linear.app" && host != "www.linear.app" {
+		return "", fmt.Errorf("invalid issue URL %q: not a Linear URL", rawURL)
+	}
+
+	// The path is /<workspace>/issue/<identifier>[/<slug>]
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] == "" || parts[1] != "issue" || !plan.IsIssueKey(parts[2]) {
+		return "", fmt.Errorf("invalid issue URL %q: expected https://linear.app/<workspace>/issue/<ISSUE_ID>", rawURL)
+	}
+	return strings.ToUpper(parts[2]), nil
+}
+
+// GetIssueByURL retrieves the issue a Linear issue URL points to
+func (c *Client) GetIssueByURL(ctx context.Context, issueURL string) (*tracker.Issue, error) {
+	identifier, err := ParseIssueURL(issueURL)
+	if err != nil {
+		return nil, err
+	}
+	return c.getIssueByIdentifier(ctx, identifier)
+}
+
 // GetIssue retrieves an issue by ID or identifier
 func (c *Client) GetIssue(ctx context.Context, id string) (*tracker.Issue, error) {
 	// Try by identifier first (e.g., "ENG-123")
@@ -270,15 +336,9 @@ func (c *Client) getIssueByIdentifier(ctx context.Context, identifier string) (*
 		}
 	`
 
-	// Parse the identifier (e.g., "ENG-123" -> team key and number)
-	parts := strings.SplitN(identifier, "-", 2)
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid identifier format: %s", identifier)
-	}
-
-	issueNumber, err := strconv.Atoi(parts[1])
+	teamKey, issueNumber, err := parseIssueIdentifier(identifier)
 	if err != nil {
-		return nil, fmt.Errorf("invalid issue number in identifier %s: %w", identifier, err)
+		return nil, err
 	}
 
 	resp, err := c.execute(ctx, &GraphQLRequest{
@@ -287,7 +347,7 @@ func (c *Client) getIssueByIdentifier(ctx context.Context, identifier string) (*
 			"filter": map[string]interface{}{
 				"team": map[string]interface{}{
 					"key": map[string]interface{}{
-						"eq": parts[0],
+						"eq": teamKey,
 					},
 				},
 				"number": map[string]interface{}{
@@ -317,6 +377,35 @@ func (c *Client) getIssueByIdentifier(ctx context.Context, identifier string) (*
 	return linearIssueToTracker(&result.Issues.Nodes[0]), nil
 }
 
+// parseIssueIdentifier splits an issue identifier into its team key and
+// issue number, e.g. "ENG-123" into "ENG" and 123. The number follows the
+// last hyphen, so team keys containing hyphens ("WEB-API-42") are kept whole.
+func parseIssueIdentifier(identifier string) (string, int, error) {
+	i := strings.LastIndex(identifier, "-")
+	if i == -1 {
+		return "", 0, fmt.Errorf("invalid identifier format %q: expected TEAM-NUMBER, e.g. ENG-123", identifier)
+	}
+	teamKey, suffix := identifier[:i], identifier[i+1:]
+	if teamKey == "" || strings.HasPrefix(teamKey, "-") || strings.HasSuffix(teamKey, "-") {
+		return "", 0, fmt.Errorf("invalid team key in identifier %q", identifier)
+	}
+	if suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+		return "", 0, fmt.Errorf("invalid issue number in identifier %q: %q is not a number", identifier, suffix)
+	}
+	if !plan.IsIssueKey(identifier) {
+		return "", 0, fmt.Errorf("invalid team key in identifier %q", identifier)
+	}
+
+	number, err := strconv.Atoi(suffix)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid issue number in identifier %q: %w", identifier, err)
+	}
+	if number == 0 {
+		return "", 0, fmt.Errorf("invalid issue number in identifier %q: issue numbers start at 1", identifier)
+	}
+	return teamKey, number, nil
+}
+
 // resolveIssueID converts a human-readable identifier (e.g., "NUM-70") to an internal UUID.
 // If the input is already a UUID, it returns it unchanged.
 func (c *Client) resolveIssueID(ctx context.Context, id string) (string, error) {
@@ -334,8 +423,19 @@ func (c *Client) resolveIssueID(ctx context.Context, id string) (string, error)
 	return id, nil
 }
 
+// defaultSearchLimit is the number of issues a search returns by default
+const defaultSearchLimit = 50
+
 // SearchIssues searches for issues matching a query
 func (c *Client) SearchIssues(ctx context.Context, searchQuery string) ([]*tracker.Issue, error) {
+	return c.SearchIssuesWithOptions(ctx, tracker.SearchOptions{Query: searchQuery})
+}
+
+// SearchIssuesWithOptions searches for issues matching a query in the given
+// statuses and team. Statuses are matched by workflow state type in the
+// query, then by StatusMap and state name, as in-progress and in-review
+// states share a type.
+func (c *Client) SearchIssuesWithOptions(ctx context.Context, opts tracker.SearchOptions) ([]*tracker.Issue, error) {
 	query := `
 		query SearchIssues($filter: IssueFilter!, $first: Int) {
 			issues(filter: $filter, first: $first) {
@@ -366,34 +466,204 @@ func (c *Client) SearchIssues(ctx context.Context, searchQuery string) ([]*track
 		}
 	`
 
-	filter := map[string]interface{}{
-		"or": []map[string]interface{}{
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	resp, err := c.execute(ctx, &GraphQLRequest{
+		Query: query,
+		Variables: map[string]interface{}{
+			"filter": c.searchFilter(opts),
+			"first":  limit,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Issues struct {
+			Nodes []LinearIssue `json:"nodes"`
+		} `json:"issues"`
+	}
+
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	issues := make([]*tracker.Issue, 0, len(result.Issues.Nodes))
+	for _, node := range result.Issues.Nodes {
+		if len(opts.Statuses) > 0 && !slices.ContainsFunc(opts.Statuses, func(status tracker.Status) bool {
+			return c.stateMatches(LinearWorkflowState(node.State), status)
+		}) {
+			continue
+		}
+		issues = append(issues, linearIssueToTracker(&node))
+	}
+
+	return issues, nil
+}
+
+// searchFilter builds the Linear issue filter for a search: title or
+// description containing the query, a state type clause for the statuses,
+// and the team scope
+func (c *Client) searchFilter(opts tracker.SearchOptions) map[string]interface{} {
+	filter := map[string]interface{}{}
+
+	if opts.Query != "" {
+		filter["or"] = []map[string]interface{}{
 			{
 				"title": map[string]interface{}{
-					"containsIgnoreCase": searchQuery,
+					"containsIgnoreCase": opts.Query,
 				},
 			},
 			{
 				"description": map[string]interface{}{
-					"containsIgnoreCase": searchQuery,
+					"containsIgnoreCase": opts.Query,
 				},
 			},
-		},
+		}
 	}
 
-	if c.teamID != "" {
+	var stateTypes []string
+	for _, status := range opts.Statuses {
+		if stateType := statusStateType(status); stateType != "" && !slices.Contains(stateTypes, stateType) {
+			stateTypes = append(stateTypes, stateType)
+		}
+	}
+	if len(stateTypes) > 0 {
+		filter["state"] = map[string]interface{}{
+			"type": map[string]interface{}{
+				"in": stateTypes,
+			},
+		}
+	}
+
+	teamID := opts.TeamID
+	if teamID == "" {
+		teamID = c.teamID
+	}
+	if teamID != "" {
 		filter["team"] = map[string]interface{}{
 			"id": map[string]interface{}{
-				"eq": c.teamID,
+				"eq": teamID,
 			},
 		}
 	}
 
+	return filter
+}
+
+// statusStateType returns the Linear workflow state type of a status, or ""
+// for an unknown status
+func statusStateType(status tracker.Status) string {
+	switch status {
+	case tracker.StatusBacklog:
+		return "backlog"
+	case tracker.StatusTodo:
+		return "unstarted"
+	case tracker.StatusInProgress, tracker.StatusInReview:
+		return "started"
+	case tracker.StatusDone:
+		return "completed"
+	case tracker.StatusCanceled:
+		return "canceled"
+	default:
+		return ""
+	}
+}
+
+// GetCurrentUser returns the user the API key belongs to. The user is fetched
+// once and cached on the client.
+func (c *Client) GetCurrentUser(ctx context.Context) (*tracker.User, error) {
+	c.viewer.mu.Lock()
+	defer c.viewer.mu.Unlock()
+	if c.viewer.user != nil {
+		return c.viewer.user, nil
+	}
+
+	query := `
+		query GetViewer {
+			viewer {
+				id
+				name
+				email
+			}
+		}
+	`
+
+	resp, err := c.execute(ctx, &GraphQLRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Viewer struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"viewer"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if result.Viewer.ID == "" {
+		return nil, fmt.Errorf("Linear returned no current user")
+	}
+
+	c.viewer.user = &tracker.User{ID: result.Viewer.ID, Name: result.Viewer.Name, Email: result.Viewer.Email}
+	return c.viewer.user, nil
+}
+
+// ListAssignedToMe returns the open (not completed or canceled) issues assigned
+// to the user who owns the API key, most recently updated first
+func (c *Client) ListAssignedToMe(ctx context.Context) ([]*tracker.Issue, error) {
+	query := `
+		query ListAssignedToMe($filter: IssueFilter, $first: Int) {
+			viewer {
+				assignedIssues(filter: $filter, first: $first, orderBy: updatedAt) {
+					nodes {
+						id
+						identifier
+						title
+						description
+						priority
+						url
+						createdAt
+						updatedAt
+						state {
+							id
+							name
+							type
+						}
+						assignee {
+							id
+							name
+						}
+						team {
+							id
+							key
+						}
+					}
+				}
+			}
+		}
+	`
+
+	filter := map[string]interface{}{
+		"state": map[string]interface{}{
+			"type": map[string]interface{}{
+				"nin": []string{"completed", "canceled"},
+			},
+		},
+	}
+
 	resp, err := c.execute(ctx, &GraphQLRequest{
 		Query: query,
 		Variables: map[string]interface{}{
 			"filter": filter,
-			"first":  50,
+			"first":  100,
 		},
 	})
 	if err != nil {
@@ -401,17 +671,19 @@ func (c *Client) SearchIssues(ctx context.Context, searchQuery string) ([]*track
 	}
 
 	var result struct {
-		Issues struct {
-			Nodes []LinearIssue `json:"nodes"`
-		} `json:"issues"`
+		Viewer struct {
+			AssignedIssues struct {
+				Nodes []LinearIssue `json:"nodes"`
+			} `json:"assignedIssues"`
+		} `json:"viewer"`
 	}
 
 	if err := json.Unmarshal(resp.Data, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	issues := make([]*tracker.Issue, len(result.Issues.Nodes))
-	for i, node := range result.Issues.Nodes {
+	issues := make([]*tracker.Issue, len(result.Viewer.AssignedIssues.Nodes))
+	for i, node := range result.Viewer.AssignedIssues.Nodes {
 		issues[i] = linearIssueToTracker(&node)
 	}
 
@@ -630,21 +902,20 @@ func (c *Client) AddComment(ctx context.Context, issueID string, body string) (*
 	return linearCommentToTracker(&result.CommentCreate.Comment), nil
 }
 
-// GetComments retrieves all comments for an issue
-func (c *Client) GetComments(ctx context.Context, issueID string) ([]*tracker.Comment, error) {
+// UpdateComment replaces the body of an existing comment
+func (c *Client) UpdateComment(ctx context.Context, commentID string, body string) (*tracker.Comment, error) {
 	query := `
-		query GetComments($id: String!) {
-			issue(id: $id) {
-				comments {
-					nodes {
+		mutation UpdateComment($id: String!, $input: CommentUpdateInput!) {
+			commentUpdate(id: $id, input: $input) {
+				success
+				comment {
+					id
+					body
+					createdAt
+					updatedAt
+					user {
 						id
-						body
-						createdAt
-						updatedAt
-						user {
-							id
-							name
-						}
+						name
 					}
 				}
 			}
@@ -654,7 +925,10 @@ func (c *Client) GetComments(ctx context.Context, issueID string) ([]*tracker.Co
 	resp, err := c.execute(ctx, &GraphQLRequest{
 		Query: query,
 		Variables: map[string]interface{}{
-			"id": issueID,
+			"id": commentID,
+			"input": map[string]interface{}{
+				"body": body,
+			},
 		},
 	})
 	if err != nil {
@@ -662,20 +936,152 @@ func (c *Client) GetComments(ctx context.Context, issueID string) ([]*tracker.Co
 	}
 
 	var result struct {
-		Issue struct {
-			Comments struct {
-				Nodes []LinearComment `json:"nodes"`
-			} `json:"comments"`
-		} `json:"issue"`
+		CommentUpdate struct {
+			Success bool          `json:"success"`
+			Comment LinearComment `json:"comment"`
+		} `json:"commentUpdate"`
 	}
 
 	if err := json.Unmarshal(resp.Data, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	comments := make([]*tracker.Comment, len(result.Issue.Comments.Nodes))
-	for i, node := range result.Issue.Comments.Nodes {
-		comments[i] = linearCommentToTracker(&node)
+	if !result.CommentUpdate.Success {
+		return nil, fmt.Errorf("failed to update comment")
+	}
+
+	return linearCommentToTracker(&result.CommentUpdate.Comment), nil
+}
+
+// DeleteComment deletes a comment
+func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
+	query := `
+		mutation DeleteComment($id: String!) {
+			commentDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	resp, err := c.execute(ctx, &GraphQLRequest{
+		Query: query,
+		Variables: map[string]interface{}{
+			"id": commentID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		CommentDelete struct {
+			Success bool `json:"success"`
+		} `json:"commentDelete"`
+	}
+
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if !result.CommentDelete.Success {
+		return fmt.Errorf("failed to delete comment")
+	}
+
+	return nil
+}
+
+// commentsPageSize is the number of comments fetched per request
+const commentsPageSize = 100
+
+// CommentFilter narrows the comments returned by ListComments
+type CommentFilter struct {
+	// AuthorID only returns comments by the user with this ID
+	AuthorID string
+	// ByViewer only returns comments by the user who owns the API key, which
+	// is who authors the comments jig posts
+	ByViewer bool
+}
+
+// GetComments retrieves all comments for an issue
+func (c *Client) GetComments(ctx context.Context, issueID string) ([]*tracker.Comment, error) {
+	return c.ListComments(ctx, issueID, CommentFilter{})
+}
+
+// ListComments retrieves all comments for an issue matching filter, following
+// pagination cursors until every page has been fetched
+func (c *Client) ListComments(ctx context.Context, issueID string, filter CommentFilter) ([]*tracker.Comment, error) {
+	query := `
+		query GetComments($id: String!, $first: Int, $after: String, $filter: CommentFilter) {
+			issue(id: $id) {
+				comments(first: $first, after: $after, filter: $filter) {
+					nodes {
+						id
+						body
+						createdAt
+						updatedAt
+						user {
+							id
+							name
+							isMe
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    issueID,
+		"first": commentsPageSize,
+	}
+	userFilter := map[string]interface{}{}
+	if filter.AuthorID != "" {
+		userFilter["id"] = map[string]interface{}{"eq": filter.AuthorID}
+	}
+	if filter.ByViewer {
+		userFilter["isMe"] = map[string]interface{}{"eq": true}
+	}
+	if len(userFilter) > 0 {
+		variables["filter"] = map[string]interface{}{"user": userFilter}
+	}
+
+	var comments []*tracker.Comment
+	for {
+		resp, err := c.execute(ctx, &GraphQLRequest{
+			Query:     query,
+			Variables: variables,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Issue struct {
+				Comments struct {
+					Nodes    []LinearComment `json:"nodes"`
+					PageInfo PageInfo        `json:"pageInfo"`
+				} `json:"comments"`
+			} `json:"issue"`
+		}
+
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		for _, node := range result.Issue.Comments.Nodes {
+			comments = append(comments, linearCommentToTracker(&node))
+		}
+
+		// Stop on a cursor that doesn't advance rather than looping forever
+		page := result.Issue.Comments.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" || page.EndCursor == variables["after"] {
+			break
+		}
+		variables["after"] = page.EndCursor
 	}
 
 	return comments, nil
@@ -698,7 +1104,7 @@ func (c *Client) TransitionIssue(ctx context.Context, id string, status tracker.
 	// Find the matching state
 	var stateID string
 	for _, state := range states {
-		if statusMatches(state, status) {
+		if c.stateMatches(state, status) {
 			stateID = state.ID
 			break
 		}
@@ -864,50 +1270,89 @@ func (c *Client) GetTeams(ctx context.Context) ([]tracker.Team, error) {
 	return teams, nil
 }
 
+// projectsPageSize is the number of projects fetched per request
+const projectsPageSize = 100
+
+// ProjectFilter narrows the projects returned by ListProjects
+type ProjectFilter struct {
+	// ExcludeClosed leaves out completed and canceled projects
+	ExcludeClosed bool
+}
+
 // GetProjects retrieves all projects for a team
 func (c *Client) GetProjects(ctx context.Context, teamID string) ([]tracker.Project, error) {
+	return c.ListProjects(ctx, teamID, ProjectFilter{})
+}
+
+// ListProjects retrieves all projects for a team matching filter, following
+// pagination cursors until every page has been fetched
+func (c *Client) ListProjects(ctx context.Context, teamID string, filter ProjectFilter) ([]tracker.Project, error) {
 	query := `
-		query GetProjects($teamId: String!) {
+		query GetProjects($teamId: String!, $first: Int, $after: String, $filter: ProjectFilter) {
 			team(id: $teamId) {
-				projects {
+				projects(first: $first, after: $after, filter: $filter) {
 					nodes {
 						id
 						name
 					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 				}
 			}
 		}
 	`
 
-	resp, err := c.execute(ctx, &GraphQLRequest{
-		Query: query,
-		Variables: map[string]interface{}{
-			"teamId": teamID,
-		},
-	})
-	if err != nil {
-		return nil, err
+	variables := map[string]interface{}{
+		"teamId": teamID,
+		"first":  projectsPageSize,
 	}
-
-	var result struct {
-		Team struct {
-			Projects struct {
-				Nodes []LinearProject `json:"nodes"`
-			} `json:"projects"`
-		} `json:"team"`
+	if filter.ExcludeClosed {
+		variables["filter"] = map[string]interface{}{
+			"status": map[string]interface{}{
+				"type": map[string]interface{}{"nin": []string{"completed", "canceled"}},
+			},
+		}
 	}
 
-	if err := json.Unmarshal(resp.Data, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+	var projects []tracker.Project
+	for {
+		resp, err := c.execute(ctx, &GraphQLRequest{
+			Query:     query,
+			Variables: variables,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	projects := make([]tracker.Project, len(result.Team.Projects.Nodes))
-	for i, node := range result.Team.Projects.Nodes {
-		projects[i] = tracker.Project{
-			ID:     node.ID,
-			Name:   node.Name,
-			TeamID: teamID,
+		var result struct {
+			Team struct {
+				Projects struct {
+					Nodes    []LinearProject `json:"nodes"`
+					PageInfo PageInfo        `json:"pageInfo"`
+				} `json:"projects"`
+			} `json:"team"`
 		}
+
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		for _, node := range result.Team.Projects.Nodes {
+			projects = append(projects, tracker.Project{
+				ID:     node.ID,
+				Name:   node.Name,
+				TeamID: teamID,
+			})
+		}
+
+		// Stop on a cursor that doesn't advance rather than looping forever
+		page := result.Team.Projects.PageInfo
+		if !page.HasNextPage || page.EndCursor == "" || page.EndCursor == variables["after"] {
+			break
+		}
+		variables["after"] = page.EndCursor
 	}
 
 	return projects, nil
@@ -956,6 +1401,8 @@ func linearCommentToTracker(lc *LinearComment) *tracker.Comment {
 		ID:        lc.ID,
 		Body:      lc.Body,
 		Author:    lc.User.Name,
+		AuthorID:  lc.User.ID,
+		ByViewer:  lc.User.IsMe,
 		CreatedAt: lc.CreatedAt,
 		UpdatedAt: lc.UpdatedAt,
 	}
@@ -978,6 +1425,48 @@ func linearStateToStatus(stateType string) tracker.Status {
 	}
 }
 
+// ParseStatusMap validates a mapping of workflow state names to jig status
+// names and returns it keyed by lowercased state name, for use as
+// Client.StatusMap
+func ParseStatusMap(m map[string]string) (map[string]tracker.Status, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	valid := []tracker.Status{
+		tracker.StatusBacklog,
+		tracker.StatusTodo,
+		tracker.StatusInProgress,
+		tracker.StatusInReview,
+		tracker.StatusDone,
+		tracker.StatusCanceled,
+	}
+
+	statusMap := make(map[string]tracker.Status, len(m))
+	for name, value := range m {
+		status := tracker.Status(value)
+		if !slices.Contains(valid, status) {
+			names := make([]string, len(valid))
+			for i, s := range valid {
+				names[i] = string(s)
+			}
+			return nil, fmt.Errorf("invalid status %q for workflow state %q (must be one of: %s)", value, name, strings.Join(names, ", "))
+		}
+		statusMap[strings.ToLower(name)] = status
+	}
+	return statusMap, nil
+}
+
+// stateMatches reports whether a workflow state corresponds to status. States
+// named in StatusMap match only their mapped status; all others fall back to
+// the statusMatches heuristic.
+func (c *Client) stateMatches(state LinearWorkflowState, status tracker.Status) bool {
+	if mapped, ok := c.StatusMap[strings.ToLower(state.Name)]; ok {
+		return mapped == status
+	}
+	return statusMatches(state, status)
+}
+
 func statusMatches(state LinearWorkflowState, status tracker.Status) bool {
 	switch status {
 	case tracker.StatusBacklog:
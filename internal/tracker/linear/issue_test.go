@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/charleslr/jig/internal/tracker"
@@ -89,6 +90,48 @@ func TestGetIssueByIdentifier_InvalidFormat(t *testing.T) {
 	}
 }
 
+func TestParseIssueIdentifier(t *testing.T) {
+	valid := []struct {
+		identifier string
+		teamKey    string
+		number     int
+	}{
+		{"ENG-123", "ENG", 123},
+		{"WEB-API-42", "WEB-API", 42},
+		{"A-B-C-7", "A-B-C", 7},
+		{"ENG2-05", "ENG2", 5},
+	}
+	for _, tt := range valid {
+		teamKey, number, err := parseIssueIdentifier(tt.identifier)
+		if err != nil || teamKey != tt.teamKey || number != tt.number {
+			t.Errorf("parseIssueIdentifier(%q) = %q, %d, %v; want %q, %d", tt.identifier, teamKey, number, err, tt.teamKey, tt.number)
+		}
+	}
+
+	invalid := []struct {
+		identifier string
+		wantErr    string
+	}{
+		{"ENG", "invalid identifier format"},
+		{"-123", "invalid team key"},
+		{"ENG--123", "invalid team key"},
+		{"2FA-1", "invalid team key"},
+		{"WEB_API-1", "invalid team key"},
+		{"ENG-", "invalid issue number"},
+		{"ENG-12a", "invalid issue number"},
+		{"ENG-+12", "invalid issue number"},
+		{"ENG- 12", "invalid issue number"},
+		{"ENG-0", "issue numbers start at 1"},
+		{"ENG-99999999999999999999", "invalid issue number"},
+	}
+	for _, tt := range invalid {
+		_, _, err := parseIssueIdentifier(tt.identifier)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("parseIssueIdentifier(%q) error = %v, want %q", tt.identifier, err, tt.wantErr)
+		}
+	}
+}
+
 func TestGetIssueByIdentifier_VariousFormats(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -120,6 +163,12 @@ func TestGetIssueByIdentifier_VariousFormats(t *testing.T) {
 			expectedTeam:   "eng",
 			expectedNumber: 42,
 		},
+		{
+			name:           "hyphenated team key",
+			identifier:     "WEB-API-42",
+			expectedTeam:   "WEB-API",
+			expectedNumber: 42,
+		},
 	}
 
 	for _, tt := range tests {
@@ -223,6 +272,74 @@ func containsAt(s, substr string, start int) bool {
 // TestStatusMatches_InProgressExcludesReview verifies that StatusInProgress does not match
 // states with "review" in the name. This is a regression test for a bug where "In Review"
 // states (type: "started", name contains "review") would incorrectly match StatusInProgress.
+func TestParseIssueURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "with title slug",
+			url:  "https://linear.app/acme/issue/NUM-41/fix-login-redirect",
+			want: "NUM-41",
+		},
+		{
+			name: "without title slug",
+			url:  "https://linear.app/acme/issue/NUM-41",
+			want: "NUM-41",
+		},
+		{
+			name: "trailing slash, query and fragment",
+			url:  "https://www.linear.app/acme/issue/num-41/?foo=bar#comment-1234",
+			want: "NUM-41",
+		},
+		{
+			name: "hyphenated team key",
+			url:  "https://linear.app/acme/issue/WEB-API-42/fix-login-redirect",
+			want: "WEB-API-42",
+		},
+		{
+			name:    "not a Linear URL",
+			url:     "https://github.com/acme/repo/issues/41",
+			wantErr: "not a Linear URL",
+		},
+		{
+			name:    "Linear URL that isn't an issue",
+			url:     "https://linear.app/acme/project/roadmap-123",
+			wantErr: "expected https://linear.app/<workspace>/issue/<ISSUE_ID>",
+		},
+		{
+			name:    "malformed identifier",
+			url:     "https://linear.app/acme/issue/not-an-id",
+			wantErr: "expected https://linear.app/<workspace>/issue/<ISSUE_ID>",
+		},
+		{
+			name:    "not a URL",
+			url:     "NUM-41",
+			wantErr: "expected an http(s) URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIssueURL(tt.url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseIssueURL(%q) error = %v, want it to contain %q", tt.url, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseIssueURL(%q) error = %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseIssueURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestStatusMatches_InProgressExcludesReview(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -302,6 +419,68 @@ func TestStatusMatches_InProgressExcludesReview(t *testing.T) {
 	}
 }
 
+// TestStateMatches_StatusMap verifies that a configured status map takes
+// precedence over the name-based heuristic, which still applies to states the
+// map doesn't mention.
+func TestStateMatches_StatusMap(t *testing.T) {
+	client := newTestClient("http://unused")
+	client.StatusMap = map[string]tracker.Status{
+		"pr open":         tracker.StatusInReview,
+		"awaiting review": tracker.StatusInProgress,
+	}
+
+	prOpen := LinearWorkflowState{ID: "1", Name: "PR Open", Type: "started"}
+	awaitingReview := LinearWorkflowState{ID: "2", Name: "Awaiting Review", Type: "started"}
+	inProgress := LinearWorkflowState{ID: "3", Name: "In Progress", Type: "started"}
+
+	tests := []struct {
+		name   string
+		state  LinearWorkflowState
+		status tracker.Status
+		want   bool
+	}{
+		{"mapped state matches its status", prOpen, tracker.StatusInReview, true},
+		{"mapped state does not match heuristic status", prOpen, tracker.StatusInProgress, false},
+		{"mapping overrides review in name", awaitingReview, tracker.StatusInProgress, true},
+		{"mapping overrides review in name for in_review", awaitingReview, tracker.StatusInReview, false},
+		{"unmapped state falls back to heuristic", inProgress, tracker.StatusInProgress, true},
+		{"unmapped state does not match in_review", inProgress, tracker.StatusInReview, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.stateMatches(tt.state, tt.status); got != tt.want {
+				t.Errorf("stateMatches(%q, %v) = %v, want %v", tt.state.Name, tt.status, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("no map uses heuristic", func(t *testing.T) {
+		client := newTestClient("http://unused")
+		if client.stateMatches(prOpen, tracker.StatusInReview) {
+			t.Error("expected PR Open not to match in_review without a status map")
+		}
+		if !client.stateMatches(prOpen, tracker.StatusInProgress) {
+			t.Error("expected PR Open to match in_progress without a status map")
+		}
+	})
+}
+
+func TestParseStatusMap(t *testing.T) {
+	statusMap, err := ParseStatusMap(map[string]string{"PR Open": "in_review", "QA": "in_progress"})
+	if err != nil {
+		t.Fatalf("ParseStatusMap() error = %v", err)
+	}
+	if statusMap["pr open"] != tracker.StatusInReview || statusMap["qa"] != tracker.StatusInProgress {
+		t.Errorf("ParseStatusMap() = %v", statusMap)
+	}
+
+	_, err = ParseStatusMap(map[string]string{"PR Open": "review"})
+	if err == nil || !strings.Contains(err.Error(), `invalid status "review" for workflow state "PR Open"`) {
+		t.Errorf("expected invalid status error, got %v", err)
+	}
+}
+
 // TestResolveIssueID_Identifier verifies that human-readable identifiers
 // (e.g., "NUM-70") are resolved to internal UUIDs via getIssueByIdentifier.
 func TestResolveIssueID_Identifier(t *testing.T) {
@@ -896,3 +1075,442 @@ func TestGetAvailableStatuses_DuplicateDetection(t *testing.T) {
 		t.Errorf("expected StatusInProgress to appear once after deduplication, appeared %d times", statusCount[tracker.StatusInProgress])
 	}
 }
+
+func TestListAssignedToMe(t *testing.T) {
+	var capturedRequest GraphQLRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&capturedRequest); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		response := GraphQLResponse{
+			Data: json.RawMessage(`{
+				"viewer": {
+					"assignedIssues": {
+						"nodes": [
+							{
+								"id": "issue-1",
+								"identifier": "NUM-1",
+								"title": "First",
+								"state": {"id": "state-1", "name": "In Progress", "type": "started"},
+								"assignee": {"id": "user-1", "name": "Tester"}
+							},
+							{
+								"id": "issue-2",
+								"identifier": "NUM-2",
+								"title": "Second",
+								"state": {"id": "state-2", "name": "Backlog", "type": "backlog"}
+							}
+						]
+					}
+				}
+			}`),
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	issues, err := client.ListAssignedToMe(context.Background())
+	if err != nil {
+		t.Fatalf("ListAssignedToMe failed: %v", err)
+	}
+
+	// The query goes through the viewer's assigned issues, not a team-wide search
+	if !strings.Contains(capturedRequest.Query, "viewer") || !strings.Contains(capturedRequest.Query, "assignedIssues") {
+		t.Errorf("expected a viewer.assignedIssues query, got: %s", capturedRequest.Query)
+	}
+
+	// Completed and canceled issues are filtered out server-side
+	filter, ok := capturedRequest.Variables["filter"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected filter to be a map, got %T", capturedRequest.Variables["filter"])
+	}
+	stateType, _ := filter["state"].(map[string]interface{})["type"].(map[string]interface{})
+	nin, _ := stateType["nin"].([]interface{})
+	if len(nin) != 2 || nin[0] != "completed" || nin[1] != "canceled" {
+		t.Errorf("expected state.type.nin [completed canceled], got %v", filter["state"])
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Identifier != "NUM-1" || issues[0].Status != tracker.StatusInProgress || issues[0].Assignee != "Tester" {
+		t.Errorf("unexpected first issue: %+v", issues[0])
+	}
+	if issues[1].Identifier != "NUM-2" || issues[1].Status != tracker.StatusBacklog {
+		t.Errorf("unexpected second issue: %+v", issues[1])
+	}
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	requests := 0
+	var capturedRequest GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := json.NewDecoder(r.Body).Decode(&capturedRequest); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{
+			Data: json.RawMessage(`{"viewer": {"id": "user-1", "name": "Jig Bot", "email": "bot@example.com"}}`),
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser failed: %v", err)
+	}
+	if !strings.Contains(capturedRequest.Query, "viewer") {
+		t.Errorf("expected a viewer query, got: %s", capturedRequest.Query)
+	}
+	if user.ID != "user-1" || user.Name != "Jig Bot" || user.Email != "bot@example.com" {
+		t.Errorf("GetCurrentUser() = %+v, want the viewer", user)
+	}
+
+	// The user is cached, including on copies made for another target
+	scoped, err := client.WithTarget(context.Background(), IssueTarget{Template: "Bug"})
+	if err != nil {
+		t.Fatalf("WithTarget failed: %v", err)
+	}
+	for _, c := range []*Client{client, scoped} {
+		if again, err := c.GetCurrentUser(context.Background()); err != nil || again != user {
+			t.Errorf("GetCurrentUser() = %+v, %v; want the cached user", again, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestListComments_Pagination(t *testing.T) {
+	type comment struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	newComment := func(id, userID string) comment {
+		c := comment{ID: id, Body: "body of " + id}
+		c.User.ID = userID
+		return c
+	}
+	all := []comment{
+		newComment("c1", "user-jig"),
+		newComment("c2", "user-human"),
+		newComment("c3", "user-jig"),
+		newComment("c4", "user-human"),
+	}
+
+	var requests []GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		// Apply the author filter the way Linear would
+		matching := all
+		filter, _ := req.Variables["filter"].(map[string]interface{})
+		user, _ := filter["user"].(map[string]interface{})
+		if id, ok := user["id"].(map[string]interface{}); ok {
+			authorID := id["eq"].(string)
+			matching = nil
+			for _, c := range all {
+				if c.User.ID == authorID {
+					matching = append(matching, c)
+				}
+			}
+		}
+
+		// Serve two comments per page, using the last comment's ID as cursor
+		start := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			for i, c := range matching {
+				if c.ID == after {
+					start = i + 1
+				}
+			}
+		}
+		end := min(start+2, len(matching))
+		page := matching[start:end]
+
+		nodes, _ := json.Marshal(page)
+		pageInfo, _ := json.Marshal(PageInfo{HasNextPage: end < len(matching), EndCursor: page[len(page)-1].ID})
+		data := `{"issue": {"comments": {"nodes": ` + string(nodes) + `, "pageInfo": ` + string(pageInfo) + `}}}`
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	t.Run("follows cursors to fetch every page", func(t *testing.T) {
+		requests = nil
+		comments, err := client.GetComments(context.Background(), "issue-1")
+		if err != nil {
+			t.Fatalf("GetComments failed: %v", err)
+		}
+
+		if len(requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(requests))
+		}
+		var ids []string
+		for _, c := range comments {
+			ids = append(ids, c.ID)
+		}
+		if strings.Join(ids, ",") != "c1,c2,c3,c4" {
+			t.Errorf("expected comments c1,c2,c3,c4, got %v", ids)
+		}
+		if _, ok := requests[0].Variables["filter"]; ok {
+			t.Errorf("expected no filter, got %v", requests[0].Variables["filter"])
+		}
+	})
+
+	t.Run("filters by author", func(t *testing.T) {
+		requests = nil
+		comments, err := client.ListComments(context.Background(), "issue-1", CommentFilter{AuthorID: "user-jig"})
+		if err != nil {
+			t.Fatalf("ListComments failed: %v", err)
+		}
+
+		if len(comments) != 2 || comments[0].ID != "c1" || comments[1].ID != "c3" {
+			t.Errorf("expected comments c1 and c3, got %+v", comments)
+		}
+		if len(requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(requests))
+		}
+	})
+
+	t.Run("filters by viewer", func(t *testing.T) {
+		requests = nil
+
+		// The test server doesn't evaluate isMe, so only check what is sent
+		if _, err := client.ListComments(context.Background(), "issue-1", CommentFilter{ByViewer: true}); err != nil {
+			t.Fatalf("ListComments failed: %v", err)
+		}
+		filter, _ := requests[0].Variables["filter"].(map[string]interface{})
+		user, _ := filter["user"].(map[string]interface{})
+		isMe, _ := user["isMe"].(map[string]interface{})
+		if isMe["eq"] != true {
+			t.Errorf("expected user.isMe.eq filter, got %v", requests[0].Variables["filter"])
+		}
+	})
+}
+
+func TestListProjects_Pagination(t *testing.T) {
+	type project struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		status string
+	}
+	all := []project{
+		{ID: "p1", Name: "Billing", status: "started"},
+		{ID: "p2", Name: "Launch", status: "completed"},
+		{ID: "p3", Name: "Search", status: "planned"},
+		{ID: "p4", Name: "Legacy", status: "canceled"},
+	}
+
+	var requests []GraphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		requests = append(requests, req)
+
+		// Apply the status filter the way Linear would
+		matching := all
+		filter, _ := req.Variables["filter"].(map[string]interface{})
+		status, _ := filter["status"].(map[string]interface{})
+		if statusType, ok := status["type"].(map[string]interface{}); ok {
+			excluded := map[string]bool{}
+			for _, s := range statusType["nin"].([]interface{}) {
+				excluded[s.(string)] = true
+			}
+			matching = nil
+			for _, p := range all {
+				if !excluded[p.status] {
+					matching = append(matching, p)
+				}
+			}
+		}
+
+		// Serve two projects per page, using the last project's ID as cursor
+		start := 0
+		if after, ok := req.Variables["after"].(string); ok {
+			for i, p := range matching {
+				if p.ID == after {
+					start = i + 1
+				}
+			}
+		}
+		end := min(start+2, len(matching))
+		page := matching[start:end]
+
+		nodes, _ := json.Marshal(page)
+		pageInfo, _ := json.Marshal(PageInfo{HasNextPage: end < len(matching), EndCursor: page[len(page)-1].ID})
+		data := `{"team": {"projects": {"nodes": ` + string(nodes) + `, "pageInfo": ` + string(pageInfo) + `}}}`
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	projectIDs := func(projects []tracker.Project) string {
+		var ids []string
+		for _, p := range projects {
+			ids = append(ids, p.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	t.Run("follows cursors to fetch every page", func(t *testing.T) {
+		requests = nil
+		projects, err := client.GetProjects(context.Background(), "team-1")
+		if err != nil {
+			t.Fatalf("GetProjects failed: %v", err)
+		}
+
+		if len(requests) != 2 {
+			t.Errorf("expected 2 requests, got %d", len(requests))
+		}
+		if got := projectIDs(projects); got != "p1,p2,p3,p4" {
+			t.Errorf("expected projects p1,p2,p3,p4, got %s", got)
+		}
+		if projects[3].Name != "Legacy" || projects[3].TeamID != "team-1" {
+			t.Errorf("expected the project's name and team, got %+v", projects[3])
+		}
+		if _, ok := requests[0].Variables["filter"]; ok {
+			t.Errorf("expected no filter, got %v", requests[0].Variables["filter"])
+		}
+	})
+
+	t.Run("excludes completed and canceled projects", func(t *testing.T) {
+		requests = nil
+		projects, err := client.ListProjects(context.Background(), "team-1", ProjectFilter{ExcludeClosed: true})
+		if err != nil {
+			t.Fatalf("ListProjects failed: %v", err)
+		}
+
+		if got := projectIDs(projects); got != "p1,p3" {
+			t.Errorf("expected projects p1,p3, got %s", got)
+		}
+		if len(requests) != 1 {
+			t.Errorf("expected 1 request, got %d", len(requests))
+		}
+	})
+}
+
+func TestSearchIssuesWithOptions(t *testing.T) {
+	// newSearchServer returns issues in several states and captures the request
+	newSearchServer := func(t *testing.T, captured *GraphQLRequest) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(captured); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(`{
+				"issues": {
+					"nodes": [
+						{"id": "issue-1", "identifier": "NUM-1", "title": "Working", "state": {"id": "s1", "name": "In Progress", "type": "started"}},
+						{"id": "issue-2", "identifier": "NUM-2", "title": "Reviewing", "state": {"id": "s2", "name": "In Review", "type": "started"}},
+						{"id": "issue-3", "identifier": "NUM-3", "title": "Next", "state": {"id": "s3", "name": "Todo", "type": "unstarted"}}
+					]
+				}
+			}`)})
+		}))
+	}
+
+	t.Run("adds state and team constraints", func(t *testing.T) {
+		var captured GraphQLRequest
+		server := newSearchServer(t, &captured)
+		defer server.Close()
+
+		client := newTestClientWithTeam(server.URL, "team-default")
+		issues, err := client.SearchIssuesWithOptions(context.Background(), tracker.SearchOptions{
+			Query:    "login",
+			Statuses: []tracker.Status{tracker.StatusTodo, tracker.StatusInProgress},
+			TeamID:   "team-other",
+			Limit:    10,
+		})
+		if err != nil {
+			t.Fatalf("SearchIssuesWithOptions failed: %v", err)
+		}
+
+		filter := captured.Variables["filter"].(map[string]interface{})
+		stateTypes := filter["state"].(map[string]interface{})["type"].(map[string]interface{})["in"].([]interface{})
+		if len(stateTypes) != 2 || stateTypes[0] != "unstarted" || stateTypes[1] != "started" {
+			t.Errorf("expected state.type.in [unstarted started], got %v", filter["state"])
+		}
+		if got := filter["team"].(map[string]interface{})["id"].(map[string]interface{})["eq"]; got != "team-other" {
+			t.Errorf("expected team.id.eq team-other, got %v", got)
+		}
+		if _, ok := filter["or"]; !ok {
+			t.Errorf("expected the query clause, got %v", filter)
+		}
+		if captured.Variables["first"] != float64(10) {
+			t.Errorf("expected first = 10, got %v", captured.Variables["first"])
+		}
+
+		// In-review shares the started type with in-progress, so it is
+		// filtered out by name
+		var ids []string
+		for _, issue := range issues {
+			ids = append(ids, issue.Identifier)
+		}
+		if strings.Join(ids, ",") != "NUM-1,NUM-3" {
+			t.Errorf("expected NUM-1 and NUM-3, got %v", ids)
+		}
+	})
+
+	t.Run("without options only the default team is set", func(t *testing.T) {
+		var captured GraphQLRequest
+		server := newSearchServer(t, &captured)
+		defer server.Close()
+
+		client := newTestClientWithTeam(server.URL, "team-default")
+		issues, err := client.SearchIssuesWithOptions(context.Background(), tracker.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchIssuesWithOptions failed: %v", err)
+		}
+
+		filter := captured.Variables["filter"].(map[string]interface{})
+		if _, ok := filter["state"]; ok {
+			t.Errorf("expected no state clause, got %v", filter["state"])
+		}
+		if _, ok := filter["or"]; ok {
+			t.Errorf("expected no query clause, got %v", filter["or"])
+		}
+		if got := filter["team"].(map[string]interface{})["id"].(map[string]interface{})["eq"]; got != "team-default" {
+			t.Errorf("expected team.id.eq team-default, got %v", got)
+		}
+		if captured.Variables["first"] != float64(defaultSearchLimit) {
+			t.Errorf("expected first = %d, got %v", defaultSearchLimit, captured.Variables["first"])
+		}
+		if len(issues) != 3 {
+			t.Errorf("expected all 3 issues, got %d", len(issues))
+		}
+	})
+
+	t.Run("SearchIssues keeps its query clause", func(t *testing.T) {
+		var captured GraphQLRequest
+		server := newSearchServer(t, &captured)
+		defer server.Close()
+
+		if _, err := newTestClient(server.URL).SearchIssues(context.Background(), "login"); err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+
+		filter := captured.Variables["filter"].(map[string]interface{})
+		or, _ := filter["or"].([]interface{})
+		if len(or) != 2 {
+			t.Errorf("expected title and description clauses, got %v", filter["or"])
+		}
+		if _, ok := filter["team"]; ok {
+			t.Errorf("expected no team clause without a team, got %v", filter["team"])
+		}
+	})
+}
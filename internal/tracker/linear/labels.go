@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 )
 
 // LinearLabel represents a label from the Linear API
@@ -118,15 +119,123 @@ func (c *Client) GetOrCreateLabel(ctx context.Context, teamID, name string) (*Li
 
 // AddLabelToIssue adds a label to an issue, preserving existing labels
 func (c *Client) AddLabelToIssue(ctx context.Context, issueID, labelID string, existingLabelIDs []string) error {
-	// Check if label is already present
-	for _, id := range existingLabelIDs {
-		if id == labelID {
-			return nil // Label already on issue
+	return c.AddLabelsToIssue(ctx, issueID, []string{labelID}, existingLabelIDs)
+}
+
+// AddLabelsToIssue adds labels to an issue, preserving existing labels. No
+// request is made if the issue already has every label.
+func (c *Client) AddLabelsToIssue(ctx context.Context, issueID string, labelIDs, existingLabelIDs []string) error {
+	allLabelIDs, changed := mergeLabelIDs(existingLabelIDs, labelIDs)
+	if !changed {
+		return nil // Labels already on issue
+	}
+
+	return c.UpdateIssueLabels(ctx, issueID, allLabelIDs)
+}
+
+// mergeLabelIDs returns existing followed by the IDs in add it doesn't
+// already contain, and whether any were added
+func mergeLabelIDs(existing, add []string) ([]string, bool) {
+	merged := slices.Clone(existing)
+	for _, id := range add {
+		if !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+	return merged, len(merged) != len(existing)
+}
+
+// RemoveLabel removes a label from an issue, preserving its other labels. No
+// request is made if the issue doesn't have the label.
+func (c *Client) RemoveLabel(ctx context.Context, issueID, labelID string) error {
+	internalID, err := c.resolveIssueID(ctx, issueID)
+	if err != nil {
+		return err
+	}
+
+	labels, err := c.GetIssueLabels(ctx, internalID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue labels: %w", err)
+	}
+
+	remaining := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label.ID != labelID {
+			remaining = append(remaining, label.ID)
+		}
+	}
+	if len(remaining) == len(labels) {
+		return nil // Label not on issue
+	}
+
+	return c.UpdateIssueLabels(ctx, internalID, remaining)
+}
+
+// FindIssueLabel returns the ID of the label with the given name (compared
+// case-insensitively) on an issue, or "" if the issue doesn't have it
+func (c *Client) FindIssueLabel(ctx context.Context, issueID, name string) (string, error) {
+	internalID, err := c.resolveIssueID(ctx, issueID)
+	if err != nil {
+		return "", err
+	}
+
+	labels, err := c.GetIssueLabels(ctx, internalID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue labels: %w", err)
+	}
+
+	if label := findLabel(labels, name); label != nil {
+		return label.ID, nil
+	}
+	return "", nil
+}
+
+// GetIssueLabels retrieves the labels currently on an issue
+func (c *Client) GetIssueLabels(ctx context.Context, issueID string) ([]LinearLabel, error) {
+	query := `
+		query GetIssueLabels($id: String!) {
+			issue(id: $id) {
+				labels {
+					nodes {
+						id
+						name
+					}
+				}
+			}
 		}
+	`
+
+	resp, err := c.execute(ctx, &GraphQLRequest{
+		Query: query,
+		Variables: map[string]interface{}{
+			"id": issueID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Issue struct {
+			Labels struct {
+				Nodes []LinearLabel `json:"nodes"`
+			} `json:"labels"`
+		} `json:"issue"`
+	}
+
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	// Combine existing labels with new label
-	allLabelIDs := append(existingLabelIDs, labelID)
+	return result.Issue.Labels.Nodes, nil
+}
+
+// UpdateIssueLabels replaces the labels of an issue with exactly labelIDs in
+// a single update; an empty list removes them all
+func (c *Client) UpdateIssueLabels(ctx context.Context, issueID string, labelIDs []string) error {
+	if labelIDs == nil {
+		labelIDs = []string{} // Sent as an empty list rather than null
+	}
 
 	query := `
 		mutation UpdateIssueLabels($id: String!, $input: IssueUpdateInput!) {
@@ -141,7 +250,7 @@ func (c *Client) AddLabelToIssue(ctx context.Context, issueID, labelID string, e
 		Variables: map[string]interface{}{
 			"id": issueID,
 			"input": map[string]interface{}{
-				"labelIds": allLabelIDs,
+				"labelIds": labelIDs,
 			},
 		},
 	})
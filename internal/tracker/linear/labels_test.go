@@ -417,3 +417,174 @@ func TestAddLabelToIssue(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateIssueLabels(t *testing.T) {
+	// newUpdateServer records the requests it receives and reports success
+	newUpdateServer := func(requests *[]GraphQLRequest) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			*requests = append(*requests, req)
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(`{"issueUpdate": {"success": true}}`)})
+		}))
+	}
+
+	t.Run("sends exactly the given label IDs in one update", func(t *testing.T) {
+		var requests []GraphQLRequest
+		server := newUpdateServer(&requests)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.UpdateIssueLabels(context.Background(), "issue-1", []string{"label-3", "label-1"}); err != nil {
+			t.Fatalf("UpdateIssueLabels failed: %v", err)
+		}
+
+		if len(requests) != 1 || !strings.Contains(requests[0].Query, "issueUpdate") {
+			t.Fatalf("expected a single issueUpdate, got %d requests", len(requests))
+		}
+		if requests[0].Variables["id"] != "issue-1" {
+			t.Errorf("expected the update for issue-1, got %v", requests[0].Variables["id"])
+		}
+		input := requests[0].Variables["input"].(map[string]interface{})
+		if len(input) != 1 {
+			t.Errorf("expected only labelIds in the input, got %v", input)
+		}
+		got, _ := input["labelIds"].([]interface{})
+		if len(got) != 2 || got[0] != "label-3" || got[1] != "label-1" {
+			t.Errorf("expected labelIds [label-3 label-1], got %v", input["labelIds"])
+		}
+	})
+
+	t.Run("clears the labels with an empty list", func(t *testing.T) {
+		var requests []GraphQLRequest
+		server := newUpdateServer(&requests)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.UpdateIssueLabels(context.Background(), "issue-1", nil); err != nil {
+			t.Fatalf("UpdateIssueLabels failed: %v", err)
+		}
+
+		got, ok := requests[0].Variables["input"].(map[string]interface{})["labelIds"].([]interface{})
+		if !ok || len(got) != 0 {
+			t.Errorf("expected an empty labelIds list rather than null, got %v", requests[0].Variables["input"])
+		}
+	})
+}
+
+func TestRemoveLabel(t *testing.T) {
+	// newLabelServer serves an issue NUM-1 with three labels and records the
+	// label IDs sent in label updates
+	newLabelServer := func(t *testing.T, updates *[][]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			var data string
+			switch {
+			case strings.Contains(req.Query, "GetIssueByIdentifier"):
+				data = `{"issues": {"nodes": [{"id": "issue-1", "identifier": "NUM-1", "title": "Issue", "state": {"type": "started"}}]}}`
+			case strings.Contains(req.Query, "GetIssueLabels"):
+				if req.Variables["id"] != "issue-1" {
+					t.Errorf("expected labels of the resolved issue, got id %v", req.Variables["id"])
+				}
+				data = `{"issue": {"labels": {"nodes": [
+					{"id": "label-1", "name": "bug"},
+					{"id": "label-2", "name": "jig-plan"},
+					{"id": "label-3", "name": "backend"}
+				]}}}`
+			case strings.Contains(req.Query, "UpdateIssueLabels"):
+				var ids []string
+				for _, id := range req.Variables["input"].(map[string]interface{})["labelIds"].([]interface{}) {
+					ids = append(ids, id.(string))
+				}
+				*updates = append(*updates, ids)
+				data = `{"issueUpdate": {"success": true}}`
+			default:
+				t.Fatalf("unexpected query: %s", req.Query)
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+		}))
+	}
+
+	t.Run("removes the label and preserves the others", func(t *testing.T) {
+		var updates [][]string
+		server := newLabelServer(t, &updates)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.RemoveLabel(context.Background(), "NUM-1", "label-2"); err != nil {
+			t.Fatalf("RemoveLabel failed: %v", err)
+		}
+
+		if len(updates) != 1 {
+			t.Fatalf("expected 1 label update, got %d", len(updates))
+		}
+		if got := strings.Join(updates[0], ","); got != "label-1,label-3" {
+			t.Errorf("expected remaining labels label-1,label-3, got %s", got)
+		}
+	})
+
+	t.Run("does nothing when the issue does not have the label", func(t *testing.T) {
+		var updates [][]string
+		server := newLabelServer(t, &updates)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.RemoveLabel(context.Background(), "NUM-1", "label-9"); err != nil {
+			t.Fatalf("RemoveLabel failed: %v", err)
+		}
+		if len(updates) != 0 {
+			t.Errorf("expected no label update, got %v", updates)
+		}
+	})
+
+	t.Run("finds label IDs by name", func(t *testing.T) {
+		var updates [][]string
+		server := newLabelServer(t, &updates)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		id, err := client.FindIssueLabel(context.Background(), "NUM-1", "JIG-PLAN")
+		if err != nil {
+			t.Fatalf("FindIssueLabel failed: %v", err)
+		}
+		if id != "label-2" {
+			t.Errorf("expected label-2, got %q", id)
+		}
+
+		id, err = client.FindIssueLabel(context.Background(), "NUM-1", "frontend")
+		if err != nil || id != "" {
+			t.Errorf("expected no label, got %q, %v", id, err)
+		}
+	})
+}
+
+func TestRemoveLabel_LastLabelSendsEmptyList(t *testing.T) {
+	var sent interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data string
+		switch {
+		case strings.Contains(req.Query, "GetIssueLabels"):
+			data = `{"issue": {"labels": {"nodes": [{"id": "label-2", "name": "jig-plan"}]}}}`
+		case strings.Contains(req.Query, "UpdateIssueLabels"):
+			sent = req.Variables["input"].(map[string]interface{})["labelIds"]
+			data = `{"issueUpdate": {"success": true}}`
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if err := client.RemoveLabel(context.Background(), "issue1", "label-2"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+
+	// Removing the last label must send an empty list, not null
+	if ids, ok := sent.([]interface{}); !ok || len(ids) != 0 {
+		t.Errorf("expected an empty labelIds list, got %#v", sent)
+	}
+}
@@ -0,0 +1,284 @@
+package linear
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// PlanOverflowMode controls where the part of a plan that doesn't fit in a
+// single Linear comment goes
+type PlanOverflowMode string
+
+const (
+	// OverflowComment splits the plan across several linked comments
+	OverflowComment PlanOverflowMode = "comment"
+	// OverflowDescription moves the overflow into the issue description
+	OverflowDescription PlanOverflowMode = "description"
+)
+
+// ParsePlanOverflowMode validates an overflow mode from config. An empty mode
+// is OverflowComment.
+func ParsePlanOverflowMode(s string) (PlanOverflowMode, error) {
+	switch mode := PlanOverflowMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return OverflowComment, nil
+	case OverflowComment, OverflowDescription:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid plan overflow mode %q (must be one of: %s, %s)", s, OverflowComment, OverflowDescription)
+	}
+}
+
+// maxPlanCommentLength is the size a plan comment is kept under. Linear
+// rejects comment bodies above its limit, so stay well clear of it.
+var maxPlanCommentLength = 50000
+
+// planCommentChromeReserve is the room kept in each comment for the header,
+// continuation note and footer around a chunk of the plan
+const planCommentChromeReserve = 500
+
+// planContinuationPrefix starts the comments holding the rest of a plan that
+// didn't fit in one comment, e.g. "## 📋 Implementation Plan (continued 2/3)".
+// It is also the heading of the overflow section of an issue description.
+const planContinuationPrefix = planCommentPrefix + " (continued"
+
+// planContinuesNote starts the line at the end of a partial plan comment that
+// says where the plan continues
+const planContinuesNote = "*This plan continues"
+
+// planCommentFooterLine is the full footer line ending every plan comment
+const planCommentFooterLine = planCommentFooter + "(https://github.com/charleslr/jig)*"
+
+// isPlanContinuation returns true if a comment holds part of a plan after the first
+func isPlanContinuation(body string) bool {
+	return strings.HasPrefix(body, planContinuationPrefix)
+}
+
+// planCommentParts formats a plan as comments of at most limit bytes each.
+// Plans that fit are a single comment, identical to formatPlanComment. Longer
+// plans are split between markdown blocks, so paragraphs and code blocks
+// stay whole; in OverflowDescription mode only the first part is returned as
+// a comment and the rest as overflow for the issue description.
+func planCommentParts(p *plan.Plan, mode PlanOverflowMode, limit int) (comments []string, overflow string) {
+	full := formatPlanComment(p)
+	if len(full) <= limit {
+		return []string{full}, ""
+	}
+
+	header := strings.TrimSuffix(full, formatPlanSections(p)+"---\n\n"+planCommentFooterLine)
+	chunks := splitMarkdown(formatPlanSections(p), limit-len(header)-planCommentChromeReserve)
+	if len(chunks) == 1 {
+		return []string{full}, ""
+	}
+
+	if mode == OverflowDescription {
+		first := header + chunks[0] + "---\n\n" + planContinuesNote + " in the issue description.*\n\n" + planCommentFooterLine
+		overflow = planContinuationPrefix + ")\n\n" + strings.Join(chunks[1:], "") + "---\n\n" + planCommentFooterLine
+		return []string{first}, overflow
+	}
+
+	for i, chunk := range chunks {
+		var sb strings.Builder
+		if i == 0 {
+			sb.WriteString(header)
+		} else {
+			sb.WriteString(fmt.Sprintf("%s %d/%d)\n\n", planContinuationPrefix, i+1, len(chunks)))
+		}
+		sb.WriteString(chunk)
+		sb.WriteString("---\n\n")
+		if i < len(chunks)-1 {
+			sb.WriteString(fmt.Sprintf("%s in the next comment (%d/%d).*\n\n", planContinuesNote, i+1, len(chunks)))
+		}
+		sb.WriteString(planCommentFooterLine)
+		comments = append(comments, sb.String())
+	}
+	return comments, ""
+}
+
+// splitMarkdown splits markdown into chunks of at most limit bytes between
+// blocks: paragraphs separated by blank lines, with fenced code blocks kept
+// whole. A heading is moved to the next chunk rather than left at the end of
+// one. Blocks larger than limit are split by lines; code blocks split that
+// way have their fence closed and reopened so each chunk renders.
+func splitMarkdown(markdown string, limit int) []string {
+	var chunks []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+	}
+
+	blocks := markdownBlocks(markdown)
+	for i := 0; i < len(blocks); i++ {
+		block := blocks[i]
+		need := len(block)
+		if isHeadingBlock(block) && i+1 < len(blocks) && need+len(blocks[i+1]) <= limit {
+			// Keep the heading with the start of its section
+			need += len(blocks[i+1])
+		}
+		if current.Len()+need > limit {
+			flush()
+		}
+		if len(block) <= limit {
+			current.WriteString(block)
+			continue
+		}
+		for _, piece := range splitBlock(block, limit) {
+			flush()
+			current.WriteString(piece)
+		}
+	}
+	flush()
+	return chunks
+}
+
+// markdownBlocks splits markdown into blocks that each end with the blank
+// line separating them from the next. Blank lines inside fenced code blocks
+// don't end a block.
+func markdownBlocks(markdown string) []string {
+	var blocks []string
+	var current strings.Builder
+	inFence := false
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		if line == "" {
+			continue
+		}
+		current.WriteString(line)
+		if isFenceLine(line) {
+			inFence = !inFence
+		}
+		if !inFence && strings.TrimSpace(line) == "" {
+			blocks = append(blocks, current.String())
+			current.Reset()
+		}
+	}
+	if current.Len() > 0 {
+		blocks = append(blocks, current.String())
+	}
+	return blocks
+}
+
+// splitBlock splits a block larger than limit by lines. Lines longer than
+// limit are cut between characters. A fenced code block is closed at the end of each piece and
+// reopened at the start of the next.
+func splitBlock(block string, limit int) []string {
+	lines := strings.SplitAfter(block, "\n")
+	fence := ""
+	if isFenceLine(lines[0]) {
+		fence = strings.TrimRight(lines[0], "\n")
+	}
+	// Room for reopening and closing the fence
+	reserve := 0
+	if fence != "" {
+		reserve = len(fence) + len("\n```\n")
+	}
+
+	var pieces []string
+	var current strings.Builder
+	for i, line := range lines {
+		if current.Len()+len(line)+reserve > limit && current.Len() > 0 {
+			piece := current.String()
+			if fence != "" {
+				piece += "```\n"
+			}
+			pieces = append(pieces, piece)
+			current.Reset()
+			if fence != "" && i < len(lines)-1 {
+				current.WriteString(fence + "\n")
+			}
+		}
+		for len(line)+reserve > limit {
+			cut := runeBoundary(line, limit-reserve)
+			pieces = append(pieces, line[:cut])
+			line = line[cut:]
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		pieces = append(pieces, current.String())
+	}
+	return pieces
+}
+
+// runeBoundary returns the largest offset up to cut that doesn't fall inside
+// a multi-byte character of s, or the end of s's first character if even that
+// doesn't fit, so that cutting there always makes progress
+func runeBoundary(s string, cut int) int {
+	for i := cut; i > 0; i-- {
+		if utf8.RuneStart(s[i]) {
+			return i
+		}
+	}
+	_, size := utf8.DecodeRuneInString(s)
+	return size
+}
+
+// isFenceLine returns true if a line opens or closes a fenced code block
+func isFenceLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// isHeadingBlock returns true if a block is a markdown heading
+func isHeadingBlock(block string) bool {
+	return strings.HasPrefix(block, "#")
+}
+
+// planCommentSet returns the most recent comment that isMain matches, along
+// with the continuation comments that follow it. Comments are in creation
+// order. Returns nil if no comment matches.
+func planCommentSet(comments []*tracker.Comment, isMain func(c *tracker.Comment) bool) (*tracker.Comment, []*tracker.Comment) {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if isPlanContinuation(comments[i].Body) || !isMain(comments[i]) {
+			continue
+		}
+		var continuations []*tracker.Comment
+		for _, c := range comments[i+1:] {
+			if isPlanContinuation(c.Body) {
+				continuations = append(continuations, c)
+			}
+		}
+		return comments[i], continuations
+	}
+	return nil, nil
+}
+
+// joinPlanComments joins the bodies of a plan comment and its continuations
+func joinPlanComments(main *tracker.Comment, continuations []*tracker.Comment) string {
+	bodies := []string{main.Body}
+	for _, c := range continuations {
+		bodies = append(bodies, c.Body)
+	}
+	return strings.Join(bodies, "\n\n")
+}
+
+// descriptionOverflow returns the plan overflow section of an issue
+// description, or "" if it has none
+func descriptionOverflow(description string) string {
+	idx := strings.Index(description, planContinuationPrefix)
+	if idx == -1 {
+		return ""
+	}
+	return description[idx:]
+}
+
+// withDescriptionOverflow returns an issue description with its plan
+// overflow section replaced by overflow, or removed if overflow is empty
+func withDescriptionOverflow(description, overflow string) string {
+	if idx := strings.Index(description, planContinuationPrefix); idx != -1 {
+		description = strings.TrimRight(description[:idx], "\n")
+	}
+	if overflow == "" {
+		return description
+	}
+	if description == "" {
+		return overflow
+	}
+	return description + "\n\n" + overflow
+}
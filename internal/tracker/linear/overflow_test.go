@@ -0,0 +1,327 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// newLongPlan returns a plan with n sections of filler text and a code block
+// in the middle
+func newLongPlan(n int) *plan.Plan {
+	var body strings.Builder
+	body.WriteString("---\ntitle: Long Plan\n---\n\n# Long Plan\n\n")
+	for i := 1; i <= n; i++ {
+		body.WriteString(fmt.Sprintf("## Section %d\n\n", i))
+		body.WriteString(strings.Repeat(fmt.Sprintf("Paragraph text for section %d. ", i), 8) + "\n\n")
+		if i == n/2 {
+			body.WriteString("```go\nfunc main() {\n\n\tfmt.Println(\"hello\")\n\n}\n```\n\n")
+		}
+	}
+	return &plan.Plan{
+		ID:               "PLAN-1",
+		Title:            "Long Plan",
+		ProblemStatement: "The problem.",
+		ProposedSolution: "The solution.",
+		RawContent:       body.String(),
+	}
+}
+
+// assertFencesBalanced fails if a comment opens a code block it doesn't close
+func assertFencesBalanced(t *testing.T, comment string) {
+	t.Helper()
+	fences := 0
+	for _, line := range strings.Split(comment, "\n") {
+		if isFenceLine(line) {
+			fences++
+		}
+	}
+	if fences%2 != 0 {
+		t.Errorf("expected balanced code fences, got:\n%s", comment)
+	}
+}
+
+func TestPlanCommentParts(t *testing.T) {
+	t.Run("plan that fits is a single comment", func(t *testing.T) {
+		p := newLongPlan(2)
+
+		parts, overflow := planCommentParts(p, OverflowComment, maxPlanCommentLength)
+
+		if len(parts) != 1 || overflow != "" {
+			t.Fatalf("expected a single comment, got %d parts and overflow %q", len(parts), overflow)
+		}
+		if parts[0] != formatPlanComment(p) {
+			t.Errorf("expected the comment to match formatPlanComment, got:\n%s", parts[0])
+		}
+	})
+
+	t.Run("long plan overflows to multiple comments", func(t *testing.T) {
+		p := newLongPlan(20)
+		const limit = 2000
+
+		parts, overflow := planCommentParts(p, OverflowComment, limit)
+
+		if len(parts) < 3 || overflow != "" {
+			t.Fatalf("expected several comments, got %d parts and overflow %q", len(parts), overflow)
+		}
+		for i, part := range parts {
+			if len(part) > limit {
+				t.Errorf("part %d is %d bytes, over the %d limit", i+1, len(part), limit)
+			}
+			if !strings.Contains(part, planCommentFooter) {
+				t.Errorf("part %d is missing the jig footer", i+1)
+			}
+			if i == 0 && (!strings.HasPrefix(part, planCommentPrefix+"\n") || isPlanContinuation(part)) {
+				t.Errorf("expected part 1 to be the plan comment, got:\n%s", part)
+			}
+			if i > 0 && !strings.HasPrefix(part, fmt.Sprintf("%s %d/%d)", planContinuationPrefix, i+1, len(parts))) {
+				t.Errorf("expected part %d to be a numbered continuation, got:\n%s", i+1, part)
+			}
+			if last := i == len(parts)-1; last == strings.Contains(part, planContinuesNote) {
+				t.Errorf("expected only parts before the last to say the plan continues, part %d:\n%s", i+1, part)
+			}
+			assertFencesBalanced(t, part)
+		}
+
+		// Joined back together, the parts read as the whole plan
+		joined := joinPlanComments(&tracker.Comment{Body: parts[0]}, commentsFromBodies(parts[1:]))
+		if got, want := ConvertCommentToBodyContent(joined), PlanBodyContent(p); got != want {
+			t.Errorf("joined parts = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("code blocks are not split", func(t *testing.T) {
+		p := newLongPlan(20)
+		codeBlock := "```go\nfunc main() {\n\n\tfmt.Println(\"hello\")\n\n}\n```"
+
+		// Try a range of limits so that chunk boundaries fall all around the code block
+		for limit := 1200; limit <= 2400; limit += 50 {
+			parts, _ := planCommentParts(p, OverflowComment, limit)
+			found := 0
+			for _, part := range parts {
+				found += strings.Count(part, codeBlock)
+				assertFencesBalanced(t, part)
+			}
+			if found != 1 {
+				t.Fatalf("limit %d: expected the code block whole in one part, found it whole %d times", limit, found)
+			}
+		}
+	})
+
+	t.Run("oversized code block is split with its fence reopened", func(t *testing.T) {
+		var code strings.Builder
+		for i := 0; i < 100; i++ {
+			code.WriteString(fmt.Sprintf("\tline%d := %d\n", i, i))
+		}
+		p := &plan.Plan{
+			Title:      "Big Code",
+			RawContent: "---\n---\n\n## Code\n\n```go\n" + code.String() + "```\n",
+		}
+
+		parts, _ := planCommentParts(p, OverflowComment, 1200)
+
+		if len(parts) < 2 {
+			t.Fatalf("expected the code block to be split, got %d parts", len(parts))
+		}
+		for i, part := range parts {
+			if len(part) > 1200 {
+				t.Errorf("part %d is %d bytes, over the limit", i+1, len(part))
+			}
+			assertFencesBalanced(t, part)
+			if i > 0 && !strings.Contains(part, "```go\n") {
+				t.Errorf("expected part %d to reopen the code block, got:\n%s", i+1, part)
+			}
+		}
+	})
+
+	t.Run("long lines are cut between characters", func(t *testing.T) {
+		// Each character is 3 bytes, so byte offsets often fall mid-character
+		line := strings.Repeat("計", 400) + "\n"
+
+		for limit := 100; limit <= 110; limit++ {
+			pieces := splitBlock(line, limit)
+			if got := strings.Join(pieces, ""); got != line {
+				t.Fatalf("limit %d: pieces don't rejoin to the line", limit)
+			}
+			for i, piece := range pieces {
+				if !utf8.ValidString(piece) || len(piece) > limit {
+					t.Errorf("limit %d: piece %d is %d bytes or cuts a character: %q", limit, i+1, len(piece), piece)
+				}
+			}
+		}
+	})
+
+	t.Run("description mode moves the overflow to the description", func(t *testing.T) {
+		p := newLongPlan(20)
+
+		parts, overflow := planCommentParts(p, OverflowDescription, 2000)
+
+		if len(parts) != 1 || len(parts[0]) > 2000 {
+			t.Fatalf("expected one comment within the limit, got %d parts", len(parts))
+		}
+		if !strings.Contains(parts[0], planContinuesNote+" in the issue description") {
+			t.Errorf("expected the comment to point to the description, got:\n%s", parts[0])
+		}
+		if !strings.HasPrefix(overflow, planContinuationPrefix+")") {
+			t.Errorf("expected overflow to start with the continuation heading, got:\n%s", overflow)
+		}
+
+		joined := parts[0] + "\n\n" + descriptionOverflow(withDescriptionOverflow("Issue description.", overflow))
+		if got, want := ConvertCommentToBodyContent(joined), PlanBodyContent(p); got != want {
+			t.Errorf("comment and overflow = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestWithDescriptionOverflow(t *testing.T) {
+	overflow := planContinuationPrefix + ")\n\n## Rest\n\nMore."
+
+	tests := []struct {
+		name        string
+		description string
+		overflow    string
+		want        string
+	}{
+		{"adds overflow", "Description.", overflow, "Description.\n\n" + overflow},
+		{"adds overflow to an empty description", "", overflow, overflow},
+		{"replaces previous overflow", "Description.\n\n" + planContinuationPrefix + ")\n\nOld.", overflow, "Description.\n\n" + overflow},
+		{"removes overflow", "Description.\n\n" + overflow, "", "Description."},
+		{"leaves a description without overflow alone", "Description.", "", "Description."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withDescriptionOverflow(tt.description, tt.overflow); got != tt.want {
+				t.Errorf("withDescriptionOverflow() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePlanOverflowMode(t *testing.T) {
+	for input, want := range map[string]PlanOverflowMode{"": OverflowComment, "comment": OverflowComment, "Description": OverflowDescription} {
+		if got, err := ParsePlanOverflowMode(input); err != nil || got != want {
+			t.Errorf("ParsePlanOverflowMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParsePlanOverflowMode("attachment"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestSyncPlanToIssue_SplitsLongPlan(t *testing.T) {
+	oldLimit := maxPlanCommentLength
+	maxPlanCommentLength = 2000
+	defer func() { maxPlanCommentLength = oldLimit }()
+
+	// The issue already has a plan comment and two continuations from a
+	// longer version of the plan
+	existing := []map[string]interface{}{
+		{"id": "comment-1", "body": planCommentPrefix + "\n\nold\n\n" + planCommentFooterLine},
+		{"id": "comment-2", "body": planContinuationPrefix + " 2/4)\n\nold\n\n" + planCommentFooterLine},
+		{"id": "comment-3", "body": planContinuationPrefix + " 3/4)\n\nold\n\n" + planCommentFooterLine},
+		{"id": "comment-4", "body": planContinuationPrefix + " 4/4)\n\nold\n\n" + planCommentFooterLine},
+	}
+
+	var updated, added, deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data string
+		switch {
+		case strings.Contains(req.Query, "GetIssueByIdentifier"):
+			data = `{"issues": {"nodes": [{"id": "issue-1", "identifier": "NUM-1", "title": "Long Plan", "state": {"id": "s", "name": "Todo", "type": "unstarted"}, "team": {"id": "team-1", "key": "NUM"}}]}}`
+		case strings.Contains(req.Query, "comments("):
+			nodes, _ := json.Marshal(existing)
+			data = fmt.Sprintf(`{"issue": {"comments": {"nodes": %s, "pageInfo": {"hasNextPage": false}}}}`, nodes)
+		case strings.Contains(req.Query, "commentUpdate"):
+			updated = append(updated, req.Variables["id"].(string))
+			data = `{"commentUpdate": {"success": true, "comment": {"id": "c"}}}`
+		case strings.Contains(req.Query, "commentCreate"):
+			added = append(added, req.Variables["input"].(map[string]interface{})["body"].(string))
+			data = `{"commentCreate": {"success": true, "comment": {"id": "c"}}}`
+		case strings.Contains(req.Query, "commentDelete"):
+			deleted = append(deleted, req.Variables["id"].(string))
+			data = `{"commentDelete": {"success": true}}`
+		case strings.Contains(req.Query, "labels"):
+			data = `{"team": {"labels": {"nodes": [{"id": "label-1", "name": "jig-plan"}]}}, "issue": {"labels": {"nodes": [{"id": "label-1", "name": "jig-plan"}]}}}`
+		default:
+			data = `{"issueUpdate": {"success": true}, "issueAddLabel": {"success": true}}`
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	p := newLongPlan(12)
+	p.IssueID = "NUM-1"
+	parts, _ := planCommentParts(p, OverflowComment, maxPlanCommentLength)
+	if len(parts) < 2 || len(parts) >= len(existing) {
+		t.Fatalf("test plan should need 2 or 3 comments, got %d", len(parts))
+	}
+
+	if err := newTestClient(server.URL).SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+		t.Fatalf("SyncPlanToIssue() error = %v", err)
+	}
+
+	// Existing comments are reused in order, and the ones no longer needed deleted
+	if len(updated) != len(parts) || updated[0] != "comment-1" {
+		t.Errorf("expected %d comments updated starting with comment-1, got %v", len(parts), updated)
+	}
+	if len(added) != 0 {
+		t.Errorf("expected no comments added, got %d", len(added))
+	}
+	if len(deleted) != len(existing)-len(parts) || deleted[len(deleted)-1] != "comment-4" {
+		t.Errorf("expected the %d unused continuations deleted, got %v", len(existing)-len(parts), deleted)
+	}
+}
+
+// commentsFromBodies wraps comment bodies in comments
+func commentsFromBodies(bodies []string) []*tracker.Comment {
+	comments := make([]*tracker.Comment, len(bodies))
+	for i, body := range bodies {
+		comments[i] = &tracker.Comment{Body: body}
+	}
+	return comments
+}
+
+func TestGetPlanComment_DescriptionOverflow(t *testing.T) {
+	overflow := planContinuationPrefix + ")\n\n## Rest\n\nMore."
+	description, _ := json.Marshal("Issue description.\n\n" + overflow)
+	mainBody := planCommentPrefix + "\n\n## Start\n\nFirst.\n\n" + planCommentFooterLine
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data string
+		switch {
+		case strings.Contains(req.Query, "GetIssueByIdentifier"):
+			data = fmt.Sprintf(`{"issues": {"nodes": [{"id": "issue-1", "identifier": "NUM-1", "title": "Long Plan", "description": %s, "state": {"id": "s", "name": "Todo", "type": "unstarted"}, "team": {"id": "team-1", "key": "NUM"}}]}}`, description)
+		case strings.Contains(req.Query, "comments("):
+			nodes, _ := json.Marshal([]map[string]interface{}{{"id": "comment-1", "body": mainBody}})
+			data = fmt.Sprintf(`{"issue": {"comments": {"nodes": %s, "pageInfo": {"hasNextPage": false}}}}`, nodes)
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	comment, err := newTestClient(server.URL).GetPlanComment(context.Background(), "NUM-1")
+	if err != nil {
+		t.Fatalf("GetPlanComment() error = %v", err)
+	}
+	if comment == nil || comment.ID != "comment-1" {
+		t.Fatalf("expected comment-1, got %+v", comment)
+	}
+	if want := mainBody + "\n\n" + overflow; comment.Body != want {
+		t.Errorf("expected the description overflow joined to the comment, got:\n%s", comment.Body)
+	}
+}
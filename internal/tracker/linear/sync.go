@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,16 +16,190 @@ import (
 
 // CreateIssueFromPlan creates a new Linear issue from a plan that has no linked issue.
 // Returns the created issue with its identifier (e.g., "NUM-123").
+//
+// The plan's estimate (see plan.TotalEstimate) is set on the issue when the
+// team uses estimates. Otherwise it is dropped, and the returned issue has a
+// nil Estimate so callers can warn about it.
+//
+// If the client has an IssueTemplate, the issue starts from the template's
+// fields, such as its labels, state and assignee; the plan's title,
+// description and estimate and the configured team and project win over it.
 func (c *Client) CreateIssueFromPlan(ctx context.Context, p *plan.Plan) (*tracker.Issue, error) {
-	if p.Title == "" {
+	title := strings.TrimSpace(p.Title)
+	if title == "" {
 		return nil, fmt.Errorf("plan title is required")
 	}
 
-	return c.CreateIssue(ctx, &tracker.Issue{
-		Title:       p.Title,
+	issue := &tracker.Issue{
+		Title:       title,
 		Description: buildPlanDescription(p),
 		TeamID:      c.teamID,
+	}
+	if c.SyncToDescription {
+		// Delimit the summary so later syncs can update it in place
+		issue.Description = planDescriptionSummary(p)
+	}
+
+	var template *IssueTemplate
+	if c.IssueTemplate != "" {
+		var err error
+		if template, err = c.GetIssueTemplate(ctx, c.IssueTemplate); err != nil {
+			return nil, err
+		}
+		// The configured team wins over the template's
+		if issue.TeamID == "" {
+			issue.TeamID = template.Data.TeamID
+		}
+	}
+
+	if estimate, ok := p.TotalEstimate(); ok {
+		if estimate < 0 {
+			return nil, fmt.Errorf("invalid estimate %d: must not be negative", estimate)
+		}
+		usesEstimates, err := c.teamUsesEstimates(ctx, issue.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team estimation settings: %w", err)
+		}
+		if usesEstimates {
+			issue.Estimate = &estimate
+		}
+	}
+
+	input, err := c.issueCreateInput(issue)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		template.Data.applyTo(input)
+	}
+
+	// Assign the issue to the API key's user unless the template assigns it.
+	// An unknown user only leaves the issue unassigned.
+	if _, ok := input["assigneeId"]; !ok {
+		if viewer, err := c.GetCurrentUser(ctx); err == nil {
+			input["assigneeId"] = viewer.ID
+		}
+	}
+
+	created, err := c.createIssueWithInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	created.Estimate = issue.Estimate
+	return created, nil
+}
+
+// IssueTarget overrides the team, project and template issues are created with
+type IssueTarget struct {
+	Team     string // Team ID or key, e.g. "ENG"
+	Project  string // Project ID or name
+	Template string // Issue template ID or name
+}
+
+// IsZero returns true if the target overrides nothing
+func (t IssueTarget) IsZero() bool {
+	return t.Team == "" && t.Project == "" && t.Template == ""
+}
+
+// WithTarget returns a copy of the client that creates issues in the target's
+// team and project, from its template, instead of the configured ones,
+// resolving team keys and project names to IDs. Overriding the team without
+// a project drops the configured project, which belongs to the configured
+// team.
+func (c *Client) WithTarget(ctx context.Context, target IssueTarget) (*Client, error) {
+	scoped := *c
+	if target.Template != "" {
+		scoped.IssueTemplate = target.Template
+	}
+	if target.Team != "" {
+		teamID, err := c.resolveTeamID(ctx, target.Team)
+		if err != nil {
+			return nil, err
+		}
+		scoped.teamID = teamID
+		scoped.projectID = ""
+	}
+	if target.Project != "" {
+		projectID, err := c.resolveProjectID(ctx, scoped.teamID, target.Project)
+		if err != nil {
+			return nil, err
+		}
+		scoped.projectID = projectID
+	}
+	return &scoped, nil
+}
+
+// resolveTeamID returns the ID of the team with the given ID or key
+func (c *Client) resolveTeamID(ctx context.Context, team string) (string, error) {
+	teams, err := c.GetTeams(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	keys := make([]string, len(teams))
+	for i, t := range teams {
+		if t.ID == team || strings.EqualFold(t.Key, team) {
+			return t.ID, nil
+		}
+		keys[i] = t.Key
+	}
+	return "", fmt.Errorf("team %q not found (available: %s)", team, strings.Join(keys, ", "))
+}
+
+// resolveProjectID returns the ID of the team's project with the given ID or name
+func (c *Client) resolveProjectID(ctx context.Context, teamID, project string) (string, error) {
+	if teamID == "" {
+		return "", fmt.Errorf("a team is required to find project %q", project)
+	}
+
+	projects, err := c.GetProjects(ctx, teamID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	for _, p := range projects {
+		if p.ID == project || strings.EqualFold(p.Name, project) {
+			return p.ID, nil
+		}
+	}
+	return "", fmt.Errorf("project %q not found in the team", project)
+}
+
+// teamUsesEstimates reports whether a team has issue estimation enabled.
+// Without a team ID there is nothing to check, so estimates are not used.
+func (c *Client) teamUsesEstimates(ctx context.Context, teamID string) (bool, error) {
+	if teamID == "" {
+		return false, nil
+	}
+
+	query := `
+		query GetTeamEstimation($id: String!) {
+			team(id: $id) {
+				issueEstimationType
+			}
+		}
+	`
+
+	resp, err := c.execute(ctx, &GraphQLRequest{
+		Query: query,
+		Variables: map[string]interface{}{
+			"id": teamID,
+		},
 	})
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		Team struct {
+			IssueEstimationType string `json:"issueEstimationType"`
+		} `json:"team"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return false, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result.Team.IssueEstimationType != "" && result.Team.IssueEstimationType != "notUsed", nil
 }
 
 // SyncPlan synchronizes a plan to Linear, creating/updating the main issue
@@ -85,26 +260,7 @@ func (c *Client) SyncPlanStatus(ctx context.Context, p *plan.Plan) error {
 		return fmt.Errorf("plan has no linked issue")
 	}
 
-	status := planStatusToTrackerStatus(p.Status)
-	return c.TransitionIssue(ctx, issueID, status)
-}
-
-// planStatusToTrackerStatus converts a plan status to a tracker status
-func planStatusToTrackerStatus(status plan.Status) tracker.Status {
-	switch status {
-	case plan.StatusDraft, plan.StatusReviewing:
-		return tracker.StatusTodo
-	case plan.StatusApproved:
-		return tracker.StatusTodo
-	case plan.StatusInProgress:
-		return tracker.StatusInProgress
-	case plan.StatusInReview:
-		return tracker.StatusInReview
-	case plan.StatusComplete:
-		return tracker.StatusDone
-	default:
-		return tracker.StatusTodo
-	}
+	return c.TransitionIssue(ctx, issueID, tracker.StatusForPlan(p.Status))
 }
 
 // buildPlanDescription creates a description for the Linear issue
@@ -124,14 +280,51 @@ func buildPlanDescription(p *plan.Plan) string {
 
 // ComputePlanContentHash computes a SHA256 hash of the plan content that would be synced.
 // This can be used to detect if the plan content has changed since the last sync.
+// Only semantic content is hashed: the title, the plan's sections, its labels
+// and its blocking relations.
+// Volatile fields, like the comment's sync timestamp and frontmatter times, are not.
 func ComputePlanContentHash(p *plan.Plan) string {
-	content := formatPlanComment(p)
+	// Hash the comment without its sync timestamp so the same plan always
+	// hashes the same, whenever it is computed. Frontmatter comes before the
+	// first section, so it isn't part of the comment.
+	content := "title: " + p.Title + "\n" + formatPlanCommentBody(p)
+	if len(p.Labels) > 0 {
+		// Labels aren't part of the comment, but changing them still needs a
+		// sync. Sort a copy so reordering them alone doesn't.
+		labels := slices.Clone(p.Labels)
+		slices.Sort(labels)
+		content += "\nlabels: " + strings.Join(labels, ",")
+	}
+	// Blocking relations are set in the tracker rather than written in the
+	// comment, so changing them needs a sync too. They have no order either.
+	if len(p.Blocks) > 0 {
+		blocks := slices.Clone(p.Blocks)
+		slices.Sort(blocks)
+		content += "\nblocks: " + strings.Join(blocks, ",")
+	}
+	if len(p.BlockedBy) > 0 {
+		blockedBy := slices.Clone(p.BlockedBy)
+		slices.Sort(blockedBy)
+		content += "\nblocked_by: " + strings.Join(blockedBy, ",")
+	}
 	hash := sha256.Sum256([]byte(content))
 	return hex.EncodeToString(hash[:])
 }
 
+// PlanContentHash computes the plan's content hash like ComputePlanContentHash,
+// also covering the PlanCommentTemplate the comment is laid out with, so that
+// changing the template needs a sync too.
+func (c *Client) PlanContentHash(p *plan.Plan) string {
+	if c.PlanCommentTemplate == "" {
+		return ComputePlanContentHash(p)
+	}
+	hash := sha256.Sum256([]byte(ComputePlanContentHash(p) + "\ntemplate: " + c.PlanCommentTemplate))
+	return hex.EncodeToString(hash[:])
+}
+
 // SyncPlanToIssue syncs a plan's content to its associated Linear issue as a comment
-// and adds a "jig-plan" label to indicate the issue has an implementation plan.
+// and adds a "jig-plan" label to indicate the issue has an implementation plan,
+// along with any labels listed in the plan. Labels already on the issue are kept.
 // This is called when saving a plan that has a linked issue (p.IssueID).
 func (c *Client) SyncPlanToIssue(ctx context.Context, p *plan.Plan, labelName string) error {
 	if p.IssueID == "" {
@@ -144,157 +337,385 @@ func (c *Client) SyncPlanToIssue(ctx context.Context, p *plan.Plan, labelName st
 		return fmt.Errorf("failed to get issue %s: %w", p.IssueID, err)
 	}
 
-	// Add plan content as a comment
-	commentBody := formatPlanComment(p)
-	if _, err := c.AddComment(ctx, issue.ID, commentBody); err != nil {
-		return fmt.Errorf("failed to add plan comment: %w", err)
+	// Update the existing plan comment if there is one, otherwise add a new one
+	if err := checkSyncCanceled(ctx, "updating the plan comment"); err != nil {
+		return err
+	}
+	if err := c.writePlanComments(ctx, issue, p); err != nil {
+		return err
 	}
 
-	// Get or create the jig-plan label
-	label, err := c.GetOrCreateLabel(ctx, issue.TeamID, labelName)
+	// Resolve the jig-plan label and the plan's own labels
+	if err := checkSyncCanceled(ctx, "fetching the plan label"); err != nil {
+		return err
+	}
+	labelIDs, err := c.resolvePlanLabelIDs(ctx, issue.TeamID, labelName, p.Labels)
 	if err != nil {
-		return fmt.Errorf("failed to get/create label %q: %w", labelName, err)
+		return err
 	}
 
-	// Get existing label IDs from the issue
-	existingLabelIDs := getIssueLabelIDs(ctx, c, issue.ID)
+	// Set the issue's labels to its existing ones plus any it's missing
+	if err := checkSyncCanceled(ctx, "applying the plan label"); err != nil {
+		return err
+	}
+	allLabelIDs, changed := mergeLabelIDs(getIssueLabelIDs(ctx, c, issue.ID), labelIDs)
+	if !changed {
+		return nil // Labels already on issue
+	}
+	if err := c.UpdateIssueLabels(ctx, issue.ID, allLabelIDs); err != nil {
+		return fmt.Errorf("failed to add labels to issue: %w", err)
+	}
 
-	// Add label to issue if not already present
-	if err := c.AddLabelToIssue(ctx, issue.ID, label.ID, existingLabelIDs); err != nil {
-		return fmt.Errorf("failed to add label to issue: %w", err)
+	return nil
+}
+
+// writePlanComments writes a plan to the plan comment on an issue, updating
+// the existing comments in place. A plan too long for one comment continues
+// in further comments or the issue description, depending on
+// PlanOverflowMode; leftovers from a previous, longer sync are removed. With
+// SyncToDescription set, the plan's summary region in the description is
+// updated too.
+func (c *Client) writePlanComments(ctx context.Context, issue *tracker.Issue, p *plan.Plan) error {
+	parts, overflow := c.planComments(p)
+
+	comments, err := c.ListComments(ctx, issue.ID, CommentFilter{ByViewer: true})
+	if err != nil {
+		return fmt.Errorf("failed to get existing comments: %w", err)
+	}
+	main, continuations := planCommentSet(comments, isJigPlanComment)
+	var existing []*tracker.Comment
+	if main != nil {
+		existing = append([]*tracker.Comment{main}, continuations...)
+	}
+
+	for i, body := range parts {
+		if i < len(existing) {
+			if _, err := c.UpdateComment(ctx, existing[i].ID, body); err != nil {
+				return fmt.Errorf("failed to update plan comment: %w", err)
+			}
+		} else if _, err := c.AddComment(ctx, issue.ID, body); err != nil {
+			return fmt.Errorf("failed to add plan comment: %w", err)
+		}
+	}
+	for _, stale := range existing[min(len(parts), len(existing)):] {
+		if err := c.DeleteComment(ctx, stale.ID); err != nil {
+			return fmt.Errorf("failed to delete outdated plan comment: %w", err)
+		}
 	}
 
+	description := withDescriptionOverflow(issue.Description, overflow)
+	if c.SyncToDescription {
+		description = withDescriptionSummary(description, planDescriptionSummary(p))
+	}
+	if description != issue.Description {
+		if err := c.UpdateIssue(ctx, issue.ID, &tracker.IssueUpdate{Description: &description}); err != nil {
+			return fmt.Errorf("failed to update the issue description: %w", err)
+		}
+	}
 	return nil
 }
 
-// getIssueLabelIDs fetches the current label IDs for an issue
-func getIssueLabelIDs(ctx context.Context, c *Client, issueID string) []string {
-	// Re-fetch the issue to get current labels with their IDs
-	// We need to use a custom query since GetIssue returns label names, not IDs
-	query := `
-		query GetIssueLabels($id: String!) {
-			issue(id: $id) {
-				labels {
-					nodes {
-						id
-					}
-				}
+// resolvePlanLabelIDs returns the IDs of the plan label and of each label named
+// in the plan. The plan label is created if the team doesn't have it; other
+// missing labels are only created when CreateMissingLabels is set, and are
+// skipped otherwise.
+func (c *Client) resolvePlanLabelIDs(ctx context.Context, teamID, planLabel string, names []string) ([]string, error) {
+	teamLabels, err := c.GetTeamLabels(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team labels: %w", err)
+	}
+
+	label := findLabel(teamLabels, planLabel)
+	if label == nil {
+		if label, err = c.CreateLabel(ctx, teamID, planLabel); err != nil {
+			return nil, fmt.Errorf("failed to get/create label %q: %w", planLabel, err)
+		}
+		teamLabels = append(teamLabels, *label)
+	}
+	ids := []string{label.ID}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		label := findLabel(teamLabels, name)
+		if label == nil {
+			if !c.CreateMissingLabels {
+				continue
+			}
+			if label, err = c.CreateLabel(ctx, teamID, name); err != nil {
+				return nil, fmt.Errorf("failed to create label %q: %w", name, err)
 			}
+			teamLabels = append(teamLabels, *label)
 		}
-	`
+		if !slices.Contains(ids, label.ID) {
+			ids = append(ids, label.ID)
+		}
+	}
 
-	resp, err := c.execute(ctx, &GraphQLRequest{
-		Query: query,
-		Variables: map[string]interface{}{
-			"id": issueID,
-		},
-	})
+	return ids, nil
+}
+
+// findLabel returns the label with the given name, ignoring case as Linear
+// does, or nil if there is none
+func findLabel(labels []LinearLabel, name string) *LinearLabel {
+	for i := range labels {
+		if strings.EqualFold(labels[i].Name, name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
+
+// GetPlanComment returns the jig plan comment on the given issue, or nil if
+// the issue has no plan comment. For a plan split across several comments or
+// into the issue description, the returned comment's body is all of its parts
+// joined, as FetchPlanFromIssue reads them.
+func (c *Client) GetPlanComment(ctx context.Context, issueID string) (*tracker.Comment, error) {
+	issue, err := c.GetIssue(ctx, issueID)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
 	}
 
-	var result struct {
-		Issue struct {
-			Labels struct {
-				Nodes []struct {
-					ID string `json:"id"`
-				} `json:"nodes"`
-			} `json:"labels"`
-		} `json:"issue"`
+	comments, err := c.ListComments(ctx, issue.ID, CommentFilter{ByViewer: true})
+	if err != nil {
+		return nil, err
+	}
+	main, continuations := planCommentSet(comments, isJigPlanComment)
+	overflow := descriptionOverflow(issue.Description)
+	if main == nil || (len(continuations) == 0 && overflow == "") {
+		return main, nil
 	}
+	joined := *main
+	joined.Body = joinPlanComments(main, continuations)
+	if overflow != "" {
+		joined.Body += "\n\n" + overflow
+	}
+	return &joined, nil
+}
 
-	if err := json.Unmarshal(resp.Data, &result); err != nil {
+// checkSyncCanceled returns an error wrapping the context error if ctx is done,
+// so a canceled sync stops between API calls instead of running to completion
+func checkSyncCanceled(ctx context.Context, step string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("plan sync canceled before %s: %w", step, err)
+	}
+	return nil
+}
+
+// isJigPlanComment returns true if a comment was written by jig, identified
+// by its footer, or by its plan header if it was written by the API key's
+// user and the footer was edited out
+func isJigPlanComment(c *tracker.Comment) bool {
+	return jigPlanCommentMatcher(nil)(c)
+}
+
+// jigPlanCommentMatcher returns a matcher for isJigPlanComment that also
+// treats comments by viewer, if known, as written by the API key's user
+func jigPlanCommentMatcher(viewer *tracker.User) func(c *tracker.Comment) bool {
+	return func(c *tracker.Comment) bool {
+		if strings.Contains(c.Body, planCommentFooter) {
+			return true
+		}
+		byViewer := c.ByViewer || (viewer != nil && viewer.ID != "" && c.AuthorID == viewer.ID)
+		return byViewer && strings.HasPrefix(c.Body, planCommentPrefix)
+	}
+}
+
+// getIssueLabelIDs fetches the current label IDs for an issue, or nil if they
+// can't be fetched
+func getIssueLabelIDs(ctx context.Context, c *Client, issueID string) []string {
+	labels, err := c.GetIssueLabels(ctx, issueID)
+	if err != nil {
 		return nil
 	}
 
-	ids := make([]string, len(result.Issue.Labels.Nodes))
-	for i, node := range result.Issue.Labels.Nodes {
-		ids[i] = node.ID
+	ids := make([]string, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
 	}
 	return ids
 }
 
-// formatPlanComment formats a plan as a markdown comment for Linear
+// formatPlanComment formats a plan as a markdown comment for Linear.
+// Sections appear in the same order as in the plan's raw content, so that
+// fetching the comment back yields the original layout. Problem statement and
+// proposed solution that have no section in the raw content come first.
 func formatPlanComment(p *plan.Plan) string {
+	header := "## 📋 Implementation Plan\n\n"
+	synced := fmt.Sprintf("%s %s\n\n", syncedLinePrefix, time.Now().UTC().Format("2006-01-02 15:04 UTC"))
+	return header + synced + strings.TrimPrefix(formatPlanCommentBody(p), header)
+}
+
+// syncedLinePrefix starts the line of a plan comment saying when it was synced
+const syncedLinePrefix = "**Synced:**"
+
+// StripSyncTimestamp removes the sync timestamp line from a plan comment, so
+// comparing two versions of a comment only compares their content
+func StripSyncTimestamp(body string) string {
+	lines := strings.SplitAfter(body, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, syncedLinePrefix) {
+			continue
+		}
+		// Drop the blank line after it too, as formatPlanComment adds it
+		rest := lines[i+1:]
+		if len(rest) > 0 && strings.TrimSpace(rest[0]) == "" {
+			rest = rest[1:]
+		}
+		return strings.Join(lines[:i], "") + strings.Join(rest, "")
+	}
+	return body
+}
+
+// formatPlanCommentBody formats the plan comment without its sync timestamp.
+// The output depends only on the plan, which makes it suitable for hashing.
+func formatPlanCommentBody(p *plan.Plan) string {
 	var sb strings.Builder
 
 	sb.WriteString("## 📋 Implementation Plan\n\n")
-	sb.WriteString(fmt.Sprintf("**Synced:** %s\n\n", time.Now().UTC().Format("2006-01-02 15:04 UTC")))
+	if len(p.RelatedIssues) > 0 {
+		// Mentioning the related issues makes Linear cross-reference them
+		sb.WriteString(fmt.Sprintf("**Related issues:** %s\n\n", strings.Join(p.RelatedIssues, ", ")))
+	}
 	sb.WriteString("---\n\n")
+	sb.WriteString(formatPlanSections(p))
+	sb.WriteString("---\n\n")
+	sb.WriteString(planCommentFooterLine)
 
-	if p.ProblemStatement != "" {
-		sb.WriteString("### Problem Statement\n\n")
-		sb.WriteString(p.ProblemStatement)
-		sb.WriteString("\n\n")
-	}
+	return sb.String()
+}
 
-	if p.ProposedSolution != "" {
-		sb.WriteString("### Proposed Solution\n\n")
-		sb.WriteString(p.ProposedSolution)
-		sb.WriteString("\n\n")
+// formatPlanSections formats the sections of a plan comment, between its
+// header and footer
+func formatPlanSections(p *plan.Plan) string {
+	var sb strings.Builder
+
+	sections := splitPlanSections(p.RawContent)
+
+	var hasProblem, hasSolution bool
+	for _, section := range sections {
+		hasProblem = hasProblem || isProblemSection(section.name)
+		hasSolution = hasSolution || isSolutionSection(section.name)
+	}
+	if !hasProblem {
+		writeCommentSection(&sb, "### Problem Statement", p.ProblemStatement)
+	}
+	if !hasSolution {
+		writeCommentSection(&sb, "### Proposed Solution", p.ProposedSolution)
 	}
 
-	// Include raw content sections beyond problem/solution if present
-	// This captures acceptance criteria, implementation details, etc.
-	if p.RawContent != "" {
-		// Extract additional sections from raw content
-		additionalSections := extractAdditionalSections(p.RawContent)
-		if additionalSections != "" {
-			sb.WriteString(additionalSections)
-			sb.WriteString("\n")
+	// Problem and solution use the parsed fields; everything else (acceptance
+	// criteria, implementation details, etc.) is copied from the raw content
+	var wroteProblem, wroteSolution bool
+	for _, section := range sections {
+		switch {
+		case isProblemSection(section.name):
+			if !wroteProblem {
+				writeCommentSection(&sb, "### Problem Statement", p.ProblemStatement)
+				wroteProblem = true
+			}
+		case isSolutionSection(section.name):
+			if !wroteSolution {
+				writeCommentSection(&sb, "### Proposed Solution", p.ProposedSolution)
+				wroteSolution = true
+			}
+		default:
+			sb.WriteString(section.header)
+			sb.WriteString("\n\n")
+			if section.content != "" {
+				sb.WriteString(section.content)
+				sb.WriteString("\n\n")
+			}
 		}
 	}
 
-	sb.WriteString("---\n\n")
-	sb.WriteString("*This plan was synced by [jig](https://github.com/charleslr/jig)*")
-
 	return sb.String()
 }
 
-// extractAdditionalSections extracts sections from raw content that aren't
-// Problem Statement or Proposed Solution (those are already included separately)
-func extractAdditionalSections(rawContent string) string {
-	var sb strings.Builder
-	lines := strings.Split(rawContent, "\n")
+// writeCommentSection writes a section with the given header, skipping empty content
+func writeCommentSection(sb *strings.Builder, header, content string) {
+	if content == "" {
+		return
+	}
+	sb.WriteString(header)
+	sb.WriteString("\n\n")
+	sb.WriteString(content)
+	sb.WriteString("\n\n")
+}
 
-	inSection := false
-	skipSection := false
+// planSection is a "## " or "### " section of a plan's markdown
+type planSection struct {
+	header  string // the header line as written, e.g. "## Acceptance Criteria"
+	name    string // the lowercased section name, e.g. "acceptance criteria"
+	content string // the trimmed section content
+}
 
-	for _, line := range lines {
-		// Check for section headers
-		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "### ") {
-			sectionName := strings.TrimPrefix(strings.TrimPrefix(line, "### "), "## ")
-			sectionName = strings.TrimSpace(sectionName)
-
-			// Skip sections we already include
-			if strings.Contains(strings.ToLower(sectionName), "problem statement") ||
-				strings.Contains(strings.ToLower(sectionName), "proposed solution") {
-				skipSection = true
-				inSection = false
-				continue
-			}
+// splitPlanSections splits markdown into "## " and "### " sections in document
+// order. Content before the first section header (frontmatter, title) is ignored.
+func splitPlanSections(markdown string) []planSection {
+	var sections []planSection
+	var content strings.Builder
 
-			// Start a new section
-			skipSection = false
-			inSection = true
-			sb.WriteString(line)
-			sb.WriteString("\n")
-			continue
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].content = strings.TrimSpace(content.String())
 		}
+		content.Reset()
+	}
 
-		// Write content for non-skipped sections
-		if inSection && !skipSection {
-			sb.WriteString(line)
-			sb.WriteString("\n")
+	for _, line := range strings.Split(markdown, "\n") {
+		if strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "### ") {
+			flush()
+			name := strings.TrimPrefix(strings.TrimPrefix(line, "### "), "## ")
+			sections = append(sections, planSection{
+				header: strings.TrimSpace(line),
+				name:   strings.ToLower(strings.TrimSpace(name)),
+			})
+			continue
 		}
+		content.WriteString(line)
+		content.WriteString("\n")
 	}
+	flush()
+
+	return sections
+}
+
+// isProblemSection reports whether a lowercased section name is the problem statement
+func isProblemSection(name string) bool {
+	return strings.Contains(name, "problem statement")
+}
 
-	return strings.TrimSpace(sb.String())
+// isSolutionSection reports whether a lowercased section name is the proposed solution
+func isSolutionSection(name string) bool {
+	return strings.Contains(name, "proposed solution")
+}
+
+// extractAdditionalSections extracts sections from raw content that aren't
+// Problem Statement or Proposed Solution (those are already included separately)
+func extractAdditionalSections(rawContent string) string {
+	var parts []string
+	for _, section := range splitPlanSections(rawContent) {
+		if isProblemSection(section.name) || isSolutionSection(section.name) {
+			continue
+		}
+		if section.content == "" {
+			parts = append(parts, section.header)
+			continue
+		}
+		parts = append(parts, section.header+"\n"+section.content)
+	}
+	return strings.Join(parts, "\n\n")
 }
 
 // planCommentPrefix is the marker that identifies a plan comment synced to Linear
 const planCommentPrefix = "## 📋 Implementation Plan"
 
+// planCommentFooter is the attribution footer that identifies a comment written by jig
+const planCommentFooter = "*This plan was synced by [jig]"
+
 // FetchPlanFromIssue retrieves a plan from an issue's comments on Linear.
 // Returns nil if no plan comment is found.
 func (c *Client) FetchPlanFromIssue(ctx context.Context, issueID string) (*plan.Plan, error) {
@@ -310,24 +731,31 @@ func (c *Client) FetchPlanFromIssue(ctx context.Context, issueID string) (*plan.
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
 
-	// Find the most recent plan comment (in case there are multiple syncs)
-	var planComment *tracker.Comment
-	for i := len(comments) - 1; i >= 0; i-- {
-		if strings.HasPrefix(comments[i].Body, planCommentPrefix) {
-			planComment = comments[i]
-			break
-		}
+	// Find the most recent plan comment (in case there are multiple syncs),
+	// along with the rest of the plan if it didn't fit in one comment. Jig's
+	// own comments win over copies of them posted by others.
+	viewer, _ := c.GetCurrentUser(ctx)
+	planComment, continuations := planCommentSet(comments, jigPlanCommentMatcher(viewer))
+	if planComment == nil {
+		planComment, continuations = planCommentSet(comments, func(c *tracker.Comment) bool {
+			return strings.HasPrefix(c.Body, planCommentPrefix)
+		})
 	}
-
 	if planComment == nil {
 		return nil, nil
 	}
+	body := joinPlanComments(planComment, continuations)
+	if overflow := descriptionOverflow(issue.Description); overflow != "" {
+		body += "\n\n" + overflow
+	}
 
 	// Parse the plan from the comment
-	return parsePlanFromComment(planComment.Body, issue)
+	return parsePlanFromComment(body, issue)
 }
 
-// parsePlanFromComment extracts plan data from a synced comment
+// parsePlanFromComment extracts plan data from a synced comment. Sections keep
+// the order they have in the comment, which matches the order of the plan that
+// was synced (see formatPlanComment).
 func parsePlanFromComment(body string, issue *tracker.Issue) (*plan.Plan, error) {
 	// Generate a plan ID based on issue ID
 	planID := fmt.Sprintf("PLAN-%d", time.Now().Unix())
@@ -335,52 +763,63 @@ func parsePlanFromComment(body string, issue *tracker.Issue) (*plan.Plan, error)
 	p := plan.NewPlan(planID, issue.Title, issue.Assignee)
 	p.IssueID = issue.Identifier
 
-	// Parse sections from the comment body
-	lines := strings.Split(body, "\n")
-	var currentSection string
-	var sectionContent strings.Builder
-
-	flushSection := func() {
-		content := strings.TrimSpace(sectionContent.String())
-		if content == "" {
-			return
-		}
-		switch currentSection {
-		case "problem statement":
-			p.ProblemStatement = content
-		case "proposed solution":
-			p.ProposedSolution = content
+	for _, section := range splitPlanSections(stripPlanCommentChrome(body)) {
+		switch {
+		case isProblemSection(section.name):
+			p.ProblemStatement = section.content
+		case isSolutionSection(section.name):
+			p.ProposedSolution = section.content
 		}
-		sectionContent.Reset()
 	}
 
-	for _, line := range lines {
-		// Skip the header and metadata lines
+	// Store the sections as a plan document so the original layout survives
+	// caching; Serialize fills in the frontmatter from the plan fields
+	p.RawContent = fmt.Sprintf("---\n---\n\n# %s\n\n%s", p.Title, ConvertCommentToBodyContent(body))
+	data, err := plan.Serialize(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize fetched plan: %w", err)
+	}
+	p.RawContent = string(data)
+
+	return p, nil
+}
+
+// stripPlanCommentChrome drops the comment header, sync metadata and footer
+// from a plan comment, keeping only the plan sections
+func stripPlanCommentChrome(body string) string {
+	var kept []string
+	for _, line := range strings.Split(body, "\n") {
 		if strings.HasPrefix(line, "## 📋") ||
-			strings.HasPrefix(line, "**Synced:**") ||
+			strings.HasPrefix(line, syncedLinePrefix) ||
+			strings.HasPrefix(line, "**Related issues:**") ||
 			strings.HasPrefix(line, "---") ||
-			strings.HasPrefix(line, "*This plan was synced") {
-			continue
-		}
-
-		// Check for section headers
-		if strings.HasPrefix(line, "### ") {
-			flushSection()
-			sectionName := strings.TrimPrefix(line, "### ")
-			currentSection = strings.ToLower(strings.TrimSpace(sectionName))
+			strings.HasPrefix(line, "*This plan was synced") ||
+			strings.HasPrefix(line, planContinuesNote) {
 			continue
 		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
 
-		// Accumulate section content
-		if currentSection != "" {
-			sectionContent.WriteString(line)
-			sectionContent.WriteString("\n")
+// ConvertCommentToBodyContent converts a synced plan comment back into plan
+// body content: the sections of the comment in order, without the header,
+// sync timestamp and footer that jig adds around them.
+func ConvertCommentToBodyContent(body string) string {
+	var sb strings.Builder
+	for _, section := range splitPlanSections(stripPlanCommentChrome(body)) {
+		sb.WriteString(section.header)
+		sb.WriteString("\n\n")
+		if section.content != "" {
+			sb.WriteString(section.content)
+			sb.WriteString("\n\n")
 		}
 	}
-	flushSection()
-
-	// Store the full comment body as raw content for complete preservation
-	p.RawContent = body
+	return sb.String()
+}
 
-	return p, nil
+// PlanBodyContent returns the body content that syncing the plan would write
+// to its Linear comment, in the same form as ConvertCommentToBodyContent.
+func PlanBodyContent(p *plan.Plan) string {
+	return ConvertCommentToBodyContent(formatPlanComment(p))
 }
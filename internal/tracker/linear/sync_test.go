@@ -1,14 +1,20 @@
 package linear
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
 )
 
 func TestFormatPlanComment(t *testing.T) {
@@ -393,7 +399,7 @@ func TestCreateIssueFromPlan(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := newTestClient(server.URL)
+		client := newTestClientWithTeam(server.URL, "team-123")
 		p := &plan.Plan{
 			ID:               "PLAN-123",
 			Title:            "Test Plan Title",
@@ -451,6 +457,21 @@ func TestCreateIssueFromPlan(t *testing.T) {
 		}
 	})
 
+	t.Run("returns error when plan title is only whitespace", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request for a blank title")
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		p := &plan.Plan{ID: "PLAN-123", Title: "  \t ", ProblemStatement: "Problem"}
+
+		_, err := client.CreateIssueFromPlan(context.Background(), p)
+		if err == nil || !strings.Contains(err.Error(), "plan title is required") {
+			t.Errorf("expected 'plan title is required' error, got: %v", err)
+		}
+	})
+
 	t.Run("handles API error gracefully", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			response := GraphQLResponse{
@@ -462,7 +483,7 @@ func TestCreateIssueFromPlan(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := newTestClient(server.URL)
+		client := newTestClientWithTeam(server.URL, "team-123")
 		p := &plan.Plan{
 			ID:    "PLAN-123",
 			Title: "Test Plan",
@@ -505,7 +526,7 @@ func TestCreateIssueFromPlan(t *testing.T) {
 		}))
 		defer server.Close()
 
-		client := newTestClient(server.URL)
+		client := newTestClientWithTeam(server.URL, "team-123")
 		p := &plan.Plan{
 			ID:               "PLAN-123",
 			Title:            "Test Plan",
@@ -578,6 +599,27 @@ func TestCreateIssueFromPlan(t *testing.T) {
 			t.Errorf("expected teamId 'team-custom-123', got '%s'", capturedTeamID)
 		}
 	})
+
+	t.Run("returns ErrNoTeam without a team", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected no request to be sent without a team")
+		}))
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		p := &plan.Plan{
+			ID:    "PLAN-123",
+			Title: "Test Plan",
+		}
+
+		_, err := client.CreateIssueFromPlan(context.Background(), p)
+		if !errors.Is(err, ErrNoTeam) {
+			t.Fatalf("expected ErrNoTeam, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "linear.team_id") || !strings.Contains(err.Error(), "--team") {
+			t.Errorf("expected the error to say how to set a team, got %q", err)
+		}
+	})
 }
 
 func TestSyncPlanToIssue(t *testing.T) {
@@ -625,6 +667,20 @@ func TestSyncPlanToIssue(t *testing.T) {
 				}
 				json.NewEncoder(w).Encode(response)
 			case 2:
+				// GetComments query (no prior plan comment)
+				response := GraphQLResponse{
+					Data: json.RawMessage(`{
+						"issue": {
+							"comments": {
+								"nodes": [
+									{"id": "comment-1", "body": "Unrelated discussion"}
+								]
+							}
+						}
+					}`),
+				}
+				json.NewEncoder(w).Encode(response)
+			case 3:
 				// AddComment mutation
 				if input, ok := req.Variables["input"].(map[string]interface{}); ok {
 					if body, ok := input["body"].(string); ok {
@@ -643,7 +699,7 @@ func TestSyncPlanToIssue(t *testing.T) {
 					}`),
 				}
 				json.NewEncoder(w).Encode(response)
-			case 3:
+			case 4:
 				// GetTeamLabels query
 				response := GraphQLResponse{
 					Data: json.RawMessage(`{
@@ -658,7 +714,7 @@ func TestSyncPlanToIssue(t *testing.T) {
 					}`),
 				}
 				json.NewEncoder(w).Encode(response)
-			case 4:
+			case 5:
 				// GetIssueLabels query
 				response := GraphQLResponse{
 					Data: json.RawMessage(`{
@@ -672,7 +728,7 @@ func TestSyncPlanToIssue(t *testing.T) {
 					}`),
 				}
 				json.NewEncoder(w).Encode(response)
-			case 5:
+			case 6:
 				// AddLabelToIssue mutation
 				response := GraphQLResponse{
 					Data: json.RawMessage(`{
@@ -709,8 +765,8 @@ func TestSyncPlanToIssue(t *testing.T) {
 		}
 
 		// Verify all expected calls were made
-		if callCount != 5 {
-			t.Errorf("expected 5 API calls, got %d", callCount)
+		if callCount != 6 {
+			t.Errorf("expected 6 API calls, got %d", callCount)
 		}
 	})
 
@@ -743,6 +799,295 @@ func TestSyncPlanToIssue(t *testing.T) {
 	})
 }
 
+// TestSyncPlanToIssue_EditsExistingComment verifies that re-syncing a plan edits the
+// jig comment created by the first sync instead of appending a second one.
+func TestSyncPlanToIssue_EditsExistingComment(t *testing.T) {
+	type storedComment struct {
+		ID   string `json:"id"`
+		Body string `json:"body"`
+	}
+	comments := []storedComment{{ID: "human-comment", Body: "Looks good to me"}}
+	createCount := 0
+	updateCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data string
+		switch {
+		case strings.Contains(req.Query, "GetIssueByIdentifier"):
+			data = `{"issues": {"nodes": [{"id": "issue-1", "identifier": "NUM-41", "title": "Test", "team": {"id": "team-1"}, "state": {"type": "unstarted"}}]}}`
+		case strings.Contains(req.Query, "GetComments"):
+			nodes, _ := json.Marshal(comments)
+			data = `{"issue": {"comments": {"nodes": ` + string(nodes) + `}}}`
+		case strings.Contains(req.Query, "commentCreate"):
+			createCount++
+			input := req.Variables["input"].(map[string]interface{})
+			comments = append(comments, storedComment{ID: "jig-comment", Body: input["body"].(string)})
+			data = `{"commentCreate": {"success": true, "comment": {"id": "jig-comment"}}}`
+		case strings.Contains(req.Query, "commentUpdate"):
+			updateCount++
+			id := req.Variables["id"].(string)
+			input := req.Variables["input"].(map[string]interface{})
+			for i := range comments {
+				if comments[i].ID == id {
+					comments[i].Body = input["body"].(string)
+				}
+			}
+			data = `{"commentUpdate": {"success": true, "comment": {"id": "` + id + `"}}}`
+		case strings.Contains(req.Query, "GetTeamLabels"):
+			data = `{"team": {"labels": {"nodes": [{"id": "label-1", "name": "jig-plan"}]}}}`
+		case strings.Contains(req.Query, "GetIssueLabels"):
+			data = `{"issue": {"labels": {"nodes": [{"id": "label-1"}]}}}`
+		default:
+			t.Fatalf("unexpected query: %s", req.Query)
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	p := &plan.Plan{
+		ID:               "PLAN-123",
+		IssueID:          "NUM-41",
+		Title:            "Test Plan",
+		ProblemStatement: "First version",
+		ProposedSolution: "Solution",
+	}
+
+	if err := client.SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+		t.Fatalf("first sync failed: %v", err)
+	}
+
+	p.ProblemStatement = "Second version"
+	if err := client.SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+		t.Fatalf("second sync failed: %v", err)
+	}
+
+	if createCount != 1 {
+		t.Errorf("expected 1 comment creation, got %d", createCount)
+	}
+	if updateCount != 1 {
+		t.Errorf("expected 1 comment update, got %d", updateCount)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("expected 2 comments on issue, got %d", len(comments))
+	}
+	if comments[0].Body != "Looks good to me" {
+		t.Errorf("human comment should be untouched, got %q", comments[0].Body)
+	}
+	if !strings.Contains(comments[1].Body, "Second version") {
+		t.Errorf("expected jig comment to contain updated content, got %q", comments[1].Body)
+	}
+	if strings.Contains(comments[1].Body, "First version") {
+		t.Error("expected jig comment to no longer contain the first version")
+	}
+}
+
+// TestSyncPlanToIssue_RelatedIssues verifies that a plan with related issues is
+// still synced to its primary issue only, with the related issues referenced
+// in the comment.
+func TestSyncPlanToIssue_RelatedIssues(t *testing.T) {
+	var fetchedNumbers []float64
+	var commentIssueIDs []string
+	var commentBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GraphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var data string
+		switch {
+		case strings.Contains(req.Query, "GetIssueByIdentifier"):
+			number := req.Variables["filter"].(map[string]interface{})["number"].(map[string]interface{})["eq"].(float64)
+			fetchedNumbers = append(fetchedNumbers, number)
+			data = `{"issues": {"nodes": [{"id": "issue-1", "identifier": "NUM-1", "title": "Epic", "team": {"id": "team-1"}, "state": {"type": "unstarted"}}]}}`
+		case strings.Contains(req.Query, "GetComments"):
+			data = `{"issue": {"comments": {"nodes": []}}}`
+		case strings.Contains(req.Query, "commentCreate"):
+			input := req.Variables["input"].(map[string]interface{})
+			commentIssueIDs = append(commentIssueIDs, input["issueId"].(string))
+			commentBody = input["body"].(string)
+			data = `{"commentCreate": {"success": true, "comment": {"id": "comment-1"}}}`
+		case strings.Contains(req.Query, "GetTeamLabels"):
+			data = `{"team": {"labels": {"nodes": [{"id": "label-1", "name": "jig-plan"}]}}}`
+		case strings.Contains(req.Query, "GetIssueLabels"):
+			data = `{"issue": {"labels": {"nodes": [{"id": "label-1"}]}}}`
+		default:
+			t.Fatalf("unexpected query: %s", req.Query)
+		}
+		json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	p := &plan.Plan{
+		ID:               "PLAN-123",
+		IssueID:          "NUM-1",
+		RelatedIssues:    []string{"NUM-2", "NUM-3"},
+		Title:            "Epic Plan",
+		ProblemStatement: "Problem",
+		ProposedSolution: "Solution",
+	}
+
+	if err := client.SyncPlanToIssue(context.Background(), p, "jig-plan"); err != nil {
+		t.Fatalf("SyncPlanToIssue failed: %v", err)
+	}
+
+	if len(fetchedNumbers) != 1 || fetchedNumbers[0] != 1 {
+		t.Errorf("expected only the primary issue to be fetched, got numbers %v", fetchedNumbers)
+	}
+	if len(commentIssueIDs) != 1 || commentIssueIDs[0] != "issue-1" {
+		t.Errorf("expected one comment on the primary issue, got %v", commentIssueIDs)
+	}
+	if !strings.Contains(commentBody, "**Related issues:** NUM-2, NUM-3") {
+		t.Errorf("expected comment to reference related issues, got:\n%s", commentBody)
+	}
+
+	// The reference is comment chrome, not part of the plan body
+	if strings.Contains(ConvertCommentToBodyContent(commentBody), "Related issues") {
+		t.Error("expected related issues line to be stripped from the body content")
+	}
+}
+
+// TestSyncPlanToIssue_PlanLabels verifies that labels listed in a plan are
+// resolved against the team's labels and added alongside the plan label.
+func TestSyncPlanToIssue_PlanLabels(t *testing.T) {
+	type labelServer struct {
+		created []string   // names passed to issueLabelCreate
+		updates [][]string // labelIds passed to issueUpdate
+	}
+
+	// newLabelServer serves a team with "jig-plan", "backend" and "security"
+	// labels and an issue that already has the labels in issueLabelIDs
+	newLabelServer := func(t *testing.T, issueLabelIDs ...string) (*httptest.Server, *labelServer) {
+		ls := &labelServer{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			var data string
+			switch {
+			case strings.Contains(req.Query, "GetIssueByIdentifier"):
+				data = `{"issues": {"nodes": [{"id": "issue-1", "identifier": "NUM-41", "title": "Test", "team": {"id": "team-1"}, "state": {"type": "unstarted"}}]}}`
+			case strings.Contains(req.Query, "GetComments"):
+				data = `{"issue": {"comments": {"nodes": []}}}`
+			case strings.Contains(req.Query, "commentCreate"):
+				data = `{"commentCreate": {"success": true, "comment": {"id": "comment-1"}}}`
+			case strings.Contains(req.Query, "GetTeamLabels"):
+				data = `{"team": {"labels": {"nodes": [
+					{"id": "label-plan", "name": "jig-plan"},
+					{"id": "label-backend", "name": "backend"},
+					{"id": "label-security", "name": "Security"}
+				]}}}`
+			case strings.Contains(req.Query, "GetIssueLabels"):
+				nodes := make([]string, len(issueLabelIDs))
+				for i, id := range issueLabelIDs {
+					nodes[i] = `{"id": "` + id + `"}`
+				}
+				data = `{"issue": {"labels": {"nodes": [` + strings.Join(nodes, ",") + `]}}}`
+			case strings.Contains(req.Query, "issueLabelCreate"):
+				name := req.Variables["input"].(map[string]interface{})["name"].(string)
+				ls.created = append(ls.created, name)
+				data = `{"issueLabelCreate": {"success": true, "issueLabel": {"id": "label-` + name + `", "name": "` + name + `"}}}`
+			case strings.Contains(req.Query, "issueUpdate"):
+				var ids []string
+				for _, id := range req.Variables["input"].(map[string]interface{})["labelIds"].([]interface{}) {
+					ids = append(ids, id.(string))
+				}
+				ls.updates = append(ls.updates, ids)
+				data = `{"issueUpdate": {"success": true}}`
+			default:
+				t.Fatalf("unexpected query: %s", req.Query)
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+		}))
+		return server, ls
+	}
+
+	newLabeledPlan := func(labels ...string) *plan.Plan {
+		return &plan.Plan{
+			ID:               "PLAN-123",
+			IssueID:          "NUM-41",
+			Title:            "Test Plan",
+			ProblemStatement: "Problem",
+			ProposedSolution: "Solution",
+			Labels:           labels,
+		}
+	}
+
+	t.Run("attaches existing labels and keeps issue labels", func(t *testing.T) {
+		server, ls := newLabelServer(t, "label-other")
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.SyncPlanToIssue(context.Background(), newLabeledPlan("backend", "security"), "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if len(ls.updates) != 1 {
+			t.Fatalf("expected 1 label update, got %d", len(ls.updates))
+		}
+		want := "label-other,label-plan,label-backend,label-security"
+		if got := strings.Join(ls.updates[0], ","); got != want {
+			t.Errorf("expected labels %s, got %s", want, got)
+		}
+		if len(ls.created) != 0 {
+			t.Errorf("expected no labels to be created, got %v", ls.created)
+		}
+	})
+
+	t.Run("skips labels already on the issue", func(t *testing.T) {
+		server, ls := newLabelServer(t, "label-plan", "label-backend")
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.SyncPlanToIssue(context.Background(), newLabeledPlan("backend", "Backend"), "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if len(ls.updates) != 0 {
+			t.Errorf("expected no label update, got %v", ls.updates)
+		}
+	})
+
+	t.Run("skips missing labels by default", func(t *testing.T) {
+		server, ls := newLabelServer(t)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		if err := client.SyncPlanToIssue(context.Background(), newLabeledPlan("backend", "frontend"), "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if len(ls.created) != 0 {
+			t.Errorf("expected no labels to be created, got %v", ls.created)
+		}
+		if len(ls.updates) != 1 || strings.Join(ls.updates[0], ",") != "label-plan,label-backend" {
+			t.Errorf("expected plan and backend labels only, got %v", ls.updates)
+		}
+	})
+
+	t.Run("creates missing labels when enabled", func(t *testing.T) {
+		server, ls := newLabelServer(t)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.CreateMissingLabels = true
+		if err := client.SyncPlanToIssue(context.Background(), newLabeledPlan("frontend", "backend"), "jig-plan"); err != nil {
+			t.Fatalf("SyncPlanToIssue failed: %v", err)
+		}
+
+		if strings.Join(ls.created, ",") != "frontend" {
+			t.Errorf("expected frontend label to be created, got %v", ls.created)
+		}
+		if len(ls.updates) != 1 || strings.Join(ls.updates[0], ",") != "label-plan,label-frontend,label-backend" {
+			t.Errorf("expected plan, frontend and backend labels, got %v", ls.updates)
+		}
+	})
+}
+
 func TestSyncPlanStatus(t *testing.T) {
 	t.Run("returns error when plan has no linked issue", func(t *testing.T) {
 		client := NewClient("test-key", "team-id", "")
@@ -863,6 +1208,28 @@ func TestSyncPlanStatus(t *testing.T) {
 	})
 }
 
+func TestStripSyncTimestamp(t *testing.T) {
+	p := &plan.Plan{ID: "PLAN-123", ProblemStatement: "Problem"}
+
+	comment := formatPlanComment(p)
+	resynced := strings.Replace(comment, time.Now().UTC().Format("2006"), "1999", 1)
+	if comment == resynced {
+		t.Fatal("expected the comment to contain the sync year")
+	}
+
+	if got, want := StripSyncTimestamp(comment), formatPlanCommentBody(p); got != want {
+		t.Errorf("StripSyncTimestamp() = %q, want %q", got, want)
+	}
+	if StripSyncTimestamp(comment) != StripSyncTimestamp(resynced) {
+		t.Error("comments differing only by sync timestamp should be equal once stripped")
+	}
+
+	// A comment without a timestamp is unchanged
+	if got := StripSyncTimestamp("## Plan\n\nEdited"); got != "## Plan\n\nEdited" {
+		t.Errorf("StripSyncTimestamp() changed a comment without a timestamp: %q", got)
+	}
+}
+
 func TestComputePlanContentHash(t *testing.T) {
 	t.Run("returns consistent hash for same content", func(t *testing.T) {
 		p := &plan.Plan{
@@ -898,6 +1265,82 @@ func TestComputePlanContentHash(t *testing.T) {
 		}
 	})
 
+	t.Run("returns different hash for different labels", func(t *testing.T) {
+		p1 := &plan.Plan{ID: "PLAN-123", ProblemStatement: "Problem"}
+		p2 := &plan.Plan{ID: "PLAN-123", ProblemStatement: "Problem", Labels: []string{"backend"}}
+
+		if ComputePlanContentHash(p1) == ComputePlanContentHash(p2) {
+			t.Error("hash should change when labels change")
+		}
+	})
+
+	t.Run("ignores the sync timestamp", func(t *testing.T) {
+		p := &plan.Plan{ID: "PLAN-123", ProblemStatement: "Problem"}
+
+		// The comment embeds the time it was synced, but the hash must be the
+		// same across runs for sync status to be meaningful
+		const want = "53970a061798f77475a6de2dcc1d1808687afc310953843e2e5451f3dc8d3435"
+		if got := ComputePlanContentHash(p); got != want {
+			t.Errorf("ComputePlanContentHash() = %q, want %q", got, want)
+		}
+		body := formatPlanCommentBody(p)
+		if strings.Contains(body, "**Synced:**") {
+			t.Errorf("expected hashed content without the sync timestamp, got:\n%s", body)
+		}
+		if !strings.HasSuffix(formatPlanComment(p), strings.TrimPrefix(body, "## 📋 Implementation Plan\n\n")) {
+			t.Errorf("expected the comment to end with the hashed body, got:\n%s", formatPlanComment(p))
+		}
+	})
+
+	t.Run("ignores volatile frontmatter", func(t *testing.T) {
+		body := "\n# Plan\n\n## Notes\n\nSome notes\n"
+		p1 := &plan.Plan{ID: "PLAN-123", Title: "Plan", RawContent: "---\ncreated: 2026-01-01T10:00:00Z\nupdated: 2026-01-01T10:00:00Z\n---\n" + body}
+		p2 := &plan.Plan{ID: "PLAN-123", Title: "Plan", RawContent: "---\ncreated: 2026-01-01T10:00:00Z\nupdated: 2026-03-15T08:30:00Z\n---\n" + body}
+
+		if ComputePlanContentHash(p1) != ComputePlanContentHash(p2) {
+			t.Error("hash should not change when only frontmatter times change")
+		}
+
+		// A real content change still changes the hash
+		p2.RawContent = strings.Replace(p2.RawContent, "Some notes", "Different notes", 1)
+		if ComputePlanContentHash(p1) == ComputePlanContentHash(p2) {
+			t.Error("hash should change when a section changes")
+		}
+	})
+
+	t.Run("returns different hash for different title", func(t *testing.T) {
+		p1 := &plan.Plan{ID: "PLAN-123", Title: "Old Title", ProblemStatement: "Problem"}
+		p2 := &plan.Plan{ID: "PLAN-123", Title: "New Title", ProblemStatement: "Problem"}
+
+		if ComputePlanContentHash(p1) == ComputePlanContentHash(p2) {
+			t.Error("hash should change when the title changes")
+		}
+	})
+
+	t.Run("ignores label order", func(t *testing.T) {
+		p1 := &plan.Plan{ID: "PLAN-123", Labels: []string{"backend", "api"}}
+		p2 := &plan.Plan{ID: "PLAN-123", Labels: []string{"api", "backend"}}
+
+		if ComputePlanContentHash(p1) != ComputePlanContentHash(p2) {
+			t.Error("hash should not change when only label order changes")
+		}
+		if p1.Labels[0] != "backend" {
+			t.Errorf("expected plan labels to be left unsorted, got %v", p1.Labels)
+		}
+	})
+
+	t.Run("ignores blocking relation order", func(t *testing.T) {
+		p1 := &plan.Plan{ID: "PLAN-123", Blocks: []string{"NUM-2", "NUM-1"}, BlockedBy: []string{"NUM-4", "NUM-3"}}
+		p2 := &plan.Plan{ID: "PLAN-123", Blocks: []string{"NUM-1", "NUM-2"}, BlockedBy: []string{"NUM-3", "NUM-4"}}
+
+		if ComputePlanContentHash(p1) != ComputePlanContentHash(p2) {
+			t.Error("hash should not change when only blocking relation order changes")
+		}
+		if p1.Blocks[0] != "NUM-2" || p1.BlockedBy[0] != "NUM-4" {
+			t.Errorf("expected plan relations to be left unsorted, got %v and %v", p1.Blocks, p1.BlockedBy)
+		}
+	})
+
 	t.Run("returns valid hex string", func(t *testing.T) {
 		p := &plan.Plan{
 			ID:    "PLAN-123",
@@ -941,3 +1384,481 @@ func TestComputePlanContentHash(t *testing.T) {
 		}
 	})
 }
+
+func TestPlanRoundTrip(t *testing.T) {
+	raw := `---
+id: PLAN-1
+title: Round Trip
+status: draft
+author: tester
+---
+
+# Round Trip
+
+## Problem Statement
+
+The problem.
+
+## Acceptance Criteria
+
+- Criterion 1
+- Criterion 2
+
+## Proposed Solution
+
+The solution.
+
+## Testing Strategy
+
+Unit tests.
+
+## Implementation Details
+
+Step by step.
+`
+	original, err := plan.Parse([]byte(raw))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	issue := &tracker.Issue{Identifier: "NUM-1", Title: "Round Trip"}
+	fetched, err := parsePlanFromComment(formatPlanComment(original), issue)
+	if err != nil {
+		t.Fatalf("parsePlanFromComment failed: %v", err)
+	}
+
+	headings := func(markdown string) []string {
+		var names []string
+		for _, section := range splitPlanSections(markdown) {
+			names = append(names, section.name)
+		}
+		return names
+	}
+
+	want := []string{"problem statement", "acceptance criteria", "proposed solution", "testing strategy", "implementation details"}
+	if got := headings(fetched.RawContent); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("section order after round trip = %v, want %v", got, want)
+	}
+
+	if fetched.ProblemStatement != "The problem." {
+		t.Errorf("ProblemStatement = %q", fetched.ProblemStatement)
+	}
+	if fetched.ProposedSolution != "The solution." {
+		t.Errorf("ProposedSolution = %q", fetched.ProposedSolution)
+	}
+
+	// A second round trip must produce the same comment sections
+	stripSynced := func(comment string) string {
+		var lines []string
+		for _, line := range strings.Split(comment, "\n") {
+			if !strings.HasPrefix(line, "**Synced:**") {
+				lines = append(lines, line)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	if first, second := stripSynced(formatPlanComment(original)), stripSynced(formatPlanComment(fetched)); first != second {
+		t.Errorf("comment changed after round trip:\nfirst:\n%s\nsecond:\n%s", first, second)
+	}
+
+	// The fetched plan must survive caching as a regular plan document
+	reparsed, err := plan.Parse([]byte(fetched.RawContent))
+	if err != nil {
+		t.Fatalf("fetched raw content is not a valid plan: %v", err)
+	}
+	if reparsed.IssueID != "NUM-1" || reparsed.ProposedSolution != "The solution." {
+		t.Errorf("unexpected reparsed plan: issue=%q solution=%q", reparsed.IssueID, reparsed.ProposedSolution)
+	}
+}
+
+func TestFormatPlanComment_PreservesSectionOrder(t *testing.T) {
+	p := &plan.Plan{
+		ProblemStatement: "Problem",
+		ProposedSolution: "Solution",
+		RawContent: `## Acceptance Criteria
+- AC
+
+## Problem Statement
+Problem
+
+## Proposed Solution
+Solution
+`,
+	}
+
+	result := formatPlanComment(p)
+	ac := strings.Index(result, "## Acceptance Criteria")
+	problem := strings.Index(result, "### Problem Statement")
+	solution := strings.Index(result, "### Proposed Solution")
+	if !(ac < problem && problem < solution) {
+		t.Errorf("expected raw content order (criteria, problem, solution), got:\n%s", result)
+	}
+}
+
+// cancelAfterFirstTransport cancels a context once the first response has been
+// fully received, simulating Ctrl-C between two API calls
+type cancelAfterFirstTransport struct {
+	base   http.RoundTripper
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (t *cancelAfterFirstTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || t.calls > 1 {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cancel()
+	return resp, nil
+}
+
+func TestSyncPlanToIssue_StopsWhenCanceled(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		json.NewEncoder(w).Encode(GraphQLResponse{
+			Data: json.RawMessage(`{"issue": {"id": "issueuuid123", "identifier": "NUM-41", "title": "Test", "state": {"name": "Todo", "type": "unstarted"}, "team": {"id": "team-1"}, "labels": {"nodes": []}}}`),
+		})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newTestClient(server.URL)
+	client.httpClient.Transport = &cancelAfterFirstTransport{
+		base:   &testTransport{baseURL: server.URL},
+		cancel: cancel,
+	}
+
+	p := &plan.Plan{ID: "PLAN-1", IssueID: "issueuuid123", ProblemStatement: "Problem"}
+	err := client.SyncPlanToIssue(ctx, p, "jig-plan")
+	if err == nil {
+		t.Fatal("expected error after cancellation")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "canceled before") {
+		t.Errorf("expected message naming the interrupted step, got: %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("expected only the issue fetch to be made, got %d calls", callCount)
+	}
+}
+
+func TestConvertCommentToBodyContent(t *testing.T) {
+	p := &plan.Plan{
+		ProblemStatement: "The problem",
+		ProposedSolution: "The solution",
+		RawContent:       "---\nid: PLAN-1\n---\n\n# Title\n\n## Problem Statement\n\nThe problem\n\n## Proposed Solution\n\nThe solution\n\n## Notes\n\nSome notes\n",
+	}
+
+	got := ConvertCommentToBodyContent(formatPlanComment(p))
+	want := "### Problem Statement\n\nThe problem\n\n### Proposed Solution\n\nThe solution\n\n## Notes\n\nSome notes\n\n"
+	if got != want {
+		t.Errorf("ConvertCommentToBodyContent() = %q, want %q", got, want)
+	}
+	if PlanBodyContent(p) != want {
+		t.Errorf("PlanBodyContent() = %q, want %q", PlanBodyContent(p), want)
+	}
+}
+
+func TestCreateIssueFromPlan_Estimate(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+
+	// newEstimateServer answers the team estimation query with estimationType
+	// and records the estimate sent with issueCreate (nil if none was sent)
+	newEstimateServer := func(t *testing.T, estimationType string, sent **float64, created *bool) *httptest.Server {
+		t.Helper()
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			if strings.Contains(req.Query, "issueEstimationType") {
+				json.NewEncoder(w).Encode(GraphQLResponse{
+					Data: json.RawMessage(fmt.Sprintf(`{"team": {"issueEstimationType": %q}}`, estimationType)),
+				})
+				return
+			}
+
+			*created = true
+			if input, ok := req.Variables["input"].(map[string]interface{}); ok {
+				if estimate, ok := input["estimate"].(float64); ok {
+					*sent = &estimate
+				}
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{
+				Data: json.RawMessage(`{"issueCreate": {"success": true, "issue": {"id": "issue-uuid-123", "identifier": "NUM-99", "title": "Test", "state": {"id": "state-1", "name": "Todo", "type": "unstarted"}, "team": {"id": "team-123", "key": "NUM"}}}}`),
+			})
+		}))
+	}
+
+	tests := []struct {
+		name           string
+		plan           *plan.Plan
+		estimationType string
+		wantSent       *float64
+		wantErr        string
+	}{
+		{
+			name:           "explicit estimate is sent",
+			plan:           &plan.Plan{Title: "Test", Estimate: intPtr(5), Phases: []plan.Phase{{ID: "p1", Estimate: intPtr(2)}}},
+			estimationType: "fibonacci",
+			wantSent:       func() *float64 { f := 5.0; return &f }(),
+		},
+		{
+			name:           "phase estimates are summed",
+			plan:           &plan.Plan{Title: "Test", Phases: []plan.Phase{{ID: "p1", Estimate: intPtr(2)}, {ID: "p2"}, {ID: "p3", Estimate: intPtr(3)}}},
+			estimationType: "linear",
+			wantSent:       func() *float64 { f := 5.0; return &f }(),
+		},
+		{
+			name:           "estimate is dropped when the team doesn't use estimates",
+			plan:           &plan.Plan{Title: "Test", Estimate: intPtr(3)},
+			estimationType: "notUsed",
+		},
+		{
+			name:           "negative estimate is rejected",
+			plan:           &plan.Plan{Title: "Test", Estimate: intPtr(-1)},
+			estimationType: "linear",
+			wantErr:        "invalid estimate -1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent *float64
+			var created bool
+			server := newEstimateServer(t, tt.estimationType, &sent, &created)
+			defer server.Close()
+
+			client := newTestClientWithTeam(server.URL, "team-123")
+			issue, err := client.CreateIssueFromPlan(context.Background(), tt.plan)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if created {
+					t.Error("expected no issue to be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateIssueFromPlan failed: %v", err)
+			}
+
+			if tt.wantSent == nil {
+				if sent != nil {
+					t.Errorf("expected no estimate to be sent, got %v", *sent)
+				}
+				if issue.Estimate != nil {
+					t.Errorf("expected nil estimate on created issue, got %d", *issue.Estimate)
+				}
+				return
+			}
+			if sent == nil || *sent != *tt.wantSent {
+				t.Errorf("expected estimate %v to be sent, got %v", *tt.wantSent, sent)
+			}
+			if issue.Estimate == nil || float64(*issue.Estimate) != *tt.wantSent {
+				t.Errorf("expected created issue estimate %v, got %v", *tt.wantSent, issue.Estimate)
+			}
+		})
+	}
+}
+
+func TestCreateIssueFromPlan_Target(t *testing.T) {
+	// newTargetServer answers team and project queries and records the input
+	// sent with issueCreate
+	newTargetServer := func(t *testing.T, input *map[string]interface{}) *httptest.Server {
+		t.Helper()
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			switch {
+			case strings.Contains(req.Query, "GetTeams"):
+				json.NewEncoder(w).Encode(GraphQLResponse{
+					Data: json.RawMessage(`{"teams": {"nodes": [{"id": "team-123", "name": "Numbers", "key": "NUM"}, {"id": "team-ops", "name": "Operations", "key": "OPS"}]}}`),
+				})
+			case strings.Contains(req.Query, "GetProjects"):
+				json.NewEncoder(w).Encode(GraphQLResponse{
+					Data: json.RawMessage(`{"team": {"projects": {"nodes": [{"id": "project-ops", "name": "Q3 Roadmap"}]}}}`),
+				})
+			default:
+				*input, _ = req.Variables["input"].(map[string]interface{})
+				json.NewEncoder(w).Encode(GraphQLResponse{
+					Data: json.RawMessage(`{"issueCreate": {"success": true, "issue": {"id": "issue-uuid-123", "identifier": "OPS-1", "title": "Test", "state": {"id": "state-1", "name": "Todo", "type": "unstarted"}, "team": {"id": "team-ops", "key": "OPS"}}}}`),
+				})
+			}
+		}))
+	}
+
+	// newConfiguredClient returns a client configured with team-123 and project-1
+	newConfiguredClient := func(serverURL string) *Client {
+		client := newTestClientWithTeam(serverURL, "team-123")
+		client.projectID = "project-1"
+		return client
+	}
+
+	p := &plan.Plan{ID: "PLAN-1", Title: "Test", ProblemStatement: "Problem"}
+
+	t.Run("override team key is resolved", func(t *testing.T) {
+		var input map[string]interface{}
+		server := newTargetServer(t, &input)
+		defer server.Close()
+
+		client, err := newConfiguredClient(server.URL).WithTarget(context.Background(), IssueTarget{Team: "ops"})
+		if err != nil {
+			t.Fatalf("WithTarget() error = %v", err)
+		}
+		if _, err := client.CreateIssueFromPlan(context.Background(), p); err != nil {
+			t.Fatalf("CreateIssueFromPlan() error = %v", err)
+		}
+
+		if input["teamId"] != "team-ops" {
+			t.Errorf("expected teamId team-ops, got %v", input["teamId"])
+		}
+		if _, ok := input["projectId"]; ok {
+			t.Errorf("expected the configured project to be dropped with another team, got %v", input["projectId"])
+		}
+	})
+
+	t.Run("override project name is resolved", func(t *testing.T) {
+		var input map[string]interface{}
+		server := newTargetServer(t, &input)
+		defer server.Close()
+
+		client, err := newConfiguredClient(server.URL).WithTarget(context.Background(), IssueTarget{Team: "team-ops", Project: "q3 roadmap"})
+		if err != nil {
+			t.Fatalf("WithTarget() error = %v", err)
+		}
+		if _, err := client.CreateIssueFromPlan(context.Background(), p); err != nil {
+			t.Fatalf("CreateIssueFromPlan() error = %v", err)
+		}
+
+		if input["teamId"] != "team-ops" || input["projectId"] != "project-ops" {
+			t.Errorf("expected team-ops and project-ops, got %v and %v", input["teamId"], input["projectId"])
+		}
+	})
+
+	t.Run("no override falls back to config", func(t *testing.T) {
+		var input map[string]interface{}
+		server := newTargetServer(t, &input)
+		defer server.Close()
+
+		configured := newConfiguredClient(server.URL)
+		client, err := configured.WithTarget(context.Background(), IssueTarget{})
+		if err != nil {
+			t.Fatalf("WithTarget() error = %v", err)
+		}
+		if _, err := client.CreateIssueFromPlan(context.Background(), p); err != nil {
+			t.Fatalf("CreateIssueFromPlan() error = %v", err)
+		}
+
+		if input["teamId"] != "team-123" || input["projectId"] != "project-1" {
+			t.Errorf("expected configured team-123 and project-1, got %v and %v", input["teamId"], input["projectId"])
+		}
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		var input map[string]interface{}
+		server := newTargetServer(t, &input)
+		defer server.Close()
+
+		configured := newConfiguredClient(server.URL)
+		_, err := configured.WithTarget(context.Background(), IssueTarget{Team: "ENG"})
+		if err == nil || !strings.Contains(err.Error(), `team "ENG" not found (available: NUM, OPS)`) {
+			t.Errorf("expected team not found error, got %v", err)
+		}
+		if configured.teamID != "team-123" {
+			t.Errorf("expected the original client to be left alone, got team %q", configured.teamID)
+		}
+	})
+
+	t.Run("template override", func(t *testing.T) {
+		configured := newConfiguredClient("http://unused")
+		configured.IssueTemplate = "Feature"
+		client, err := configured.WithTarget(context.Background(), IssueTarget{Template: "Bug"})
+		if err != nil {
+			t.Fatalf("WithTarget() error = %v", err)
+		}
+		if client.IssueTemplate != "Bug" || client.teamID != "team-123" || configured.IssueTemplate != "Feature" {
+			t.Errorf("expected only the copy to use the Bug template, got %q (team %q) and %q",
+				client.IssueTemplate, client.teamID, configured.IssueTemplate)
+		}
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		var input map[string]interface{}
+		server := newTargetServer(t, &input)
+		defer server.Close()
+
+		_, err := newConfiguredClient(server.URL).WithTarget(context.Background(), IssueTarget{Project: "Backlog"})
+		if err == nil || !strings.Contains(err.Error(), `project "Backlog" not found`) {
+			t.Errorf("expected project not found error, got %v", err)
+		}
+	})
+}
+
+func TestJigPlanCommentMatcher(t *testing.T) {
+	footer := "\n\n---\n\n" + planCommentFooterLine
+	human := &tracker.Comment{ID: "human", Body: "Looks good to me."}
+	older := &tracker.Comment{ID: "older", Body: planCommentPrefix + "\n\nOld." + footer, ByViewer: true}
+	newer := &tracker.Comment{ID: "newer", Body: planCommentPrefix + "\n\nNew." + footer, ByViewer: true}
+	noFooter := &tracker.Comment{ID: "no-footer", Body: planCommentPrefix + "\n\nFooter edited out.", ByViewer: true}
+	quoted := &tracker.Comment{ID: "quoted", Body: planCommentPrefix + "\n\nCopied by someone else."}
+	continuation := &tracker.Comment{ID: "continuation", Body: planContinuationPrefix + " 2)\n\nMore." + footer, ByViewer: true}
+	byViewerID := &tracker.Comment{ID: "by-viewer-id", Body: planCommentPrefix + "\n\nFooter edited out.", AuthorID: "user-1"}
+	viewer := &tracker.User{ID: "user-1", Name: "Jig Bot"}
+
+	tests := []struct {
+		name     string
+		comments []*tracker.Comment
+		viewer   *tracker.User
+		want     *tracker.Comment
+	}{
+		{"no comments", nil, nil, nil},
+		{"no jig comment", []*tracker.Comment{human, quoted}, nil, nil},
+		{"most recent jig comment", []*tracker.Comment{older, human, newer, human}, nil, newer},
+		{"skips continuations", []*tracker.Comment{older, continuation}, nil, older},
+		{"API user's comment without footer", []*tracker.Comment{older, noFooter}, nil, noFooter},
+		{"other user's comment without footer", []*tracker.Comment{older, quoted}, nil, older},
+		{"current user's comment without footer", []*tracker.Comment{older, byViewerID}, viewer, byViewerID},
+		{"unknown current user", []*tracker.Comment{older, byViewerID}, nil, older},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := planCommentSet(tt.comments, jigPlanCommentMatcher(tt.viewer)); got != tt.want {
+				t.Errorf("planCommentSet() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinearCommentToTracker(t *testing.T) {
+	var lc LinearComment
+	data := `{"id": "c1", "body": "Hi", "createdAt": "2024-05-01T10:00:00Z", "updatedAt": "2024-05-02T10:00:00Z",
+		"user": {"id": "user-1", "name": "Jig Bot", "isMe": true}}`
+	if err := json.Unmarshal([]byte(data), &lc); err != nil {
+		t.Fatalf("failed to unmarshal comment: %v", err)
+	}
+
+	got := linearCommentToTracker(&lc)
+	if got.ID != "c1" || got.Author != "Jig Bot" || got.AuthorID != "user-1" || !got.ByViewer {
+		t.Errorf("linearCommentToTracker() = %+v, want the comment's ID and author", got)
+	}
+	if !got.CreatedAt.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) || !got.UpdatedAt.Equal(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("linearCommentToTracker() times = %v, %v", got.CreatedAt, got.UpdatedAt)
+	}
+}
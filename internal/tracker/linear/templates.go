@@ -0,0 +1,140 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// IssueTemplate is a Linear issue template
+type IssueTemplate struct {
+	ID   string
+	Name string
+	Data IssueTemplateData
+}
+
+// IssueTemplateData holds the issue fields a template sets. Fields the
+// template leaves unset are empty.
+type IssueTemplateData struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Priority    *int     `json:"priority"`
+	Estimate    *int     `json:"estimate"`
+	TeamID      string   `json:"teamId"`
+	ProjectID   string   `json:"projectId"`
+	StateID     string   `json:"stateId"`
+	AssigneeID  string   `json:"assigneeId"`
+	LabelIDs    []string `json:"labelIds"`
+}
+
+// GetIssueTemplate returns the issue template with the given ID or name
+// (case-insensitive)
+func (c *Client) GetIssueTemplate(ctx context.Context, template string) (*IssueTemplate, error) {
+	query := `
+		query GetTemplates {
+			templates {
+				id
+				name
+				type
+				templateData
+			}
+		}
+	`
+
+	resp, err := c.execute(ctx, &GraphQLRequest{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Templates []struct {
+			ID           string          `json:"id"`
+			Name         string          `json:"name"`
+			Type         string          `json:"type"`
+			TemplateData json.RawMessage `json:"templateData"`
+		} `json:"templates"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	var names []string
+	for _, t := range result.Templates {
+		if t.Type != "issue" {
+			continue
+		}
+		if t.ID != template && !strings.EqualFold(t.Name, template) {
+			names = append(names, t.Name)
+			continue
+		}
+
+		data, err := parseTemplateData(t.TemplateData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data in issue template %q: %w", t.Name, err)
+		}
+		return &IssueTemplate{ID: t.ID, Name: t.Name, Data: data}, nil
+	}
+	return nil, fmt.Errorf("issue template %q not found (available: %s)", template, strings.Join(names, ", "))
+}
+
+// parseTemplateData decodes a template's data, which the API returns as a
+// JSON object or as a string holding one
+func parseTemplateData(raw json.RawMessage) (IssueTemplateData, error) {
+	var data IssueTemplateData
+	if len(raw) == 0 || string(raw) == "null" {
+		return data, nil
+	}
+	if raw[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return data, err
+		}
+		raw = json.RawMessage(encoded)
+	}
+	err := json.Unmarshal(raw, &data)
+	return data, err
+}
+
+// applyTo fills in the fields of an IssueCreateInput that aren't already
+// set with the template's defaults, so fields from the plan and the client's
+// configured project win. Labels are added to any already in the input.
+func (d IssueTemplateData) applyTo(input map[string]interface{}) {
+	setDefault := func(key string, value interface{}) {
+		if _, ok := input[key]; !ok {
+			input[key] = value
+		}
+	}
+
+	if d.Title != "" {
+		setDefault("title", d.Title)
+	}
+	if d.Description != "" {
+		setDefault("description", d.Description)
+	}
+	if d.Priority != nil {
+		setDefault("priority", *d.Priority)
+	}
+	if d.Estimate != nil {
+		setDefault("estimate", *d.Estimate)
+	}
+	if d.ProjectID != "" {
+		setDefault("projectId", d.ProjectID)
+	}
+	if d.StateID != "" {
+		setDefault("stateId", d.StateID)
+	}
+	if d.AssigneeID != "" {
+		setDefault("assigneeId", d.AssigneeID)
+	}
+	if len(d.LabelIDs) > 0 {
+		labelIDs, _ := input["labelIds"].([]string)
+		for _, id := range d.LabelIDs {
+			if !slices.Contains(labelIDs, id) {
+				labelIDs = append(labelIDs, id)
+			}
+		}
+		input["labelIds"] = labelIDs
+	}
+}
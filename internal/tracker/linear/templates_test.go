@@ -0,0 +1,145 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charleslr/jig/internal/plan"
+)
+
+func TestCreateIssueFromPlan_IssueTemplate(t *testing.T) {
+	const templates = `{"templates": [
+		{"id": "tmpl-project", "name": "Project Update", "type": "project", "templateData": {}},
+		{"id": "tmpl-1", "name": "Feature", "type": "issue", "templateData": {
+			"title": "Feature: ", "description": "## Checklist", "priority": 2, "estimate": 1,
+			"teamId": "team-template", "projectId": "project-template", "stateId": "state-triage",
+			"assigneeId": "user-1", "labelIds": ["label-feature"]
+		}},
+		{"id": "tmpl-2", "name": "Bug", "type": "issue", "templateData": "{\"labelIds\": [\"label-bug\"]}"}
+	]}`
+
+	// newTemplateServer serves the templates and records the issueCreate input
+	newTemplateServer := func(t *testing.T) (*httptest.Server, *map[string]interface{}) {
+		var input map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req GraphQLRequest
+			json.NewDecoder(r.Body).Decode(&req)
+
+			var data string
+			switch {
+			case strings.Contains(req.Query, "GetTemplates"):
+				data = templates
+			case strings.Contains(req.Query, "GetTeamEstimation"):
+				data = `{"team": {"issueEstimationType": "fibonacci"}}`
+			case strings.Contains(req.Query, "GetViewer"):
+				data = `{"viewer": {"id": "user-viewer", "name": "Jig Bot", "email": "bot@example.com"}}`
+			case strings.Contains(req.Query, "issueCreate"):
+				input = req.Variables["input"].(map[string]interface{})
+				data = `{"issueCreate": {"success": true, "issue": {"id": "issue-1", "identifier": "NUM-1", "title": "Add retries",
+					"state": {"id": "state-1", "name": "Triage", "type": "triage"}, "team": {"id": "team-123", "key": "NUM"}}}}`
+			default:
+				t.Fatalf("unexpected query: %s", req.Query)
+			}
+			json.NewEncoder(w).Encode(GraphQLResponse{Data: json.RawMessage(data)})
+		}))
+		return server, &input
+	}
+
+	estimate := 5
+	p := &plan.Plan{
+		ID:               "PLAN-1",
+		Title:            "Add retries",
+		ProblemStatement: "Requests fail.",
+		ProposedSolution: "Retry them.",
+	}
+
+	t.Run("applies the template's defaults", func(t *testing.T) {
+		server, input := newTemplateServer(t)
+		defer server.Close()
+
+		client := newTestClient(server.URL)
+		client.IssueTemplate = "feature"
+		if _, err := client.CreateIssueFromPlan(context.Background(), p); err != nil {
+			t.Fatalf("CreateIssueFromPlan failed: %v", err)
+		}
+
+		want := map[string]interface{}{
+			"title":       "Add retries",
+			"description": buildPlanDescription(p),
+			"teamId":      "team-template",
+			"projectId":   "project-template",
+			"priority":    float64(2),
+			"estimate":    float64(1),
+			"stateId":     "state-triage",
+			"assigneeId":  "user-1",
+			"labelIds":    []interface{}{"label-feature"},
+		}
+		if !reflect.DeepEqual(*input, want) {
+			t.Errorf("issueCreate input = %v, want %v", *input, want)
+		}
+	})
+
+	t.Run("explicit fields override the template", func(t *testing.T) {
+		server, input := newTemplateServer(t)
+		defer server.Close()
+
+		client := NewClient("test-api-key", "team-123", "project-123")
+		client.httpClient = newTestClient(server.URL).httpClient
+		client.IssueTemplate = "tmpl-1"
+		withEstimate := *p
+		withEstimate.Estimate = &estimate
+		if _, err := client.CreateIssueFromPlan(context.Background(), &withEstimate); err != nil {
+			t.Fatalf("CreateIssueFromPlan failed: %v", err)
+		}
+
+		for key, want := range map[string]interface{}{
+			"title":     "Add retries",
+			"teamId":    "team-123",
+			"projectId": "project-123",
+			"estimate":  float64(5),
+			"stateId":   "state-triage",
+		} {
+			if got := (*input)[key]; got != want {
+				t.Errorf("input[%q] = %v, want %v", key, got, want)
+			}
+		}
+	})
+
+	t.Run("template data given as a string", func(t *testing.T) {
+		server, input := newTemplateServer(t)
+		defer server.Close()
+
+		client := newTestClientWithTeam(server.URL, "team-123")
+		client.IssueTemplate = "Bug"
+		if _, err := client.CreateIssueFromPlan(context.Background(), p); err != nil {
+			t.Fatalf("CreateIssueFromPlan failed: %v", err)
+		}
+		if got := (*input)["labelIds"]; !reflect.DeepEqual(got, []interface{}{"label-bug"}) {
+			t.Errorf("labelIds = %v, want [label-bug]", got)
+		}
+		// A template without an assignee leaves the issue to the API key's user
+		if got := (*input)["assigneeId"]; got != "user-viewer" {
+			t.Errorf("assigneeId = %v, want user-viewer", got)
+		}
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		server, input := newTemplateServer(t)
+		defer server.Close()
+
+		client := newTestClientWithTeam(server.URL, "team-123")
+		client.IssueTemplate = "Project Update"
+		_, err := client.CreateIssueFromPlan(context.Background(), p)
+		if err == nil || !strings.Contains(err.Error(), `issue template "Project Update" not found (available: Feature, Bug)`) {
+			t.Errorf("expected a not found error listing issue templates, got %v", err)
+		}
+		if *input != nil {
+			t.Error("expected no issue to be created")
+		}
+	})
+}
@@ -3,6 +3,9 @@ package mock
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +26,8 @@ type Client struct {
 	comments    map[string][]*tracker.Comment
 	relations   map[string][]string // issueID -> blockedByIDs
 	syncedPlans []SyncedPlan        // tracks plans synced via SyncPlanToIssue
+	viewer      string              // assignee name treated as the current user
+	statuses    []tracker.Status    // statuses returned by GetAvailableStatuses; nil for all
 	counter     int
 }
 
@@ -52,6 +57,8 @@ func (c *Client) CreateIssue(ctx context.Context, issue *tracker.Issue) (*tracke
 		Description: issue.Description,
 		Status:      tracker.StatusTodo,
 		Priority:    issue.Priority,
+		Assignee:    issue.Assignee,
+		Labels:      slices.Clone(issue.Labels),
 		ParentID:    issue.ParentID,
 		TeamID:      issue.TeamID,
 		ProjectID:   issue.ProjectID,
@@ -117,6 +124,21 @@ func (c *Client) GetIssue(ctx context.Context, id string) (*tracker.Issue, error
 	return nil, fmt.Errorf("issue not found: %s", id)
 }
 
+// GetIssueByURL retrieves a mock issue by its URL, ignoring any trailing
+// title slug, query or fragment
+func (c *Client) GetIssueByURL(ctx context.Context, url string) (*tracker.Issue, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, issue := range c.issues {
+		if url == issue.URL || strings.HasPrefix(url, issue.URL+"/") || strings.HasPrefix(url, issue.URL+"?") || strings.HasPrefix(url, issue.URL+"#") {
+			return issue, nil
+		}
+	}
+
+	return nil, fmt.Errorf("issue not found: %s", url)
+}
+
 // SearchIssues searches mock issues
 func (c *Client) SearchIssues(ctx context.Context, query string) ([]*tracker.Issue, error) {
 	c.mu.RLock()
@@ -133,6 +155,72 @@ func (c *Client) SearchIssues(ctx context.Context, query string) ([]*tracker.Iss
 	return results, nil
 }
 
+// SearchIssuesWithOptions searches mock issues by query, status and team
+func (c *Client) SearchIssuesWithOptions(ctx context.Context, opts tracker.SearchOptions) ([]*tracker.Issue, error) {
+	matches, err := c.SearchIssues(ctx, opts.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*tracker.Issue
+	for _, issue := range matches {
+		if len(opts.Statuses) > 0 && !slices.Contains(opts.Statuses, issue.Status) {
+			continue
+		}
+		if opts.TeamID != "" && issue.TeamID != opts.TeamID {
+			continue
+		}
+		results = append(results, issue)
+		if opts.Limit > 0 && len(results) == opts.Limit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// SetViewer sets the assignee name that ListAssignedToMe treats as the current user
+func (c *Client) SetViewer(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.viewer = name
+}
+
+// GetCurrentUser returns the viewer set with SetViewer, or "mock-user", the
+// author of the mock's comments
+func (c *Client) GetCurrentUser(ctx context.Context) (*tracker.User, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	name := c.viewer
+	if name == "" {
+		name = "mock-user"
+	}
+	return &tracker.User{ID: name, Name: name}, nil
+}
+
+// ListAssignedToMe returns the open mock issues assigned to the viewer, sorted by identifier
+func (c *Client) ListAssignedToMe(ctx context.Context) ([]*tracker.Issue, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var results []*tracker.Issue
+	for _, issue := range c.issues {
+		if c.viewer == "" || issue.Assignee != c.viewer {
+			continue
+		}
+		if issue.Status == tracker.StatusDone || issue.Status == tracker.StatusCanceled {
+			continue
+		}
+		results = append(results, issue)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Identifier < results[j].Identifier
+	})
+
+	return results, nil
+}
+
 // CreateSubIssue creates a sub-issue under a parent
 func (c *Client) CreateSubIssue(ctx context.Context, parentID string, issue *tracker.Issue) (*tracker.Issue, error) {
 	issue.ParentID = parentID
@@ -226,8 +314,85 @@ func (c *Client) TransitionIssue(ctx context.Context, id string, status tracker.
 	return nil
 }
 
-// GetAvailableStatuses returns all possible statuses
+// RemoveLabel removes a label from a mock issue. Mock labels are identified by
+// name, so labelID is the label's name.
+func (c *Client) RemoveLabel(ctx context.Context, issueID, labelID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue := c.findIssue(issueID)
+	if issue == nil {
+		return fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	issue.Labels = slices.DeleteFunc(issue.Labels, func(label string) bool { return label == labelID })
+	return nil
+}
+
+// UpdateIssueLabels replaces a mock issue's labels. Mock labels are
+// identified by name, so labelIDs are the labels' names.
+func (c *Client) UpdateIssueLabels(ctx context.Context, issueID string, labelIDs []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	issue := c.findIssue(issueID)
+	if issue == nil {
+		return fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	issue.Labels = slices.Clone(labelIDs)
+	return nil
+}
+
+// FindIssueLabel implements the LabelFinder interface for testing. Mock labels
+// are identified by name, so the name is returned as the ID.
+func (c *Client) FindIssueLabel(ctx context.Context, issueID, name string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	issue := c.findIssue(issueID)
+	if issue == nil {
+		return "", fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	for _, label := range issue.Labels {
+		if equalIgnoreCase(label, name) {
+			return label, nil
+		}
+	}
+	return "", nil
+}
+
+// findIssue returns the issue with the given ID or identifier. Callers must
+// hold c.mu.
+func (c *Client) findIssue(id string) *tracker.Issue {
+	if issue, ok := c.issues[id]; ok {
+		return issue
+	}
+	for _, issue := range c.issues {
+		if issue.Identifier == id {
+			return issue
+		}
+	}
+	return nil
+}
+
+// SetAvailableStatuses sets the statuses returned by GetAvailableStatuses,
+// as for a team without some workflow states
+func (c *Client) SetAvailableStatuses(statuses ...tracker.Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.statuses = statuses
+}
+
+// GetAvailableStatuses returns the statuses set with SetAvailableStatuses,
+// or all possible statuses
 func (c *Client) GetAvailableStatuses(ctx context.Context, id string) ([]tracker.Status, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.statuses != nil {
+		return slices.Clone(c.statuses), nil
+	}
 	return []tracker.Status{
 		tracker.StatusBacklog,
 		tracker.StatusTodo,
@@ -324,6 +489,24 @@ func (c *Client) SyncPlanToIssue(ctx context.Context, p *plan.Plan, labelName st
 	return nil
 }
 
+// GetPlanComment implements the PlanSyncer interface for testing by returning the
+// most recent comment on the issue
+func (c *Client) GetPlanComment(ctx context.Context, issueID string) (*tracker.Comment, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, issue := range c.issues {
+		if issue.Identifier == issueID || issue.ID == issueID {
+			comments := c.comments[issue.ID]
+			if len(comments) == 0 {
+				return nil, nil
+			}
+			return comments[len(comments)-1], nil
+		}
+	}
+	return nil, fmt.Errorf("issue not found: %s", issueID)
+}
+
 // GetSyncedPlans returns all plans that were synced via SyncPlanToIssue (for test assertions)
 func (c *Client) GetSyncedPlans() []SyncedPlan {
 	c.mu.RLock()
@@ -19,6 +19,21 @@ const (
 	StatusCanceled   Status = "canceled"
 )
 
+// StatusForPlan returns the issue status matching a plan status
+func StatusForPlan(status plan.Status) Status {
+	switch status {
+	case plan.StatusInProgress:
+		return StatusInProgress
+	case plan.StatusInReview:
+		return StatusInReview
+	case plan.StatusComplete:
+		return StatusDone
+	default:
+		// Plans that haven't started yet (draft, reviewing, approved)
+		return StatusTodo
+	}
+}
+
 // Priority represents issue priority
 type Priority int
 
@@ -43,6 +58,7 @@ type Issue struct {
 	ParentID    string // For sub-issues
 	ProjectID   string
 	TeamID      string
+	Estimate    *int // Story points; nil if not estimated
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	URL         string // Web URL to the issue
@@ -64,6 +80,8 @@ type Comment struct {
 	ID        string
 	Body      string
 	Author    string
+	AuthorID  string
+	ByViewer  bool // Written by the user the tracker's API key belongs to
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -75,6 +93,10 @@ type Tracker interface {
 	UpdateIssue(ctx context.Context, id string, updates *IssueUpdate) error
 	GetIssue(ctx context.Context, id string) (*Issue, error)
 	SearchIssues(ctx context.Context, query string) ([]*Issue, error)
+	ListAssignedToMe(ctx context.Context) ([]*Issue, error) // Open issues assigned to the current user
+
+	// GetCurrentUser returns the user the tracker's API key belongs to
+	GetCurrentUser(ctx context.Context) (*User, error)
 
 	// Sub-issues
 	CreateSubIssue(ctx context.Context, parentID string, issue *Issue) (*Issue, error)
@@ -88,16 +110,46 @@ type Tracker interface {
 	TransitionIssue(ctx context.Context, id string, status Status) error
 	GetAvailableStatuses(ctx context.Context, id string) ([]Status, error)
 
+	// Labels
+	RemoveLabel(ctx context.Context, issueID, labelID string) error                 // Keeps the issue's other labels
+	UpdateIssueLabels(ctx context.Context, issueID string, labelIDs []string) error // Replaces the issue's labels with exactly labelIDs
+
 	// Team and project info
 	GetTeams(ctx context.Context) ([]Team, error)
 	GetProjects(ctx context.Context, teamID string) ([]Project, error)
 }
 
+// SearchOptions narrows an issue search
+type SearchOptions struct {
+	Query    string   // Text the title or description contains; empty matches all issues
+	Statuses []Status // Only issues in one of these statuses; empty matches any status
+	TeamID   string   // Only issues in this team; empty uses the tracker's default team
+	Limit    int      // Maximum number of issues to return; 0 uses the tracker's default
+}
+
+// IssueSearcher defines the interface for searching issues with filters
+// beyond Tracker.SearchIssues' plain text query
+type IssueSearcher interface {
+	// SearchIssuesWithOptions returns the issues matching all of the options set
+	SearchIssuesWithOptions(ctx context.Context, opts SearchOptions) ([]*Issue, error)
+}
+
 // PlanSyncer defines the interface for syncing plans to a tracker
 type PlanSyncer interface {
 	// SyncPlanToIssue syncs a plan's content to its associated issue as a comment
 	// and adds a label to indicate the issue has an implementation plan.
 	SyncPlanToIssue(ctx context.Context, p *plan.Plan, labelName string) error
+
+	// GetPlanComment returns the plan comment previously synced to an issue,
+	// or nil if the issue has no plan comment.
+	GetPlanComment(ctx context.Context, issueID string) (*Comment, error)
+}
+
+// LabelFinder defines the interface for looking up the labels on an issue
+type LabelFinder interface {
+	// FindIssueLabel returns the ID of the label with the given name on an
+	// issue, as expected by RemoveLabel, or "" if the issue doesn't have it.
+	FindIssueLabel(ctx context.Context, issueID, name string) (string, error)
 }
 
 // PlanFetcher defines the interface for fetching plans from a tracker
@@ -107,6 +159,33 @@ type PlanFetcher interface {
 	FetchPlanFromIssue(ctx context.Context, issueID string) (*plan.Plan, error)
 }
 
+// IssueRelator defines the interface for relating issues that block each other
+type IssueRelator interface {
+	// GetIssue retrieves an issue by ID or identifier
+	GetIssue(ctx context.Context, id string) (*Issue, error)
+
+	// SetBlocking makes the issue blockerID block blockedID. Both are the
+	// tracker's IDs, not identifiers.
+	SetBlocking(ctx context.Context, blockerID, blockedID string) error
+
+	// GetBlockedBy returns the issues blocking an issue
+	GetBlockedBy(ctx context.Context, issueID string) ([]*Issue, error)
+}
+
+// IssueURLGetter defines the interface for fetching an issue from its web URL
+type IssueURLGetter interface {
+	// GetIssueByURL retrieves the issue a web URL points to, e.g. one copied
+	// from the tracker's app. Returns an error for URLs of other sites.
+	GetIssueByURL(ctx context.Context, url string) (*Issue, error)
+}
+
+// User represents a user of the tracker
+type User struct {
+	ID    string
+	Name  string
+	Email string
+}
+
 // Team represents a team in the tracker
 type Team struct {
 	ID   string
@@ -39,21 +39,38 @@ type FormModel struct {
 	saved     bool
 	cancelled bool
 	width     int
+
+	title          string
+	savedMessage   string
+	unsavedMessage string
 }
 
-// NewForm creates a new form with the given fields
+// NewForm creates a new configuration form with the given fields
 func NewForm(fields []FormField) FormModel {
 	ti := textinput.New()
 	ti.CharLimit = 256
 	ti.Width = 50
 
 	return FormModel{
-		fields:    fields,
-		textInput: ti,
-		width:     60,
+		fields:         fields,
+		textInput:      ti,
+		width:          60,
+		title:          "Jig Configuration",
+		savedMessage:   "✓ Configuration saved",
+		unsavedMessage: "Configuration unchanged",
 	}
 }
 
+// NewFormWithTitle creates a new form with the given title and fields, for
+// forms that aren't the configuration form
+func NewFormWithTitle(title string, fields []FormField) FormModel {
+	m := NewForm(fields)
+	m.title = title
+	m.savedMessage = "✓ Saved"
+	m.unsavedMessage = "Cancelled"
+	return m
+}
+
 // Init implements tea.Model
 func (m FormModel) Init() tea.Cmd {
 	return nil
@@ -132,15 +149,15 @@ func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View implements tea.Model
 func (m FormModel) View() string {
 	if m.saved {
-		return formSavedStyle.Render("✓ Configuration saved\n")
+		return formSavedStyle.Render(m.savedMessage + "\n")
 	}
 	if m.cancelled {
-		return "Configuration unchanged\n"
+		return m.unsavedMessage + "\n"
 	}
 
 	var b strings.Builder
 
-	b.WriteString(formSectionStyle.Render("Jig Configuration"))
+	b.WriteString(formSectionStyle.Render(m.title))
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", 40))
 	b.WriteString("\n\n")
@@ -236,9 +253,19 @@ func (m FormModel) GetValue(key string) string {
 	return ""
 }
 
-// RunForm runs the form and returns the updated fields
+// RunForm runs the configuration form and returns the updated fields
 func RunForm(fields []FormField) ([]FormField, bool, error) {
-	m := NewForm(fields)
+	return runForm(NewForm(fields))
+}
+
+// RunFormWithTitle runs a form with the given title and returns the updated
+// fields, and whether they were saved
+func RunFormWithTitle(title string, fields []FormField) ([]FormField, bool, error) {
+	return runForm(NewFormWithTitle(title, fields))
+}
+
+// runForm runs a form and returns the updated fields
+func runForm(m FormModel) ([]FormField, bool, error) {
 	p := tea.NewProgram(m)
 
 	result, err := p.Run()
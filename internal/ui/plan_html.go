@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+// planHTMLTemplate is a self-contained page for a plan, with its styles
+// inlined so the file can be opened or shared on its own
+var planHTMLTemplate = template.Must(template.New("plan").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { max-width: 48rem; margin: 2rem auto; padding: 0 1rem; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; line-height: 1.6; color: #1f2328; }
+  header { border-bottom: 1px solid #d0d7de; margin-bottom: 1.5rem; }
+  header h1 { margin-bottom: 0.25rem; }
+  .meta { color: #59636e; font-size: 0.9rem; margin-top: 0; }
+  h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3rem; }
+  code { background: #f6f8fa; padding: 0.1rem 0.3rem; border-radius: 4px; font-size: 0.9em; }
+  pre { background: #f6f8fa; padding: 1rem; border-radius: 6px; overflow-x: auto; }
+  pre code { padding: 0; background: none; }
+  table { border-collapse: collapse; }
+  th, td { border: 1px solid #d0d7de; padding: 0.4rem 0.8rem; }
+  blockquote { margin: 0; padding-left: 1rem; border-left: 4px solid #d0d7de; color: #59636e; }
+  li:has(> input[type=checkbox]) { list-style: none; }
+</style>
+</head>
+<body>
+<header>
+{{if .ShowTitle}}<h1>{{.Title}}</h1>
+{{end}}<p class="meta">{{.Meta}}</p>
+</header>
+<main>
+{{.Body}}
+</main>
+</body>
+</html>
+`))
+
+// PlanHTML renders a plan as a self-contained HTML page: its title and
+// metadata, then its markdown body and any comments
+func PlanHTML(p *plan.Plan, comments []*tracker.Comment) ([]byte, error) {
+	md := extractMarkdownBody(p.RawContent)
+	if len(comments) > 0 {
+		md += "\n\n" + CommentsMarkdown(comments)
+	}
+
+	// GFM adds tables, task lists and strikethrough. Raw HTML in the plan is
+	// left out of the page.
+	var body bytes.Buffer
+	renderer := goldmark.New(goldmark.WithExtensions(extension.GFM))
+	if err := renderer.Convert([]byte(md), &body); err != nil {
+		return nil, fmt.Errorf("failed to render plan: %w", err)
+	}
+
+	meta := fmt.Sprintf("%s · %s", p.ID, p.Status)
+	if p.IssueID != "" {
+		meta += " · " + p.IssueID
+	}
+
+	var page bytes.Buffer
+	err := planHTMLTemplate.Execute(&page, struct {
+		Title     string
+		ShowTitle bool
+		Meta      string
+		Body      template.HTML
+	}{
+		Title: p.Title,
+		// Plans usually open with their title as a heading already
+		ShowTitle: !strings.HasPrefix(md, "# "),
+		Meta:      meta,
+		Body:      template.HTML(body.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render plan: %w", err)
+	}
+	return page.Bytes(), nil
+}
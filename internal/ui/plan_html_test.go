@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
+)
+
+func TestPlanHTML(t *testing.T) {
+	p := &plan.Plan{
+		ID:      "PLAN-1",
+		IssueID: "NUM-1",
+		Title:   "Rate <Limiting>",
+		Status:  plan.StatusApproved,
+		RawContent: "---\nid: PLAN-1\ntitle: Rate Limiting\n---\n\n" +
+			"## Problem Statement\n\nToo many requests.\n\n" +
+			"## Acceptance Criteria\n\n- [x] Limits apply\n- [ ] Limits are configurable\n\n" +
+			"<script>alert(1)</script>\n",
+	}
+
+	page, err := PlanHTML(p, nil)
+	if err != nil {
+		t.Fatalf("PlanHTML() error = %v", err)
+	}
+	html := string(page)
+
+	for _, want := range []string{
+		"<title>Rate &lt;Limiting&gt;</title>",
+		"<h1>Rate &lt;Limiting&gt;</h1>",
+		"PLAN-1 · approved · NUM-1",
+		"<h2>Problem Statement</h2>",
+		"<h2>Acceptance Criteria</h2>",
+		`<input checked="" disabled="" type="checkbox"> Limits apply`,
+		"<style>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected the page to contain %q, got:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Errorf("expected raw HTML in the plan to be left out, got:\n%s", html)
+	}
+
+	t.Run("uses the plan's own title heading", func(t *testing.T) {
+		p := &plan.Plan{ID: "PLAN-2", Title: "Caching", RawContent: "---\nid: PLAN-2\n---\n\n# Caching\n\nBody.\n"}
+		page, err := PlanHTML(p, nil)
+		if err != nil {
+			t.Fatalf("PlanHTML() error = %v", err)
+		}
+		if got := strings.Count(string(page), "<h1>"); got != 1 {
+			t.Errorf("expected one title heading, got %d:\n%s", got, page)
+		}
+	})
+
+	t.Run("includes comments", func(t *testing.T) {
+		comments := []*tracker.Comment{{Body: "Looks good", Author: "Alice", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}
+		page, err := PlanHTML(p, comments)
+		if err != nil {
+			t.Fatalf("PlanHTML() error = %v", err)
+		}
+		if !strings.Contains(string(page), "<h2>Comments (1)</h2>") || !strings.Contains(string(page), "Looks good") {
+			t.Errorf("expected the comments section, got:\n%s", page)
+		}
+	})
+}
@@ -393,3 +393,87 @@ func RunPlanPrompt(issue *tracker.Issue) (*PlanPromptResult, error) {
 
 	return model.Result(), nil
 }
+
+// PlanFields are the plan details collected up front by RunPlanFieldsForm
+type PlanFields struct {
+	Title  string
+	Goal   string
+	Labels []string
+	Team   string // Team ID or key to create the plan's issue in
+}
+
+// Keys of the plan fields form
+const (
+	planFieldTitle  = "title"
+	planFieldGoal   = "goal"
+	planFieldLabels = "labels"
+	planFieldTeam   = "team"
+)
+
+// PlanFieldsFormFields returns the form fields for collecting plan details,
+// filled in with defaults
+func PlanFieldsFormFields(defaults PlanFields) []FormField {
+	return []FormField{
+		{
+			Key:         planFieldTitle,
+			Label:       "Title",
+			Description: "The plan's title, also used for its new issue",
+			Value:       defaults.Title,
+			Placeholder: "Generated during planning",
+		},
+		{
+			Key:         planFieldGoal,
+			Label:       "Goal",
+			Description: "What you want to plan",
+			Value:       defaults.Goal,
+		},
+		{
+			Key:         planFieldLabels,
+			Label:       "Labels",
+			Description: "Comma-separated labels to add to the plan's issue",
+			Value:       strings.Join(defaults.Labels, ", "),
+			Placeholder: "backend, security",
+		},
+		{
+			Key:         planFieldTeam,
+			Label:       "Team",
+			Description: "Team ID or key to create the plan's issue in (default from config)",
+			Value:       defaults.Team,
+		},
+	}
+}
+
+// PlanFieldsFromForm reads plan details back from the plan fields form.
+// Values are trimmed, and empty labels dropped.
+func PlanFieldsFromForm(fields []FormField) PlanFields {
+	var result PlanFields
+	for _, f := range fields {
+		value := strings.TrimSpace(f.Value)
+		switch f.Key {
+		case planFieldTitle:
+			result.Title = value
+		case planFieldGoal:
+			result.Goal = value
+		case planFieldLabels:
+			for _, label := range strings.Split(value, ",") {
+				if label = strings.TrimSpace(label); label != "" {
+					result.Labels = append(result.Labels, label)
+				}
+			}
+		case planFieldTeam:
+			result.Team = value
+		}
+	}
+	return result
+}
+
+// RunPlanFieldsForm runs a form for the plan's title, goal, labels and team,
+// starting from defaults. Returns false if the form was cancelled.
+func RunPlanFieldsForm(defaults PlanFields) (*PlanFields, bool, error) {
+	fields, saved, err := RunFormWithTitle("New Plan", PlanFieldsFormFields(defaults))
+	if err != nil || !saved {
+		return nil, false, err
+	}
+	result := PlanFieldsFromForm(fields)
+	return &result, true, nil
+}
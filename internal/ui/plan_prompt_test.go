@@ -687,3 +687,31 @@ func TestPlanPromptModelWindowSizeInViewContext(t *testing.T) {
 		t.Errorf("expected width to be 100, got %d", m.width)
 	}
 }
+
+func TestPlanFieldsForm(t *testing.T) {
+	defaults := PlanFields{Title: "Add auth", Labels: []string{"backend", "security"}, Team: "ENG"}
+
+	fields := PlanFieldsFormFields(defaults)
+	if len(fields) != 4 {
+		t.Fatalf("expected 4 fields, got %d", len(fields))
+	}
+	if got := PlanFieldsFromForm(fields); got.Title != "Add auth" || got.Goal != "" || strings.Join(got.Labels, ",") != "backend,security" || got.Team != "ENG" {
+		t.Errorf("expected the defaults back unchanged, got %+v", got)
+	}
+
+	// Edited values are trimmed and empty labels dropped
+	for i := range fields {
+		switch fields[i].Key {
+		case "goal":
+			fields[i].Value = "  Support SSO logins "
+		case "labels":
+			fields[i].Value = "auth, ,sso,"
+		case "team":
+			fields[i].Value = ""
+		}
+	}
+	got := PlanFieldsFromForm(fields)
+	if got.Goal != "Support SSO logins" || strings.Join(got.Labels, ",") != "auth,sso" || got.Team != "" {
+		t.Errorf("unexpected fields from form: %+v", got)
+	}
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/charleslr/jig/internal/plan"
@@ -92,6 +94,7 @@ func CachedPlanTableColumns() []TableColumn {
 		{Title: "Title", Width: 36},
 		{Title: "Status", Width: 12},
 		{Title: "Synced", Width: 10},
+		{Title: "Criteria", Width: 10},
 	}
 }
 
@@ -115,12 +118,18 @@ func CachedPlanToTableRow(cp *state.CachedPlan) TableRow {
 		}
 	}
 
+	criteria := "-"
+	if done, total := cp.Plan.Progress(); total > 0 {
+		criteria = fmt.Sprintf("%d/%d", done, total)
+	}
+
 	return TableRow{
 		Cells: []string{
 			cp.Plan.ID,
 			cp.Plan.Title,
 			status,
 			syncStatus,
+			criteria,
 		},
 		Value: cp.Plan,
 	}
@@ -11,11 +11,11 @@ import (
 func TestCachedPlanTableColumns(t *testing.T) {
 	columns := CachedPlanTableColumns()
 
-	if len(columns) != 4 {
-		t.Fatalf("expected 4 columns, got %d", len(columns))
+	if len(columns) != 5 {
+		t.Fatalf("expected 5 columns, got %d", len(columns))
 	}
 
-	expectedTitles := []string{"ID", "Title", "Status", "Synced"}
+	expectedTitles := []string{"ID", "Title", "Status", "Synced", "Criteria"}
 	for i, col := range columns {
 		if col.Title != expectedTitles[i] {
 			t.Errorf("column %d: expected title %q, got %q", i, expectedTitles[i], col.Title)
@@ -59,7 +59,7 @@ func TestCachedPlanToTableRow(t *testing.T) {
 				},
 				UpdatedAt: now,
 			},
-			expectedCells: []string{"PLAN-1", "Test Plan", "draft", "-"},
+			expectedCells: []string{"PLAN-1", "Test Plan", "draft", "-", "-"},
 		},
 		{
 			name: "plan never synced (needs sync)",
@@ -73,7 +73,7 @@ func TestCachedPlanToTableRow(t *testing.T) {
 				UpdatedAt: now,
 				SyncedAt:  nil,
 			},
-			expectedCells: []string{"PLAN-2", "Unsynced Plan", "in-progress", "no"},
+			expectedCells: []string{"PLAN-2", "Unsynced Plan", "in-progress", "no", "-"},
 		},
 		{
 			name: "plan synced and up to date",
@@ -87,7 +87,7 @@ func TestCachedPlanToTableRow(t *testing.T) {
 				UpdatedAt: past,
 				SyncedAt:  &now,
 			},
-			expectedCells: []string{"PLAN-3", "Synced Plan", "complete", "yes"},
+			expectedCells: []string{"PLAN-3", "Synced Plan", "complete", "yes", "-"},
 		},
 		{
 			name: "plan updated after sync (needs sync)",
@@ -101,7 +101,7 @@ func TestCachedPlanToTableRow(t *testing.T) {
 				UpdatedAt: now,
 				SyncedAt:  &past,
 			},
-			expectedCells: []string{"PLAN-4", "Updated Plan", "draft", "no"},
+			expectedCells: []string{"PLAN-4", "Updated Plan", "draft", "no", "-"},
 		},
 		{
 			name: "plan with empty status defaults to draft",
@@ -114,7 +114,20 @@ func TestCachedPlanToTableRow(t *testing.T) {
 				},
 				UpdatedAt: now,
 			},
-			expectedCells: []string{"PLAN-5", "No Status Plan", "draft", "-"},
+			expectedCells: []string{"PLAN-5", "No Status Plan", "draft", "-", "-"},
+		},
+		{
+			name: "plan with acceptance criteria",
+			cached: &state.CachedPlan{
+				Plan: &plan.Plan{
+					ID:         "PLAN-6",
+					Title:      "Criteria Plan",
+					Status:     plan.StatusInProgress,
+					RawContent: "---\ntitle: Criteria Plan\n---\n\n## Acceptance Criteria\n\n- [x] One\n- [ ] Two\n- [x] Three\n",
+				},
+				UpdatedAt: now,
+			},
+			expectedCells: []string{"PLAN-6", "Criteria Plan", "in-progress", "-", "2/3"},
 		},
 	}
 
@@ -8,8 +8,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
 	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
 )
 
 var (
@@ -32,8 +34,10 @@ var (
 // PlanViewModel displays a plan in a nice format with scrolling
 type PlanViewModel struct {
 	plan     *plan.Plan
+	comments []*tracker.Comment
 	viewport viewport.Model
 	renderer *glamour.TermRenderer
+	noColor  bool
 	width    int
 	ready    bool
 	quitting bool
@@ -71,10 +75,7 @@ func (m PlanViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if !m.ready {
 			// Initialize glamour renderer with terminal width
-			renderer, err := glamour.NewTermRenderer(
-				glamour.WithAutoStyle(),
-				glamour.WithWordWrap(msg.Width),
-			)
+			renderer, err := newPlanRenderer(msg.Width, m.noColor)
 			if err == nil {
 				m.renderer = renderer
 			}
@@ -89,10 +90,7 @@ func (m PlanViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Height = msg.Height - headerHeight - footerHeight
 			// Re-render content with new width
 			if m.renderer != nil {
-				renderer, err := glamour.NewTermRenderer(
-					glamour.WithAutoStyle(),
-					glamour.WithWordWrap(msg.Width),
-				)
+				renderer, err := newPlanRenderer(msg.Width, m.noColor)
 				if err == nil {
 					m.renderer = renderer
 					m.viewport.SetContent(m.renderContent())
@@ -108,6 +106,17 @@ func (m PlanViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// newPlanRenderer creates a markdown renderer for the plan body that wraps at
+// width. Code blocks are syntax highlighted unless noColor is set, in which
+// case the output has no colors at all.
+func newPlanRenderer(width int, noColor bool) (*glamour.TermRenderer, error) {
+	style := glamour.WithAutoStyle()
+	if noColor {
+		style = glamour.WithStandardStyle("notty")
+	}
+	return glamour.NewTermRenderer(style, glamour.WithWordWrap(width))
+}
+
 // headerHeight returns the height of the fixed header
 func (m PlanViewModel) headerHeight() int {
 	return 4 // title + separator + blank + status line
@@ -126,13 +135,33 @@ func (m PlanViewModel) renderHeader() string {
 	// Status
 	b.WriteString(sectionStyle.Render("Status: "))
 	b.WriteString(formatPlanStatus(m.plan.Status))
+	if progress := CriteriaProgress(m.plan); progress != "" {
+		b.WriteString("  ")
+		b.WriteString(helpStyle.Render(progress))
+	}
 	b.WriteString("\n")
 
 	return b.String()
 }
 
-// renderContent renders the scrollable content (markdown body)
+// renderContent renders the scrollable content (phase checklist, markdown
+// body and issue comments)
 func (m PlanViewModel) renderContent() string {
+	var parts []string
+	if phases := renderPhases(m.plan); phases != "" {
+		parts = append(parts, phases)
+	}
+	if body := m.renderBody(); body != "" {
+		parts = append(parts, body)
+	}
+	if len(m.comments) > 0 {
+		parts = append(parts, m.renderMarkdown(CommentsMarkdown(m.comments)))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderBody renders the markdown body of the plan
+func (m PlanViewModel) renderBody() string {
 	if m.plan.RawContent == "" {
 		return ""
 	}
@@ -141,17 +170,74 @@ func (m PlanViewModel) renderContent() string {
 	if body == "" {
 		return ""
 	}
+	return m.renderMarkdown(body)
+}
 
-	// Use glamour to render markdown if available
+// renderMarkdown renders markdown with glamour, falling back to the raw
+// markdown if no renderer is available
+func (m PlanViewModel) renderMarkdown(md string) string {
 	if m.renderer != nil {
-		rendered, err := m.renderer.Render(body)
+		rendered, err := m.renderer.Render(md)
 		if err == nil {
 			return strings.TrimSpace(rendered)
 		}
 	}
+	return md
+}
 
-	// Fallback to raw markdown
-	return body
+// CommentsMarkdown formats an issue's comments as a markdown section, each
+// with its author and when it was posted. Returns "" if there are none.
+func CommentsMarkdown(comments []*tracker.Comment) string {
+	if len(comments) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("## Comments (%d)\n", len(comments)))
+	for _, c := range comments {
+		author := c.Author
+		if author == "" {
+			author = "Unknown"
+		}
+		b.WriteString(fmt.Sprintf("\n**%s** · %s\n\n", author, c.CreatedAt.Local().Format("2006-01-02 15:04")))
+		b.WriteString(strings.TrimSpace(c.Body))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderPhases renders the plan's phases as a checklist with a progress rollup.
+// Returns an empty string if the plan has no phases.
+func renderPhases(p *plan.Plan) string {
+	if len(p.Phases) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	done, total := p.PhaseProgress()
+	b.WriteString(sectionStyle.Render("Phases"))
+	b.WriteString(helpStyle.Render(fmt.Sprintf(" (%d/%d phases done)", done, total)))
+
+	for _, phase := range p.Phases {
+		b.WriteString("\n  ")
+		b.WriteString(formatPhaseStatus(phase.Status))
+		b.WriteString(" ")
+		b.WriteString(phase.Title)
+	}
+
+	return b.String()
+}
+
+// formatPhaseStatus returns a checklist marker for a phase status
+func formatPhaseStatus(status plan.PhaseStatus) string {
+	switch status {
+	case plan.PhaseDone:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render("[x]")
+	case plan.PhaseInProgress:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Render("[~]")
+	default:
+		return helpStyle.Render("[ ]")
+	}
 }
 
 // renderFooter renders the help text
@@ -214,14 +300,39 @@ func extractMarkdownBody(rawContent string) string {
 	return strings.TrimSpace(body)
 }
 
-// ShowPlan displays a plan in an interactive view
-func ShowPlan(p *plan.Plan) error {
+// PlanViewOptions configures how ShowPlan displays a plan
+type PlanViewOptions struct {
+	// NoColor displays the plan without colors or syntax highlighting
+	NoColor bool
+	// Comments are the linked issue's comments, shown below the plan
+	Comments []*tracker.Comment
+}
+
+// ShowPlan displays a plan in an interactive view, with its body rendered as
+// markdown
+func ShowPlan(p *plan.Plan, opts PlanViewOptions) error {
 	m := NewPlanView(p)
+	m.noColor = opts.NoColor
+	m.comments = opts.Comments
+	if opts.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
 	program := tea.NewProgram(m, tea.WithAltScreen())
 	_, err := program.Run()
 	return err
 }
 
+// CriteriaProgress describes how many of a plan's acceptance criteria are
+// checked off, e.g. "3/7 acceptance criteria complete". Returns "" if the
+// plan has no acceptance criteria checkboxes.
+func CriteriaProgress(p *plan.Plan) string {
+	done, total := p.Progress()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d acceptance criteria complete", done, total)
+}
+
 // RenderPlanSummary returns a simple text summary of a plan
 func RenderPlanSummary(p *plan.Plan) string {
 	var b strings.Builder
@@ -3,10 +3,12 @@ package ui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/charleslr/jig/internal/plan"
+	"github.com/charleslr/jig/internal/tracker"
 )
 
 func TestExtractMarkdownBody(t *testing.T) {
@@ -217,6 +219,19 @@ func TestPlanViewModelRenderHeader(t *testing.T) {
 				"DRAFT",
 			},
 		},
+		{
+			name: "header with acceptance criteria progress",
+			plan: &plan.Plan{
+				ID:         "test-plan",
+				Title:      "Test Plan Title",
+				Status:     plan.StatusInProgress,
+				RawContent: "---\ntitle: Test Plan Title\n---\n\n## Acceptance Criteria\n\n- [x] One\n- [x] Two\n- [ ] Three\n",
+			},
+			wantContains: []string{
+				"Test Plan Title",
+				"2/3 acceptance criteria complete",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +291,26 @@ Custom content here.
 				"Custom content here.",
 			},
 		},
+		{
+			name: "renders phase checklist with rollup",
+			plan: &plan.Plan{
+				ID:     "test-plan",
+				Title:  "Test Plan",
+				Status: plan.StatusInProgress,
+				Phases: []plan.Phase{
+					{ID: "phase-1", Title: "Add schema", Status: plan.PhaseDone},
+					{ID: "phase-2", Title: "Wire up API", Status: plan.PhaseInProgress},
+					{ID: "phase-3", Title: "Write docs", Status: plan.PhasePending},
+				},
+			},
+			wantContains: []string{
+				"Phases",
+				"1/3 phases done",
+				"[x] Add schema",
+				"[~] Wire up API",
+				"[ ] Write docs",
+			},
+		},
 		{
 			name: "empty content when no raw content",
 			plan: &plan.Plan{
@@ -303,6 +338,30 @@ Custom content here.
 	}
 }
 
+func TestPlanViewModelRenderContent_Comments(t *testing.T) {
+	view := NewPlanView(&plan.Plan{ID: "test-plan", Title: "Test Plan", Status: plan.StatusDraft})
+	view.comments = []*tracker.Comment{
+		{Author: "Ada", Body: "Should we cap retries?", CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)},
+		{Body: "Yes, at three."},
+	}
+
+	output := view.renderContent()
+	for _, want := range []string{"Comments (2)", "Ada", "2024-05-01 10:00", "Should we cap retries?", "Unknown", "Yes, at three."} {
+		if !strings.Contains(output, want) {
+			t.Errorf("renderContent() should contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "Should we cap retries?") > strings.Index(output, "Yes, at three.") {
+		t.Errorf("expected comments oldest first, got:\n%s", output)
+	}
+}
+
+func TestCommentsMarkdown_Empty(t *testing.T) {
+	if got := CommentsMarkdown(nil); got != "" {
+		t.Errorf("CommentsMarkdown(nil) = %q, want empty", got)
+	}
+}
+
 func TestPlanViewModelHeaderHeight(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -508,3 +567,39 @@ func TestPlanViewModelUpdateWindowSize(t *testing.T) {
 		t.Errorf("viewport.Height = %d, want %d", updatedView.viewport.Height, expectedHeight)
 	}
 }
+
+func TestPlanViewModelRendersMarkdown(t *testing.T) {
+	p := &plan.Plan{
+		ID:     "test-plan",
+		Title:  "Test Plan",
+		Status: plan.StatusDraft,
+		RawContent: "---\ntitle: Test Plan\n---\n\n" +
+			"## Steps\n\n" +
+			"- Add the schema\n  - Create the table\n    1. Write the migration\n  - Add indexes\n- Wire up the API\n\n" +
+			"```go\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n```\n\n" +
+			"```\nunterminated fence\n",
+	}
+
+	for _, noColor := range []bool{false, true} {
+		view := NewPlanView(p)
+		view.noColor = noColor
+		model, _ := view.Update(tea.WindowSizeMsg{Height: 30, Width: 80})
+		updated := model.(PlanViewModel)
+		if updated.renderer == nil {
+			t.Fatalf("noColor=%v: expected a markdown renderer", noColor)
+		}
+
+		output := updated.renderContent()
+		for _, want := range []string{"Steps", "Create the table", "Write the migration", "fmt.Println", "unterminated fence"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("noColor=%v: expected rendered body to contain %q, got:\n%s", noColor, want, output)
+			}
+		}
+		if strings.Contains(output, "```") {
+			t.Errorf("noColor=%v: expected code fences to be rendered, got:\n%s", noColor, output)
+		}
+		if noColor && strings.Contains(output, "\x1b[") {
+			t.Errorf("expected no color codes with noColor, got:\n%q", output)
+		}
+	}
+}
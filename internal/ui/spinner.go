@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,10 +12,11 @@ import (
 
 // SpinnerModel is a simple spinner component
 type SpinnerModel struct {
-	spinner  spinner.Model
-	message  string
-	quitting bool
-	err      error
+	spinner   spinner.Model
+	message   string
+	quitting  bool
+	cancelled bool
+	err       error
 }
 
 // NewSpinner creates a new spinner with a message
@@ -41,6 +43,7 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "q", "esc", "ctrl+c":
 			m.quitting = true
+			m.cancelled = true
 			return m, tea.Quit
 		}
 
@@ -49,6 +52,9 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case SpinnerMessageMsg:
+		m.message = msg.Message
+
 	case SpinnerDoneMsg:
 		m.quitting = true
 		return m, tea.Quit
@@ -65,6 +71,9 @@ func (m SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View implements tea.Model
 func (m SpinnerModel) View() string {
 	if m.quitting {
+		if m.cancelled {
+			return fmt.Sprintf("✗ %s: cancelled\n", m.message)
+		}
 		if m.err != nil {
 			return fmt.Sprintf("✗ %s: %v\n", m.message, m.err)
 		}
@@ -76,6 +85,11 @@ func (m SpinnerModel) View() string {
 // SpinnerDoneMsg signals the spinner is done
 type SpinnerDoneMsg struct{}
 
+// SpinnerMessageMsg replaces the spinner's message, e.g. to show progress
+type SpinnerMessageMsg struct {
+	Message string
+}
+
 // SpinnerErrorMsg signals an error occurred
 type SpinnerErrorMsg struct {
 	Err error
@@ -83,12 +97,24 @@ type SpinnerErrorMsg struct {
 
 // RunWithSpinner runs a function while showing a spinner
 func RunWithSpinner(message string, fn func() error) error {
+	return RunWithSpinnerContext(context.Background(), message, func(context.Context) error {
+		return fn()
+	})
+}
+
+// RunWithSpinnerContext runs a function while showing a spinner. The context
+// passed to fn is cancelled if the user presses q, esc or ctrl+c, so a
+// function that respects it returns promptly with a context.Canceled error.
+func RunWithSpinnerContext(ctx context.Context, message string, fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	p := tea.NewProgram(NewSpinner(message))
 
 	// Run the function in a goroutine
 	errChan := make(chan error, 1)
 	go func() {
-		err := fn()
+		err := fn(ctx)
 		if err != nil {
 			p.Send(SpinnerErrorMsg{Err: err})
 		} else {
@@ -97,15 +123,45 @@ func RunWithSpinner(message string, fn func() error) error {
 		errChan <- err
 	}()
 
-	// Run the spinner
+	// Run the spinner, stopping the function if the user cancels it
 	if _, err := p.Run(); err != nil {
 		return err
 	}
+	cancel()
 
 	// Return the function's error
 	return <-errChan
 }
 
+// RunWithProgressSpinner runs a function while showing a spinner whose
+// message the function can change as it goes, e.g. "Syncing 3/10…". fn may
+// call setMessage from any goroutine. As with RunWithSpinnerContext, the
+// context passed to fn is cancelled if the user presses q, esc or ctrl+c.
+func RunWithProgressSpinner(ctx context.Context, message string, fn func(ctx context.Context, setMessage func(string)) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	p := tea.NewProgram(NewSpinner(message))
+
+	errChan := make(chan error, 1)
+	go func() {
+		err := fn(ctx, func(message string) { p.Send(SpinnerMessageMsg{Message: message}) })
+		if err != nil {
+			p.Send(SpinnerErrorMsg{Err: err})
+		} else {
+			p.Send(SpinnerDoneMsg{})
+		}
+		errChan <- err
+	}()
+
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+	cancel()
+
+	return <-errChan
+}
+
 // SimpleSpinner shows a spinner for a duration (for demos)
 func SimpleSpinner(message string, duration time.Duration) {
 	p := tea.NewProgram(NewSpinner(message))
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -62,8 +63,8 @@ type WizardStep struct {
 	Secret      bool
 	Validate    func(string) error
 
-	// For spinner steps
-	Action      func() error
+	// For spinner steps. The context is cancelled if the user aborts the step.
+	Action      func(ctx context.Context) error
 	ActionLabel string
 
 	// For summary steps
@@ -168,7 +169,7 @@ func (m WizardModel) runStepAction() tea.Cmd {
 	step := m.steps[m.currentStep]
 	return func() tea.Msg {
 		if step.Action != nil {
-			err := step.Action()
+			err := step.Action(context.Background())
 			return WizardActionDone{Err: err}
 		}
 		return WizardActionDone{}